@@ -8,7 +8,8 @@ var authCmd = &cobra.Command{
 	Long: `Authentication helpers for Microsoft SSO + OnePoint session cookies.
 
 Use "auth login" to perform an interactive browser login and save auth state.
-Use "auth show-cookies" to print the Cookie header for direct REST calls.`,
+Use "auth show-cookies" to print the Cookie header for direct REST calls.
+Use "auth status" to check whether the saved session is still valid.`,
 }
 
 func init() {
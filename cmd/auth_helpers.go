@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/riadshalaby/gohour/config"
@@ -15,17 +16,51 @@ import (
 	"github.com/spf13/viper"
 )
 
+// resolveDefaultAuthStatePath returns explicitPath when set, else the state
+// file of the profile selected with --profile, else the default state file.
 func resolveDefaultAuthStatePath(explicitPath string) (string, error) {
 	if strings.TrimSpace(explicitPath) != "" {
 		return explicitPath, nil
 	}
+	name, profile, ok, err := selectedProfile()
+	if err != nil {
+		return "", err
+	}
+	if ok && strings.TrimSpace(profile.StateFile) != "" {
+		return strings.TrimSpace(profile.StateFile), nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("resolve home directory: %w", err)
 	}
+	if ok {
+		return filepath.Join(home, ".gohour", "onepoint-auth-state-"+name+".json"), nil
+	}
 	return filepath.Join(home, ".gohour", "onepoint-auth-state.json"), nil
 }
 
+// selectedProfile returns the name and settings of the profile selected with
+// --profile. ok is false when no profile is selected. An unknown name fails
+// with the list of configured profiles.
+func selectedProfile() (name string, profile config.ProfileConfig, ok bool, err error) {
+	name = strings.ToLower(strings.TrimSpace(onePointProfile))
+	if name == "" {
+		return "", config.ProfileConfig{}, false, nil
+	}
+	if strings.TrimSpace(viper.ConfigFileUsed()) == "" {
+		return "", config.ProfileConfig{}, false, fmt.Errorf("--profile %q needs a config file with a profiles section", onePointProfile)
+	}
+	cfg, err := config.LoadAndValidate()
+	if err != nil {
+		return "", config.ProfileConfig{}, false, fmt.Errorf("load config: %w", err)
+	}
+	profile, err = cfg.Profile(name)
+	if err != nil {
+		return "", config.ProfileConfig{}, false, err
+	}
+	return name, profile, true, nil
+}
+
 func resolveProfileDir(explicitDir string) (string, bool, error) {
 	if strings.TrimSpace(explicitDir) != "" {
 		return explicitDir, false, nil
@@ -53,8 +88,18 @@ func ensureParentDir(path string, mode os.FileMode) error {
 	return nil
 }
 
+// resolveOnePointURLs returns the API base URL, home URL and host of
+// urlOverride when set, else of the profile selected with --profile, else of
+// onepoint.url.
 func resolveOnePointURLs(urlOverride string) (string, string, string, error) {
 	rawURL := strings.TrimSpace(urlOverride)
+	_, profile, ok, err := selectedProfile()
+	if err != nil {
+		return "", "", "", err
+	}
+	if rawURL == "" && ok {
+		rawURL = strings.TrimSpace(profile.URL)
+	}
 	if rawURL == "" {
 		if strings.TrimSpace(viper.ConfigFileUsed()) == "" {
 			return "", "", "", errors.New("no config file loaded; set `onepoint.url` in config or pass --url")
@@ -105,10 +150,24 @@ func ensureAuthenticatedWithStateFile(urlOverride, stateFilePath string) (cookie
 	}
 
 	fmt.Println("Not logged in to OnePoint. Opening browser for login...")
-	cookieHeader, err = runBrowserLogin(baseURL, homeURL, host, stateFile, 10*time.Minute, false)
+	cookieHeader, err = loginAndReloadCookies(baseURL, homeURL, host, stateFile)
 	return
 }
 
+// loginAndReloadCookies runs the interactive browser login and reloads the
+// session cookie header from the freshly written auth state file. The header
+// returned by the login flow is used when the state file cannot be re-read.
+func loginAndReloadCookies(baseURL, homeURL, host, stateFile string) (string, error) {
+	cookieHeader, err := runBrowserLogin(baseURL, homeURL, host, stateFile, 10*time.Minute, false)
+	if err != nil {
+		return "", err
+	}
+	if reloaded, readErr := onepoint.SessionCookieHeaderFromStateFile(stateFile, host); readErr == nil {
+		return reloaded, nil
+	}
+	return cookieHeader, nil
+}
+
 // ensureAuthenticated returns a valid session cookie header, triggering an
 // interactive browser login automatically if the auth state is missing or
 // incomplete.
@@ -117,7 +176,25 @@ func ensureAuthenticated(urlOverride, stateFilePath string) (cookieHeader, baseU
 	return
 }
 
+// retryWithRelogin runs operation with a OnePoint client whose read-only
+// requests are retried up to maxRetries times (onepoint.max_retries) and logs
+// in again once when OnePoint rejects the session.
 func retryWithRelogin[T any](
+	maxRetries int,
+	baseURL, homeURL, host, stateFile, userAgent string,
+	cookieHeader *string,
+	operation func(client onepoint.Client) (T, error),
+) (T, error) {
+	return retryWithReloginUsing(nil, nil, maxRetries, baseURL, homeURL, host, stateFile, userAgent, cookieHeader, operation)
+}
+
+// retryWithReloginUsing is retryWithRelogin with an explicit HTTP doer for the
+// OnePoint client, e.g. a logging decorator, and a rate limiter shared by the
+// clients it builds; nil uses the default client and no pacing.
+func retryWithReloginUsing[T any](
+	httpClient onepoint.HTTPDoer,
+	limiter *onepoint.RateLimiter,
+	maxRetries int,
 	baseURL, homeURL, host, stateFile, userAgent string,
 	cookieHeader *string,
 	operation func(client onepoint.Client) (T, error),
@@ -127,16 +204,80 @@ func retryWithRelogin[T any](
 		return zero, errors.New("cookie header pointer is required")
 	}
 
+	session := newSharedCookieSession(*cookieHeader, func() (string, error) {
+		return loginAndReloadCookies(baseURL, homeURL, host, stateFile)
+	})
+	result, err := retryWithSharedSession(httpClient, limiter, maxRetries, baseURL, homeURL, userAgent, session, operation)
+	*cookieHeader = session.current()
+	return result, err
+}
+
+// sharedCookieSession is a session cookie header shared by concurrent
+// OnePoint calls. When several calls are rejected at once, only the first
+// runs the browser login; the others wait for it and reuse its header.
+type sharedCookieSession struct {
+	mu     sync.Mutex
+	header string
+	login  func() (string, error)
+}
+
+func newSharedCookieSession(header string, login func() (string, error)) *sharedCookieSession {
+	return &sharedCookieSession{header: header, login: login}
+}
+
+func (s *sharedCookieSession) current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.header
+}
+
+// relogin returns a fresh header for a call rejected with stale. When another
+// call already replaced stale, its header is returned without a new login.
+func (s *sharedCookieSession) relogin(stale string, cause error) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.header != stale {
+		return s.header, nil
+	}
+
+	if errors.Is(cause, onepoint.ErrSessionExpired) {
+		fmt.Println("OnePoint returned its login page instead of data, the session expired. Opening browser for login...")
+	} else {
+		fmt.Println("OnePoint session expired. Opening browser for login...")
+	}
+	header, err := s.login()
+	if err != nil {
+		return "", err
+	}
+	s.header = header
+	return header, nil
+}
+
+// retryWithSharedSession runs operation with the current header of session
+// and, when OnePoint rejects the session, once more after a shared re-login.
+func retryWithSharedSession[T any](
+	httpClient onepoint.HTTPDoer,
+	limiter *onepoint.RateLimiter,
+	maxRetries int,
+	baseURL, homeURL, userAgent string,
+	session *sharedCookieSession,
+	operation func(client onepoint.Client) (T, error),
+) (T, error) {
+	var zero T
 	newClient := func(header string) (onepoint.Client, error) {
 		return onepoint.NewClient(onepoint.ClientConfig{
 			BaseURL:        baseURL,
 			RefererURL:     homeURL,
 			SessionCookies: header,
 			UserAgent:      userAgent,
+			HTTPClient:     httpClient,
+			MaxRetries:     maxRetries,
+			RateLimiter:    limiter,
 		})
 	}
 
-	client, err := newClient(*cookieHeader)
+	header := session.current()
+	client, err := newClient(header)
 	if err != nil {
 		return zero, err
 	}
@@ -149,14 +290,11 @@ func retryWithRelogin[T any](
 		return zero, err
 	}
 
-	fmt.Println("OnePoint session expired. Opening browser for login...")
-	refreshedHeader, loginErr := runBrowserLogin(baseURL, homeURL, host, stateFile, 10*time.Minute, false)
+	refreshedHeader, loginErr := session.relogin(header, err)
 	if loginErr != nil {
 		return zero, loginErr
 	}
-	*cookieHeader = refreshedHeader
-
-	client, err = newClient(*cookieHeader)
+	client, err = newClient(refreshedHeader)
 	if err != nil {
 		return zero, err
 	}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -115,6 +120,72 @@ func TestResolveOnePointURLs_NoConfigAndNoOverride(t *testing.T) {
 	}
 }
 
+func TestResolveOnePointURLs_ProfilePrecedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configPath := filepath.Join(t.TempDir(), "gohour.yaml")
+	content := `onepoint:
+  url: "https://default.example.com/onepoint/faces/home"
+profiles:
+  client-a:
+    url: "https://a.example.com/onepoint/faces/home"
+  client-b:
+    url: "https://b.example.com/onepoint/faces/home"
+    state_file: "/tmp/client-b.json"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	viper.Reset()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	t.Cleanup(func() {
+		viper.Reset()
+		onePointProfile = ""
+	})
+
+	tests := []struct {
+		name      string
+		profile   string
+		urlFlag   string
+		stateFlag string
+		wantHost  string
+		wantState string
+	}{
+		{name: "no profile uses onepoint.url", wantHost: "default.example.com", wantState: filepath.Join(home, ".gohour", "onepoint-auth-state.json")},
+		{name: "profile url and derived state file", profile: "client-a", wantHost: "a.example.com", wantState: filepath.Join(home, ".gohour", "onepoint-auth-state-client-a.json")},
+		{name: "profile state file", profile: "Client-B", wantHost: "b.example.com", wantState: "/tmp/client-b.json"},
+		{name: "explicit flags win over profile", profile: "client-b", urlFlag: "https://other.example.com/onepoint/faces/home", stateFlag: "./state.json", wantHost: "other.example.com", wantState: "./state.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onePointProfile = tt.profile
+			_, _, host, err := resolveOnePointURLs(tt.urlFlag)
+			if err != nil {
+				t.Fatalf("resolve urls: %v", err)
+			}
+			if host != tt.wantHost {
+				t.Fatalf("expected host %q, got %q", tt.wantHost, host)
+			}
+			stateFile, err := resolveDefaultAuthStatePath(tt.stateFlag)
+			if err != nil {
+				t.Fatalf("resolve state file: %v", err)
+			}
+			if stateFile != tt.wantState {
+				t.Fatalf("expected state file %q, got %q", tt.wantState, stateFile)
+			}
+		})
+	}
+
+	onePointProfile = "client-c"
+	_, _, _, err := resolveOnePointURLs("https://other.example.com/onepoint/faces/home")
+	if err == nil || !strings.Contains(err.Error(), "available: client-a, client-b") {
+		t.Fatalf("expected unknown profile error even with --url, got %v", err)
+	}
+}
+
 func TestEnsureAuthenticated_AlreadyLoggedIn(t *testing.T) {
 	stateFile := filepath.Join(t.TempDir(), "state.json")
 	stateJSON := `{
@@ -233,6 +304,7 @@ func TestRetryWithRelogin_RetriesOnceOnUnauthorized(t *testing.T) {
 
 	attempts := 0
 	result, err := retryWithRelogin(
+		2,
 		"https://onepoint.virtual7.io",
 		"https://onepoint.virtual7.io/onepoint/faces/home",
 		"onepoint.virtual7.io",
@@ -278,6 +350,7 @@ func TestRetryWithRelogin_DoesNotRetryOnNonUnauthorized(t *testing.T) {
 
 	wantErr := errors.New("boom")
 	_, err := retryWithRelogin(
+		2,
 		"https://onepoint.virtual7.io",
 		"https://onepoint.virtual7.io/onepoint/faces/home",
 		"onepoint.virtual7.io",
@@ -295,3 +368,65 @@ func TestRetryWithRelogin_DoesNotRetryOnNonUnauthorized(t *testing.T) {
 		t.Fatalf("did not expect browser login call")
 	}
 }
+
+func TestRetryWithSharedSession_ConcurrentUnauthorizedLogsInOnce(t *testing.T) {
+	const workers = 3
+	var stale sync.WaitGroup
+	stale.Add(workers)
+	doer := submitFakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		if strings.Contains(r.Header.Get("Cookie"), "JSESSIONID=old") {
+			// Answer only once every worker was rejected, so all of them
+			// need a new session at the same time.
+			stale.Done()
+			stale.Wait()
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     make(http.Header),
+			}, nil
+		}
+		return submitJSONResponse([]onepoint.Project{}), nil
+	}}
+
+	var logins atomic.Int32
+	session := newSharedCookieSession("JSESSIONID=old", func() (string, error) {
+		logins.Add(1)
+		return "JSESSIONID=new", nil
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := retryWithSharedSession(
+				doer,
+				nil,
+				2,
+				"https://onepoint.virtual7.io",
+				"https://onepoint.virtual7.io/onepoint/faces/home",
+				"gohour-test/1.0",
+				session,
+				func(client onepoint.Client) ([]onepoint.Project, error) {
+					return client.ListProjects(context.Background())
+				},
+			)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := logins.Load(); got != 1 {
+		t.Fatalf("expected one shared login, got %d", got)
+	}
+	if session.current() != "JSESSIONID=new" {
+		t.Fatalf("expected refreshed header, got %q", session.current())
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/riadshalaby/gohour/onepoint"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	authStatusStateFile string
+	authStatusURL       string
+)
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check whether the saved auth state is still accepted by OnePoint.",
+	Long: `Load the auth state JSON, extract the session cookies and make a single
+read-only ListProjects call to OnePoint.
+
+Prints "valid (N projects visible)" when OnePoint accepts the session. A missing
+or incomplete state file, or a session OnePoint rejects, is reported as invalid or
+expired and the command exits non-zero; run "gohour auth login" to renew it.
+
+Before calling OnePoint, the command also warns when the saved JSESSIONID cookie
+has an expiry date in the past.`,
+	Example: `
+  # Check the default auth state file
+  gohour auth status
+
+  # Check a specific state file against another OnePoint URL
+  gohour auth status --state-file ./state.json --url https://onepoint.example.com/onepoint/faces/home
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stateFile, err := resolveDefaultAuthStatePath(authStatusStateFile)
+		if err != nil {
+			return err
+		}
+		baseURL, homeURL, host, err := resolveOnePointURLs(authStatusURL)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return runAuthStatus(ctx, os.Stdout, stateFile, baseURL, homeURL, host, nil, time.Now())
+	},
+}
+
+// runAuthStatus checks stateFile against OnePoint and prints the outcome to
+// out. httpClient may be nil to use the default HTTP client.
+func runAuthStatus(ctx context.Context, out io.Writer, stateFile, baseURL, homeURL, host string, httpClient onepoint.HTTPDoer, now time.Time) error {
+	cookieHeader, err := onepoint.SessionCookieHeaderFromStateFile(stateFile, host)
+	if err != nil {
+		return fmt.Errorf("auth state %s is invalid: %w", stateFile, err)
+	}
+
+	if expires, ok, err := onepoint.SessionCookieExpiryFromStateFile(stateFile, host); err == nil && ok && expires.Before(now) {
+		fmt.Fprintf(out, "Warning: %s cookie expired at %s\n", onepoint.SessionCookieJSESSIONID, expires.Local().Format(time.RFC3339))
+	}
+
+	client, err := onepoint.NewClient(onepoint.ClientConfig{
+		BaseURL:        baseURL,
+		RefererURL:     homeURL,
+		SessionCookies: cookieHeader,
+		UserAgent:      "gohour-auth/1.0",
+		HTTPClient:     httpClient,
+	})
+	if err != nil {
+		return err
+	}
+
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		if errors.Is(err, onepoint.ErrAuthUnauthorized) {
+			return fmt.Errorf("auth state %s has expired; run \"gohour auth login\": %w", stateFile, err)
+		}
+		return fmt.Errorf("auth check failed (ListProjects): %w", err)
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("auth state %s has expired; run \"gohour auth login\": %w: no projects visible", stateFile, onepoint.ErrAuthUnauthorized)
+	}
+
+	fmt.Fprintf(out, "valid (%d projects visible)\n", len(projects))
+	return nil
+}
+
+func init() {
+	authCmd.AddCommand(authStatusCmd)
+
+	authStatusCmd.Flags().StringVar(&authStatusStateFile, "state-file", "", "Path to auth state JSON (default: $HOME/.gohour/onepoint-auth-state.json)")
+	authStatusCmd.Flags().StringVar(&authStatusURL, "url", "", "Override OnePoint URL from config (full home URL)")
+}
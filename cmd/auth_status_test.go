@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+func writeAuthStatusState(t *testing.T, expires float64) string {
+	t.Helper()
+	stateJSON := fmt.Sprintf(`{
+  "cookies": [
+    {"name":"JSESSIONID","value":"abc","domain":"onepoint.virtual7.io","path":"/","expires":%g}
+  ],
+  "origins": []
+}`, expires)
+	file := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(file, []byte(stateJSON), 0o600); err != nil {
+		t.Fatalf("write state file: %v", err)
+	}
+	return file
+}
+
+func TestRunAuthStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	projectsDoer := submitFakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		return submitJSONResponse([]onepoint.Project{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}}), nil
+	}}
+	unauthorizedDoer := submitFakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+		}, nil
+	}}
+
+	tests := []struct {
+		name        string
+		expires     float64
+		doer        onepoint.HTTPDoer
+		wantOutput  []string
+		wantErr     string
+		wantWarning bool
+	}{
+		{
+			name:       "valid session cookie",
+			expires:    -1,
+			doer:       projectsDoer,
+			wantOutput: []string{"valid (2 projects visible)"},
+		},
+		{
+			name:        "past cookie expiry warns before the call",
+			expires:     float64(now.Add(-time.Hour).Unix()),
+			doer:        unauthorizedDoer,
+			wantErr:     "has expired",
+			wantWarning: true,
+		},
+		{
+			name:    "rejected session",
+			expires: float64(now.Add(time.Hour).Unix()),
+			doer:    unauthorizedDoer,
+			wantErr: "has expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateFile := writeAuthStatusState(t, tt.expires)
+			var out bytes.Buffer
+			err := runAuthStatus(
+				context.Background(),
+				&out,
+				stateFile,
+				"https://onepoint.virtual7.io",
+				"https://onepoint.virtual7.io/onepoint/faces/home",
+				"onepoint.virtual7.io",
+				tt.doer,
+				now,
+			)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !errors.Is(err, onepoint.ErrAuthUnauthorized) {
+					t.Fatalf("expected %q error, got %v", tt.wantErr, err)
+				}
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Fatalf("expected output to contain %q, got %q", want, out.String())
+				}
+			}
+			if got := strings.Contains(out.String(), "Warning: JSESSIONID cookie expired"); got != tt.wantWarning {
+				t.Fatalf("expected expiry warning=%t, got output %q", tt.wantWarning, out.String())
+			}
+		})
+	}
+}
+
+func TestRunAuthStatus_MissingStateFileIsInvalid(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	err := runAuthStatus(
+		context.Background(),
+		&out,
+		filepath.Join(t.TempDir(), "missing.json"),
+		"https://onepoint.virtual7.io",
+		"https://onepoint.virtual7.io/onepoint/faces/home",
+		"onepoint.virtual7.io",
+		nil,
+		time.Now(),
+	)
+	if err == nil || !errors.Is(err, onepoint.ErrAuthStateNotFound) || !strings.Contains(err.Error(), "is invalid") {
+		t.Fatalf("expected invalid auth state error, got %v", err)
+	}
+}
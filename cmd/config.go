@@ -9,8 +9,46 @@ var configCmd = &cobra.Command{
 
 The configuration stores application-wide values and import rules:
 - onepoint.url
+- onepoint.project_code_pattern
+- onepoint.selectable_project_statuses
+- onepoint.ignore_diacritics
+- onepoint.requests_per_second
+- onepoint.max_retries
 - import.auto_reconcile_after_import
-- rules[].mapper / file_template / billable / project_id+project / activity_id+activity / skill_id+skill`,
+- import.insert_batch_size
+- import.store_sources
+- import.epm_day_total_check
+- import.epm_day_total_tolerance_minutes
+- import.epm_break_threshold_minutes
+- import.epm_break_minutes
+- import.file_duplicate_check
+- import.detect_mapper
+- reconcile.skip_days_with_manual_entries
+- reconcile.floating_mappers
+- reconcile.workday_end
+- submit.verify_persist_results
+- submit.comment_sanitization
+- submit.default_range
+- submit.webhook_url
+- submit.skip_unchanged_days
+- submit.ticket_pattern
+- submit.ticket_template
+- submit.require_ticket
+- submit.retry_on_conflict
+- web.max_entries_per_day
+- web.tag_colors
+- web.daily_target_hours
+- web.workdays
+- web.hide_empty_days
+- web.delete_local_on_remote_delete
+- web.contract_monthly_hours
+- web.session_ping_minutes
+- timezone
+- activity_default_skills
+- dry_run_by_default
+- max_daily_hours
+- profiles.<name>.url / state_file
+- rules[].mapper / file_template / billable / project_id+project / activity_id+activity / skill_id+skill / source_format_label`,
 	Example: `
   # Create default config in $HOME/.gohour.yaml
   gohour config create
@@ -21,18 +21,20 @@ import (
 )
 
 var (
-	configRuleAddAuthStateFile  string
-	configRuleAddURL            string
-	configRuleAddTimeout        time.Duration
-	configRuleAddIncludeArchive bool
-	configRuleAddIncludeLocked  bool
+	configRuleAddAuthStateFile   string
+	configRuleAddURL             string
+	configRuleAddTimeout         time.Duration
+	configRuleAddIncludeArchive  bool
+	configRuleAddIncludeLocked   bool
+	configRuleAddIncludeInactive bool
 )
 
 var configRuleAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Interactively add one import rule from OnePoint lookups.",
-	Long: `Fetch projects, activities, and skills from OnePoint for the logged-in user,
-let you choose each entry interactively, then store a new rules entry in config.`,
+	Long: `Fetch projects from OnePoint for the logged-in user and let you choose one,
+then fetch only the chosen project's activities and the chosen activity's skills,
+and store a new rules entry in config.`,
 	Example: `
   # Add one rule interactively using onepoint.url from config and default auth state file
   gohour config rule add
@@ -52,27 +54,29 @@ let you choose each entry interactively, then store a new rules entry in config.
 		if err := viper.ReadInConfig(); err != nil {
 			return fmt.Errorf("read config %q: %w", configPath, err)
 		}
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
 
 		cookieHeader, baseURL, homeURL, host, stateFile, err := ensureAuthenticatedWithStateFile(configRuleAddURL, configRuleAddAuthStateFile)
 		if err != nil {
 			return err
 		}
 
-		snapshot, err := retryWithRelogin(
-			baseURL,
-			homeURL,
-			host,
-			stateFile,
-			"gohour-config-rule/1.0",
-			&cookieHeader,
-			func(client onepoint.Client) (onepoint.LookupSnapshot, error) {
-				ctx, cancel := context.WithTimeout(context.Background(), configRuleAddTimeout)
-				defer cancel()
-				return client.FetchLookupSnapshot(ctx)
-			},
-		)
+		lookup := configRuleAddLookup{
+			maxRetries:   cfg.OnePoint.MaxRetries,
+			baseURL:      baseURL,
+			homeURL:      homeURL,
+			host:         host,
+			stateFile:    stateFile,
+			cookieHeader: &cookieHeader,
+		}
+		allProjects, err := fetchConfigRuleLookup(lookup, func(ctx context.Context, client onepoint.Client) ([]onepoint.Project, error) {
+			return client.ListProjects(ctx)
+		})
 		if err != nil {
-			return fmt.Errorf("fetch OnePoint lookup values: %w", err)
+			return fmt.Errorf("fetch OnePoint projects: %w", err)
 		}
 
 		reader := bufio.NewReader(os.Stdin)
@@ -91,7 +95,12 @@ let you choose each entry interactively, then store a new rules entry in config.
 		}
 		selectedMapper := mapperNames[selectedMapperIdx]
 
-		projects := filterProjects(snapshot.Projects, configRuleAddIncludeArchive)
+		projects := filterProjects(
+			allProjects,
+			configRuleAddIncludeArchive,
+			cfg.OnePoint.SelectableProjectStatuses,
+			configRuleAddIncludeInactive,
+		)
 		if len(projects) == 0 {
 			return fmt.Errorf("no selectable projects found")
 		}
@@ -120,7 +129,13 @@ let you choose each entry interactively, then store a new rules entry in config.
 		}
 		selectedProject := projects[selectedProjectIdx]
 
-		activities := filterActivities(snapshot.Activities, selectedProject.ID, configRuleAddIncludeLocked)
+		projectActivities, err := fetchConfigRuleLookup(lookup, func(ctx context.Context, client onepoint.Client) ([]onepoint.Activity, error) {
+			return client.ListProjectActivities(ctx, selectedProject.ID)
+		})
+		if err != nil {
+			return fmt.Errorf("fetch OnePoint activities for project %q: %w", selectedProject.Name, err)
+		}
+		activities := filterActivities(projectActivities, selectedProject.ID, configRuleAddIncludeLocked)
 		if len(activities) == 0 {
 			return fmt.Errorf("no selectable activities found for project %q", selectedProject.Name)
 		}
@@ -144,7 +159,13 @@ let you choose each entry interactively, then store a new rules entry in config.
 		}
 		selectedActivity := activities[selectedActivityIdx]
 
-		skills := filterSkills(snapshot.Skills, selectedActivity.ID)
+		activitySkills, err := fetchConfigRuleLookup(lookup, func(ctx context.Context, client onepoint.Client) ([]onepoint.Skill, error) {
+			return client.ListActivitySkills(ctx, selectedActivity.ID)
+		})
+		if err != nil {
+			return fmt.Errorf("fetch OnePoint skills for activity %q: %w", selectedActivity.Name, err)
+		}
+		skills := filterSkills(activitySkills, selectedActivity.ID)
 		if len(skills) == 0 {
 			return fmt.Errorf("no selectable skills found for activity %q", selectedActivity.Name)
 		}
@@ -222,13 +243,40 @@ let you choose each entry interactively, then store a new rules entry in config.
 	},
 }
 
-func filterProjects(projects []onepoint.Project, includeArchived bool) []onepoint.Project {
-	if includeArchived {
-		return append([]onepoint.Project(nil), projects...)
-	}
+// configRuleAddLookup holds the OnePoint session that config rule add reuses
+// for its project, activity and skill requests.
+type configRuleAddLookup struct {
+	maxRetries                        int
+	baseURL, homeURL, host, stateFile string
+	cookieHeader                      *string
+}
+
+// fetchConfigRuleLookup runs one lookup request with the command timeout,
+// retrying and logging in again like the other OnePoint commands.
+func fetchConfigRuleLookup[T any](lookup configRuleAddLookup, fetch func(ctx context.Context, client onepoint.Client) (T, error)) (T, error) {
+	return retryWithRelogin(
+		lookup.maxRetries,
+		lookup.baseURL,
+		lookup.homeURL,
+		lookup.host,
+		lookup.stateFile,
+		"gohour-config-rule/1.0",
+		lookup.cookieHeader,
+		func(client onepoint.Client) (T, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), configRuleAddTimeout)
+			defer cancel()
+			return fetch(ctx, client)
+		},
+	)
+}
+
+func filterProjects(projects []onepoint.Project, includeArchived bool, statuses []int64, includeInactive bool) []onepoint.Project {
 	out := make([]onepoint.Project, 0, len(projects))
 	for _, project := range projects {
-		if project.IsArchived() {
+		if project.IsArchived() && !includeArchived {
+			continue
+		}
+		if !project.HasSelectableStatus(statuses) && !includeInactive {
 			continue
 		}
 		out = append(out, project)
@@ -453,5 +501,6 @@ func init() {
 	configRuleAddCmd.Flags().StringVar(&configRuleAddAuthStateFile, "state-file", "", "Path to auth state JSON (default: $HOME/.gohour/onepoint-auth-state.json)")
 	configRuleAddCmd.Flags().DurationVar(&configRuleAddTimeout, "timeout", 60*time.Second, "Timeout for OnePoint lookup API calls")
 	configRuleAddCmd.Flags().BoolVar(&configRuleAddIncludeArchive, "include-archived-projects", false, "Include archived projects in project selection")
+	configRuleAddCmd.Flags().BoolVar(&configRuleAddIncludeInactive, "include-inactive-projects", false, "Include projects outside onepoint.selectable_project_statuses in project selection")
 	configRuleAddCmd.Flags().BoolVar(&configRuleAddIncludeLocked, "include-locked-activities", false, "Include locked activities in activity selection")
 }
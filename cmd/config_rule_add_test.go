@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/onepoint"
 )
 
 func TestAppendRuleToConfigYAML_AppendsRule(t *testing.T) {
@@ -132,3 +133,28 @@ import:
 		t.Fatalf("unexpected added rule: %+v", cfg.Rules[0])
 	}
 }
+
+func TestFilterProjects_StatusHandling(t *testing.T) {
+	t.Parallel()
+
+	projects := []onepoint.Project{
+		{ID: 1, Name: "Active", Archived: "0", Status: 0},
+		{ID: 2, Name: "Inactive", Archived: "0", Status: 3},
+		{ID: 3, Name: "Archived", Archived: "1", Status: 0},
+	}
+
+	got := filterProjects(projects, false, []int64{0}, false)
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("expected only active project, got %+v", got)
+	}
+
+	got = filterProjects(projects, false, []int64{0}, true)
+	if len(got) != 2 || got[1].ID != 2 {
+		t.Fatalf("expected active and inactive projects, got %+v", got)
+	}
+
+	got = filterProjects(projects, false, nil, false)
+	if len(got) != 2 {
+		t.Fatalf("expected status filter to be disabled without statuses, got %+v", got)
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/riadshalaby/gohour/config"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configRuleListJSON bool
+
+var configRuleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured import rules.",
+	Long: `Print the import rules of the active config file as a table
+(name, mapper, file_template, project, activity and skill with their ids).
+
+Use --json to print the rules as a JSON array using the config key names.`,
+	Example: `
+  # List rules as a table
+  gohour config rule list
+
+  # List rules as JSON
+  gohour config rule list --json
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, err := resolveConfigEditPath(cfgFile, viper.ConfigFileUsed())
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(configPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("config file %s does not exist (run: gohour config create)", configPath)
+			}
+			return fmt.Errorf("read config %q: %w", configPath, err)
+		}
+		cfg, err := config.ValidateYAMLContent(content)
+		if err != nil {
+			return fmt.Errorf("config validation failed in %s: %w", configPath, err)
+		}
+
+		return writeRuleList(os.Stdout, cfg.Rules, configRuleListJSON)
+	},
+}
+
+// writeRuleList prints rules as a table, or as JSON when asJSON is set.
+func writeRuleList(out io.Writer, rules []config.Rule, asJSON bool) error {
+	if asJSON {
+		if rules == nil {
+			rules = []config.Rule{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rules)
+	}
+
+	if len(rules) == 0 {
+		_, err := fmt.Fprintln(out, "No rules configured. Add one with: gohour config rule add")
+		return err
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tMAPPER\tFILE_TEMPLATE\tPROJECT\tACTIVITY\tSKILL")
+	for _, rule := range rules {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s (%d)\t%s (%d)\t%s (%d)\n",
+			rule.Name,
+			rule.Mapper,
+			rule.FileTemplate,
+			rule.Project,
+			rule.ProjectID,
+			rule.Activity,
+			rule.ActivityID,
+			rule.Skill,
+			rule.SkillID,
+		)
+	}
+	return writer.Flush()
+}
+
+func init() {
+	configRuleCmd.AddCommand(configRuleListCmd)
+
+	configRuleListCmd.Flags().BoolVar(&configRuleListJSON, "json", false, "Print rules as JSON")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/riadshalaby/gohour/config"
+)
+
+func TestWriteRuleList_TableAndJSON(t *testing.T) {
+	t.Parallel()
+
+	billable := false
+	rules := []config.Rule{
+		{
+			Name:         "rz",
+			Mapper:       "epm",
+			FileTemplate: "EPMExportRZ*.xlsx",
+			Billable:     &billable,
+			ProjectID:    1,
+			Project:      "Project A",
+			ActivityID:   2,
+			Activity:     "Delivery",
+			SkillID:      3,
+			Skill:        "Go",
+		},
+	}
+
+	var table bytes.Buffer
+	if err := writeRuleList(&table, rules, false); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "NAME") {
+		t.Fatalf("unexpected table:\n%s", table.String())
+	}
+	for _, want := range []string{"rz", "epm", "EPMExportRZ*.xlsx", "Project A (1)", "Delivery (2)", "Go (3)"} {
+		if !strings.Contains(lines[1], want) {
+			t.Fatalf("expected %q in row %q", want, lines[1])
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeRuleList(&out, rules, true); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, out.String())
+	}
+	if len(decoded) != 1 || decoded[0]["file_template"] != "EPMExportRZ*.xlsx" || decoded[0]["project_id"] != 1.0 || decoded[0]["billable"] != false {
+		t.Fatalf("unexpected json: %s", out.String())
+	}
+}
+
+func TestWriteRuleList_Empty(t *testing.T) {
+	t.Parallel()
+
+	var table bytes.Buffer
+	if err := writeRuleList(&table, nil, false); err != nil {
+		t.Fatalf("write table: %v", err)
+	}
+	if !strings.Contains(table.String(), "No rules configured") {
+		t.Fatalf("expected friendly empty message, got %q", table.String())
+	}
+
+	var out bytes.Buffer
+	if err := writeRuleList(&out, nil, true); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Fatalf("expected empty json array, got %q", out.String())
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/riadshalaby/gohour/config"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var configRuleRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove one import rule by name.",
+	Long: `Remove the rules entry whose name matches (case-insensitive) from the config file.
+
+The updated config is validated before it is written back.`,
+	Example: `
+  # Remove the rule named "rz"
+  gohour config rule remove rz
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, err := resolveConfigEditPath(cfgFile, viper.ConfigFileUsed())
+		if err != nil {
+			return err
+		}
+
+		current, err := os.ReadFile(configPath)
+		if err != nil {
+			return fmt.Errorf("read config file: %w", err)
+		}
+
+		updated, removed, err := removeRuleFromConfigYAML(current, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(configPath, updated, 0o600); err != nil {
+			return fmt.Errorf("write config file: %w", err)
+		}
+
+		fmt.Println("Rule removed successfully.")
+		fmt.Printf("Config:   %s\n", configPath)
+		fmt.Printf("Name:     %s\n", removed.Name)
+		fmt.Printf("Mapper:   %s\n", removed.Mapper)
+		fmt.Printf("Template: %s\n", removed.FileTemplate)
+		return nil
+	},
+}
+
+// removeRuleFromConfigYAML removes the rule named name (case-insensitive,
+// like the duplicate check in appendRuleToConfigYAML) and returns the
+// validated YAML together with the removed rule.
+func removeRuleFromConfigYAML(content []byte, name string) ([]byte, config.Rule, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, config.Rule{}, fmt.Errorf("rule name is required")
+	}
+
+	doc := map[string]any{}
+	if strings.TrimSpace(string(content)) != "" {
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, config.Rule{}, fmt.Errorf("parse config yaml: %w", err)
+		}
+	}
+
+	rulesList, err := ensureSliceAny(doc, "rules")
+	if err != nil {
+		return nil, config.Rule{}, err
+	}
+
+	kept := make([]any, 0, len(rulesList))
+	var removed config.Rule
+	found := false
+	for _, existing := range rulesList {
+		ruleMap, ok := existing.(map[string]any)
+		if !ok {
+			kept = append(kept, existing)
+			continue
+		}
+		existingName, _ := ruleMap["name"].(string)
+		if found || !strings.EqualFold(strings.TrimSpace(existingName), name) {
+			kept = append(kept, existing)
+			continue
+		}
+		found = true
+		removed.Name = existingName
+		removed.Mapper, _ = ruleMap["mapper"].(string)
+		removed.FileTemplate, _ = ruleMap["file_template"].(string)
+	}
+	if !found {
+		return nil, config.Rule{}, fmt.Errorf("no rule with name %q found", name)
+	}
+	doc["rules"] = kept
+
+	updated, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, config.Rule{}, fmt.Errorf("marshal updated config yaml: %w", err)
+	}
+	if _, err := config.ValidateYAMLContent(updated); err != nil {
+		return nil, config.Rule{}, fmt.Errorf("updated config is invalid: %w", err)
+	}
+	return updated, removed, nil
+}
+
+func init() {
+	configRuleCmd.AddCommand(configRuleRemoveCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/riadshalaby/gohour/config"
+)
+
+func TestRemoveRuleFromConfigYAML_RemovesMatchingRule(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`onepoint:
+  url: "https://onepoint.virtual7.io"
+rules: []
+`)
+	for _, rule := range []config.Rule{
+		{Name: "rz", Mapper: "epm", FileTemplate: "EPMExportRZ*.xlsx", ProjectID: 1, Project: "Project A", ActivityID: 2, Activity: "Activity A", SkillID: 3, Skill: "Skill A"},
+		{Name: "sz", Mapper: "epm", FileTemplate: "EPMExportSZ*.xlsx", ProjectID: 10, Project: "Project B", ActivityID: 20, Activity: "Activity B", SkillID: 30, Skill: "Skill B"},
+	} {
+		updated, err := appendRuleToConfigYAML(content, rule)
+		if err != nil {
+			t.Fatalf("append rule %s: %v", rule.Name, err)
+		}
+		content = updated
+	}
+
+	updated, removed, err := removeRuleFromConfigYAML(content, "RZ")
+	if err != nil {
+		t.Fatalf("remove rule: %v", err)
+	}
+	if removed.Name != "rz" || removed.FileTemplate != "EPMExportRZ*.xlsx" {
+		t.Fatalf("unexpected removed rule: %+v", removed)
+	}
+
+	cfg, err := config.ValidateYAMLContent(updated)
+	if err != nil {
+		t.Fatalf("updated config should validate: %v", err)
+	}
+	if len(cfg.Rules) != 1 || cfg.Rules[0].Name != "sz" || cfg.Rules[0].ProjectID != 10 {
+		t.Fatalf("expected only rule sz to remain, got %+v", cfg.Rules)
+	}
+}
+
+func TestRemoveRuleFromConfigYAML_UnknownName(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`onepoint:
+  url: "https://onepoint.virtual7.io"
+rules: []
+`)
+	_, _, err := removeRuleFromConfigYAML(content, "missing")
+	if err == nil || !strings.Contains(err.Error(), `no rule with name "missing" found`) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
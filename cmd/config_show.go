@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"github.com/spf13/viper"
+	"sort"
 
 	"github.com/riadshalaby/gohour/config"
 	"github.com/spf13/cobra"
@@ -29,7 +30,53 @@ This command validates the configuration before printing values.`,
 			fmt.Println("Config file loaded from:", viper.ConfigFileUsed())
 			fmt.Println("Configuration:")
 			fmt.Printf("onepoint.url: %s\n", cfg.OnePoint.URL)
+			fmt.Printf("onepoint.project_code_pattern: %s\n", cfg.OnePoint.ProjectCodePattern)
+			fmt.Printf("onepoint.selectable_project_statuses: %v\n", cfg.OnePoint.SelectableProjectStatuses)
+			fmt.Printf("onepoint.ignore_diacritics: %t\n", cfg.OnePoint.IgnoreDiacritics)
+			fmt.Printf("onepoint.requests_per_second: %g\n", cfg.OnePoint.RequestsPerSecond)
+			fmt.Printf("onepoint.max_retries: %d\n", cfg.OnePoint.MaxRetries)
 			fmt.Printf("import.auto_reconcile_after_import: %t\n", cfg.Import.AutoReconcileAfterImport)
+			fmt.Printf("import.insert_batch_size: %d\n", cfg.Import.InsertBatchSize)
+			fmt.Printf("import.store_sources: %t\n", cfg.Import.StoreSources)
+			fmt.Printf("import.epm_day_total_check: %t\n", cfg.Import.EPMDayTotalCheck)
+			fmt.Printf("import.epm_day_total_tolerance_minutes: %d\n", cfg.Import.EPMDayTotalToleranceMins)
+			fmt.Printf("import.epm_break_threshold_minutes: %d\n", cfg.Import.EPMBreakThresholdMins)
+			fmt.Printf("import.epm_break_minutes: %d\n", cfg.Import.EPMBreakMinutes)
+			fmt.Printf("import.file_duplicate_check: %t\n", cfg.Import.FileDuplicateCheck)
+			fmt.Printf("import.detect_mapper: %t\n", cfg.Import.DetectMapper)
+			fmt.Printf("reconcile.skip_days_with_manual_entries: %t\n", cfg.Reconcile.SkipDaysWithManualEntries)
+			fmt.Printf("reconcile.floating_mappers: %v\n", cfg.Reconcile.FloatingMappers)
+			fmt.Printf("reconcile.workday_end: %s\n", cfg.Reconcile.WorkdayEnd)
+			fmt.Printf("submit.verify_persist_results: %t\n", cfg.Submit.VerifyPersistResults)
+			fmt.Printf("submit.comment_sanitization: %s\n", cfg.Submit.CommentSanitizationMode())
+			fmt.Printf("submit.default_range: %s\n", cfg.Submit.DefaultRangeMode())
+			fmt.Printf("submit.webhook_url: %s\n", cfg.Submit.WebhookURL)
+			fmt.Printf("submit.skip_unchanged_days: %t\n", cfg.Submit.SkipUnchangedDays)
+			fmt.Printf("submit.ticket_pattern: %s\n", cfg.Submit.TicketPattern)
+			fmt.Printf("submit.ticket_template: %s\n", cfg.Submit.TicketTemplate)
+			fmt.Printf("submit.require_ticket: %t\n", cfg.Submit.RequireTicket)
+			fmt.Printf("submit.retry_on_conflict: %t\n", cfg.Submit.RetryOnConflict)
+			fmt.Printf("web.max_entries_per_day: %d\n", cfg.Web.MaxEntriesPerDay)
+			fmt.Printf("web.tag_colors: %v\n", cfg.Web.TagColors)
+			fmt.Printf("web.daily_target_hours: %g\n", cfg.Web.DailyTargetHours)
+			fmt.Printf("web.workdays: %v\n", cfg.Web.Workdays)
+			fmt.Printf("web.hide_empty_days: %t\n", cfg.Web.HideEmptyDays)
+			fmt.Printf("web.delete_local_on_remote_delete: %t\n", cfg.Web.DeleteLocalOnRemoteDelete)
+			fmt.Printf("web.contract_monthly_hours: %g\n", cfg.Web.ContractMonthlyHours)
+			fmt.Printf("web.session_ping_minutes: %d\n", cfg.Web.SessionPingMinutes)
+			fmt.Printf("timezone: %s\n", cfg.Timezone)
+			fmt.Printf("activity_default_skills: %v\n", cfg.ActivityDefaultSkills)
+			fmt.Printf("dry_run_by_default: %t\n", cfg.DryRunByDefault)
+			fmt.Printf("max_daily_hours: %g\n", cfg.MaxDailyHours)
+			profileNames := make([]string, 0, len(cfg.Profiles))
+			for name := range cfg.Profiles {
+				profileNames = append(profileNames, name)
+			}
+			sort.Strings(profileNames)
+			for _, name := range profileNames {
+				fmt.Printf("profiles.%s.url: %s\n", name, cfg.Profiles[name].URL)
+				fmt.Printf("profiles.%s.state_file: %s\n", name, cfg.Profiles[name].StateFile)
+			}
 			fmt.Printf("rules: %d\n", len(cfg.Rules))
 			for i, rule := range cfg.Rules {
 				fmt.Printf("rules[%d].name: %s\n", i, rule.Name)
@@ -46,6 +93,7 @@ This command validates the configuration before printing values.`,
 					billableStr = fmt.Sprintf("%t", *rule.Billable)
 				}
 				fmt.Printf("rules[%d].billable: %s\n", i, billableStr)
+				fmt.Printf("rules[%d].source_format_label: %s\n", i, rule.SourceFormatLabel)
 			}
 		}
 
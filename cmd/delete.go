@@ -8,11 +8,14 @@ import (
 	"os"
 	"strings"
 
+	"github.com/riadshalaby/gohour/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var (
 	deleteDBPath string
+	deleteCommit bool
 )
 
 var (
@@ -26,12 +29,29 @@ var deleteCmd = &cobra.Command{
 	Long: `Destructive database cleanup command.
 
 This command always deletes the complete SQLite database file.
-Before deletion, an interactive security prompt requires typing exactly "Y".`,
+Before deletion, an interactive security prompt requires typing exactly "Y".
+
+With dry_run_by_default: true in the config, delete only reports the file it
+would remove unless --commit is passed.`,
 	Example: `
   # Delete the complete SQLite file (requires interactive confirmation)
   gohour delete
+
+  # Delete when dry_run_by_default is enabled in config
+  gohour delete --commit
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Delete reads only dry_run_by_default, so an unrelated invalid
+		// config key does not block removing the database.
+		dryRun, err := resolveDryRun(false, deleteCommit, viper.GetBool(config.KeyDryRunByDefault))
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Printf("Dry-run: would delete database file %s (pass --commit to delete).\n", deleteDBPath)
+			return nil
+		}
+
 		confirmed, err := confirmDeletePrompt(deletePromptInput, deletePromptOutput, deleteDBPath)
 		if err != nil {
 			return err
@@ -52,6 +72,7 @@ func init() {
 	rootCmd.AddCommand(deleteCmd)
 
 	deleteCmd.Flags().StringVar(&deleteDBPath, "db", "./gohour.db", "Path to local SQLite database")
+	deleteCmd.Flags().BoolVar(&deleteCommit, "commit", false, "Delete when dry_run_by_default is enabled in config")
 }
 
 func confirmDeletePrompt(input io.Reader, output io.Writer, path string) (bool, error) {
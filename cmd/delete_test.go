@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/spf13/viper"
 )
 
 func TestConfirmDeletePrompt(t *testing.T) {
@@ -37,6 +41,38 @@ func TestConfirmDeletePrompt(t *testing.T) {
 	}
 }
 
+func TestDeleteCmd_IgnoresUnrelatedInvalidConfig(t *testing.T) {
+	previousPath, previousCommit := deleteDBPath, deleteCommit
+	t.Cleanup(func() {
+		viper.Reset()
+		deleteDBPath, deleteCommit = previousPath, previousCommit
+	})
+	viper.Reset()
+	// onepoint.url is missing, so full validation would fail.
+	viper.Set(config.KeyDryRunByDefault, true)
+	viper.Set(config.KeyMaxDailyHours, -1)
+
+	path := filepath.Join(t.TempDir(), "gohour.db")
+	if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+		t.Fatalf("write temp db file: %v", err)
+	}
+	deleteDBPath, deleteCommit = path, false
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = deleteCmd.RunE(deleteCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("delete with invalid config: %v", runErr)
+	}
+	if !strings.Contains(out, "Dry-run: would delete database file") {
+		t.Fatalf("expected dry-run output, got:\n%s", out)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected database file to be kept in dry-run: %v", err)
+	}
+}
+
 func TestRemoveDatabaseFile(t *testing.T) {
 	t.Run("deletes existing file", func(t *testing.T) {
 		path := filepath.Join(t.TempDir(), "gohour.db")
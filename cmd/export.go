@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/riadshalaby/gohour/config"
 	"github.com/riadshalaby/gohour/output"
-	"github.com/riadshalaby/gohour/storage"
+	"github.com/riadshalaby/gohour/worklog"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -15,6 +18,12 @@ var (
 	exportMode   string
 	exportOutput string
 	exportDBPath string
+	exportFrom   string
+	exportTo     string
+
+	exportNoComments bool
+	exportAnonymize  bool
+	exportMappingOut string
 )
 
 var exportCmd = &cobra.Command{
@@ -26,48 +35,104 @@ Modes:
 - raw: export each normalized worklog row
 - daily: export per-day aggregates (start/end, worked hours, billable hours, break hours)
 
-Output format can be selected explicitly via --format or inferred from --output extension.`,
+Output format can be selected explicitly via --format or inferred from --output extension.
+Use --output - to write raw CSV to stdout. --from/--to limit the export to a day range.
+
+Raw CSV columns use the generic importer names (StartDateTime, EndDateTime, Billable, Description,
+Project, Activity, Skill) with RFC3339 times. The export is not a lossless backup: the generic
+mapper reads Billable as a minutes override that also moves the end time, so rows whose billable
+minutes differ from their duration change when re-imported. An empty database produces a
+header-only file.
+
+Use --no-comments in raw mode to blank the Description column before sharing an
+export. The column structure stays the same.
+
+Use --anonymize to replace project, activity, skill, description and source file with
+stable pseudonyms ("Project-1", "Task-2", ...) before sharing an export for debugging.
+Times and durations are kept and repeated names get the same pseudonym. Local notes and
+tags are dropped. --anonymize-mapping writes the pseudonym-to-original mapping as CSV.`,
 	Example: `
   # Export rows to CSV (default mode: raw)
   gohour export --output ./worklogs.csv
 
   # Export rows to Excel (default mode: raw)
   gohour export --output ./worklogs.xlsx
+
+  # Export one month to stdout
+  gohour export --output - --from 2026-03-01 --to 2026-03-31
+
+  # Export rows without descriptions
+  gohour export --output ./worklogs.csv --no-comments
+
+  # Export with pseudonyms and keep the mapping locally
+  gohour export --output ./worklogs.csv --anonymize --anonymize-mapping ./mapping.csv
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format := exportFormat
 		if strings.TrimSpace(format) == "" {
 			format = detectExportFormat(exportOutput)
 		}
+		if exportMappingOut != "" && !exportAnonymize {
+			return fmt.Errorf("--anonymize-mapping requires --anonymize")
+		}
 
-		store, err := storage.OpenSQLite(exportDBPath)
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
+		// Notices go to stderr so they never mix with --output - data.
+		store, err := openConfiguredStoreWithNotices(exportDBPath, *cfg, os.Stderr)
 		if err != nil {
 			return err
 		}
 		defer store.Close()
 
+		loc := cfg.Location()
+		from, to, err := parseSubmitRange(exportFrom, exportTo, loc)
+		if err != nil {
+			return err
+		}
+
 		entries, err := store.ListWorklogs()
 		if err != nil {
 			return err
 		}
+		entries = filterEntriesByDayRange(entriesInLocation(entries, loc), from, to, loc)
+		if exportAnonymize {
+			anonymizer := output.NewAnonymizer()
+			entries = anonymizer.Anonymize(entries)
+			if exportMappingOut != "" {
+				if err := writeAnonymizeMapping(exportMappingOut, anonymizer.Mapping()); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Keep stdout clean for the exported data when writing to "-".
+		var status io.Writer = os.Stdout
+		if exportOutput == "-" {
+			status = os.Stderr
+		}
 
 		mode := strings.TrimSpace(strings.ToLower(exportMode))
 		switch mode {
 		case "", "raw":
-			writer, writerErr := output.WriterForFormat(format)
-			if writerErr != nil {
-				return writerErr
+			if exportNoComments {
+				entries = withoutComments(entries)
 			}
-			if err := writer.Write(exportOutput, entries); err != nil {
+			if err := writeRawExport(exportOutput, format, entries, os.Stdout); err != nil {
 				return err
 			}
-			fmt.Printf("Export completed. Rows: %d, Mode: raw, Format: %s, File: %s\n", len(entries), format, exportOutput)
+			fmt.Fprintf(status, "Export completed. Rows: %d, Mode: raw, Format: %s, File: %s\n", len(entries), format, exportOutput)
 		case "daily":
+			if exportOutput == "-" {
+				return fmt.Errorf("--output - is only supported for raw mode")
+			}
 			summaries := output.BuildDailySummaries(entries)
 			if err := output.WriteDailySummaries(exportOutput, format, summaries); err != nil {
 				return err
 			}
-			fmt.Printf("Export completed. Days: %d, Mode: daily, Format: %s, File: %s\n", len(summaries), format, exportOutput)
+			fmt.Fprintf(status, "Export completed. Days: %d, Mode: daily, Format: %s, File: %s\n", len(summaries), format, exportOutput)
 		default:
 			return fmt.Errorf("unsupported export mode: %s (supported: raw, daily)", exportMode)
 		}
@@ -75,6 +140,42 @@ Output format can be selected explicitly via --format or inferred from --output
 	},
 }
 
+// writeRawExport writes entries to path in format; "-" writes CSV to stdout.
+func writeRawExport(path, format string, entries []worklog.Entry, stdout io.Writer) error {
+	if path == "-" {
+		if strings.ToLower(strings.TrimSpace(format)) != "csv" {
+			return fmt.Errorf("--output - requires csv format, got %q", format)
+		}
+		return output.WriteCSV(stdout, entries)
+	}
+	writer, err := output.WriterForFormat(format)
+	if err != nil {
+		return err
+	}
+	return writer.Write(path, entries)
+}
+
+// withoutComments returns a copy of entries with blank descriptions.
+func withoutComments(entries []worklog.Entry) []worklog.Entry {
+	out := make([]worklog.Entry, len(entries))
+	for i, entry := range entries {
+		entry.Description = ""
+		out[i] = entry
+	}
+	return out
+}
+
+// writeAnonymizeMapping writes the pseudonym mapping of an anonymized export
+// to path as CSV.
+func writeAnonymizeMapping(path string, mapping []output.Pseudonym) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create anonymize mapping %s: %w", path, err)
+	}
+	defer file.Close()
+	return output.WriteMappingCSV(file, mapping)
+}
+
 func detectExportFormat(path string) string {
 	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
 	switch ext {
@@ -92,8 +193,13 @@ func init() {
 
 	exportCmd.Flags().StringVar(&exportMode, "mode", "raw", "Export mode: raw|daily")
 	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "Output format: csv|excel (optional, inferred from output extension)")
-	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path (- for stdout, csv only)")
 	exportCmd.Flags().StringVar(&exportDBPath, "db", "./gohour.db", "Path to local SQLite database")
+	exportCmd.Flags().StringVar(&exportFrom, "from", "", "Filter start day (inclusive), format YYYY-MM-DD")
+	exportCmd.Flags().StringVar(&exportTo, "to", "", "Filter end day (inclusive), format YYYY-MM-DD")
+	exportCmd.Flags().BoolVar(&exportNoComments, "no-comments", false, "Blank the Description column in raw exports")
+	exportCmd.Flags().BoolVar(&exportAnonymize, "anonymize", false, "Replace project/activity/skill/description/source file with stable pseudonyms")
+	exportCmd.Flags().StringVar(&exportMappingOut, "anonymize-mapping", "", "Write the pseudonym mapping of --anonymize as CSV to this path")
 
 	_ = exportCmd.MarkFlagRequired("output")
 }
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/importer"
+	"github.com/riadshalaby/gohour/output"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func exportTestEntries() []worklog.Entry {
+	return []worklog.Entry{
+		{
+			StartDateTime: time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 5, 10, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "Customer call",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+		},
+	}
+}
+
+func readExportedCSV(t *testing.T, entries []worklog.Entry) [][]string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "export.csv")
+	writer, err := output.WriterForFormat("csv")
+	if err != nil {
+		t.Fatalf("writer for format: %v", err)
+	}
+	if err := writer.Write(path, entries); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected header and 1 row, got %d rows", len(rows))
+	}
+	return rows
+}
+
+func TestExport_WithComments(t *testing.T) {
+	t.Parallel()
+
+	rows := readExportedCSV(t, exportTestEntries())
+	if rows[0][3] != "Description" {
+		t.Fatalf("unexpected header: %v", rows[0])
+	}
+	if rows[1][3] != "Customer call" {
+		t.Fatalf("expected description to be exported, got %q", rows[1][3])
+	}
+}
+
+func TestExport_NoCommentsBlanksDescription(t *testing.T) {
+	t.Parallel()
+
+	entries := exportTestEntries()
+	rows := readExportedCSV(t, withoutComments(entries))
+	withComments := readExportedCSV(t, entries)
+
+	if len(rows[0]) != len(withComments[0]) {
+		t.Fatalf("expected same column count, got %d and %d", len(rows[0]), len(withComments[0]))
+	}
+	if rows[1][3] != "" {
+		t.Fatalf("expected blank description, got %q", rows[1][3])
+	}
+	if rows[1][4] != "Project A" {
+		t.Fatalf("expected other columns to be kept, got %v", rows[1])
+	}
+	if entries[0].Description != "Customer call" {
+		t.Fatalf("expected input entries to stay untouched")
+	}
+}
+
+func TestWriteRawExport_StdoutWritesCSV(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	if err := writeRawExport("-", "csv", exportTestEntries(), &stdout); err != nil {
+		t.Fatalf("write raw export: %v", err)
+	}
+
+	rows, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("read stdout csv: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected header and 1 row, got %d rows", len(rows))
+	}
+	if _, err := time.Parse(time.RFC3339, rows[1][0]); err != nil {
+		t.Fatalf("expected RFC3339 start value, got %q: %v", rows[1][0], err)
+	}
+}
+
+func TestWriteRawExport_StdoutRejectsExcel(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	err := writeRawExport("-", "excel", exportTestEntries(), &stdout)
+	if err == nil || !strings.Contains(err.Error(), "requires csv") {
+		t.Fatalf("expected csv-only error, got %v", err)
+	}
+}
+
+func TestWriteRawExport_EmptyWritesHeaderOnly(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	if err := writeRawExport("-", "csv", nil, &stdout); err != nil {
+		t.Fatalf("write raw export: %v", err)
+	}
+
+	rows, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("read stdout csv: %v", err)
+	}
+	if len(rows) != 1 || rows[0][0] != "StartDateTime" {
+		t.Fatalf("expected header-only output, got %v", rows)
+	}
+}
+
+// Only fully billable rows map back unchanged; the generic mapper reads
+// Billable as a minutes override that moves the end time.
+func TestExport_FullyBillableRowsMapBackThroughGenericMapper(t *testing.T) {
+	t.Parallel()
+
+	entries := append(exportTestEntries(), worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 6, 13, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 6, 14, 30, 0, 0, time.Local),
+		Billable:      90,
+		Description:   "Review, \"quoted\"",
+		Project:       "Project B",
+		Activity:      "Review",
+		Skill:         "SQL",
+	})
+
+	path := filepath.Join(t.TempDir(), "export.csv")
+	if err := writeRawExport(path, "csv", entries, nil); err != nil {
+		t.Fatalf("write raw export: %v", err)
+	}
+
+	records, err := (&importer.CSVReader{}).Read(path)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if len(records) != len(entries) {
+		t.Fatalf("expected %d records, got %d", len(entries), len(records))
+	}
+
+	mapper := &importer.GenericMapper{}
+	for i, record := range records {
+		entry, ok, err := mapper.Map(record, config.Config{}, "csv", path)
+		if err != nil || !ok {
+			t.Fatalf("map record %d: ok=%v err=%v", i, ok, err)
+		}
+		want := entries[i]
+		if !entry.StartDateTime.Equal(want.StartDateTime) || !entry.EndDateTime.Equal(want.EndDateTime) {
+			t.Fatalf("record %d: unexpected times %s-%s", i, entry.StartDateTime, entry.EndDateTime)
+		}
+		if entry.Billable != want.Billable || entry.Description != want.Description ||
+			entry.Project != want.Project || entry.Activity != want.Activity || entry.Skill != want.Skill {
+			t.Fatalf("record %d: unexpected entry %+v", i, entry)
+		}
+	}
+}
+
+func TestFilterEntriesByDayRange_ForExport(t *testing.T) {
+	t.Parallel()
+
+	entries := exportTestEntries()
+	from, to, err := parseSubmitRange("2026-03-06", "2026-03-31", time.Local)
+	if err != nil {
+		t.Fatalf("parse range: %v", err)
+	}
+	if filtered := filterEntriesByDayRange(entries, from, to, time.Local); len(filtered) != 0 {
+		t.Fatalf("expected entries outside range to be dropped, got %d", len(filtered))
+	}
+}
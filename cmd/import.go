@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/riadshalaby/gohour/config"
 	"github.com/riadshalaby/gohour/importer"
 	"github.com/riadshalaby/gohour/reconcile"
 	"github.com/riadshalaby/gohour/storage"
 	"github.com/riadshalaby/gohour/worklog"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -21,15 +26,50 @@ var (
 	importActivity      string
 	importSkill         string
 	importReconcileMode string
+	importDryRun        bool
+	importMaxEntryMins  int
+	importOutput        string
 )
 
+// importJSONOutput is the --output json summary of one import run.
+type importJSONOutput struct {
+	FilesProcessed int  `json:"filesProcessed"`
+	RowsRead       int  `json:"rowsRead"`
+	RowsMapped     int  `json:"rowsMapped"`
+	RowsSkipped    int  `json:"rowsSkipped"`
+	EntriesSplit   int  `json:"entriesSplit"`
+	RowsPersisted  int  `json:"rowsPersisted"`
+	DryRun         bool `json:"dryRun,omitempty"`
+	// BatchID identifies the inserted rows for "import undo"; empty when
+	// nothing was inserted.
+	BatchID string `json:"batchId,omitempty"`
+	// NewRows and AlreadyStored are only reported for --dry-run.
+	NewRows       *int                 `json:"newRows,omitempty"`
+	AlreadyStored *int                 `json:"alreadyStored,omitempty"`
+	Reconcile     *importReconcileJSON `json:"reconcile,omitempty"`
+	// DayTotalMismatches lists EPM days whose entries miss their Tagessumme.
+	DayTotalMismatches []importer.DayTotalMismatch `json:"dayTotalMismatches,omitempty"`
+	// FileDuplicates lists rows that repeat an earlier row of their file.
+	FileDuplicates []importer.FileDuplicate `json:"fileDuplicates,omitempty"`
+}
+
+type importReconcileJSON struct {
+	DaysProcessed      int `json:"daysProcessed"`
+	DaysSkipped        int `json:"daysSkipped"`
+	OverlapsBefore     int `json:"overlapsBefore"`
+	OverlapsAfter      int `json:"overlapsAfter"`
+	EPMEntriesAdjusted int `json:"epmEntriesAdjusted"`
+	EntriesUnresolved  int `json:"entriesUnresolved"`
+	RowsUpdated        int `json:"rowsUpdated"`
+}
+
 var importCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import CSV/Excel worklogs into a local SQLite database",
 	Long: `Read source files, normalize each row via the selected mapper, and persist results in SQLite.
 
 Use mapper "epm" for EPM-style Excel exports, mapper "generic" for structured CSV/Excel inputs,
-and mapper "atwork" for UTF-16 tab-separated atwork exports.
+mapper "atwork" for UTF-16 tab-separated atwork exports, and mapper "toggl" for Toggl Track CSV exports.
 When --format is omitted, format is inferred from each input file extension.
 
 Mapper selection per input file:
@@ -39,19 +79,65 @@ Mapper selection per input file:
 For EPM-mapped files, project/activity/skill must be provided by either:
 - matching rules in configuration via file_template, or
 - explicit --project/--activity/--skill flags.
-If neither provides all values, import fails.`,
+If neither provides all values, import fails.
+
+With --dry-run, files are mapped and compared against the database, and the number of
+new vs already stored rows is reported without inserting anything.
+
+With --max-entry-minutes, mapped entries longer than the cap are split into consecutive,
+equally long rows (split at midnight first). Parts keep project/activity/skill, share the
+billable minutes and get a numbered description, e.g. "Review (1/2)".
+
+With import.epm_day_total_check (default on), EPM days whose mapped entries do not add up to
+the declared Tagessumme (a dropped or unparsable row) are reported as warnings.
+
+If no rule matches a file and --mapper is not given, the mapper is detected from the file's
+header row (import.detect_mapper, default on) and printed; undetectable files use "epm".
+
+With import.file_duplicate_check (default on), rows that map to the same entry as an earlier
+row of the same file are reported with their row numbers; the database keeps only one of them.
+
+Every import that inserts rows is recorded as a batch, like imports through the web UI. The
+summary prints its id, and "gohour import undo --batch <id>" deletes exactly the rows it
+inserted; "gohour import list-batches" lists recorded batches. With import.store_sources the
+input files are kept with the batch, so POST /api/import/{batch}/remap of "gohour serve" can
+re-run the mappers over them.
+
+With --output json, the summary (row counters, persisted rows, dry-run counts and auto-reconcile
+stats) is printed as one JSON object instead of the text lines.`,
 	Example: `
   # Import one file
   gohour import -i EPMExportRZ202601.xlsx
 
   # Import multiple files
   gohour import -i EPMExportRZ202601.xlsx -i EPMExportSZ202601.xlsx
+
+  # Preview how many rows are new before importing
+  gohour import -i EPMExportRZ202601.xlsx --dry-run
+
+  # Split entries longer than 4 hours
+  gohour import -i EPMExportRZ202601.xlsx --max-entry-minutes 240
+
+  # Print the summary as JSON for scripts
+  gohour import -i EPMExportRZ202601.xlsx --output json
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.LoadAndValidate()
 		if err != nil {
 			return err
 		}
+		if importMaxEntryMins < 0 {
+			return fmt.Errorf("invalid --max-entry-minutes %d (must be >= 0)", importMaxEntryMins)
+		}
+		jsonOutput, err := resolveImportOutput(importOutput)
+		if err != nil {
+			return err
+		}
+		// In json mode stdout only carries the JSON object.
+		notices := io.Writer(os.Stdout)
+		if jsonOutput {
+			notices = os.Stderr
+		}
 
 		result := &importer.Result{Entries: make([]worklog.Entry, 0, 256)}
 		runOptions := importer.RunOptions{
@@ -60,8 +146,17 @@ If neither provides all values, import fails.`,
 			EPMSkill:    importSkill,
 		}
 		defaultMapper := strings.TrimSpace(importMapper)
+		detectMapper := cfg.Import.DetectMapper && !cmd.Flags().Changed("mapper")
+		mapperNames := make([]string, 0, 1)
+		sources := make([]storage.ImportSource, 0)
 		for _, path := range importInputs {
-			mapperName := resolveMapperNameForFile(path, defaultMapper, cfg.Rules)
+			mapperName, detected := resolveMapperNameForFile(path, importFormat, defaultMapper, cfg.Rules, detectMapper)
+			if detected {
+				fmt.Fprintf(notices, "Auto-detected mapper %q for %s from its columns\n", mapperName, path)
+			}
+			if !slices.Contains(mapperNames, mapperName) {
+				mapperNames = append(mapperNames, mapperName)
+			}
 			mapper, mapErr := importer.MapperByName(mapperName)
 			if mapErr != nil {
 				return mapErr
@@ -71,52 +166,143 @@ If neither provides all values, import fails.`,
 			if runErr != nil {
 				return runErr
 			}
+			if cfg.Import.StoreSources && !importDryRun {
+				content, readErr := os.ReadFile(path)
+				if readErr != nil {
+					return fmt.Errorf("read %s: %w", path, readErr)
+				}
+				sources = append(sources, storage.ImportSource{
+					FileName: filepath.Base(path),
+					Mapper:   mapperName,
+					Project:  runOptions.EPMProject,
+					Activity: runOptions.EPMActivity,
+					Skill:    runOptions.EPMSkill,
+					Content:  content,
+				})
+			}
 
 			result.FilesProcessed += fileResult.FilesProcessed
 			result.RowsRead += fileResult.RowsRead
 			result.RowsMapped += fileResult.RowsMapped
 			result.RowsSkipped += fileResult.RowsSkipped
 			result.Entries = append(result.Entries, fileResult.Entries...)
+			result.DayTotalMismatches = append(result.DayTotalMismatches, fileResult.DayTotalMismatches...)
+			result.FileDuplicates = append(result.FileDuplicates, fileResult.FileDuplicates...)
 		}
+		var entriesSplit int
+		result.Entries, entriesSplit = importer.SplitLongEntries(result.Entries, importMaxEntryMins)
 
-		store, err := storage.OpenSQLite(importDBPath)
+		store, err := openConfiguredStoreWithNotices(importDBPath, *cfg, notices)
 		if err != nil {
 			return err
 		}
 		defer store.Close()
+		store.SetInsertBatchSize(cfg.Import.InsertBatchSize)
+
+		summary := importJSONOutput{
+			FilesProcessed:     result.FilesProcessed,
+			RowsRead:           result.RowsRead,
+			RowsMapped:         result.RowsMapped,
+			RowsSkipped:        result.RowsSkipped,
+			EntriesSplit:       entriesSplit,
+			DayTotalMismatches: result.DayTotalMismatches,
+			FileDuplicates:     result.FileDuplicates,
+		}
+		if !jsonOutput {
+			for _, mismatch := range result.DayTotalMismatches {
+				fmt.Printf("Warning: %s\n", mismatch)
+			}
+			for _, duplicate := range result.FileDuplicates {
+				fmt.Printf("Warning: %s\n", duplicate)
+			}
+		}
 
-		inserted, err := store.InsertWorklogs(result.Entries)
+		if importDryRun {
+			preview, err := importer.PreviewAgainstStore(store, result.Entries)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				summary.DryRun = true
+				summary.NewRows = &preview.New
+				summary.AlreadyStored = &preview.Duplicates
+				return writeImportJSON(os.Stdout, summary)
+			}
+			fmt.Printf("Import dry-run. Files: %d, Rows read: %d, Rows mapped: %d, Rows skipped: %d, Entries split: %d, New rows: %d, Already stored: %d\n",
+				result.FilesProcessed,
+				result.RowsRead,
+				result.RowsMapped,
+				result.RowsSkipped,
+				entriesSplit,
+				preview.New,
+				preview.Duplicates,
+			)
+			return nil
+		}
+
+		record, err := store.InsertImportWorklogs(importInputs, mapperNames, result.Entries)
 		if err != nil {
 			return err
 		}
+		if record.ID != "" && len(sources) > 0 {
+			if err := store.SaveImportSources(record.ID, sources); err != nil {
+				return err
+			}
+		}
+		inserted := record.RowsInserted
+		summary.RowsPersisted = inserted
+		summary.BatchID = record.ID
 
-		fmt.Printf("Import completed. Files: %d, Rows read: %d, Rows mapped: %d, Rows skipped: %d, Rows persisted: %d\n",
-			result.FilesProcessed,
-			result.RowsRead,
-			result.RowsMapped,
-			result.RowsSkipped,
-			inserted,
-		)
+		if !jsonOutput {
+			fmt.Printf("Import completed. Files: %d, Rows read: %d, Rows mapped: %d, Rows skipped: %d, Entries split: %d, Rows persisted: %d\n",
+				result.FilesProcessed,
+				result.RowsRead,
+				result.RowsMapped,
+				result.RowsSkipped,
+				entriesSplit,
+				inserted,
+			)
+			if record.ID != "" {
+				fmt.Printf("Undo with: gohour import undo --batch %s\n", record.ID)
+			}
+		}
 
 		shouldReconcile, err := resolveReconcileMode(importReconcileMode, cfg.Import.AutoReconcileAfterImport)
 		if err != nil {
 			return err
 		}
 		if shouldReconcile {
-			reconcileResult, err := reconcile.Run(store)
+			reconcileResult, err := reconcile.Run(store, reconcile.OptionsFromConfig(*cfg))
 			if err != nil {
 				return err
 			}
-			fmt.Printf(
-				"Auto-reconcile completed. Days processed: %d, Overlaps before: %d, Overlaps after: %d, EPM entries adjusted: %d, Rows updated: %d\n",
-				reconcileResult.DaysProcessed,
-				reconcileResult.OverlapsBefore,
-				reconcileResult.OverlapsAfter,
-				reconcileResult.EPMEntriesAdjusted,
-				reconcileResult.RowsUpdated,
-			)
+			if jsonOutput {
+				summary.Reconcile = &importReconcileJSON{
+					DaysProcessed:      reconcileResult.DaysProcessed,
+					DaysSkipped:        reconcileResult.DaysSkipped,
+					OverlapsBefore:     reconcileResult.OverlapsBefore,
+					OverlapsAfter:      reconcileResult.OverlapsAfter,
+					EPMEntriesAdjusted: reconcileResult.EPMEntriesAdjusted,
+					EntriesUnresolved:  reconcileResult.EntriesUnresolved,
+					RowsUpdated:        reconcileResult.RowsUpdated,
+				}
+			} else {
+				fmt.Printf(
+					"Auto-reconcile completed. Days processed: %d, Days skipped: %d, Overlaps before: %d, Overlaps after: %d, EPM entries adjusted: %d, Entries unresolved: %d, Rows updated: %d\n",
+					reconcileResult.DaysProcessed,
+					reconcileResult.DaysSkipped,
+					reconcileResult.OverlapsBefore,
+					reconcileResult.OverlapsAfter,
+					reconcileResult.EPMEntriesAdjusted,
+					reconcileResult.EntriesUnresolved,
+					reconcileResult.RowsUpdated,
+				)
+			}
 		}
 
+		if jsonOutput {
+			return writeImportJSON(os.Stdout, summary)
+		}
 		return nil
 	},
 }
@@ -126,12 +312,15 @@ func init() {
 
 	importCmd.Flags().StringArrayVarP(&importInputs, "input", "i", nil, "Input file path (repeatable)")
 	importCmd.Flags().StringVarP(&importFormat, "format", "f", "", "Input format: csv|excel (optional, inferred from extension when omitted)")
-	importCmd.Flags().StringVarP(&importMapper, "mapper", "m", "epm", "Fallback mapper when no rule matches a file: epm|generic|atwork")
+	importCmd.Flags().StringVarP(&importMapper, "mapper", "m", "epm", "Mapper when no rule matches a file (skips header detection): epm|generic|atwork|toggl")
 	importCmd.Flags().StringVar(&importProject, "project", "", "Explicit project value for EPM imports (overrides matching config rule)")
 	importCmd.Flags().StringVar(&importActivity, "activity", "", "Explicit activity value for EPM imports (overrides matching config rule)")
 	importCmd.Flags().StringVar(&importSkill, "skill", "", "Explicit skill value for EPM imports (overrides matching config rule)")
 	importCmd.Flags().StringVar(&importDBPath, "db", "./gohour.db", "Path to local SQLite database")
 	importCmd.Flags().StringVar(&importReconcileMode, "reconcile", "auto", "Reconcile mode after import: auto|on|off")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Report new vs already stored rows without inserting")
+	importCmd.Flags().IntVar(&importMaxEntryMins, "max-entry-minutes", 0, "Split mapped entries longer than this many minutes into consecutive rows (0 disables)")
+	importCmd.Flags().StringVar(&importOutput, "output", "text", "Summary output format: text|json")
 
 	_ = importCmd.MarkFlagRequired("input")
 }
@@ -149,10 +338,38 @@ func resolveReconcileMode(mode string, configDefault bool) (bool, error) {
 	}
 }
 
-func resolveMapperNameForFile(path, fallbackMapper string, rules []config.Rule) string {
+// resolveImportOutput reports whether --output selects json.
+func resolveImportOutput(output string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(output)) {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid --output %q (supported: text|json)", output)
+	}
+}
+
+func writeImportJSON(w io.Writer, summary importJSONOutput) error {
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(summary); err != nil {
+		return fmt.Errorf("write import summary: %w", err)
+	}
+	return nil
+}
+
+// resolveMapperNameForFile returns the mapper of the rule matching path, else
+// the mapper detected from the file's headers when detect is set, else
+// fallbackMapper. The second return value reports whether it was detected.
+func resolveMapperNameForFile(path, format, fallbackMapper string, rules []config.Rule, detect bool) (string, bool) {
 	rule := importer.MatchRuleByTemplate(path, rules)
 	if mapper := strings.TrimSpace(rule.Mapper); mapper != "" {
-		return mapper
+		return mapper, false
+	}
+	if detect {
+		if mapper := importer.DetectMapper(path, format); mapper != "" {
+			return mapper, true
+		}
 	}
-	return strings.TrimSpace(fallbackMapper)
+	return strings.TrimSpace(fallbackMapper), false
 }
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/storage"
+
+	"github.com/spf13/cobra"
+)
+
+var importListBatchesDBPath string
+
+var importListBatchesCmd = &cobra.Command{
+	Use:   "list-batches",
+	Short: "List recorded import batches",
+	Long: `Print the recorded import batches of "gohour import" and the web import, newest first:
+batch id, import time, rows still stored out of the rows inserted, mappers and input files.
+
+Pass a batch id to "gohour import undo --batch <id>" to delete the rows of that import.`,
+	Example: `
+  # List import batches
+  gohour import list-batches
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
+
+		store, err := openConfiguredStore(importListBatchesDBPath, *cfg)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		records, err := store.ListImportRecords()
+		if err != nil {
+			return err
+		}
+		return writeImportBatchList(os.Stdout, records, cfg.Location())
+	},
+}
+
+// writeImportBatchList prints records as a table with import times in loc.
+func writeImportBatchList(out io.Writer, records []storage.ImportRecord, loc *time.Location) error {
+	if len(records) == 0 {
+		_, err := fmt.Fprintln(out, "No import batches recorded.")
+		return err
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "BATCH\tIMPORTED\tROWS\tMAPPERS\tFILES")
+	for _, record := range records {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%d/%d\t%s\t%s\n",
+			record.ID,
+			record.CreatedAt.In(loc).Format("2006-01-02 15:04"),
+			record.RowsRemaining,
+			record.RowsInserted,
+			strings.Join(record.Mappers, ", "),
+			strings.Join(record.Files, ", "),
+		)
+	}
+	return writer.Flush()
+}
+
+func init() {
+	importCmd.AddCommand(importListBatchesCmd)
+
+	importListBatchesCmd.Flags().StringVar(&importListBatchesDBPath, "db", "./gohour.db", "Path to local SQLite database")
+}
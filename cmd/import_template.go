@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/importer"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var importTemplateOutput string
+
+var importTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Write a CSV template for the generic mapper",
+	Long: `Write a CSV file with the columns the generic mapper accepts (StartDateTime, EndDateTime,
+Billable, Description, Project, Activity, Skill) and one example row. Fill it in and import it with
+"gohour import -i <file> --mapper generic".
+
+The example row names the project, activity and skill of the first generic rule in the config, if any.
+The web UI serves the same file at GET /api/import/template.csv.`,
+	Example: `
+  # Write the template to the current directory
+  gohour import template
+
+  # Write the template to a custom path
+  gohour import template --output ./timesheet.csv
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := &config.Config{}
+		if strings.TrimSpace(viper.ConfigFileUsed()) != "" {
+			loaded, err := config.LoadAndValidate()
+			if err != nil {
+				return err
+			}
+			cfg = loaded
+		}
+
+		path := strings.TrimSpace(importTemplateOutput)
+		if path == "" {
+			return fmt.Errorf("--output must not be empty")
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("create template file: %w", err)
+		}
+		if err := importer.WriteGenericCSVTemplate(file, *cfg); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("write template file: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("write template file: %w", err)
+		}
+		fmt.Printf("Wrote generic import template to %s\n", path)
+		return nil
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importTemplateCmd)
+
+	importTemplateCmd.Flags().StringVarP(&importTemplateOutput, "output", "o", "./gohour-import-template.csv", "Path of the CSV template to write")
+}
@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
 	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/storage"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestResolveReconcileMode(t *testing.T) {
@@ -52,16 +59,262 @@ func TestResolveMapperNameForFile(t *testing.T) {
 	}
 
 	t.Run("uses mapper from matching rule", func(t *testing.T) {
-		got := resolveMapperNameForFile("EPMExportRZ202601.xlsx", "generic", rules)
-		if got != "epm" {
+		got, detected := resolveMapperNameForFile("EPMExportRZ202601.xlsx", "", "generic", rules, true)
+		if got != "epm" || detected {
 			t.Fatalf("expected rule mapper epm, got %q", got)
 		}
 	})
 
 	t.Run("falls back to CLI mapper when no rule matches", func(t *testing.T) {
-		got := resolveMapperNameForFile("generic.csv", "generic", rules)
-		if got != "generic" {
+		got, detected := resolveMapperNameForFile("generic.csv", "", "generic", rules, false)
+		if got != "generic" || detected {
 			t.Fatalf("expected fallback mapper generic, got %q", got)
 		}
 	})
+
+	t.Run("detects mapper from headers when no rule matches", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "export.csv")
+		if err := os.WriteFile(path, []byte("Start date,Start time,End date,End time,Description\n"), 0o644); err != nil {
+			t.Fatalf("write csv: %v", err)
+		}
+		got, detected := resolveMapperNameForFile(path, "", "epm", rules, true)
+		if got != "toggl" || !detected {
+			t.Fatalf("expected detected mapper toggl, got %q (detected=%t)", got, detected)
+		}
+	})
+}
+
+func TestImportCmd_JSONOutputPrintsSingleSummaryObject(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("onepoint:\n  url: \"https://onepoint.virtual7.io/onepoint/faces/home\"\n"), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	inputPath := filepath.Join(dir, "import.csv")
+	csv := "description,startdatetime,enddatetime,project,activity,skill\n" +
+		"Task,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n" +
+		"Review,2026-03-01 10:00,2026-03-01 11:30,P,A,S\n"
+	if err := os.WriteFile(inputPath, []byte(csv), 0o644); err != nil {
+		t.Fatalf("write input file: %v", err)
+	}
+
+	viper.Reset()
+	config.SetDefaults()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	importInputs = []string{inputPath}
+	importMapper = "generic"
+	importDBPath = filepath.Join(dir, "gohour.db")
+	importReconcileMode = "on"
+	importOutput = "json"
+	t.Cleanup(func() {
+		viper.Reset()
+		importInputs = nil
+		importMapper = "epm"
+		importDBPath = "./gohour.db"
+		importReconcileMode = "auto"
+		importOutput = "text"
+	})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = importCmd.RunE(importCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("run import: %v", runErr)
+	}
+
+	var summary importJSONOutput
+	decoder := json.NewDecoder(strings.NewReader(out))
+	if err := decoder.Decode(&summary); err != nil {
+		t.Fatalf("decode json output %q: %v", out, err)
+	}
+	if decoder.More() {
+		t.Fatalf("expected a single JSON object, got %q", out)
+	}
+	if summary.FilesProcessed != 1 || summary.RowsRead != 2 || summary.RowsMapped != 2 || summary.RowsPersisted != 2 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if summary.BatchID == "" {
+		t.Fatalf("expected the import batch id in the summary, got %+v", summary)
+	}
+	if summary.Reconcile == nil || summary.Reconcile.DaysProcessed != 1 {
+		t.Fatalf("expected reconcile stats for one day, got %+v", summary.Reconcile)
+	}
+	if strings.Contains(out, "Import completed") {
+		t.Fatalf("expected human summary to be suppressed, got %q", out)
+	}
+}
+
+func TestImportCmd_StoreSourcesKeepsInputFilesWithBatch(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	configYAML := "onepoint:\n  url: \"https://onepoint.virtual7.io/onepoint/faces/home\"\nimport:\n  store_sources: true\n"
+	if err := os.WriteFile(configPath, []byte(configYAML), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	inputPath := filepath.Join(dir, "import.csv")
+	csv := "description,startdatetime,enddatetime,project,activity,skill\n" +
+		"Task,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n"
+	if err := os.WriteFile(inputPath, []byte(csv), 0o644); err != nil {
+		t.Fatalf("write input file: %v", err)
+	}
+	dbPath := filepath.Join(dir, "gohour.db")
+
+	viper.Reset()
+	config.SetDefaults()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	importInputs = []string{inputPath}
+	importMapper = "generic"
+	importDBPath = dbPath
+	importReconcileMode = "off"
+	importOutput = "json"
+	t.Cleanup(func() {
+		viper.Reset()
+		importInputs = nil
+		importMapper = "epm"
+		importDBPath = "./gohour.db"
+		importReconcileMode = "auto"
+		importOutput = "text"
+	})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = importCmd.RunE(importCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("run import: %v", runErr)
+	}
+	var summary importJSONOutput
+	if err := json.Unmarshal([]byte(out), &summary); err != nil {
+		t.Fatalf("decode json output %q: %v", out, err)
+	}
+
+	store, err := storage.OpenSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+	sources, err := store.ListImportSources(summary.BatchID)
+	if err != nil {
+		t.Fatalf("list import sources: %v", err)
+	}
+	if len(sources) != 1 || sources[0].FileName != "import.csv" || sources[0].Mapper != "generic" || string(sources[0].Content) != csv {
+		t.Fatalf("expected the input file to be kept with batch %q, got %+v", summary.BatchID, sources)
+	}
+}
+
+func TestResolveImportOutput_RejectsUnknownFormat(t *testing.T) {
+	if _, err := resolveImportOutput("yaml"); err == nil {
+		t.Fatalf("expected error for unsupported output format")
+	}
+}
+
+func TestImportUndo_DeletesRowsOfBatch(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("onepoint:\n  url: \"https://onepoint.virtual7.io/onepoint/faces/home\"\n"), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	inputPath := filepath.Join(dir, "import.csv")
+	csv := "description,startdatetime,enddatetime,project,activity,skill\n" +
+		"Task,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n"
+	if err := os.WriteFile(inputPath, []byte(csv), 0o644); err != nil {
+		t.Fatalf("write input file: %v", err)
+	}
+	dbPath := filepath.Join(dir, "gohour.db")
+
+	viper.Reset()
+	config.SetDefaults()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	importInputs = []string{inputPath}
+	importMapper = "generic"
+	importDBPath = dbPath
+	importReconcileMode = "off"
+	importUndoDBPath = dbPath
+	importListBatchesDBPath = dbPath
+	t.Cleanup(func() {
+		viper.Reset()
+		importInputs = nil
+		importMapper = "epm"
+		importDBPath = "./gohour.db"
+		importReconcileMode = "auto"
+		importUndoBatch = ""
+		importUndoDBPath = "./gohour.db"
+		importListBatchesDBPath = "./gohour.db"
+	})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = importCmd.RunE(importCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("run import: %v", runErr)
+	}
+	const undoPrefix = "Undo with: gohour import undo --batch "
+	index := strings.Index(out, undoPrefix)
+	if index < 0 {
+		t.Fatalf("expected an undo hint, got %q", out)
+	}
+	batchID := strings.TrimSpace(strings.SplitN(out[index+len(undoPrefix):], "\n", 2)[0])
+
+	listed := captureStdout(t, func() {
+		runErr = importListBatchesCmd.RunE(importListBatchesCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("list batches: %v", runErr)
+	}
+	if !strings.Contains(listed, batchID) || !strings.Contains(listed, "1/1") || !strings.Contains(listed, "import.csv") {
+		t.Fatalf("expected the batch in the list, got %q", listed)
+	}
+
+	importUndoBatch = batchID
+	undone := captureStdout(t, func() {
+		runErr = importUndoCmd.RunE(importUndoCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("undo import: %v", runErr)
+	}
+	if !strings.Contains(undone, "Deleted 1 worklogs") {
+		t.Fatalf("unexpected undo output %q", undone)
+	}
+
+	captureStdout(t, func() {
+		runErr = importUndoCmd.RunE(importUndoCmd, nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "not found") {
+		t.Fatalf("expected a second undo to report the batch as unknown, got %v", runErr)
+	}
+}
+
+func TestImportTemplate_WritesGenericTemplateWithoutConfig(t *testing.T) {
+	viper.Reset()
+	importTemplateOutput = filepath.Join(t.TempDir(), "template.csv")
+	t.Cleanup(func() {
+		viper.Reset()
+		importTemplateOutput = "./gohour-import-template.csv"
+	})
+
+	var runErr error
+	captureStdout(t, func() {
+		runErr = importTemplateCmd.RunE(importTemplateCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("run import template: %v", runErr)
+	}
+	content, err := os.ReadFile(importTemplateOutput)
+	if err != nil {
+		t.Fatalf("read template: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "StartDateTime,EndDateTime,Billable,Description,Project,Activity,Skill\n") {
+		t.Fatalf("unexpected template:\n%s", content)
+	}
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/storage"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importUndoBatch  string
+	importUndoDBPath string
+	importUndoDryRun bool
+	importUndoCommit bool
+)
+
+var importUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Delete the worklogs inserted by one import",
+	Long: `Delete every worklog inserted by the import batch given with --batch, and the batch record.
+
+The batch id is printed at the end of each import, returned as batchId by the web import and
+listed by "gohour import list-batches".
+Rows the import skipped as duplicates of already stored rows are not touched; rows of the batch
+that were edited since are deleted too, rows already deleted are ignored.
+
+With --dry-run, or dry_run_by_default: true in the config without --commit, only the number of
+rows that would be deleted is printed.`,
+	Example: `
+  # Undo the last import
+  gohour import undo --batch 20260316-150405-a1b2c3
+
+  # Show how many rows would be deleted
+  gohour import undo --batch 20260316-150405-a1b2c3 --dry-run
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
+		dryRun, err := resolveDryRun(importUndoDryRun, importUndoCommit, cfg.DryRunByDefault)
+		if err != nil {
+			return err
+		}
+		batchID := strings.TrimSpace(importUndoBatch)
+		if batchID == "" {
+			return fmt.Errorf("--batch must not be empty")
+		}
+
+		store, err := openConfiguredStore(importUndoDBPath, *cfg)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		if dryRun {
+			record, found, err := store.GetImportRecord(batchID)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return importBatchNotFoundError(batchID)
+			}
+			fmt.Printf("Dry-run: would delete %d worklogs of import batch %s (pass --commit to delete).\n", record.RowsRemaining, batchID)
+			return nil
+		}
+
+		deleted, err := store.DeleteImportBatch(batchID)
+		if errors.Is(err, storage.ErrImportBatchNotFound) {
+			return importBatchNotFoundError(batchID)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d worklogs of import batch %s.\n", deleted, batchID)
+		return nil
+	},
+}
+
+func importBatchNotFoundError(batchID string) error {
+	return fmt.Errorf("import batch %q not found (list batches with: gohour import list-batches)", batchID)
+}
+
+func init() {
+	importCmd.AddCommand(importUndoCmd)
+
+	importUndoCmd.Flags().StringVar(&importUndoBatch, "batch", "", "Import batch id printed by the import summary")
+	importUndoCmd.Flags().StringVar(&importUndoDBPath, "db", "./gohour.db", "Path to local SQLite database")
+	importUndoCmd.Flags().BoolVar(&importUndoDryRun, "dry-run", false, "Print how many rows would be deleted without deleting")
+	importUndoCmd.Flags().BoolVar(&importUndoCommit, "commit", false, "Delete when dry_run_by_default is enabled in config")
+
+	_ = importUndoCmd.MarkFlagRequired("batch")
+}
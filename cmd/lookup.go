@@ -0,0 +1,15 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var lookupCmd = &cobra.Command{
+	Use:   "lookup",
+	Short: "Search OnePoint lookup data (projects, activities, skills).",
+	Long: `Helpers for the OnePoint lookup data that rules and submit resolve names against.
+
+Use "lookup search" to find the exact project, activity or skill name for a rule.`,
+}
+
+func init() {
+	rootCmd.AddCommand(lookupCmd)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/onepoint"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	lookupSearchURL       string
+	lookupSearchStateFile string
+	lookupSearchTimeout   time.Duration
+	lookupSearchTop       int
+	lookupSearchMinScore  float64
+)
+
+var lookupSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Find projects, activities and skills by approximate name",
+	Long: `Fetch the OnePoint lookup data and list the projects, activities and skills whose names
+fuzzily match the query, best match first, so the exact name can be copied into a rule.
+
+Matching ignores case, extra whitespace and diacritics. Each match gets a score from 0 to 1:
+1 for an equal name, at least 0.8 when the name contains the query, otherwise the edit-distance
+similarity to the name or its closest word. Matches below --min-score are dropped and at most
+--top matches are printed. Activities show their project and skills their activity as PARENT.`,
+	Example: `
+  # Find the exact name of a project
+  gohour lookup search "identity platfrom"
+
+  # Show only the three closest matches, including weak ones
+  gohour lookup search entwicklung --top 3 --min-score 0.3
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lookupSearchTop < 0 {
+			return fmt.Errorf("--top must not be negative, got %d", lookupSearchTop)
+		}
+		if lookupSearchMinScore < 0 || lookupSearchMinScore > 1 {
+			return fmt.Errorf("--min-score must be between 0 and 1, got %g", lookupSearchMinScore)
+		}
+		query := strings.TrimSpace(args[0])
+		if query == "" {
+			return fmt.Errorf("search query must not be empty")
+		}
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
+
+		cookieHeader, baseURL, homeURL, host, stateFile, err := ensureAuthenticatedWithStateFile(lookupSearchURL, lookupSearchStateFile)
+		if err != nil {
+			return err
+		}
+		snapshot, err := retryWithRelogin(
+			cfg.OnePoint.MaxRetries,
+			baseURL,
+			homeURL,
+			host,
+			stateFile,
+			"gohour-lookup/1.0",
+			&cookieHeader,
+			func(client onepoint.Client) (onepoint.LookupSnapshot, error) {
+				ctx, cancel := context.WithTimeout(context.Background(), lookupSearchTimeout)
+				defer cancel()
+				return client.FetchLookupSnapshot(ctx)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("fetch OnePoint lookup values: %w", err)
+		}
+
+		matches := onepoint.SearchLookup(snapshot, query, lookupSearchMinScore, lookupSearchTop)
+		return writeLookupMatches(os.Stdout, query, matches)
+	},
+}
+
+// writeLookupMatches prints matches as a table, best match first.
+func writeLookupMatches(out io.Writer, query string, matches []onepoint.LookupMatch) error {
+	if len(matches) == 0 {
+		_, err := fmt.Fprintf(out, "No projects, activities or skills match %q.\n", query)
+		return err
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "SCORE\tKIND\tNAME\tID\tPARENT")
+	for _, match := range matches {
+		fmt.Fprintf(writer, "%.2f\t%s\t%s\t%d\t%s\n", match.Score, match.Kind, match.Name, match.ID, match.Parent)
+	}
+	return writer.Flush()
+}
+
+func init() {
+	lookupCmd.AddCommand(lookupSearchCmd)
+
+	lookupSearchCmd.Flags().StringVar(&lookupSearchURL, "url", "", "Override OnePoint URL from config (full home URL)")
+	lookupSearchCmd.Flags().StringVar(&lookupSearchStateFile, "state-file", "", "Path to auth state JSON (default: $HOME/.gohour/onepoint-auth-state.json)")
+	lookupSearchCmd.Flags().DurationVar(&lookupSearchTimeout, "timeout", 60*time.Second, "Timeout for OnePoint lookup API calls")
+	lookupSearchCmd.Flags().IntVar(&lookupSearchTop, "top", 10, "Maximum number of matches to print (0 prints all)")
+	lookupSearchCmd.Flags().Float64Var(&lookupSearchMinScore, "min-score", 0.5, "Minimum similarity (0-1) a name needs to be listed")
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+func TestWriteLookupMatches_PrintsTableOrEmptyNotice(t *testing.T) {
+	var out strings.Builder
+	err := writeLookupMatches(&out, "dev", []onepoint.LookupMatch{
+		{Kind: onepoint.LookupKindActivity, ID: 10, Name: "Development", Parent: "Identity Platform", Score: 0.85},
+	})
+	if err != nil {
+		t.Fatalf("write matches: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "SCORE") {
+		t.Fatalf("expected header and one row, got %q", out.String())
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "0.85 activity Development 10 Identity Platform" {
+		t.Fatalf("unexpected row %q", lines[1])
+	}
+
+	out.Reset()
+	if err := writeLookupMatches(&out, "xyz", nil); err != nil {
+		t.Fatalf("write empty matches: %v", err)
+	}
+	if !strings.Contains(out.String(), `No projects, activities or skills match "xyz".`) {
+		t.Fatalf("unexpected empty output %q", out.String())
+	}
+}
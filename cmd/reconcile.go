@@ -2,13 +2,23 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/internal/timeutil"
+	"github.com/riadshalaby/gohour/output"
 	"github.com/riadshalaby/gohour/reconcile"
 	"github.com/riadshalaby/gohour/storage"
+	"github.com/riadshalaby/gohour/worklog"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
-var reconcileDBPath string
+var (
+	reconcileDBPath string
+	reconcileDryRun bool
+)
 
 var reconcileCmd = &cobra.Command{
 	Use:   "reconcile",
@@ -19,38 +29,63 @@ Why this exists:
 - EPM imports simulate per-task times within a day window.
 - Additional imports from other sources can introduce overlaps.
 
-This command adjusts EPM rows only, so one resource is not assigned to overlapping work at the same time.`,
+This command adjusts EPM rows only, so one resource is not assigned to overlapping work at the same time.
+Entries are grouped by calendar day in the configured timezone (config key "timezone", default: system zone).
+
+Use --dry-run to list the planned start/end changes without writing them to the database.
+
+Days reconcile adjusts whose entries add up to more than max_daily_hours (default 16) worked
+hours are printed as warnings; other stored days are not checked.`,
 	Example: `
   # Reconcile overlaps
   gohour reconcile
 
+  # Preview the planned adjustments without persisting them
+  gohour reconcile --dry-run
+
   # Typical workflow: import, reconcile, export
   gohour import -i EPMExportRZ202601.xlsx
   gohour reconcile
   gohour export --output ./worklogs.csv
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		store, err := storage.OpenSQLite(reconcileDBPath)
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
+
+		store, err := openConfiguredStore(reconcileDBPath, *cfg)
 		if err != nil {
 			return err
 		}
 		defer store.Close()
 
-		result, err := reconcile.Run(store)
+		options := reconcile.OptionsFromConfig(*cfg)
+		options.DryRun = reconcileDryRun
+		result, err := reconcile.Run(store, options)
 		if err != nil {
 			return err
 		}
 
+		if reconcileDryRun {
+			if err := printReconcilePlan(cmd.OutOrStdout(), store, result, options); err != nil {
+				return err
+			}
+			return warnReconcileDaysOverMaxHours(cmd.OutOrStdout(), store, result.PlannedUpdates, cfg.MaxDailyHours, cfg.Location())
+		}
+
 		fmt.Printf(
-			"Reconcile completed. Days processed: %d, Overlaps before: %d, Overlaps after: %d, EPM entries adjusted: %d, Rows updated: %d\n",
+			"Reconcile completed. Days processed: %d, Days skipped: %d, Overlaps before: %d, Overlaps after: %d, EPM entries adjusted: %d, Entries unresolved: %d, Rows updated: %d\n",
 			result.DaysProcessed,
+			result.DaysSkipped,
 			result.OverlapsBefore,
 			result.OverlapsAfter,
 			result.EPMEntriesAdjusted,
+			result.EntriesUnresolved,
 			result.RowsUpdated,
 		)
 
-		return nil
+		return warnReconcileDaysOverMaxHours(cmd.OutOrStdout(), store, result.PlannedUpdates, cfg.MaxDailyHours, cfg.Location())
 	},
 }
 
@@ -58,4 +93,88 @@ func init() {
 	rootCmd.AddCommand(reconcileCmd)
 
 	reconcileCmd.Flags().StringVar(&reconcileDBPath, "db", "./gohour.db", "Path to local SQLite database")
+	reconcileCmd.Flags().BoolVar(&reconcileDryRun, "dry-run", false, "Show planned adjustments without updating the database")
+}
+
+func printReconcilePlan(out io.Writer, store *storage.SQLiteStore, result *reconcile.Result, options reconcile.Options) error {
+	fmt.Fprintf(
+		out,
+		"Reconcile dry run. Days processed: %d, Days skipped: %d, Overlaps before: %d, Overlaps after: %d, EPM entries adjusted: %d, Entries unresolved: %d, Rows to update: %d\n",
+		result.DaysProcessed,
+		result.DaysSkipped,
+		result.OverlapsBefore,
+		result.OverlapsAfter,
+		result.EPMEntriesAdjusted,
+		result.EntriesUnresolved,
+		len(result.PlannedUpdates),
+	)
+
+	loc := options.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	ids := make([]int64, 0, len(result.PlannedUpdates))
+	for _, planned := range result.PlannedUpdates {
+		ids = append(ids, planned.ID)
+	}
+	currentByID, err := store.GetWorklogsByIDs(ids)
+	if err != nil {
+		return fmt.Errorf("load planned worklogs: %w", err)
+	}
+	for _, planned := range result.PlannedUpdates {
+		current, found := currentByID[planned.ID]
+		if !found {
+			fmt.Fprintf(out, "  #%d -> %s-%s\n", planned.ID, planned.StartDateTime.In(loc).Format("2006-01-02 15:04"), planned.EndDateTime.In(loc).Format("15:04"))
+			continue
+		}
+		fmt.Fprintf(
+			out,
+			"  #%d %s %s-%s -> %s-%s %s\n",
+			planned.ID,
+			current.StartDateTime.In(loc).Format("2006-01-02"),
+			current.StartDateTime.In(loc).Format("15:04"),
+			current.EndDateTime.In(loc).Format("15:04"),
+			planned.StartDateTime.In(loc).Format("15:04"),
+			planned.EndDateTime.In(loc).Format("15:04"),
+			strings.TrimSpace(planned.Description),
+		)
+	}
+	return nil
+}
+
+// warnReconcileDaysOverMaxHours prints a warning per day in loc that
+// reconciliation adjusted (the days of planned) whose stored entries add up to
+// more than maxHours. maxHours <= 0 disables the check.
+func warnReconcileDaysOverMaxHours(out io.Writer, store *storage.SQLiteStore, planned []worklog.Entry, maxHours float64, loc *time.Location) error {
+	if maxHours <= 0 || len(planned) == 0 {
+		return nil
+	}
+	days := make(map[string]struct{}, len(planned))
+	from, to := planned[0].StartDateTime.In(loc), planned[0].StartDateTime.In(loc)
+	for _, entry := range planned {
+		start := entry.StartDateTime.In(loc)
+		days[start.Format("2006-01-02")] = struct{}{}
+		if start.Before(from) {
+			from = start
+		}
+		if start.After(to) {
+			to = start
+		}
+	}
+	stored, err := store.ListWorklogsBetween(timeutil.StartOfDay(from), timeutil.StartOfDay(to).AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		return err
+	}
+	entries := make([]worklog.Entry, 0, len(stored))
+	for _, entry := range entriesInLocation(stored, loc) {
+		if _, ok := days[entry.StartDateTime.Format("2006-01-02")]; ok {
+			entries = append(entries, entry)
+		}
+	}
+	for _, summary := range output.BuildDailySummaries(entries) {
+		if summary.WorkedHours > maxHours {
+			fmt.Fprintf(out, "Warning: day %s has %.2f worked hours, more than max_daily_hours %g\n", summary.Date, summary.WorkedHours, maxHours)
+		}
+	}
+	return nil
 }
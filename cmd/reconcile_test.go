@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/reconcile"
+	"github.com/riadshalaby/gohour/storage"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestWarnReconcileDaysOverMaxHours_OnlyAdjustedDays(t *testing.T) {
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "gohour.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.Local)
+	}
+	entries := []worklog.Entry{
+		{StartDateTime: at(2, 8), EndDateTime: at(2, 14), Billable: 360, Project: "Alpha", Description: "Adjusted day", SourceFormat: "epm", SourceMapper: "epm"},
+		{StartDateTime: at(3, 8), EndDateTime: at(3, 14), Billable: 360, Project: "Alpha", Description: "Untouched day", SourceFormat: "epm", SourceMapper: "epm"},
+	}
+	if _, err := store.InsertWorklogs(entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	planned := []worklog.Entry{{StartDateTime: at(2, 9), EndDateTime: at(2, 15)}}
+	var out bytes.Buffer
+	if err := warnReconcileDaysOverMaxHours(&out, store, planned, 4, time.Local); err != nil {
+		t.Fatalf("warn days over max hours: %v", err)
+	}
+	if !strings.Contains(out.String(), "Warning: day 2026-03-02 has 6.00 worked hours") {
+		t.Fatalf("expected warning for the adjusted day, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "2026-03-03") {
+		t.Fatalf("expected no warning for a day reconcile did not adjust, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := warnReconcileDaysOverMaxHours(&out, store, nil, 4, time.Local); err != nil {
+		t.Fatalf("warn days over max hours: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no warnings without adjusted days, got:\n%s", out.String())
+	}
+}
+
+func TestPrintReconcilePlan_ShowsCurrentAndPlannedTimes(t *testing.T) {
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "gohour.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	at := func(hour int) time.Time {
+		return time.Date(2026, 3, 2, hour, 0, 0, 0, time.Local)
+	}
+	if _, err := store.InsertWorklogs([]worklog.Entry{
+		{StartDateTime: at(9), EndDateTime: at(10), Billable: 60, Project: "Alpha", Description: "Shifted", SourceFormat: "epm", SourceMapper: "epm"},
+	}); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+	stored, err := store.ListWorklogs()
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("list worklogs: %v (%d)", err, len(stored))
+	}
+
+	result := &reconcile.Result{PlannedUpdates: []worklog.Entry{
+		{ID: stored[0].ID, StartDateTime: at(11), EndDateTime: at(12), Description: "Shifted"},
+		{ID: 999, StartDateTime: at(13), EndDateTime: at(14)},
+	}}
+	var out bytes.Buffer
+	if err := printReconcilePlan(&out, store, result, reconcile.Options{Location: time.Local}); err != nil {
+		t.Fatalf("print reconcile plan: %v", err)
+	}
+	if !strings.Contains(out.String(), "2026-03-02 09:00-10:00 -> 11:00-12:00 Shifted") {
+		t.Fatalf("expected current and planned times, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "#999 -> 2026-03-02 13:00-14:00") {
+		t.Fatalf("expected planned times for an unknown id, got:\n%s", out.String())
+	}
+
+	store.Close()
+	if err := printReconcilePlan(&out, store, result, reconcile.Options{}); err == nil {
+		t.Fatal("expected an error when the planned worklogs cannot be loaded")
+	}
+}
@@ -18,13 +18,20 @@ package cmd
 import (
 	"fmt"
 	"github.com/spf13/viper"
+	"io"
 	"os"
 
 	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/storage"
 	"github.com/spf13/cobra"
 )
 
-var cfgFile string
+var (
+	cfgFile string
+	// onePointProfile is the name of the configured OnePoint profile
+	// selected with --profile; empty uses onepoint.url.
+	onePointProfile string
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -55,6 +62,9 @@ Supported input formats:
   # Submit local worklogs to OnePoint
   gohour submit
 
+  # Submit to the OnePoint tenant of a configured profile
+  gohour submit --profile client-b
+
   # Export rows
   gohour export --output ./worklogs.csv
 `,
@@ -79,6 +89,7 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "configFile", "", "Config file override (default discovery: $HOME/.gohour.yaml, then ./.gohour.yaml)")
+	rootCmd.PersistentFlags().StringVar(&onePointProfile, "profile", "", "OnePoint profile from the profiles config section (URL and auth state file)")
 
 	// Optional: Validate configuration
 	rootCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
@@ -119,3 +130,54 @@ func initConfig() {
 		fmt.Fprintln(os.Stderr, "No config file found. Create one first with: gohour config create")
 	}
 }
+
+// openConfiguredStore opens the SQLite database and normalizes stored
+// timestamps to the configured timezone, so day grouping stays consistent.
+func openConfiguredStore(path string, cfg config.Config) (*storage.SQLiteStore, error) {
+	return openConfiguredStoreWithNotices(path, cfg, os.Stdout)
+}
+
+// openConfiguredStoreWithNotices is openConfiguredStore writing the
+// normalization notice to notices instead of stdout.
+func openConfiguredStoreWithNotices(path string, cfg config.Config, notices io.Writer) (*storage.SQLiteStore, error) {
+	store, err := storage.OpenSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := store.SetLocation(cfg.Location())
+	if err != nil {
+		_ = store.Close()
+		return nil, err
+	}
+	if normalized > 0 {
+		fmt.Fprintf(notices, "Normalized %d stored worklogs to timezone %s\n", normalized, cfg.Location())
+	}
+	return store, nil
+}
+
+// useOnePointTenant scopes the cached OnePoint ids and submitted day hashes
+// of store to baseURL, so switching tenants with --profile or --url never
+// reuses data recorded against another OnePoint instance.
+func useOnePointTenant(store *storage.SQLiteStore, baseURL string, notices io.Writer) error {
+	cleared, err := store.UseOnePointTenant(baseURL)
+	if err != nil {
+		return err
+	}
+	if cleared {
+		fmt.Fprintf(notices, "OnePoint URL is now %s: cleared cached ids and submitted day records of the previous one\n", baseURL)
+	}
+	return nil
+}
+
+// resolveDryRun decides whether a destructive command only reports its
+// changes. With dry_run_by_default enabled, --commit is required to apply
+// them; --dry-run always wins and combining it with --commit is an error.
+func resolveDryRun(dryRunFlag, commitFlag, dryRunByDefault bool) (bool, error) {
+	if dryRunFlag && commitFlag {
+		return false, fmt.Errorf("--dry-run and --commit cannot be combined")
+	}
+	if dryRunFlag {
+		return true, nil
+	}
+	return dryRunByDefault && !commitFlag, nil
+}
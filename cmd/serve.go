@@ -14,7 +14,6 @@ import (
 	"time"
 
 	"github.com/riadshalaby/gohour/config"
-	"github.com/riadshalaby/gohour/storage"
 	"github.com/riadshalaby/gohour/web"
 
 	"github.com/spf13/cobra"
@@ -28,19 +27,59 @@ var (
 	serveFromMonth string
 	serveToMonth   string
 	serveNoOpen    bool
+	serveMetrics   bool
+	serveAutoLogin bool
+	serveLaxJSON   bool
+	serveWeekly    bool
+	serveNoSubmit  bool
 )
 
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start local interactive web UI for local/remote review and submit",
-	Long: `Start a local HTTP server with monthly and daily overview pages.
+	Long: `Start a local HTTP server with monthly, weekly and daily overview pages.
 
 The UI supports in-place remote refresh, local import/edit/delete actions, and day/month submit
-with dry-run mode while comparing local SQLite entries against current OnePoint entries.`,
+with dry-run mode while comparing local SQLite entries against current OnePoint entries.
+
+On startup serve verifies the saved OnePoint session. When no session is saved or OnePoint rejects
+it, serve opens the browser login flow and continues with the refreshed cookies. With --auto-login,
+an auth state file that cannot be read (e.g. corrupted) opens the login flow as well instead of
+stopping serve.
+
+GET /healthz returns 200 when the database and the OnePoint session work and 503 naming the
+failed dependency otherwise; the OnePoint check is cached for 30 seconds.
+
+With --metrics, GET /metrics exposes request, OnePoint fetch latency, OnePoint call, cache and
+submit counters in Prometheus text format. onepoint.requests_per_second paces OnePoint calls.
+
+JSON API endpoints reject unknown request fields by default. --allow-unknown-json-fields ignores
+them instead, so clients sending extra fields keep working; a body must still hold one JSON object.
+
+Web imports are recorded as import batches like "gohour import", so "gohour import undo" removes
+them. With import.store_sources, imported files are kept with their batch and
+POST /api/import/{batch}/remap re-runs the mappers over them, replacing the batch's entries with
+the same overlap checks and auto-reconcile as a new import.
+
+With --weekly-remote-fetch, remote worklogs for ranges longer than a week are loaded as weekly
+requests (at most 4 in parallel) instead of one request for the whole range.
+
+With web.session_ping_minutes set, serve checks the OnePoint session in the background at that
+interval, logs when it expires and shows the session banner on the next page load.
+
+With --no-submit, the day and month submit endpoints (including dry runs) and the remote delete
+endpoints answer 403 and their buttons are hidden, so nothing is changed in OnePoint by accident.
+Local create, edit, delete and import keep working.`,
 	Example: `
   # Start local server on default port
   gohour serve
 
+  # Also log in again when the saved auth state file cannot be read
+  gohour serve --auto-login
+
+  # Compare and edit locally without being able to submit
+  gohour serve --no-submit
+
   # Start with explicit db/url/auth-state and custom port
   gohour serve --port 9090 --db ./gohour.db --url https://onepoint.virtual7.io/onepoint/faces/home --state-file ~/.gohour/onepoint-auth-state.json
 `,
@@ -50,26 +89,49 @@ with dry-run mode while comparing local SQLite entries against current OnePoint
 			return err
 		}
 
-		bounds, err := parseServeMonthBounds(serveFromMonth, serveToMonth)
+		bounds, err := parseServeMonthBounds(serveFromMonth, serveToMonth, cfg.Location())
 		if err != nil {
 			return err
 		}
 
-		store, err := storage.OpenSQLite(serveDBPath)
+		store, err := openConfiguredStore(serveDBPath, *cfg)
 		if err != nil {
 			return err
 		}
 		defer store.Close()
+		store.SetInsertBatchSize(cfg.Import.InsertBatchSize)
 
-		client, err := buildServeClient(*cfg)
+		client, baseURL, err := buildServeClient(*cfg)
 		if err != nil {
 			return err
 		}
+		if baseURL != "" {
+			if err := useOnePointTenant(store, baseURL, os.Stdout); err != nil {
+				return err
+			}
+		}
+
+		var sessionWatch *web.SessionWatch
+		if cfg.Web.SessionPingMinutes > 0 {
+			sessionWatch = web.NewSessionWatch(client, os.Stderr)
+			watchCtx, stopWatch := context.WithCancel(context.Background())
+			defer stopWatch()
+			ticker := time.NewTicker(time.Duration(cfg.Web.SessionPingMinutes) * time.Minute)
+			defer ticker.Stop()
+			go sessionWatch.Run(watchCtx, ticker.C)
+		}
 
+		handler := web.NewServerWithOptions(store, client, *cfg, web.Options{
+			EnableMetrics:             serveMetrics,
+			AllowUnknownJSONFields:    serveLaxJSON,
+			FetchRemoteInWeeklyChunks: serveWeekly,
+			SessionWatch:              sessionWatch,
+			DisableSubmit:             serveNoSubmit,
+		})
 		addr := fmt.Sprintf(":%d", servePort)
 		server := &http.Server{
 			Addr:    addr,
-			Handler: withServeMonthRedirect(web.NewServer(store, client, *cfg), bounds),
+			Handler: withServeMonthRedirect(handler, bounds),
 		}
 
 		errCh := make(chan error, 1)
@@ -130,9 +192,14 @@ func init() {
 	serveCmd.Flags().StringVar(&serveFromMonth, "from", "", "Preferred start month for initial view, format YYYY-MM")
 	serveCmd.Flags().StringVar(&serveToMonth, "to", "", "Preferred end month for initial view, format YYYY-MM")
 	serveCmd.Flags().BoolVar(&serveNoOpen, "no-open", false, "Do not open browser automatically")
+	serveCmd.Flags().BoolVar(&serveMetrics, "metrics", false, "Expose GET /metrics in Prometheus text format")
+	serveCmd.Flags().BoolVar(&serveLaxJSON, "allow-unknown-json-fields", false, "Ignore unknown fields in JSON API request bodies instead of rejecting them")
+	serveCmd.Flags().BoolVar(&serveWeekly, "weekly-remote-fetch", false, "Load remote worklogs in concurrent weekly requests instead of one request per range")
+	serveCmd.Flags().BoolVar(&serveNoSubmit, "no-submit", false, "Reject submit and remote delete requests with 403 while keeping local edits")
+	serveCmd.Flags().BoolVar(&serveAutoLogin, "auto-login", false, "Also open the browser login flow when the saved auth state file cannot be read")
 }
 
-func parseServeMonthBounds(fromValue, toValue string) (serveMonthBounds, error) {
+func parseServeMonthBounds(fromValue, toValue string, loc *time.Location) (serveMonthBounds, error) {
 	var out serveMonthBounds
 
 	parse := func(raw string) (*time.Time, error) {
@@ -140,11 +207,11 @@ func parseServeMonthBounds(fromValue, toValue string) (serveMonthBounds, error)
 		if raw == "" {
 			return nil, nil
 		}
-		parsed, err := time.ParseInLocation("2006-01", raw, time.Local)
+		parsed, err := time.ParseInLocation("2006-01", raw, loc)
 		if err != nil {
 			return nil, fmt.Errorf("invalid month %q (expected YYYY-MM)", raw)
 		}
-		value := time.Date(parsed.Year(), parsed.Month(), 1, 0, 0, 0, 0, time.Local)
+		value := time.Date(parsed.Year(), parsed.Month(), 1, 0, 0, 0, 0, loc)
 		return &value, nil
 	}
 
@@ -163,7 +230,8 @@ func parseServeMonthBounds(fromValue, toValue string) (serveMonthBounds, error)
 	out.from = from
 	out.to = to
 
-	nowMonth := time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.Local)
+	now := time.Now().In(loc)
+	nowMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
 	switch {
 	case from != nil && nowMonth.Before(*from):
 		out.defaultMonth = from.Format("2006-01")
@@ -15,53 +15,104 @@ import (
 
 const e2eStubRemoteEnv = "GOHOUR_E2E_STUB_REMOTE"
 
-func buildServeClient(cfg config.Config) (onepoint.Client, error) {
+// buildServeClient returns the OnePoint client for serve and the base URL it
+// talks to; the base URL is empty for the e2e stub client.
+func buildServeClient(cfg config.Config) (onepoint.Client, string, error) {
 	if strings.TrimSpace(os.Getenv(e2eStubRemoteEnv)) == "1" {
-		return newServeE2EStubClient(cfg), nil
+		return newServeE2EStubClient(cfg), "", nil
 	}
 
-	cookieHeader, baseURL, homeURL, host, stateFile, err := ensureAuthenticatedWithStateFile(serveURL, serveStateFile)
+	baseURL, homeURL, host, err := resolveOnePointURLs(serveURL)
+	if err != nil {
+		return nil, "", err
+	}
+	stateFile, err := resolveDefaultAuthStatePath(serveStateFile)
+	if err != nil {
+		return nil, "", err
+	}
+	limiter := onepoint.NewRateLimiter(cfg.OnePoint.RequestsPerSecond)
+	client, err := connectServeClient(baseURL, homeURL, host, stateFile, serveAutoLogin, limiter, cfg.OnePoint.MaxRetries)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, baseURL, nil
+}
+
+// connectServeClient builds a OnePoint client from the saved auth state and
+// verifies the session. A missing session, or one OnePoint rejects, opens the
+// browser login flow and continues with the reloaded cookies. With autoLogin,
+// an auth state file that cannot be read opens the login flow as well instead
+// of failing. limiter paces the client's OnePoint calls; nil disables pacing.
+// maxRetries is how often the client retries read-only requests.
+func connectServeClient(baseURL, homeURL, host, stateFile string, autoLogin bool, limiter *onepoint.RateLimiter, maxRetries int) (onepoint.Client, error) {
+	cookieHeader, err := onepoint.SessionCookieHeaderFromStateFile(stateFile, host)
+	if err != nil {
+		switch {
+		case errors.Is(err, onepoint.ErrAuthStateNotFound) || errors.Is(err, onepoint.ErrMissingSessionCookies):
+			fmt.Println("Not logged in to OnePoint. Opening browser for login...")
+		case autoLogin:
+			fmt.Printf("Cannot read the saved OnePoint auth state (%v). Opening browser for login...\n", err)
+		default:
+			return nil, fmt.Errorf("read auth state: %w (start serve with --auto-login to log in again)", err)
+		}
+		cookieHeader, err = loginAndReloadCookies(baseURL, homeURL, host, stateFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := newServeOnePointClient(baseURL, homeURL, cookieHeader, limiter, maxRetries)
 	if err != nil {
 		return nil, err
 	}
+	err = verifyServeSession(client)
+	if err == nil {
+		return client, nil
+	}
+	if !errors.Is(err, onepoint.ErrAuthUnauthorized) {
+		return nil, fmt.Errorf("validate OnePoint session: %w", err)
+	}
 
-	_, err = retryWithRelogin(
-		baseURL,
-		homeURL,
-		host,
-		stateFile,
-		"gohour-serve/1.0",
-		&cookieHeader,
-		func(client onepoint.Client) (struct{}, error) {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-			projects, err := client.ListProjects(ctx)
-			if err != nil {
-				return struct{}{}, err
-			}
-			if len(projects) == 0 {
-				return struct{}{}, fmt.Errorf(
-					"%w: ListProjects returned empty result (session may have expired)",
-					onepoint.ErrAuthUnauthorized,
-				)
-			}
-			return struct{}{}, nil
-		},
-	)
+	fmt.Println("OnePoint session expired. Opening browser for login...")
+	cookieHeader, err = loginAndReloadCookies(baseURL, homeURL, host, stateFile)
+	if err != nil {
+		return nil, err
+	}
+	client, err = newServeOnePointClient(baseURL, homeURL, cookieHeader, limiter, maxRetries)
 	if err != nil {
+		return nil, err
+	}
+	if err := verifyServeSession(client); err != nil {
 		return nil, fmt.Errorf("validate OnePoint session: %w", err)
 	}
+	return client, nil
+}
 
-	client, err := onepoint.NewClient(onepoint.ClientConfig{
+func newServeOnePointClient(baseURL, homeURL, cookieHeader string, limiter *onepoint.RateLimiter, maxRetries int) (onepoint.Client, error) {
+	return onepoint.NewClient(onepoint.ClientConfig{
 		BaseURL:        baseURL,
 		RefererURL:     homeURL,
 		SessionCookies: cookieHeader,
 		UserAgent:      "gohour-serve/1.0",
+		MaxRetries:     maxRetries,
+		RateLimiter:    limiter,
 	})
+}
+
+func verifyServeSession(client onepoint.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	projects, err := client.ListProjects(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return client, nil
+	if len(projects) == 0 {
+		return fmt.Errorf(
+			"%w: ListProjects returned empty result (session may have expired)",
+			onepoint.ErrAuthUnauthorized,
+		)
+	}
+	return nil
 }
 
 type serveE2EStubClient struct {
@@ -140,6 +191,26 @@ func (c serveE2EStubClient) ListSkills(context.Context) ([]onepoint.Skill, error
 	return append([]onepoint.Skill(nil), c.snapshot.Skills...), nil
 }
 
+func (c serveE2EStubClient) ListProjectActivities(_ context.Context, projectID int64) ([]onepoint.Activity, error) {
+	activities := make([]onepoint.Activity, 0, len(c.snapshot.Activities))
+	for _, activity := range c.snapshot.Activities {
+		if activity.ProjectNodeID == projectID {
+			activities = append(activities, activity)
+		}
+	}
+	return activities, nil
+}
+
+func (c serveE2EStubClient) ListActivitySkills(_ context.Context, activityID int64) ([]onepoint.Skill, error) {
+	skills := make([]onepoint.Skill, 0, len(c.snapshot.Skills))
+	for _, skill := range c.snapshot.Skills {
+		if skill.ActivityID == activityID {
+			skills = append(skills, skill)
+		}
+	}
+	return skills, nil
+}
+
 func (c serveE2EStubClient) GetFilteredWorklogs(context.Context, time.Time, time.Time) ([]onepoint.DayWorklog, error) {
 	return nil, errors.New("remote refresh unavailable in e2e stub")
 }
@@ -164,6 +235,10 @@ func (c serveE2EStubClient) PersistWorklogs(_ context.Context, day time.Time, wo
 	return results, nil
 }
 
+func (c serveE2EStubClient) DeleteDayWorklog(_ context.Context, day time.Time, timeRecordID int64) error {
+	return fmt.Errorf("time record %d on %s: %w", timeRecordID, onepoint.FormatDay(day), onepoint.ErrWorklogNotFound)
+}
+
 func (c serveE2EStubClient) FetchLookupSnapshot(context.Context) (onepoint.LookupSnapshot, error) {
 	return c.snapshot, nil
 }
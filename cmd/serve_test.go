@@ -2,18 +2,24 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/onepoint"
 )
 
 func TestParseServeMonthBounds_NoFlagsUsesCurrentMonth(t *testing.T) {
 	t.Parallel()
 
-	bounds, err := parseServeMonthBounds("", "")
+	bounds, err := parseServeMonthBounds("", "", time.Local)
 	if err != nil {
 		t.Fatalf("parse bounds: %v", err)
 	}
@@ -30,7 +36,7 @@ func TestParseServeMonthBounds_ClampsWhenNowOutsideRange(t *testing.T) {
 	fromFuture := now.AddDate(0, 2, 0).Format("2006-01")
 	toPast := now.AddDate(0, -2, 0).Format("2006-01")
 
-	futureBounds, err := parseServeMonthBounds(fromFuture, "")
+	futureBounds, err := parseServeMonthBounds(fromFuture, "", time.Local)
 	if err != nil {
 		t.Fatalf("parse future bounds: %v", err)
 	}
@@ -38,7 +44,7 @@ func TestParseServeMonthBounds_ClampsWhenNowOutsideRange(t *testing.T) {
 		t.Fatalf("expected future clamp %q, got %q", fromFuture, futureBounds.defaultMonth)
 	}
 
-	pastBounds, err := parseServeMonthBounds("", toPast)
+	pastBounds, err := parseServeMonthBounds("", toPast, time.Local)
 	if err != nil {
 		t.Fatalf("parse past bounds: %v", err)
 	}
@@ -75,7 +81,7 @@ func TestWithServeMonthRedirect(t *testing.T) {
 
 func TestBuildServeClient_UsesE2EStubWhenEnabled(t *testing.T) {
 	t.Setenv(e2eStubRemoteEnv, "1")
-	client, err := buildServeClient(config.Config{
+	client, _, err := buildServeClient(config.Config{
 		Rules: []config.Rule{
 			{
 				Name:         "generic-local",
@@ -111,7 +117,7 @@ func TestBuildServeClient_UsesE2EStubWhenEnabled(t *testing.T) {
 
 func TestBuildServeClient_StubRefreshFailsButDayLookupWorks(t *testing.T) {
 	t.Setenv(e2eStubRemoteEnv, "1")
-	client, err := buildServeClient(config.Config{})
+	client, _, err := buildServeClient(config.Config{})
 	if err != nil {
 		t.Fatalf("buildServeClient returned error: %v", err)
 	}
@@ -127,3 +133,97 @@ func TestBuildServeClient_StubRefreshFailsButDayLookupWorks(t *testing.T) {
 		t.Fatalf("expected empty day worklogs, got %+v", worklogs)
 	}
 }
+
+func TestConnectServeClient_ExpiredSessionReloadsCookies(t *testing.T) {
+	remote := newServeSessionTestServer(t, "JSESSIONID=fresh")
+	defer remote.Close()
+	host := strings.TrimPrefix(remote.URL, "http://")
+	host = host[:strings.LastIndex(host, ":")]
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	writeServeAuthState(t, stateFile, host, "stale")
+
+	loginCalls := 0
+	previous := runBrowserLogin
+	runBrowserLogin = func(baseURL, homeURL, loginHost, stateFile string, timeout time.Duration, debugCookies bool) (string, error) {
+		loginCalls++
+		writeServeAuthState(t, stateFile, loginHost, "fresh")
+		return "", nil
+	}
+	t.Cleanup(func() {
+		runBrowserLogin = previous
+	})
+
+	client, err := connectServeClient(remote.URL, remote.URL+"/onepoint/faces/home", host, stateFile, false, nil, 2)
+	if err != nil {
+		t.Fatalf("connectServeClient returned error: %v", err)
+	}
+	if loginCalls != 1 {
+		t.Fatalf("expected one browser login, got %d", loginCalls)
+	}
+	projects, err := client.ListProjects(t.Context())
+	if err != nil {
+		t.Fatalf("ListProjects with reloaded cookies: %v", err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+}
+
+func TestConnectServeClient_UnreadableStateLogsInOnlyWithAutoLogin(t *testing.T) {
+	remote := newServeSessionTestServer(t, "JSESSIONID=fresh")
+	defer remote.Close()
+	host := strings.TrimPrefix(remote.URL, "http://")
+	host = host[:strings.LastIndex(host, ":")]
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(stateFile, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("write auth state: %v", err)
+	}
+
+	loginCalls := 0
+	previous := runBrowserLogin
+	runBrowserLogin = func(baseURL, homeURL, loginHost, stateFile string, timeout time.Duration, debugCookies bool) (string, error) {
+		loginCalls++
+		writeServeAuthState(t, stateFile, loginHost, "fresh")
+		return "", nil
+	}
+	t.Cleanup(func() {
+		runBrowserLogin = previous
+	})
+
+	_, err := connectServeClient(remote.URL, remote.URL+"/onepoint/faces/home", host, stateFile, false, nil, 2)
+	if err == nil || !strings.Contains(err.Error(), "--auto-login") {
+		t.Fatalf("expected read error with --auto-login hint, got %v", err)
+	}
+	if loginCalls != 0 {
+		t.Fatalf("browser login must not run without --auto-login, got %d calls", loginCalls)
+	}
+
+	if _, err := connectServeClient(remote.URL, remote.URL+"/onepoint/faces/home", host, stateFile, true, nil, 2); err != nil {
+		t.Fatalf("connectServeClient with --auto-login returned error: %v", err)
+	}
+	if loginCalls != 1 {
+		t.Fatalf("expected one browser login, got %d", loginCalls)
+	}
+}
+
+func newServeSessionTestServer(t *testing.T, validCookie string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Cookie") != validCookie {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]onepoint.Project{{ID: 1, Name: "P", Archived: "0"}})
+	}))
+}
+
+func writeServeAuthState(t *testing.T, path, host, sessionID string) {
+	t.Helper()
+	content := fmt.Sprintf(`{"cookies":[{"name":"JSESSIONID","value":%q,"domain":%q,"path":"/"}]}`, sessionID, host)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write auth state: %v", err)
+	}
+}
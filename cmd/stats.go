@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/output"
+	"github.com/riadshalaby/gohour/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsDBPath string
+	statsFrom   string
+	statsTo     string
+	statsJSON   bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Print worked and billable hours per project, activity and week",
+	Long: `Print where the time of the locally stored worklogs went.
+
+Worklogs are grouped by project, by activity and by ISO week (for example 2026-W10).
+Each group shows worked, billable and non-billable hours and the number of worklogs,
+sorted by worked hours, largest first, followed by the overall total. Entries with
+zero billable minutes still count as worked hours; the time not covered by billable
+minutes is reported as non-billable. Worklogs without a project or activity are
+grouped as "(none)". --from/--to limit the worklogs to a day range.
+
+The default output is text tables. Use --json to print a JSON object with total,
+byProject, byActivity and byWeek instead; each group has name, workedHours,
+billableHours, nonBillableHours and worklogCount.`,
+	Example: `
+  # Break down all stored worklogs
+  gohour stats
+
+  # Break down one month as JSON
+  gohour stats --from 2026-03-01 --to 2026-03-31 --json
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
+		loc := cfg.Location()
+		from, to, err := parseSubmitRange(statsFrom, statsTo, loc)
+		if err != nil {
+			return err
+		}
+
+		store, err := openConfiguredStoreWithNotices(statsDBPath, *cfg, os.Stderr)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		return runStats(os.Stdout, store, from, to, loc, statsJSON)
+	},
+}
+
+// runStats writes the grouped totals of stored worklogs within [from, to] to
+// out, as JSON when asJSON is set and as text tables otherwise. Days and
+// weeks are taken in loc.
+func runStats(out io.Writer, store *storage.SQLiteStore, from, to *time.Time, loc *time.Location, asJSON bool) error {
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		return err
+	}
+	stats := output.BuildStats(filterEntriesByDayRange(entriesInLocation(entries, loc), from, to, loc))
+
+	if asJSON {
+		return output.WriteStatsJSON(out, stats)
+	}
+	return output.WriteStatsTable(out, stats)
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsDBPath, "db", "./gohour.db", "Path to local SQLite database")
+	statsCmd.Flags().StringVar(&statsFrom, "from", "", "Filter start day (inclusive), format YYYY-MM-DD")
+	statsCmd.Flags().StringVar(&statsTo, "to", "", "Filter end day (inclusive), format YYYY-MM-DD")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Print stats as JSON instead of text tables")
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/storage"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestRunStats_JSONForDayRange(t *testing.T) {
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "gohour.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.Local)
+	}
+	entries := []worklog.Entry{
+		{StartDateTime: at(2, 9), EndDateTime: at(2, 10), Billable: 60, Project: "Out of range", Description: "Skipped", SourceFormat: "generic"},
+		{StartDateTime: at(3, 9), EndDateTime: at(3, 11), Billable: 120, Project: "Alpha", Activity: "Dev", Description: "Build", SourceFormat: "generic"},
+		{StartDateTime: at(4, 9), EndDateTime: at(4, 10), Billable: 0, Project: "Alpha", Activity: "Meeting", Description: "Sync", SourceFormat: "generic"},
+	}
+	if _, err := store.InsertWorklogs(entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	from, to, err := parseSubmitRange("2026-03-03", "2026-03-04", time.Local)
+	if err != nil {
+		t.Fatalf("parse range: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runStats(&out, store, from, to, time.Local, true); err != nil {
+		t.Fatalf("run stats: %v", err)
+	}
+
+	var got struct {
+		Total struct {
+			WorkedHours      float64 `json:"workedHours"`
+			BillableHours    float64 `json:"billableHours"`
+			NonBillableHours float64 `json:"nonBillableHours"`
+		} `json:"total"`
+		ByProject []struct {
+			Name         string `json:"name"`
+			WorklogCount int    `json:"worklogCount"`
+		} `json:"byProject"`
+		ByActivity []struct {
+			Name string `json:"name"`
+		} `json:"byActivity"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, out.String())
+	}
+	if got.Total.WorkedHours != 3 || got.Total.BillableHours != 2 || got.Total.NonBillableHours != 1 {
+		t.Fatalf("unexpected total: %+v", got.Total)
+	}
+	if len(got.ByProject) != 1 || got.ByProject[0].Name != "Alpha" || got.ByProject[0].WorklogCount != 2 {
+		t.Fatalf("unexpected projects: %+v", got.ByProject)
+	}
+	if len(got.ByActivity) != 2 || got.ByActivity[0].Name != "Dev" || got.ByActivity[1].Name != "Meeting" {
+		t.Fatalf("unexpected activities: %+v", got.ByActivity)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/riadshalaby/gohour/config"
 	"github.com/riadshalaby/gohour/internal/timeutil"
@@ -10,8 +11,13 @@ import (
 	"github.com/riadshalaby/gohour/storage"
 	"github.com/riadshalaby/gohour/submitter"
 	"github.com/riadshalaby/gohour/worklog"
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -27,10 +33,28 @@ var (
 	submitDryRun                  bool
 	submitIncludeArchived         bool
 	submitIncludeLockedActivities bool
+	submitIncludeInactive         bool
+	submitInteractivePlan         bool
+	submitRetryFailedDays         bool
+	submitVerbose                 bool
+	submitFailOnDuplicates        bool
+	submitStrict                  bool
+	submitVerifyRules             bool
+	submitOverrideProject         string
+	submitOverrideActivity        string
+	submitOverrideSkill           string
+	submitRemapDates              []string
+	submitCommit                  bool
+	submitForce                   bool
+	submitConcurrency             int
+	submitOrder                   string
 )
 
 var submitInputReader = bufio.NewReader(os.Stdin)
 
+// submitWebhookTimeout bounds the submit.webhook_url notification.
+const submitWebhookTimeout = 10 * time.Second
+
 var submitCmd = &cobra.Command{
 	Use:   "submit",
 	Short: "Submit worklogs from SQLite to OnePoint",
@@ -44,28 +68,129 @@ For each day it:
 - prompts how to handle overlaps (write/skip/write-all/skip-all/abort), unless --dry-run is used
 
 In --dry-run mode, remote day worklogs are still loaded to report locked days and overlaps,
-but no persist call is made.
+but no persist call is made. With dry_run_by_default: true in the config, submit always runs
+in dry-run mode unless --commit is passed.
+
+With --interactive-plan the full plan (ready/duplicate/overlap/locked per day) is computed
+and printed first, followed by a single confirmation. Overlapping entries are skipped in
+this mode instead of prompting per day.
+
+With --retry-failed-days a day that fails to persist does not abort the run. Failed days
+are retried once after all other days; days that still fail are reported and the command
+exits with an error.
+
+When OnePoint rejects a day because it was modified concurrently (status 409), the day is
+loaded again, the local entries are merged with its current entries and the persist is
+retried once (submit.retry_on_conflict, default true). Local entries that now overlap an
+entry added concurrently are skipped with a warning.
+
+OnePoint answers every persisted entry with a result. A result for a new entry whose
+messageType is error or warning (for example a skill that does not belong to the activity) is
+a rejected entry: it is printed with its time range and message, counted as "Rejected entries"
+instead of "Added entries" in the summary, and makes the command exit with an error. Results
+for the existing entries of the day, which are persisted along with the new ones, are never
+rejected.
+
+Equivalent local entries of a day (same time + project/activity/skill) are collapsed to one
+before classification and counted as "Local duplicates collapsed". With --fail-on-duplicates
+the command aborts instead and lists the duplicated time ranges.
+
+Days whose entries add up to more than max_daily_hours (default 16) worked hours are
+printed as warnings and counted as "Days over max hours" in the summaries. With --strict
+the command aborts before anything is sent.
+
+With --verify-rules the ids configured in rules are checked against the OnePoint lookup
+data as well: when the rule's project/activity/skill names no longer resolve to those ids
+(for example after a project was renamed or merged) a drift warning is printed. The rule
+ids are still used.
+
+When submit.webhook_url is configured, a JSON summary (days, entries submitted, duplicates,
+overlaps, locked days, rejected entries, failed days) is POSTed to it after the run completed.
+A failing webhook only prints a warning.
+
+With submit.skip_unchanged_days: true, a hash of each day's prepared entries is stored locally
+after the day was submitted. Later runs skip days whose hash did not change, so re-submitting a
+month after editing one day only checks that day against OnePoint. --force processes every day.
+The recorded hashes and the OnePoint ids cached on local rows are cleared when submit runs
+against another OnePoint URL than last time (e.g. another --profile).
+
+Without --from/--to, submit.default_range in the config selects the days ("all" by default,
+"current-month" or "previous-month"). Explicit flags always win.
+
+Days are processed in ascending order by default; --order desc submits the most recent
+days first. The handling of each day is the same in both orders.
+
+Days are independent, so loading existing remote entries and persisting run for up to
+--concurrency days at once (default 3). Overlap prompts are still asked one day at a time in
+day order after all days were loaded, and per-day output is printed in day order.
+
+--override-project, --override-activity and --override-skill submit every selected entry
+under the given name instead of the stored one, for example to reclassify a batch under a
+different activity. Overrides are applied before resolving ids, must resolve like any other
+name, and do not change the local database.
+
+--remap-date FROM=TO moves every selected entry of day FROM to day TO before building
+batches, keeping clock times, so a mis-dated batch lands on the right OnePoint day. FROM must
+lie within the selected range. The flag is repeatable and does not change the local database.
+
+With --verbose every OnePoint HTTP call is logged to stderr (method, path, status, duration,
+request headers with the Cookie value redacted).
 Authentication uses session cookies from auth state JSON (created by "gohour auth login").`,
 	Example: `
   # Submit all local worklogs
   gohour submit
+
+  # Review the whole plan and confirm once
+  gohour submit --interactive-plan
+
+  # Keep going on transient upstream errors and retry failed days at the end
+  gohour submit --retry-failed-days
+
+  # Log every OnePoint request/response summary to stderr
+  gohour submit --verbose
+
+  # Load and persist one day at a time
+  gohour submit --concurrency 1
+
+  # Submit the most recent days first
+  gohour submit --order desc
+
+  # Submit one week under a different activity without changing local entries
+  gohour submit --from 2026-03-02 --to 2026-03-06 --override-activity "Support"
+
+  # Submit entries recorded on the 4th to the 5th instead
+  gohour submit --from 2026-03-04 --to 2026-03-04 --remap-date 2026-03-04=2026-03-05
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.LoadAndValidate()
 		if err != nil {
 			return err
 		}
+		dryRun, err := resolveDryRun(submitDryRun, submitCommit, cfg.DryRunByDefault)
+		if err != nil {
+			return err
+		}
+		if submitConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1, got %d", submitConcurrency)
+		}
+		descending, err := resolveSubmitOrder(submitOrder)
+		if err != nil {
+			return err
+		}
 
 		cookieHeader, baseURL, homeURL, host, stateFile, err := ensureAuthenticatedWithStateFile(submitURL, submitStateFile)
 		if err != nil {
 			return err
 		}
 
-		store, err := storage.OpenSQLite(submitDBPath)
+		store, err := openConfiguredStore(submitDBPath, *cfg)
 		if err != nil {
 			return err
 		}
 		defer store.Close()
+		if err := useOnePointTenant(store, baseURL, os.Stdout); err != nil {
+			return err
+		}
 
 		allEntries, err := store.ListWorklogs()
 		if err != nil {
@@ -75,16 +200,36 @@ Authentication uses session cookies from auth state JSON (created by "gohour aut
 			return fmt.Errorf("no worklogs found in %s", submitDBPath)
 		}
 
-		from, to, err := parseSubmitRange(submitFromDay, submitToDay)
+		from, to, err := resolveSubmitRange(submitFromDay, submitToDay, cfg.Submit.DefaultRangeMode(), time.Now(), cfg.Location())
 		if err != nil {
 			return err
 		}
-		entries := filterEntriesByDayRange(allEntries, from, to)
+		remaps, err := parseSubmitDateRemaps(submitRemapDates, cfg.Location())
+		if err != nil {
+			return err
+		}
+		entries := filterEntriesByDayRange(allEntries, from, to, cfg.Location())
 		if len(entries) == 0 {
 			return fmt.Errorf("no worklogs matched the selected date range")
 		}
+		override := submitNameOverride{
+			Project:  submitOverrideProject,
+			Activity: submitOverrideActivity,
+			Skill:    submitOverrideSkill,
+		}
+		entries = applySubmitNameOverride(entries, override)
+		entries, unmatchedRemaps := applySubmitDateRemaps(entries, remaps, cfg.Location())
+		for _, remap := range unmatchedRemaps {
+			fmt.Printf("Warning: --remap-date %s matched no selected entries\n", remap)
+		}
 
-		idMap, err := retryWithRelogin(
+		httpClient := newSubmitHTTPClient(submitVerbose, os.Stderr)
+		limiter := onepoint.NewRateLimiter(cfg.OnePoint.RequestsPerSecond)
+		var ruleWarnings []string
+		idMap, err := retryWithReloginUsing(
+			httpClient,
+			limiter,
+			cfg.OnePoint.MaxRetries,
 			baseURL,
 			homeURL,
 			host,
@@ -94,206 +239,736 @@ Authentication uses session cookies from auth state JSON (created by "gohour aut
 			func(client onepoint.Client) (map[submitNameTuple]submitResolvedIDs, error) {
 				resolveCtx, cancelResolve := context.WithTimeout(context.Background(), submitTimeout)
 				defer cancelResolve()
-				return resolveIDsForEntries(resolveCtx, client, cfg.Rules, entries, onepoint.ResolveOptions{
-					IncludeArchivedProjects: submitIncludeArchived,
-					IncludeLockedActivities: submitIncludeLockedActivities,
-				})
+				options := onepoint.ResolveOptions{
+					IncludeArchivedProjects:   submitIncludeArchived,
+					IncludeLockedActivities:   submitIncludeLockedActivities,
+					ProjectCodePattern:        cfg.OnePoint.ProjectCodeRegexp(),
+					SelectableProjectStatuses: cfg.OnePoint.SelectableProjectStatuses,
+					IncludeInactiveProjects:   submitIncludeInactive,
+					IgnoreDiacritics:          cfg.OnePoint.IgnoreDiacritics,
+				}
+				if !submitVerifyRules {
+					return resolveIDsForEntries(resolveCtx, client, cfg.Rules, entries, options)
+				}
+				ids, warnings, err := submitter.ResolveIDsForEntriesVerifyingRules(resolveCtx, client, cfg.Rules, entries, options)
+				ruleWarnings = warnings
+				return ids, err
 			},
 		)
 		if err != nil {
-			return err
+			if override.active() {
+				return fmt.Errorf("resolve overridden project/activity/skill: %w", err)
+			}
+			return explainSubmitSessionExpired(err)
+		}
+		for _, warning := range ruleWarnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+		// Overridden names are not the stored ones, so their ids must not be
+		// cached on the local rows.
+		if !override.active() {
+			if err := storeResolvedIDs(store, entries, idMap); err != nil {
+				return err
+			}
 		}
 
 		dayBatches, err := buildSubmitDayBatches(entries, idMap)
 		if err != nil {
 			return err
 		}
+		localDuplicates, err := collapseLocalDuplicates(dayBatches, submitFailOnDuplicates)
+		if err != nil {
+			return err
+		}
+		sanitizeMode := submitter.CommentSanitization(cfg.Submit.CommentSanitizationMode())
+		ticketFormat := submitter.TicketFormat{
+			Pattern:  cfg.Submit.TicketRegexp(),
+			Template: cfg.Submit.TicketTemplate,
+			Required: cfg.Submit.RequireTicket,
+		}
+		daysOverMaxHours, err := checkSubmitDailyHours(os.Stdout, dayBatches, cfg.MaxDailyHours, submitStrict)
+		if err != nil {
+			return err
+		}
+		for i := range dayBatches {
+			for _, warning := range submitter.SanitizeDayBatchComments(&dayBatches[i], sanitizeMode) {
+				fmt.Printf("Warning: %s\n", warning)
+			}
+			for _, warning := range submitter.FormatDayBatchTickets(&dayBatches[i], ticketFormat) {
+				fmt.Printf("Warning: %s\n", warning)
+			}
+		}
 		if len(dayBatches) == 0 {
 			return fmt.Errorf("no valid day batches to submit")
 		}
 
-		totalLocal := 0
-		for _, batch := range dayBatches {
-			totalLocal += len(batch.Worklogs)
+		// Days run concurrently and share one session: when several days are
+		// rejected together, a single re-login serves all of them.
+		sharedSession := newSharedCookieSession(cookieHeader, func() (string, error) {
+			return loginAndReloadCookies(baseURL, homeURL, host, stateFile)
+		})
+		session := submitSession{
+			timeout:     submitTimeout,
+			concurrency: submitConcurrency,
+			call: func(op func(client onepoint.Client) error) error {
+				_, callErr := retryWithSharedSession(
+					httpClient,
+					limiter,
+					cfg.OnePoint.MaxRetries,
+					baseURL,
+					homeURL,
+					"gohour-submit/1.0",
+					sharedSession,
+					func(client onepoint.Client) (struct{}, error) {
+						return struct{}{}, op(client)
+					},
+				)
+				return callErr
+			},
 		}
 
-		classified := make([]classifiedDay, 0, len(dayBatches))
-		totalDuplicates := 0
-		totalOverlaps := 0
-		lockedDays := make([]string, 0, len(dayBatches))
-		globalSkipAllOverlaps := false
-		globalWriteAllOverlaps := false
+		err = runSubmit(session, dayBatches, localDuplicates, dryRun, submitExecuteOptions{
+			interactivePlan:   submitInteractivePlan,
+			retryFailedDays:   submitRetryFailedDays,
+			verifyPersist:     cfg.Submit.VerifyPersistResults,
+			retryOnConflict:   cfg.Submit.RetryOnConflict,
+			webhookURL:        strings.TrimSpace(cfg.Submit.WebhookURL),
+			dayHashes:         store,
+			skipUnchangedDays: cfg.Submit.SkipUnchangedDays && !submitForce,
+			descending:        descending,
+			daysOverMaxHours:  daysOverMaxHours,
+		})
+		return explainSubmitSessionExpired(err)
+	},
+}
 
-		if submitDryRun {
-			fmt.Println("Submit dry-run mode: validating against existing OnePoint entries without persisting changes.")
-		}
+// explainSubmitSessionExpired tells the user how to continue when OnePoint
+// still answered with its login page after the automatic re-login.
+func explainSubmitSessionExpired(err error) error {
+	if errors.Is(err, onepoint.ErrSessionExpired) {
+		return fmt.Errorf("%w; after logging in, re-run submit: entries already sent are skipped as duplicates", err)
+	}
+	return err
+}
 
-		for _, batch := range dayBatches {
-			dayLabel := onepoint.FormatDay(batch.Day)
-			cd := classifiedDay{
-				batch:    batch,
-				dayLabel: dayLabel,
-			}
+// runSubmit classifies dayBatches against OnePoint and either prints the
+// dry-run report or persists the plan.
+func runSubmit(session submitSession, dayBatches []submitDayBatch, localDuplicates int, dryRun bool, options submitExecuteOptions) error {
+	if dryRun {
+		fmt.Println("Submit dry-run mode: validating against existing OnePoint entries without persisting changes.")
+	}
+	sortSubmitDayBatches(dayBatches, options.descending)
 
-			existing, submitErr := retryWithRelogin(
-				baseURL,
-				homeURL,
-				host,
-				stateFile,
-				"gohour-submit/1.0",
-				&cookieHeader,
-				func(client onepoint.Client) ([]onepoint.DayWorklog, error) {
-					dayCtx, cancelDay := context.WithTimeout(context.Background(), submitTimeout)
-					defer cancelDay()
-					return client.GetDayWorklogs(dayCtx, batch.Day)
-				},
-			)
-			if submitErr != nil {
-				return fmt.Errorf("load existing day %s failed: %w", dayLabel, submitErr)
-			}
+	if options.skipUnchangedDays && options.dayHashes != nil {
+		changed, unchanged, err := filterUnchangedDayBatches(dayBatches, options.dayHashes)
+		if err != nil {
+			return err
+		}
+		if len(unchanged) > 0 {
+			fmt.Printf("Skipping %d unchanged day(s) (use --force to resubmit): %s\n", len(unchanged), strings.Join(unchanged, ", "))
+		}
+		if len(changed) == 0 {
+			fmt.Println("No changed days to submit.")
+			return nil
+		}
+		dayBatches = changed
+	}
 
-			lockedCount := submitter.CountLockedDayWorklogs(existing)
-			if lockedCount > 0 {
-				cd.locked = true
-				lockedDays = append(lockedDays, dayLabel)
-				classified = append(classified, cd)
-				continue
-			}
+	plan, err := buildSubmitPlan(session, dayBatches)
+	if err != nil {
+		return err
+	}
+	plan.localDuplicates = localDuplicates
+	plan.daysOverMaxHours = options.daysOverMaxHours
 
-			cd.existingPayload = submitter.DayWorklogsToPersistPayload(existing)
-			cd.toAdd, cd.overlaps, cd.duplicates = submitter.ClassifyWorklogs(batch.Worklogs, cd.existingPayload)
-			totalDuplicates += len(cd.duplicates)
-			totalOverlaps += len(cd.overlaps)
-			classified = append(classified, cd)
+	if dryRun {
+		printSubmitPlan(plan, "Dry-run day")
+		fmt.Println("Dry-run summary:")
+		fmt.Printf("  Days to submit:               %d\n", len(plan.days))
+		if len(plan.lockedDays) > 0 {
+			fmt.Printf("  Days skipped (locked):        %d  [%s]\n", len(plan.lockedDays), strings.Join(plan.lockedDays, ", "))
+		} else {
+			fmt.Printf("  Days skipped (locked):        %d\n", 0)
 		}
+		fmt.Printf("  Local entries prepared:       %d\n", plan.totalLocal)
+		fmt.Printf("  Duplicates (skipped):         %d\n", plan.totalDuplicates)
+		fmt.Printf("  Local duplicates collapsed:   %d\n", plan.localDuplicates)
+		fmt.Printf("  Overlapping entries (warned): %d\n", plan.totalOverlaps)
+		fmt.Printf("  Days over max hours:          %d\n", plan.daysOverMaxHours)
+		return nil
+	}
+
+	return executeSubmitPlan(session, plan, options)
+}
+
+// submitSession runs OnePoint calls for a submit, hiding how the client is
+// created and re-authenticated. call must be safe for concurrent use when
+// concurrency is above 1.
+type submitSession struct {
+	call    func(op func(client onepoint.Client) error) error
+	timeout time.Duration
+	// concurrency bounds how many days are loaded or persisted at once;
+	// values below 1 mean one day at a time.
+	concurrency int
+}
 
-		if submitDryRun {
-			for _, cd := range classified {
-				fmt.Printf("Dry-run day %s:\n", cd.dayLabel)
-				if cd.locked {
-					fmt.Println("  [locked] day contains locked remote entries (skipped)")
-					continue
+// forEachSubmitDay calls fn for the indexes 0..n-1 with at most concurrency
+// calls running at once. Indexes are started in ascending order; once fn
+// returns false no further indexes are started.
+func forEachSubmitDay(n, concurrency int, fn func(i int) bool) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var (
+		mu      sync.Mutex
+		next    int
+		stopped bool
+		wg      sync.WaitGroup
+	)
+	take := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped || next >= n {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+	for range min(concurrency, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, ok := take()
+				if !ok {
+					return
 				}
-				for _, item := range cd.batch.Worklogs {
-					if containsEquivalentPersistWorklog(cd.duplicates, item) {
-						fmt.Printf("  [duplicate] %s (skipped - already remote)\n", formatDryRunWorklog(item))
-						continue
-					}
-					if overlap, ok := findOverlapForLocal(cd.overlaps, item); ok {
-						fmt.Printf(
-							"  [overlap]   %s overlaps with existing %s\n",
-							formatDryRunWorklog(item),
-							formatPersistWorklogRange(overlap.Existing),
-						)
-						continue
-					}
-					fmt.Printf("  [ready]     %s\n", formatDryRunWorklog(item))
+				if !fn(i) {
+					mu.Lock()
+					stopped = true
+					mu.Unlock()
 				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s submitSession) getDayWorklogs(day time.Time) ([]onepoint.DayWorklog, error) {
+	var out []onepoint.DayWorklog
+	err := s.call(func(client onepoint.Client) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		values, err := client.GetDayWorklogs(ctx, day)
+		out = values
+		return err
+	})
+	return out, err
+}
+
+func (s submitSession) persistWorklogs(day time.Time, payload []onepoint.PersistWorklog) ([]onepoint.PersistResult, error) {
+	var out []onepoint.PersistResult
+	err := s.call(func(client onepoint.Client) error {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		values, err := client.PersistWorklogs(ctx, day, payload)
+		out = values
+		return err
+	})
+	return out, err
+}
+
+// submitPlan is the classification of all day batches against OnePoint,
+// computed before anything is persisted.
+type submitPlan struct {
+	days            []classifiedDay
+	lockedDays      []string
+	totalLocal      int
+	totalDuplicates int
+	totalOverlaps   int
+	// localDuplicates counts equivalent local entries collapsed before
+	// classification.
+	localDuplicates int
+	// daysOverMaxHours counts days above max_daily_hours (warned only).
+	daysOverMaxHours int
+}
+
+// buildSubmitPlan loads and classifies up to session.concurrency days at
+// once. The plan keeps the order of dayBatches; the first failing day in that
+// order is reported.
+func buildSubmitPlan(session submitSession, dayBatches []submitDayBatch) (submitPlan, error) {
+	days := make([]classifiedDay, len(dayBatches))
+	errs := make([]error, len(dayBatches))
+	forEachSubmitDay(len(dayBatches), session.concurrency, func(i int) bool {
+		days[i], errs[i] = classifySubmitDay(session, dayBatches[i])
+		return errs[i] == nil
+	})
+
+	plan := submitPlan{
+		days:       make([]classifiedDay, 0, len(dayBatches)),
+		lockedDays: make([]string, 0, len(dayBatches)),
+	}
+	for i, cd := range days {
+		if errs[i] != nil {
+			return submitPlan{}, errs[i]
+		}
+		plan.totalLocal += len(cd.batch.Worklogs)
+		if cd.locked {
+			plan.lockedDays = append(plan.lockedDays, cd.dayLabel)
+		}
+		plan.totalDuplicates += len(cd.duplicates)
+		plan.totalOverlaps += len(cd.overlaps)
+		plan.days = append(plan.days, cd)
+	}
+
+	return plan, nil
+}
+
+// classifySubmitDay loads the existing remote entries of batch's day and
+// classifies the local entries against them.
+func classifySubmitDay(session submitSession, batch submitDayBatch) (classifiedDay, error) {
+	dayLabel := onepoint.FormatDay(batch.Day)
+	cd := classifiedDay{
+		batch:    batch,
+		dayLabel: dayLabel,
+	}
+
+	existing, err := session.getDayWorklogs(batch.Day)
+	if err != nil {
+		return classifiedDay{}, fmt.Errorf("load existing day %s failed: %w", dayLabel, err)
+	}
+
+	if submitter.CountLockedDayWorklogs(existing) > 0 {
+		cd.locked = true
+		return cd, nil
+	}
+
+	cd.existingPayload = submitter.DayWorklogsToPersistPayload(existing)
+	cd.toAdd, cd.overlaps, cd.duplicates = submitter.ClassifyWorklogs(batch.Worklogs, cd.existingPayload)
+	return cd, nil
+}
+
+func printSubmitPlan(plan submitPlan, heading string) {
+	for _, cd := range plan.days {
+		fmt.Printf("%s %s:\n", heading, cd.dayLabel)
+		if cd.locked {
+			fmt.Println("  [locked] day contains locked remote entries (skipped)")
+			continue
+		}
+		for _, item := range cd.batch.Worklogs {
+			if containsEquivalentPersistWorklog(cd.duplicates, item) {
+				fmt.Printf("  [duplicate] %s (skipped - already remote)\n", formatDryRunWorklog(item))
+				continue
+			}
+			if overlap, ok := findOverlapForLocal(cd.overlaps, item); ok {
 				fmt.Printf(
-					"  Summary: local=%d ready=%d duplicates=%d overlaps=%d\n",
-					len(cd.batch.Worklogs),
-					len(cd.toAdd),
-					len(cd.duplicates),
-					len(cd.overlaps),
+					"  [overlap]   %s overlaps with existing %s\n",
+					formatDryRunWorklog(item),
+					formatPersistWorklogRange(overlap.Existing),
 				)
+				continue
 			}
+			fmt.Printf("  [ready]     %s\n", formatDryRunWorklog(item))
+		}
+		fmt.Printf(
+			"  Summary: local=%d ready=%d duplicates=%d overlaps=%d\n",
+			len(cd.batch.Worklogs),
+			len(cd.toAdd),
+			len(cd.duplicates),
+			len(cd.overlaps),
+		)
+	}
+}
+
+// submitExecuteOptions controls how executeSubmitPlan walks the plan.
+type submitExecuteOptions struct {
+	// interactivePlan prints the plan, asks once and skips overlaps.
+	interactivePlan bool
+	// retryFailedDays keeps going when a day fails to persist and retries
+	// the failed days once after all other days were submitted.
+	retryFailedDays bool
+	// verifyPersist warns when OnePoint confirms fewer new time records
+	// than entries were added for a day.
+	verifyPersist bool
+	// webhookURL, when set, receives a JSON run summary once the run
+	// completed. Webhook failures only print a warning.
+	webhookURL string
+	// dayHashes, when set, records the hash of every day that was fully
+	// submitted (or already fully present remotely).
+	dayHashes submitDayHashStore
+	// skipUnchangedDays drops days whose hash matches the recorded one
+	// before anything is loaded from OnePoint.
+	skipUnchangedDays bool
+	// descending processes the most recent day first.
+	descending bool
+	// daysOverMaxHours is reported in the summaries; the days were already
+	// warned about.
+	daysOverMaxHours int
+	// retryOnConflict re-fetches a day OnePoint rejected as modified
+	// concurrently, merges its entries again and persists it once more.
+	retryOnConflict bool
+}
+
+// submitDayHashStore persists the hash of each day's submitted local entries.
+// *storage.SQLiteStore satisfies it.
+type submitDayHashStore interface {
+	ListSubmittedDayHashes() (map[string]string, error)
+	SaveSubmittedDayHash(day, hash string) error
+}
+
+// pendingSubmitDay is a day whose overlaps were resolved and whose payload
+// is ready to persist.
+type pendingSubmitDay struct {
+	cd      classifiedDay
+	payload []onepoint.PersistWorklog
+	// toAdd are the local entries payload writes.
+	toAdd []onepoint.PersistWorklog
+	added int
+	// complete is true when no overlapping entry of the day was skipped.
+	complete bool
+	// done is set once the persist call returned; results and err hold
+	// its outcome.
+	done    bool
+	results []onepoint.PersistResult
+	err     error
+	// warnings are printed when the day is reported, e.g. entries skipped
+	// while merging after a conflict.
+	warnings []string
+}
+
+// persistPendingDay persists day. With retryOnConflict, a conflict reported
+// by OnePoint re-fetches the day, merges the entries still to add with the
+// current remote entries and persists once more.
+func persistPendingDay(session submitSession, day *pendingSubmitDay, retryOnConflict bool) {
+	day.results, day.err = session.persistWorklogs(day.cd.batch.Day, day.payload)
+	if day.err == nil || !retryOnConflict || !errors.Is(day.err, onepoint.ErrConflict) {
+		return
+	}
+
+	fresh, err := session.getDayWorklogs(day.cd.batch.Day)
+	if err != nil {
+		day.err = fmt.Errorf("reload after conflict: %w", err)
+		return
+	}
+	merge, err := submitter.MergeAfterConflict(fresh, day.cd.existingPayload, day.toAdd)
+	if err != nil {
+		day.err = fmt.Errorf("merge after conflict: %w", err)
+		return
+	}
+
+	day.warnings = append(day.warnings, fmt.Sprintf("day %s was modified concurrently; merged with the current remote entries and retried", day.cd.dayLabel))
+	for _, skipped := range merge.Skipped {
+		day.warnings = append(day.warnings, fmt.Sprintf(
+			"day %s: skipping entry %s, it overlaps entry %s added concurrently",
+			day.cd.dayLabel,
+			formatPersistWorklogRange(skipped.Local),
+			formatPersistWorklogRange(skipped.Existing),
+		))
+	}
+	day.payload = merge.Payload
+	day.toAdd = merge.ToWrite
+	day.added = len(merge.ToWrite)
+	day.complete = day.complete && len(merge.Skipped) == 0
+	if len(merge.ToWrite) == 0 {
+		day.results, day.err = nil, nil
+		return
+	}
+	day.results, day.err = session.persistWorklogs(day.cd.batch.Day, day.payload)
+}
+
+// failedSubmitDay is a day whose persist call failed and may be retried.
+type failedSubmitDay struct {
+	dayLabel string
+	day      time.Time
+	payload  []onepoint.PersistWorklog
+	added    int
+	err      error
+}
 
-			fmt.Println("Dry-run summary:")
-			fmt.Printf("  Days to submit:               %d\n", len(dayBatches))
-			if len(lockedDays) > 0 {
-				fmt.Printf("  Days skipped (locked):        %d  [%s]\n", len(lockedDays), strings.Join(lockedDays, ", "))
+// executeSubmitPlan persists a computed plan. Overlaps are resolved for all
+// days first, in day order; the days are then persisted with up to
+// session.concurrency calls at once and reported in day order. In
+// interactive-plan mode the full plan is printed and confirmed once;
+// overlapping entries are then skipped instead of prompting per day. With
+// retryFailedDays a failing day does not abort the run; failed days are
+// retried once at the end.
+func executeSubmitPlan(session submitSession, plan submitPlan, options submitExecuteOptions) error {
+	interactivePlan := options.interactivePlan
+	totalResponses := 0
+	totalAdded := 0
+	totalReady := countTotalToAdd(plan.days)
+	failedDays := make([]failedSubmitDay, 0)
+	failedBatches := make(map[string]submitDayBatch)
+	rejectedEntries := make([]string, 0)
+	globalSkipAllOverlaps := interactivePlan
+	globalWriteAllOverlaps := false
+
+	if interactivePlan {
+		fmt.Println("Submit plan:")
+		printSubmitPlan(plan, "Day")
+	}
+
+	fmt.Printf("\nPre-flight summary:\n")
+	fmt.Printf("  Days:               %d\n", len(plan.days))
+	fmt.Printf("  Locked (skipped):   %d\n", len(plan.lockedDays))
+	fmt.Printf("  Entries to add:     %d\n", totalReady)
+	fmt.Printf("  Duplicates to skip: %d\n", plan.totalDuplicates)
+	fmt.Printf("  Local duplicates:   %d\n", plan.localDuplicates)
+	fmt.Printf("  Overlapping:        %d\n", plan.totalOverlaps)
+	fmt.Printf("  Over max hours:     %d\n", plan.daysOverMaxHours)
+
+	if interactivePlan || plan.totalDuplicates > 0 || plan.totalOverlaps > 0 {
+		if plan.totalDuplicates > 0 {
+			fmt.Printf("Warning: %d duplicate entries will be silently skipped.\n", plan.totalDuplicates)
+		}
+		if plan.totalOverlaps > 0 {
+			if interactivePlan {
+				fmt.Printf("Warning: %d overlapping entries will be skipped.\n", plan.totalOverlaps)
 			} else {
-				fmt.Printf("  Days skipped (locked):        %d\n", 0)
+				fmt.Printf("Warning: %d overlapping entries will require interactive resolution.\n", plan.totalOverlaps)
 			}
-			fmt.Printf("  Local entries prepared:       %d\n", totalLocal)
-			fmt.Printf("  Duplicates (skipped):         %d\n", totalDuplicates)
-			fmt.Printf("  Overlapping entries (warned): %d\n", totalOverlaps)
+		}
+		fmt.Print("Proceed with submit? [y/N]: ")
+		input, err := submitInputReader.ReadString('\n')
+		if err != nil && strings.TrimSpace(input) == "" {
+			return fmt.Errorf("read submit confirmation: %w", err)
+		}
+		if strings.ToLower(strings.TrimSpace(input)) != "y" {
+			fmt.Println("Submit aborted.")
 			return nil
 		}
+	}
 
-		totalResponses := 0
-		totalAdded := 0
-		totalReady := countTotalToAdd(classified)
+	pending := make([]pendingSubmitDay, 0, len(plan.days))
+	for _, cd := range plan.days {
+		if cd.locked {
+			fmt.Printf("Warning: skipping day %s: locked\n", cd.dayLabel)
+			continue
+		}
 
-		fmt.Printf("\nPre-flight summary:\n")
-		fmt.Printf("  Days:               %d\n", len(dayBatches))
-		fmt.Printf("  Locked (skipped):   %d\n", len(lockedDays))
-		fmt.Printf("  Entries to add:     %d\n", totalReady)
-		fmt.Printf("  Duplicates to skip: %d\n", totalDuplicates)
-		fmt.Printf("  Overlapping:        %d\n", totalOverlaps)
+		approvedOverlaps, err := handleOverlaps(cd.overlaps, false, &globalSkipAllOverlaps, &globalWriteAllOverlaps)
+		if err != nil {
+			return err
+		}
 
-		if totalDuplicates > 0 || totalOverlaps > 0 {
-			if totalDuplicates > 0 {
-				fmt.Printf("Warning: %d duplicate entries will be silently skipped.\n", totalDuplicates)
-			}
-			if totalOverlaps > 0 {
-				fmt.Printf("Warning: %d overlapping entries will require interactive resolution.\n", totalOverlaps)
-			}
-			fmt.Print("Proceed with submit? [y/N]: ")
-			input, err := submitInputReader.ReadString('\n')
-			if err != nil && strings.TrimSpace(input) == "" {
-				return fmt.Errorf("read submit confirmation: %w", err)
-			}
-			if strings.ToLower(strings.TrimSpace(input)) != "y" {
-				fmt.Println("Submit aborted.")
-				return nil
+		toAdd := make([]onepoint.PersistWorklog, 0, len(cd.toAdd)+len(approvedOverlaps))
+		toAdd = append(toAdd, cd.toAdd...)
+		toAdd = append(toAdd, approvedOverlaps...)
+		if len(toAdd) == 0 {
+			fmt.Printf("No new entries for day %s. Skipping.\n", cd.dayLabel)
+			if len(cd.overlaps) == 0 {
+				recordSubmittedDay(options, cd.batch)
 			}
+			continue
 		}
 
-		for _, cd := range classified {
-			if cd.locked {
-				fmt.Printf("Warning: skipping day %s: locked\n", cd.dayLabel)
-				continue
-			}
+		pending = append(pending, pendingSubmitDay{
+			cd:       cd,
+			payload:  submitter.BuildPersistPayload(cd.existingPayload, toAdd),
+			toAdd:    toAdd,
+			added:    len(toAdd),
+			complete: len(approvedOverlaps) == len(cd.overlaps),
+		})
+	}
 
-			approvedOverlaps, err := handleOverlaps(cd.overlaps, false, &globalSkipAllOverlaps, &globalWriteAllOverlaps)
-			if err != nil {
-				return err
-			}
+	// Without retryFailedDays no further day is started after a failure.
+	forEachSubmitDay(len(pending), session.concurrency, func(i int) bool {
+		day := &pending[i]
+		persistPendingDay(session, day, options.retryOnConflict)
+		day.done = true
+		return day.err == nil || options.retryFailedDays
+	})
 
-			toAdd := make([]onepoint.PersistWorklog, 0, len(cd.toAdd)+len(approvedOverlaps))
-			toAdd = append(toAdd, cd.toAdd...)
-			toAdd = append(toAdd, approvedOverlaps...)
-			if len(toAdd) == 0 {
-				fmt.Printf("No new entries for day %s. Skipping.\n", cd.dayLabel)
+	var abortErr error
+	for _, day := range pending {
+		if !day.done {
+			continue
+		}
+		cd := day.cd
+		for _, warning := range day.warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+		if day.err != nil {
+			if !options.retryFailedDays {
+				if abortErr == nil {
+					abortErr = fmt.Errorf("submit day %s failed: %w", cd.dayLabel, day.err)
+				}
 				continue
 			}
+			fmt.Printf("Warning: submit day %s failed: %v (will retry)\n", cd.dayLabel, day.err)
+			failedBatches[cd.dayLabel] = cd.batch
+			failedDays = append(failedDays, failedSubmitDay{
+				dayLabel: cd.dayLabel,
+				day:      cd.batch.Day,
+				payload:  day.payload,
+				added:    day.added,
+				err:      day.err,
+			})
+			continue
+		}
 
-			payload := submitter.BuildPersistPayload(cd.existingPayload, toAdd)
-
-			results, err := retryWithRelogin(
-				baseURL,
-				homeURL,
-				host,
-				stateFile,
-				"gohour-submit/1.0",
-				&cookieHeader,
-				func(client onepoint.Client) ([]onepoint.PersistResult, error) {
-					dayCtx, cancelDay := context.WithTimeout(context.Background(), submitTimeout)
-					defer cancelDay()
-					return client.PersistWorklogs(dayCtx, cd.batch.Day, payload)
-				},
-			)
-			if err != nil {
-				return fmt.Errorf("submit day %s failed: %w", cd.dayLabel, err)
-			}
+		totalResponses += len(day.results)
+		rejected := reportRejectedEntries(cd.dayLabel, day.payload, day.results)
+		rejectedEntries = append(rejectedEntries, rejected...)
+		added := submitter.CountAddedRecords(day.payload, day.results)
+		totalAdded += added
+		fmt.Printf("Submitted day %s. Added: %d\n", cd.dayLabel, added)
+		warnOnUnconfirmedPersist(options, cd.dayLabel, day.added, day.results)
+		if len(rejected) == 0 && day.complete {
+			recordSubmittedDay(options, cd.batch)
+		}
+	}
+	if abortErr != nil {
+		// Days persisted concurrently with the failing one were reported
+		// above; the run still stops before the summary.
+		return abortErr
+	}
+
+	stillFailing := make([]failedSubmitDay, 0, len(failedDays))
+	if len(failedDays) > 0 {
+		fmt.Printf("Retrying %d failed day(s)...\n", len(failedDays))
+	}
+	for _, failed := range failedDays {
+		// The payload is the complete day (existing + new), so persisting it
+		// again is safe even if the first attempt reached the server.
+		results, err := session.persistWorklogs(failed.day, failed.payload)
+		if err != nil {
+			failed.err = err
+			stillFailing = append(stillFailing, failed)
+			fmt.Printf("Warning: retry of day %s failed: %v\n", failed.dayLabel, err)
+			continue
+		}
+		totalResponses += len(results)
+		rejected := reportRejectedEntries(failed.dayLabel, failed.payload, results)
+		rejectedEntries = append(rejectedEntries, rejected...)
+		added := submitter.CountAddedRecords(failed.payload, results)
+		totalAdded += added
+		fmt.Printf("Submitted day %s on retry. Added: %d\n", failed.dayLabel, added)
+		warnOnUnconfirmedPersist(options, failed.dayLabel, failed.added, results)
+		if len(rejected) == 0 {
+			recordSubmittedDay(options, failedBatches[failed.dayLabel])
+		}
+	}
+
+	fmt.Printf(
+		"Submit completed. Days: %d, Local entries prepared: %d, Added entries: %d, Duplicates skipped: %d, Local duplicates collapsed: %d, Overlaps seen: %d, Days over max hours: %d, Rejected entries: %d, Persist responses: %d\n",
+		len(plan.days),
+		plan.totalLocal,
+		totalAdded,
+		plan.totalDuplicates,
+		plan.localDuplicates,
+		plan.totalOverlaps,
+		plan.daysOverMaxHours,
+		len(rejectedEntries),
+		totalResponses,
+	)
+	failedLabels := make([]string, 0, len(stillFailing))
+	for _, failed := range stillFailing {
+		failedLabels = append(failedLabels, failed.dayLabel)
+	}
+	notifySubmitWebhook(options.webhookURL, submitter.RunSummary{
+		Days:             len(plan.days),
+		LockedDays:       plan.lockedDays,
+		LocalEntries:     plan.totalLocal,
+		EntriesSubmitted: totalAdded,
+		Duplicates:       plan.totalDuplicates,
+		LocalDuplicates:  plan.localDuplicates,
+		Overlaps:         plan.totalOverlaps,
+		RejectedEntries:  len(rejectedEntries),
+		FailedDays:       failedLabels,
+	})
+	if len(stillFailing) > 0 {
+		return fmt.Errorf("submit failed for %d day(s) after retry: %s: %w", len(stillFailing), strings.Join(failedLabels, ", "), stillFailing[0].err)
+	}
+	if len(rejectedEntries) > 0 {
+		return fmt.Errorf("OnePoint rejected %d entries: %s", len(rejectedEntries), strings.Join(rejectedEntries, "; "))
+	}
+	return nil
+}
 
-			totalResponses += len(results)
-			totalAdded += len(toAdd)
-			fmt.Printf("Submitted day %s. Added: %d\n", cd.dayLabel, len(toAdd))
+// filterUnchangedDayBatches splits dayBatches into days whose hash differs
+// from the recorded one and the labels of unchanged days.
+func filterUnchangedDayBatches(dayBatches []submitDayBatch, store submitDayHashStore) ([]submitDayBatch, []string, error) {
+	recorded, err := store.ListSubmittedDayHashes()
+	if err != nil {
+		return nil, nil, err
+	}
+	changed := make([]submitDayBatch, 0, len(dayBatches))
+	unchanged := make([]string, 0)
+	for _, batch := range dayBatches {
+		hash, err := submitter.DayBatchHash(batch)
+		if err != nil {
+			return nil, nil, err
 		}
+		if recorded[submitDayHashKey(batch.Day)] == hash {
+			unchanged = append(unchanged, onepoint.FormatDay(batch.Day))
+			continue
+		}
+		changed = append(changed, batch)
+	}
+	return changed, unchanged, nil
+}
 
-		fmt.Printf(
-			"Submit completed. Days: %d, Local entries prepared: %d, Added entries: %d, Duplicates skipped: %d, Overlaps seen: %d, Persist responses: %d\n",
-			len(dayBatches),
-			totalLocal,
-			totalAdded,
-			totalDuplicates,
-			totalOverlaps,
-			totalResponses,
-		)
-		return nil
-	},
+// recordSubmittedDay stores the hash of a fully submitted day. Failures only
+// print a warning; the day is then simply processed again next time.
+func recordSubmittedDay(options submitExecuteOptions, batch submitDayBatch) {
+	if options.dayHashes == nil {
+		return
+	}
+	hash, err := submitter.DayBatchHash(batch)
+	if err == nil {
+		err = options.dayHashes.SaveSubmittedDayHash(submitDayHashKey(batch.Day), hash)
+	}
+	if err != nil {
+		fmt.Printf("Warning: could not record submitted day %s: %v\n", onepoint.FormatDay(batch.Day), err)
+	}
+}
+
+func submitDayHashKey(day time.Time) string {
+	return day.Format("2006-01-02")
+}
+
+// notifySubmitWebhook posts summary to url with submitWebhookTimeout. Errors
+// are printed as a warning and never fail the submit.
+func notifySubmitWebhook(url string, summary submitter.RunSummary) {
+	if url == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), submitWebhookTimeout)
+	defer cancel()
+	if err := submitter.PostRunSummary(ctx, http.DefaultClient, url, summary); err != nil {
+		fmt.Printf("Warning: submit webhook failed: %v\n", err)
+	}
+}
+
+// reportRejectedEntries prints one warning per persist result OnePoint
+// reported as failed and returns their descriptions ("<day> <range>: <message>").
+func reportRejectedEntries(dayLabel string, payload []onepoint.PersistWorklog, results []onepoint.PersistResult) []string {
+	_, failed := submitter.PartitionPersistResults(payload, results)
+	descriptions := make([]string, 0, len(failed))
+	for _, failure := range failed {
+		timeRange := "unknown time range"
+		if failure.Worklog != nil {
+			timeRange = formatPersistWorklogRange(*failure.Worklog)
+		}
+		message := strings.TrimSpace(failure.Result.Message)
+		if message == "" {
+			message = "no message"
+		}
+		description := fmt.Sprintf("%s %s: %s", dayLabel, timeRange, message)
+		fmt.Printf("Warning: OnePoint rejected entry %s\n", description)
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+func warnOnUnconfirmedPersist(options submitExecuteOptions, dayLabel string, added int, results []onepoint.PersistResult) {
+	if !options.verifyPersist {
+		return
+	}
+	if err := submitter.VerifyPersistResults(added, results); err != nil {
+		fmt.Printf("Warning: day %s: %v\n", dayLabel, err)
+	}
 }
 
 type submitDayBatch = submitter.DayBatch
@@ -317,18 +992,66 @@ func init() {
 	submitCmd.Flags().StringVar(&submitURL, "url", "", "Override OnePoint URL from config (full home URL)")
 	submitCmd.Flags().StringVar(&submitStateFile, "state-file", "", "Path to auth state JSON (default: $HOME/.gohour/onepoint-auth-state.json)")
 	submitCmd.Flags().DurationVar(&submitTimeout, "timeout", 60*time.Second, "Timeout per OnePoint API operation")
-	submitCmd.Flags().StringVar(&submitFromDay, "from", "", "Filter start day (inclusive), format YYYY-MM-DD")
-	submitCmd.Flags().StringVar(&submitToDay, "to", "", "Filter end day (inclusive), format YYYY-MM-DD")
+	submitCmd.Flags().StringVar(&submitFromDay, "from", "", "Filter start day (inclusive), format YYYY-MM-DD (overrides submit.default_range)")
+	submitCmd.Flags().StringVar(&submitToDay, "to", "", "Filter end day (inclusive), format YYYY-MM-DD (overrides submit.default_range)")
+	submitCmd.Flags().BoolVar(&submitCommit, "commit", false, "Persist changes when dry_run_by_default is enabled in config")
 	submitCmd.Flags().BoolVar(&submitDryRun, "dry-run", false, "Validate against remote day worklogs without persisting (warns for locked days/overlaps)")
 	submitCmd.Flags().BoolVar(&submitIncludeArchived, "include-archived-projects", false, "Allow archived projects during name->ID lookup fallback")
 	submitCmd.Flags().BoolVar(&submitIncludeLockedActivities, "include-locked-activities", false, "Allow locked activities during name->ID lookup fallback")
+	submitCmd.Flags().BoolVar(&submitIncludeInactive, "include-inactive-projects", false, "Allow projects outside onepoint.selectable_project_statuses during lookup fallback")
+	submitCmd.Flags().BoolVar(&submitVerifyRules, "verify-rules", false, "Check rule ids against OnePoint lookup data and warn when names resolve to different ids")
+	submitCmd.Flags().StringVar(&submitOverrideProject, "override-project", "", "Submit every selected entry under this project name (local entries are unchanged)")
+	submitCmd.Flags().StringVar(&submitOverrideActivity, "override-activity", "", "Submit every selected entry under this activity name (local entries are unchanged)")
+	submitCmd.Flags().StringVar(&submitOverrideSkill, "override-skill", "", "Submit every selected entry under this skill name (local entries are unchanged)")
+	submitCmd.Flags().StringArrayVar(&submitRemapDates, "remap-date", nil, "Submit selected entries of one day to another, as FROM=TO in YYYY-MM-DD (repeatable, local entries are unchanged)")
+	submitCmd.Flags().BoolVar(&submitInteractivePlan, "interactive-plan", false, "Print the full submit plan and confirm once (overlaps are skipped)")
+	submitCmd.Flags().BoolVar(&submitRetryFailedDays, "retry-failed-days", false, "Continue after a day fails to submit and retry failed days once at the end")
+	submitCmd.Flags().BoolVar(&submitFailOnDuplicates, "fail-on-duplicates", false, "Abort instead of collapsing equivalent local entries of a day")
+	submitCmd.Flags().BoolVar(&submitStrict, "strict", false, "Abort instead of warning when a day exceeds max_daily_hours")
+	submitCmd.Flags().BoolVar(&submitForce, "force", false, "Process every day even if submit.skip_unchanged_days recorded it as unchanged")
+	submitCmd.Flags().StringVar(&submitOrder, "order", "asc", "Day processing order: asc (oldest first) or desc (most recent first)")
+	submitCmd.Flags().IntVar(&submitConcurrency, "concurrency", 3, "Number of days loaded and persisted in parallel")
+	submitCmd.Flags().BoolVar(&submitVerbose, "verbose", false, "Log each OnePoint HTTP request (method, path, status, duration) to stderr")
+}
+
+// newSubmitHTTPClient returns a logging OnePoint HTTP doer writing to out when
+// verbose is set, or nil to keep the client's default transport.
+func newSubmitHTTPClient(verbose bool, out io.Writer) onepoint.HTTPDoer {
+	if !verbose {
+		return nil
+	}
+	return onepoint.NewLoggingDoer(nil, slog.New(slog.NewTextHandler(out, nil)))
+}
+
+// resolveSubmitOrder reports whether --order selects descending day order.
+func resolveSubmitOrder(order string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(order)) {
+	case "", "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid --order %q (supported: asc|desc)", order)
+	}
+}
+
+// sortSubmitDayBatches sorts dayBatches by day, oldest first or, with
+// descending, most recent first.
+func sortSubmitDayBatches(dayBatches []submitDayBatch, descending bool) {
+	sort.SliceStable(dayBatches, func(i, j int) bool {
+		if descending {
+			return dayBatches[i].Day.After(dayBatches[j].Day)
+		}
+		return dayBatches[i].Day.Before(dayBatches[j].Day)
+	})
 }
 
-func parseSubmitRange(fromValue, toValue string) (*time.Time, *time.Time, error) {
+// parseSubmitRange parses the --from/--to days as midnight in loc.
+func parseSubmitRange(fromValue, toValue string, loc *time.Location) (*time.Time, *time.Time, error) {
 	var from *time.Time
 	var to *time.Time
 	if strings.TrimSpace(fromValue) != "" {
-		day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(fromValue), time.Local)
+		day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(fromValue), loc)
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid --from value %q (expected YYYY-MM-DD)", fromValue)
 		}
@@ -336,7 +1059,7 @@ func parseSubmitRange(fromValue, toValue string) (*time.Time, *time.Time, error)
 		from = &normalized
 	}
 	if strings.TrimSpace(toValue) != "" {
-		day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(toValue), time.Local)
+		day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(toValue), loc)
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid --to value %q (expected YYYY-MM-DD)", toValue)
 		}
@@ -349,14 +1072,39 @@ func parseSubmitRange(fromValue, toValue string) (*time.Time, *time.Time, error)
 	return from, to, nil
 }
 
-func filterEntriesByDayRange(entries []worklog.Entry, from, to *time.Time) []worklog.Entry {
+// resolveSubmitRange returns the submit day range. Explicit --from/--to win;
+// without them defaultRange ("all", "current-month", "previous-month")
+// decides, with month bounds computed in loc relative to now.
+func resolveSubmitRange(fromValue, toValue, defaultRange string, now time.Time, loc *time.Location) (*time.Time, *time.Time, error) {
+	if strings.TrimSpace(fromValue) != "" || strings.TrimSpace(toValue) != "" {
+		return parseSubmitRange(fromValue, toValue, loc)
+	}
+
+	now = now.In(loc)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	switch defaultRange {
+	case "", "all":
+		return nil, nil, nil
+	case "current-month":
+	case "previous-month":
+		monthStart = monthStart.AddDate(0, -1, 0)
+	default:
+		return nil, nil, fmt.Errorf("unsupported submit.default_range %q", defaultRange)
+	}
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	return &monthStart, &monthEnd, nil
+}
+
+// filterEntriesByDayRange keeps entries whose start falls on a day within
+// [from, to], with days taken in loc.
+func filterEntriesByDayRange(entries []worklog.Entry, from, to *time.Time, loc *time.Location) []worklog.Entry {
 	if from == nil && to == nil {
 		return append([]worklog.Entry(nil), entries...)
 	}
 
 	out := make([]worklog.Entry, 0, len(entries))
 	for _, entry := range entries {
-		day := timeutil.StartOfDay(entry.StartDateTime)
+		day := timeutil.StartOfDayIn(entry.StartDateTime, loc)
 		if from != nil && day.Before(*from) {
 			continue
 		}
@@ -368,6 +1116,18 @@ func filterEntriesByDayRange(entries []worklog.Entry, from, to *time.Time) []wor
 	return out
 }
 
+// entriesInLocation returns copies of entries with their times converted to
+// loc, so day and week grouping follows the configured timezone.
+func entriesInLocation(entries []worklog.Entry, loc *time.Location) []worklog.Entry {
+	out := make([]worklog.Entry, len(entries))
+	for i, entry := range entries {
+		entry.StartDateTime = entry.StartDateTime.In(loc)
+		entry.EndDateTime = entry.EndDateTime.In(loc)
+		out[i] = entry
+	}
+	return out
+}
+
 func resolveIDsForEntries(
 	ctx context.Context,
 	client onepoint.Client,
@@ -378,10 +1138,163 @@ func resolveIDsForEntries(
 	return submitter.ResolveIDsForEntries(ctx, client, rules, entries, options)
 }
 
+// submitNameOverride replaces project, activity and/or skill of every
+// submitted entry; empty fields keep the stored value.
+type submitNameOverride struct {
+	Project  string
+	Activity string
+	Skill    string
+}
+
+func (o submitNameOverride) active() bool {
+	return strings.TrimSpace(o.Project) != "" || strings.TrimSpace(o.Activity) != "" || strings.TrimSpace(o.Skill) != ""
+}
+
+// applySubmitNameOverride returns copies of entries with the override applied.
+// Overridden entries drop their stored ids so the new names are resolved.
+func applySubmitNameOverride(entries []worklog.Entry, override submitNameOverride) []worklog.Entry {
+	if !override.active() {
+		return entries
+	}
+	out := make([]worklog.Entry, len(entries))
+	for i, entry := range entries {
+		if value := strings.TrimSpace(override.Project); value != "" {
+			entry.Project = value
+		}
+		if value := strings.TrimSpace(override.Activity); value != "" {
+			entry.Activity = value
+		}
+		if value := strings.TrimSpace(override.Skill); value != "" {
+			entry.Skill = value
+		}
+		entry.ProjectID, entry.ActivityID, entry.SkillID = 0, 0, 0
+		out[i] = entry
+	}
+	return out
+}
+
+// submitDateRemap moves the selected entries of day From to day To.
+type submitDateRemap struct {
+	From time.Time
+	To   time.Time
+}
+
+func (r submitDateRemap) String() string {
+	return r.From.Format("2006-01-02") + "=" + r.To.Format("2006-01-02")
+}
+
+// parseSubmitDateRemaps parses --remap-date values of the form FROM=TO with
+// days taken in loc. Each source day may only be remapped once.
+func parseSubmitDateRemaps(values []string, loc *time.Location) ([]submitDateRemap, error) {
+	remaps := make([]submitDateRemap, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		fromValue, toValue, ok := strings.Cut(strings.TrimSpace(value), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --remap-date value %q (expected FROM=TO as YYYY-MM-DD)", value)
+		}
+		from, fromErr := time.ParseInLocation("2006-01-02", strings.TrimSpace(fromValue), loc)
+		to, toErr := time.ParseInLocation("2006-01-02", strings.TrimSpace(toValue), loc)
+		if fromErr != nil || toErr != nil {
+			return nil, fmt.Errorf("invalid --remap-date value %q (expected FROM=TO as YYYY-MM-DD)", value)
+		}
+		key := from.Format("2006-01-02")
+		if seen[key] {
+			return nil, fmt.Errorf("--remap-date lists day %s more than once", key)
+		}
+		seen[key] = true
+		remaps = append(remaps, submitDateRemap{From: from, To: to})
+	}
+	return remaps, nil
+}
+
+// applySubmitDateRemaps returns copies of entries with every entry starting on
+// a remapped day moved to its target day. Clock times and durations are kept.
+// It also returns the remaps that matched no entry.
+func applySubmitDateRemaps(entries []worklog.Entry, remaps []submitDateRemap, loc *time.Location) ([]worklog.Entry, []string) {
+	if len(remaps) == 0 {
+		return entries, nil
+	}
+	targets := make(map[string]time.Time, len(remaps))
+	for _, remap := range remaps {
+		targets[onepoint.FormatDay(remap.From)] = remap.To
+	}
+	matched := make(map[string]bool, len(remaps))
+	out := make([]worklog.Entry, len(entries))
+	for i, entry := range entries {
+		start := entry.StartDateTime.In(loc)
+		key := onepoint.FormatDay(start)
+		target, ok := targets[key]
+		if ok {
+			matched[key] = true
+			duration := entry.EndDateTime.Sub(entry.StartDateTime)
+			entry.StartDateTime = time.Date(target.Year(), target.Month(), target.Day(), start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), loc)
+			entry.EndDateTime = entry.StartDateTime.Add(duration)
+		}
+		out[i] = entry
+	}
+	unmatched := make([]string, 0)
+	for _, remap := range remaps {
+		if !matched[onepoint.FormatDay(remap.From)] {
+			unmatched = append(unmatched, remap.String())
+		}
+	}
+	return out, unmatched
+}
+
+// storeResolvedIDs caches the resolved OnePoint ids on the local entries, so
+// the next submit skips resolving their names again.
+func storeResolvedIDs(store *storage.SQLiteStore, entries []worklog.Entry, idsByTuple map[submitNameTuple]submitResolvedIDs) error {
+	for _, entry := range submitter.EntriesWithChangedIDs(entries, idsByTuple) {
+		if err := store.SetResolvedIDs(entry.ID, entry.ProjectID, entry.ActivityID, entry.SkillID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func buildSubmitDayBatches(entries []worklog.Entry, idsByTuple map[submitNameTuple]submitResolvedIDs) ([]submitDayBatch, error) {
 	return submitter.BuildDayBatches(entries, idsByTuple)
 }
 
+// collapseLocalDuplicates removes equivalent local entries from every batch
+// and returns how many were removed. With failOnDuplicates the batches are
+// left untouched and an error lists the duplicated time ranges per day.
+func collapseLocalDuplicates(dayBatches []submitDayBatch, failOnDuplicates bool) (int, error) {
+	if failOnDuplicates {
+		offending := make([]string, 0)
+		for _, batch := range dayBatches {
+			probe := submitDayBatch{Day: batch.Day, Worklogs: append([]onepoint.PersistWorklog(nil), batch.Worklogs...)}
+			for _, item := range submitter.CollapseLocalDuplicates(&probe) {
+				offending = append(offending, onepoint.FormatDay(batch.Day)+" "+formatPersistWorklogRange(item))
+			}
+		}
+		if len(offending) > 0 {
+			return 0, fmt.Errorf("found %d duplicate local entries: %s", len(offending), strings.Join(offending, ", "))
+		}
+		return 0, nil
+	}
+
+	collapsed := 0
+	for i := range dayBatches {
+		collapsed += len(submitter.CollapseLocalDuplicates(&dayBatches[i]))
+	}
+	return collapsed, nil
+}
+
+// checkSubmitDailyHours prints a warning per day above maxHours and returns
+// how many days exceed it. With strict, such days are an error instead.
+func checkSubmitDailyHours(out io.Writer, dayBatches []submitDayBatch, maxHours float64, strict bool) (int, error) {
+	warnings := submitter.DailyHoursWarnings(dayBatches, maxHours)
+	if strict && len(warnings) > 0 {
+		return 0, fmt.Errorf("%d day(s) exceed max_daily_hours (--strict): %s", len(warnings), strings.Join(warnings, "; "))
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "Warning: %s\n", warning)
+	}
+	return len(warnings), nil
+}
+
 func countTotalToAdd(classified []classifiedDay) int {
 	total := 0
 	for _, cd := range classified {
@@ -4,11 +4,14 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -496,3 +499,1064 @@ func submitIntPtr(value int) *int {
 	out := value
 	return &out
 }
+
+type submitRecordingClient struct {
+	onepoint.Client
+
+	mu       sync.Mutex
+	calls    []string
+	existing map[string][]onepoint.DayWorklog
+	// persistFailures is the number of failing persist calls per day label.
+	persistFailures map[string]int
+	// persistResults overrides the default of one confirmed result per
+	// persisted worklog when set.
+	persistResults []onepoint.PersistResult
+	// persisted records the payload of every persist call per day label.
+	persisted map[string][]onepoint.PersistWorklog
+	// conflicts fails the next persist of a day label with
+	// onepoint.ErrConflict after replacing its remote entries, as if the day
+	// was edited concurrently.
+	conflicts map[string][]onepoint.DayWorklog
+}
+
+func (c *submitRecordingClient) GetDayWorklogs(ctx context.Context, day time.Time) ([]onepoint.DayWorklog, error) {
+	label := onepoint.FormatDay(day)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, "get "+label)
+	return c.existing[label], nil
+}
+
+func (c *submitRecordingClient) PersistWorklogs(ctx context.Context, day time.Time, worklogs []onepoint.PersistWorklog) ([]onepoint.PersistResult, error) {
+	label := onepoint.FormatDay(day)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, "persist "+label)
+	if c.persisted == nil {
+		c.persisted = make(map[string][]onepoint.PersistWorklog)
+	}
+	c.persisted[label] = append([]onepoint.PersistWorklog(nil), worklogs...)
+	if concurrent, ok := c.conflicts[label]; ok {
+		delete(c.conflicts, label)
+		if c.existing == nil {
+			c.existing = make(map[string][]onepoint.DayWorklog)
+		}
+		c.existing[label] = concurrent
+		return nil, fmt.Errorf("%w: status 409", onepoint.ErrConflict)
+	}
+	if c.persistFailures[label] > 0 {
+		c.persistFailures[label]--
+		return nil, fmt.Errorf("upstream unavailable")
+	}
+	if c.persistResults != nil {
+		return c.persistResults, nil
+	}
+	results := make([]onepoint.PersistResult, 0, len(worklogs))
+	for i, worklog := range worklogs {
+		results = append(results, onepoint.PersistResult{NewTimeRecordID: int64(1000 + i), OldTimeRecordID: worklog.TimeRecordID})
+	}
+	return results, nil
+}
+
+func newSubmitTestSession(client onepoint.Client) submitSession {
+	return submitSession{
+		timeout: time.Second,
+		call: func(op func(client onepoint.Client) error) error {
+			return op(client)
+		},
+	}
+}
+
+func submitPlanTestBatches(t *testing.T) []submitDayBatch {
+	t.Helper()
+
+	entries := []worklog.Entry{
+		{
+			StartDateTime: time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 5, 10, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "Day one",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		},
+		{
+			StartDateTime: time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 6, 10, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "Day two",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		},
+	}
+	ids := map[submitNameTuple]submitResolvedIDs{
+		{Mapper: "epm", Project: "project a", Activity: "delivery", Skill: "go"}: {ProjectID: 100, ActivityID: 200, SkillID: 300},
+	}
+	batches, err := buildSubmitDayBatches(entries, ids)
+	if err != nil {
+		t.Fatalf("build day batches: %v", err)
+	}
+	return batches
+}
+
+func TestExecuteSubmitPlan_InteractivePlanComputesPlanBeforePersist(t *testing.T) {
+	restore := withTemporaryStdin(t, "y\n")
+	defer restore()
+
+	client := &submitRecordingClient{}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	if err := executeSubmitPlan(session, plan, submitExecuteOptions{interactivePlan: true}); err != nil {
+		t.Fatalf("execute submit plan: %v", err)
+	}
+
+	want := []string{"get 05-03-2026", "get 06-03-2026", "persist 05-03-2026", "persist 06-03-2026"}
+	if strings.Join(client.calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected call order: %v", client.calls)
+	}
+}
+
+func TestExecuteSubmitPlan_InteractivePlanDeclineAborts(t *testing.T) {
+	restore := withTemporaryStdin(t, "n\n")
+	defer restore()
+
+	client := &submitRecordingClient{}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	if err := executeSubmitPlan(session, plan, submitExecuteOptions{interactivePlan: true}); err != nil {
+		t.Fatalf("execute submit plan: %v", err)
+	}
+
+	for _, call := range client.calls {
+		if strings.HasPrefix(call, "persist") {
+			t.Fatalf("expected no persist calls after declining, got %v", client.calls)
+		}
+	}
+}
+
+func TestExecuteSubmitPlan_InteractivePlanSkipsOverlapsWithoutPrompt(t *testing.T) {
+	restore := withTemporaryStdin(t, "y\n")
+	defer restore()
+
+	client := &submitRecordingClient{
+		existing: map[string][]onepoint.DayWorklog{
+			"05-03-2026": {
+				{TimeRecordID: 7, StartTime: 570, FinishTime: 630, ProjectID: 1, ActivityID: 2, SkillID: 3, WorklogDate: "05-03-2026"},
+			},
+		},
+	}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	if plan.totalOverlaps != 1 {
+		t.Fatalf("expected 1 overlap in plan, got %d", plan.totalOverlaps)
+	}
+	if err := executeSubmitPlan(session, plan, submitExecuteOptions{interactivePlan: true}); err != nil {
+		t.Fatalf("execute submit plan: %v", err)
+	}
+
+	want := []string{"get 05-03-2026", "get 06-03-2026", "persist 06-03-2026"}
+	if strings.Join(client.calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected calls: %v", client.calls)
+	}
+}
+
+func TestExecuteSubmitPlan_RetryFailedDaysRecoversOnRetry(t *testing.T) {
+	client := &submitRecordingClient{persistFailures: map[string]int{"05-03-2026": 1}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	if err := executeSubmitPlan(session, plan, submitExecuteOptions{retryFailedDays: true}); err != nil {
+		t.Fatalf("execute submit plan: %v", err)
+	}
+
+	want := []string{"get 05-03-2026", "get 06-03-2026", "persist 05-03-2026", "persist 06-03-2026", "persist 05-03-2026"}
+	if strings.Join(client.calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected calls: %v", client.calls)
+	}
+}
+
+func TestExecuteSubmitPlan_RetryFailedDaysReportsStillFailing(t *testing.T) {
+	client := &submitRecordingClient{persistFailures: map[string]int{"05-03-2026": 2}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	err = executeSubmitPlan(session, plan, submitExecuteOptions{retryFailedDays: true})
+	if err == nil || !strings.Contains(err.Error(), "05-03-2026") {
+		t.Fatalf("expected still-failing day in error, got %v", err)
+	}
+	if !strings.Contains(strings.Join(client.calls, ","), "persist 06-03-2026") {
+		t.Fatalf("expected remaining days to be submitted, got %v", client.calls)
+	}
+}
+
+func TestExecuteSubmitPlan_WithoutRetryAbortsOnFailure(t *testing.T) {
+	client := &submitRecordingClient{persistFailures: map[string]int{"05-03-2026": 1}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	if err := executeSubmitPlan(session, plan, submitExecuteOptions{}); err == nil {
+		t.Fatalf("expected submit to fail")
+	}
+	if strings.Contains(strings.Join(client.calls, ","), "persist 06-03-2026") {
+		t.Fatalf("expected run to abort after first failure, got %v", client.calls)
+	}
+}
+
+func TestExecuteSubmitPlan_RetriesConflictWithRefetchedDay(t *testing.T) {
+	concurrent := onepoint.DayWorklog{TimeRecordID: 77, StartTime: 13 * 60, FinishTime: 14 * 60, Duration: 60, ProjectID: 100, ActivityID: 200, SkillID: 300, Billable: 60, Comment: "Other tab", WorklogDate: "05-03-2026"}
+	client := &submitRecordingClient{conflicts: map[string][]onepoint.DayWorklog{"05-03-2026": {concurrent}}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	out := captureStdout(t, func() {
+		if err := executeSubmitPlan(session, plan, submitExecuteOptions{retryOnConflict: true}); err != nil {
+			t.Fatalf("execute submit plan: %v", err)
+		}
+	})
+
+	want := []string{"get 05-03-2026", "get 06-03-2026", "persist 05-03-2026", "get 05-03-2026", "persist 05-03-2026", "persist 06-03-2026"}
+	if strings.Join(client.calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected calls: %v", client.calls)
+	}
+	payload := client.persisted["05-03-2026"]
+	if len(payload) != 2 || payload[0].TimeRecordID != 77 || payload[1].Comment != "Day one" {
+		t.Fatalf("expected concurrent entry kept next to the local one, got %+v", payload)
+	}
+	if !strings.Contains(out, "Warning: day 05-03-2026 was modified concurrently") || !strings.Contains(out, "Added entries: 2,") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
+
+func TestExecuteSubmitPlan_ConflictSkipsEntriesOverlappingConcurrentEdits(t *testing.T) {
+	concurrent := onepoint.DayWorklog{TimeRecordID: 77, StartTime: 9*60 + 30, FinishTime: 10*60 + 30, Duration: 60, ProjectID: 100, ActivityID: 200, SkillID: 300, Billable: 60, Comment: "Other tab", WorklogDate: "05-03-2026"}
+	client := &submitRecordingClient{conflicts: map[string][]onepoint.DayWorklog{"05-03-2026": {concurrent}}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	out := captureStdout(t, func() {
+		if err := executeSubmitPlan(session, plan, submitExecuteOptions{retryOnConflict: true}); err != nil {
+			t.Fatalf("execute submit plan: %v", err)
+		}
+	})
+
+	if got := strings.Count(strings.Join(client.calls, ","), "persist 05-03-2026"); got != 1 {
+		t.Fatalf("expected no second persist for the skipped entry, got %v", client.calls)
+	}
+	if !strings.Contains(out, "skipping entry 09:00-10:00, it overlaps entry 09:30-10:30 added concurrently") {
+		t.Fatalf("expected skipped entry warning, got:\n%s", out)
+	}
+}
+
+func TestExecuteSubmitPlan_ConflictFailsWithoutRetryOnConflict(t *testing.T) {
+	client := &submitRecordingClient{conflicts: map[string][]onepoint.DayWorklog{"05-03-2026": nil}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	err = executeSubmitPlan(session, plan, submitExecuteOptions{})
+	if !errors.Is(err, onepoint.ErrConflict) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestExecuteSubmitPlan_WarnsOnUnconfirmedPersist(t *testing.T) {
+	client := &submitRecordingClient{persistResults: []onepoint.PersistResult{}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	out := captureStdout(t, func() {
+		if err := executeSubmitPlan(session, plan, submitExecuteOptions{verifyPersist: true}); err != nil {
+			t.Fatalf("execute submit plan: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Warning: day 05-03-2026: OnePoint confirmed 0 time record(s) for 1 added entries") {
+		t.Fatalf("expected persist warning, got:\n%s", out)
+	}
+}
+
+func TestExecuteSubmitPlan_ConcurrentDaysKeepTotalsAndOrder(t *testing.T) {
+	existing := make(map[string][]onepoint.DayWorklog)
+	entries := make([]worklog.Entry, 0, 10)
+	for day := 1; day <= 10; day++ {
+		start := time.Date(2026, 3, day, 9, 0, 0, 0, time.Local)
+		entries = append(entries, worklog.Entry{
+			StartDateTime: start,
+			EndDateTime:   start.Add(time.Hour),
+			Billable:      60,
+			Description:   "Task",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		})
+		if day%2 == 0 {
+			// Every second day already holds the same entry remotely.
+			existing[onepoint.FormatDay(start)] = []onepoint.DayWorklog{
+				{TimeRecordID: int64(day), StartTime: 540, FinishTime: 600, Duration: 60, ProjectID: 100, ActivityID: 200, SkillID: 300, Billable: 60, Comment: "Task", WorklogDate: onepoint.FormatDay(start)},
+			}
+		}
+		entries = append(entries, worklog.Entry{
+			StartDateTime: start.Add(2 * time.Hour),
+			EndDateTime:   start.Add(3 * time.Hour),
+			Billable:      60,
+			Description:   "Review",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		})
+	}
+	ids := map[submitNameTuple]submitResolvedIDs{
+		{Mapper: "epm", Project: "project a", Activity: "delivery", Skill: "go"}: {ProjectID: 100, ActivityID: 200, SkillID: 300},
+	}
+	batches, err := buildSubmitDayBatches(entries, ids)
+	if err != nil {
+		t.Fatalf("build day batches: %v", err)
+	}
+
+	client := &submitRecordingClient{existing: existing}
+	session := newSubmitTestSession(client)
+	session.concurrency = 4
+
+	plan, err := buildSubmitPlan(session, batches)
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	if plan.totalLocal != 20 || plan.totalDuplicates != 5 || len(plan.days) != 10 {
+		t.Fatalf("unexpected plan totals: local=%d duplicates=%d days=%d", plan.totalLocal, plan.totalDuplicates, len(plan.days))
+	}
+	for i, cd := range plan.days {
+		if want := onepoint.FormatDay(time.Date(2026, 3, i+1, 0, 0, 0, 0, time.Local)); cd.dayLabel != want {
+			t.Fatalf("expected day %d to be %s, got %s", i, want, cd.dayLabel)
+		}
+	}
+
+	restore := withTemporaryStdin(t, "y\n")
+	defer restore()
+	out := captureStdout(t, func() {
+		if err := executeSubmitPlan(session, plan, submitExecuteOptions{}); err != nil {
+			t.Fatalf("execute submit plan: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Added entries: 15,") || !strings.Contains(out, "Persist responses: 20") {
+		t.Fatalf("unexpected totals in summary:\n%s", out)
+	}
+	persists := 0
+	for _, call := range client.calls {
+		if strings.HasPrefix(call, "persist ") {
+			persists++
+		}
+	}
+	if persists != 10 {
+		t.Fatalf("expected 10 persist calls, got %v", client.calls)
+	}
+	last := -1
+	for day := 1; day <= 10; day++ {
+		line := "Submitted day " + onepoint.FormatDay(time.Date(2026, 3, day, 0, 0, 0, 0, time.Local))
+		index := strings.Index(out, line)
+		if index <= last {
+			t.Fatalf("expected %q after the previous day, got:\n%s", line, out)
+		}
+		last = index
+	}
+}
+
+func TestNewSubmitHTTPClient_OnlyLogsWhenVerbose(t *testing.T) {
+	if client := newSubmitHTTPClient(false, io.Discard); client != nil {
+		t.Fatalf("expected default transport without --verbose, got %T", client)
+	}
+	if _, ok := newSubmitHTTPClient(true, io.Discard).(*onepoint.LoggingDoer); !ok {
+		t.Fatalf("expected logging doer with --verbose")
+	}
+}
+
+func TestCollapseLocalDuplicates_CollapsesOrFails(t *testing.T) {
+	newBatches := func() []submitDayBatch {
+		item := onepoint.PersistWorklog{
+			WorklogDate: "05.03.2026",
+			StartTime:   submitIntPtr(9 * 60),
+			FinishTime:  submitIntPtr(10 * 60),
+			ProjectID:   onepoint.ID(1),
+			ActivityID:  onepoint.ID(2),
+			SkillID:     onepoint.ID(3),
+		}
+		return []submitDayBatch{{
+			Day:      time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local),
+			Worklogs: []onepoint.PersistWorklog{item, item},
+		}}
+	}
+
+	batches := newBatches()
+	collapsed, err := collapseLocalDuplicates(batches, false)
+	if err != nil {
+		t.Fatalf("collapse: %v", err)
+	}
+	if collapsed != 1 || len(batches[0].Worklogs) != 1 {
+		t.Fatalf("expected one collapsed entry, got collapsed=%d remaining=%d", collapsed, len(batches[0].Worklogs))
+	}
+
+	batches = newBatches()
+	_, err = collapseLocalDuplicates(batches, true)
+	if err == nil {
+		t.Fatal("expected error with failOnDuplicates")
+	}
+	if !strings.Contains(err.Error(), "09:00-10:00") {
+		t.Fatalf("expected offending time range in error, got %v", err)
+	}
+	if len(batches[0].Worklogs) != 2 {
+		t.Fatalf("expected batches to stay untouched on failure, got %d worklogs", len(batches[0].Worklogs))
+	}
+}
+
+func TestCheckSubmitDailyHours_WarnsOrFailsWithStrict(t *testing.T) {
+	batches := []submitDayBatch{{
+		Day: time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local),
+		Worklogs: []onepoint.PersistWorklog{
+			{Duration: 14 * 60},
+			{Duration: 12 * 60},
+		},
+	}}
+
+	var out strings.Builder
+	count, err := checkSubmitDailyHours(&out, batches, 16, false)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if count != 1 || !strings.Contains(out.String(), "Warning: day 05-03-2026 has 26.00 worked hours") {
+		t.Fatalf("expected one warning, got count=%d output=%q", count, out.String())
+	}
+
+	if _, err := checkSubmitDailyHours(io.Discard, batches, 16, true); err == nil || !strings.Contains(err.Error(), "--strict") {
+		t.Fatalf("expected strict error, got %v", err)
+	}
+	if count, err := checkSubmitDailyHours(io.Discard, batches, 0, true); err != nil || count != 0 {
+		t.Fatalf("expected disabled check to pass, got count=%d err=%v", count, err)
+	}
+}
+
+func TestExplainSubmitSessionExpired_AddsRerunHint(t *testing.T) {
+	expired := fmt.Errorf("submit day 05-03-2026 failed: %w", onepoint.ErrSessionExpired)
+	err := explainSubmitSessionExpired(expired)
+	if !errors.Is(err, onepoint.ErrSessionExpired) {
+		t.Fatalf("expected session expired error to be kept, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "gohour auth login") || !strings.Contains(err.Error(), "re-run submit") {
+		t.Fatalf("expected login and re-run hint, got %v", err)
+	}
+
+	other := errors.New("boom")
+	if got := explainSubmitSessionExpired(other); got != other {
+		t.Fatalf("expected other errors unchanged, got %v", got)
+	}
+	if explainSubmitSessionExpired(nil) != nil {
+		t.Fatal("expected nil to stay nil")
+	}
+}
+
+func TestResolveSubmitRange_DefaultRangeAndFlagPrecedence(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.Local)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.Local)
+	}
+
+	from, to, err := resolveSubmitRange("", "", "current-month", now, time.Local)
+	if err != nil {
+		t.Fatalf("current-month: %v", err)
+	}
+	if from == nil || to == nil || !from.Equal(day(3, 1)) || !to.Equal(day(3, 31)) {
+		t.Fatalf("unexpected current-month range: %v - %v", from, to)
+	}
+
+	from, to, err = resolveSubmitRange("", "", "previous-month", now, time.Local)
+	if err != nil {
+		t.Fatalf("previous-month: %v", err)
+	}
+	if from == nil || to == nil || !from.Equal(day(2, 1)) || !to.Equal(day(2, 28)) {
+		t.Fatalf("unexpected previous-month range: %v - %v", from, to)
+	}
+
+	from, to, err = resolveSubmitRange("", "", "all", now, time.Local)
+	if err != nil || from != nil || to != nil {
+		t.Fatalf("expected open range for all, got %v - %v (%v)", from, to, err)
+	}
+
+	from, to, err = resolveSubmitRange("2026-01-10", "", "current-month", now, time.Local)
+	if err != nil {
+		t.Fatalf("explicit from: %v", err)
+	}
+	if from == nil || !from.Equal(day(1, 10)) || to != nil {
+		t.Fatalf("expected explicit --from to win, got %v - %v", from, to)
+	}
+}
+
+func TestResolveIDsForEntriesVerifyingRules_WarnsOnDrift(t *testing.T) {
+	t.Parallel()
+
+	doer := submitFakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		switch fmt.Sprintf("%s %s", r.Method, r.URL.Path) {
+		case "POST /OPServices/resources/OpProjects/getAllUserProjects":
+			return submitJSONResponse([]onepoint.Project{
+				{ID: 21, Name: "Project A", Archived: "0"},
+				{ID: 22, Name: "Project B", Archived: "0"},
+			}), nil
+		case "POST /OPServices/resources/OpProjects/getAllUserActivities":
+			return submitJSONResponse([]onepoint.Activity{
+				{ID: 31, Name: "Delivery", ProjectNodeID: 21},
+				{ID: 32, Name: "Delivery", ProjectNodeID: 22},
+			}), nil
+		case "POST /OPServices/resources/OpProjects/getAllUserSkills":
+			return submitJSONResponse([]onepoint.Skill{
+				{SkillID: 41, Name: "Go", ActivityID: 31},
+				{SkillID: 42, Name: "Go", ActivityID: 32},
+			}), nil
+		default:
+			return nil, fmt.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}}
+
+	client, err := onepoint.NewClient(onepoint.ClientConfig{
+		BaseURL:        "https://onepoint.virtual7.io",
+		RefererURL:     "https://onepoint.virtual7.io/onepoint/faces/home",
+		SessionCookies: "JSESSIONID=test",
+		HTTPClient:     doer,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	entries := []worklog.Entry{
+		{Project: "Project A", Activity: "Delivery", Skill: "Go", SourceMapper: "epm"},
+		{Project: "Project B", Activity: "Delivery", Skill: "Go", SourceMapper: "epm"},
+	}
+	rules := []config.Rule{
+		// Stale ids: Project A was merged and now resolves to 21/31/41.
+		{Mapper: "epm", Project: "Project A", Activity: "Delivery", Skill: "Go", ProjectID: 11, ActivityID: 12, SkillID: 13},
+		{Mapper: "epm", Project: "Project B", Activity: "Delivery", Skill: "Go", ProjectID: 22, ActivityID: 32, SkillID: 42},
+	}
+
+	resolved, warnings, err := submitter.ResolveIDsForEntriesVerifyingRules(context.Background(), client, rules, entries, onepoint.ResolveOptions{})
+	if err != nil {
+		t.Fatalf("resolve ids: %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected exactly one drift warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], `project="project a"`) || !strings.Contains(warnings[0], "11/12/13") || !strings.Contains(warnings[0], "21/31/41") {
+		t.Fatalf("unexpected drift warning: %s", warnings[0])
+	}
+
+	staleTuple := submitNameTuple{Mapper: "epm", Project: "project a", Activity: "delivery", Skill: "go"}
+	if got := resolved[staleTuple]; got.ProjectID != 11 || got.ActivityID != 12 || got.SkillID != 13 {
+		t.Fatalf("expected rule ids to be kept, got %+v", got)
+	}
+}
+
+func TestExecuteSubmitPlan_ReportsRejectedEntriesAndFails(t *testing.T) {
+	client := &submitRecordingClient{persistResults: []onepoint.PersistResult{
+		{MessageType: "ERROR", Message: "Skill does not belong to activity"},
+	}}
+	session := newSubmitTestSession(client)
+
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	var execErr error
+	out := captureStdout(t, func() {
+		execErr = executeSubmitPlan(session, plan, submitExecuteOptions{})
+	})
+
+	if execErr == nil {
+		t.Fatal("expected error when OnePoint rejects entries")
+	}
+	if !strings.Contains(execErr.Error(), "OnePoint rejected 2 entries") ||
+		!strings.Contains(execErr.Error(), "05-03-2026 09:00-10:00: Skill does not belong to activity") {
+		t.Fatalf("unexpected error: %v", execErr)
+	}
+	if !strings.Contains(out, "Warning: OnePoint rejected entry 06-03-2026 09:00-10:00: Skill does not belong to activity") {
+		t.Fatalf("expected per-entry warning, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Rejected entries: 2") || !strings.Contains(out, "Added entries: 0") {
+		t.Fatalf("expected rejected entries to be excluded from added entries, got:\n%s", out)
+	}
+}
+
+func TestExecuteSubmitPlan_PostsSummaryToWebhook(t *testing.T) {
+	received := make(chan submitter.RunSummary, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected webhook request: %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		var summary submitter.RunSummary
+		if err := json.NewDecoder(r.Body).Decode(&summary); err != nil {
+			t.Errorf("decode webhook payload: %v", err)
+		}
+		received <- summary
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+
+	client := &submitRecordingClient{}
+	session := newSubmitTestSession(client)
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	captureStdout(t, func() {
+		if err := executeSubmitPlan(session, plan, submitExecuteOptions{webhookURL: webhook.URL}); err != nil {
+			t.Fatalf("execute submit plan: %v", err)
+		}
+	})
+
+	select {
+	case summary := <-received:
+		if summary.Days != 2 || summary.LocalEntries != 2 || summary.EntriesSubmitted != 2 {
+			t.Fatalf("unexpected webhook summary: %+v", summary)
+		}
+		if summary.Duplicates != 0 || summary.Overlaps != 0 || len(summary.LockedDays) != 0 || len(summary.FailedDays) != 0 {
+			t.Fatalf("unexpected webhook counters: %+v", summary)
+		}
+	default:
+		t.Fatal("expected webhook to receive the summary")
+	}
+}
+
+func TestExecuteSubmitPlan_WebhookFailureDoesNotFailSubmit(t *testing.T) {
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer webhook.Close()
+
+	client := &submitRecordingClient{}
+	session := newSubmitTestSession(client)
+	plan, err := buildSubmitPlan(session, submitPlanTestBatches(t))
+	if err != nil {
+		t.Fatalf("build submit plan: %v", err)
+	}
+	out := captureStdout(t, func() {
+		if err := executeSubmitPlan(session, plan, submitExecuteOptions{webhookURL: webhook.URL}); err != nil {
+			t.Fatalf("webhook failure must not fail submit: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Warning: submit webhook failed: webhook returned status 500") {
+		t.Fatalf("expected webhook warning, got:\n%s", out)
+	}
+}
+
+func TestResolveDryRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		dryRun, commit  bool
+		dryRunByDefault bool
+		want            bool
+		wantErr         bool
+	}{
+		{name: "default persists"},
+		{name: "dry-run flag", dryRun: true, want: true},
+		{name: "safety without commit", dryRunByDefault: true, want: true},
+		{name: "safety with commit", dryRunByDefault: true, commit: true},
+		{name: "safety with dry-run", dryRunByDefault: true, dryRun: true, want: true},
+		{name: "dry-run and commit", dryRun: true, commit: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := resolveDryRun(tt.dryRun, tt.commit, tt.dryRunByDefault)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%s: expected dry-run=%t, got %t", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRunSubmit_DryRunByDefaultRequiresCommit(t *testing.T) {
+	for _, commit := range []bool{false, true} {
+		dryRun, err := resolveDryRun(false, commit, true)
+		if err != nil {
+			t.Fatalf("resolve dry-run: %v", err)
+		}
+
+		client := &submitRecordingClient{}
+		session := newSubmitTestSession(client)
+		if err := runSubmit(session, submitPlanTestBatches(t), 0, dryRun, submitExecuteOptions{}); err != nil {
+			t.Fatalf("run submit (commit=%t): %v", commit, err)
+		}
+
+		persisted := 0
+		for _, call := range client.calls {
+			if strings.HasPrefix(call, "persist") {
+				persisted++
+			}
+		}
+		if !commit && persisted != 0 {
+			t.Fatalf("expected no persist calls without --commit, got %v", client.calls)
+		}
+		if commit && persisted != 2 {
+			t.Fatalf("expected persist for both days with --commit, got %v", client.calls)
+		}
+	}
+}
+
+func TestRunSubmit_OrderControlsDayProcessingOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		order      string
+		wantCalls  []string
+		wantErrMsg string
+	}{
+		{
+			name:      "ascending by default",
+			order:     "",
+			wantCalls: []string{"get 05-03-2026", "get 06-03-2026", "persist 05-03-2026", "persist 06-03-2026"},
+		},
+		{
+			name:      "descending",
+			order:     "desc",
+			wantCalls: []string{"get 06-03-2026", "get 05-03-2026", "persist 06-03-2026", "persist 05-03-2026"},
+		},
+		{
+			name:       "invalid",
+			order:      "newest",
+			wantErrMsg: "invalid --order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			descending, err := resolveSubmitOrder(tt.order)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve order: %v", err)
+			}
+
+			client := &submitRecordingClient{}
+			if err := runSubmit(newSubmitTestSession(client), submitPlanTestBatches(t), 0, false, submitExecuteOptions{descending: descending}); err != nil {
+				t.Fatalf("run submit: %v", err)
+			}
+			if strings.Join(client.calls, ",") != strings.Join(tt.wantCalls, ",") {
+				t.Fatalf("unexpected call order: %v", client.calls)
+			}
+		})
+	}
+}
+
+type memoryDayHashStore struct {
+	hashes map[string]string
+}
+
+func (s *memoryDayHashStore) ListSubmittedDayHashes() (map[string]string, error) {
+	out := make(map[string]string, len(s.hashes))
+	for day, hash := range s.hashes {
+		out[day] = hash
+	}
+	return out, nil
+}
+
+func (s *memoryDayHashStore) SaveSubmittedDayHash(day, hash string) error {
+	s.hashes[day] = hash
+	return nil
+}
+
+func TestRunSubmit_SkipUnchangedDaysProcessesOnlyChangedDays(t *testing.T) {
+	hashes := &memoryDayHashStore{hashes: make(map[string]string)}
+	options := submitExecuteOptions{dayHashes: hashes, skipUnchangedDays: true}
+
+	first := &submitRecordingClient{}
+	if err := runSubmit(newSubmitTestSession(first), submitPlanTestBatches(t), 0, false, options); err != nil {
+		t.Fatalf("first submit: %v", err)
+	}
+	if len(hashes.hashes) != 2 {
+		t.Fatalf("expected both days recorded, got %v", hashes.hashes)
+	}
+
+	batches := submitPlanTestBatches(t)
+	batches[1].Worklogs[0].Comment = "Day two (edited)"
+	second := &submitRecordingClient{}
+	if err := runSubmit(newSubmitTestSession(second), batches, 0, false, options); err != nil {
+		t.Fatalf("second submit: %v", err)
+	}
+	want := []string{"get 06-03-2026", "persist 06-03-2026"}
+	if strings.Join(second.calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected only the changed day to be processed, got %v", second.calls)
+	}
+
+	forced := &submitRecordingClient{}
+	options.skipUnchangedDays = false
+	if err := runSubmit(newSubmitTestSession(forced), submitPlanTestBatches(t), 0, false, options); err != nil {
+		t.Fatalf("forced submit: %v", err)
+	}
+	if len(forced.calls) != 4 {
+		t.Fatalf("expected --force to process both days, got %v", forced.calls)
+	}
+}
+
+func TestRunSubmit_SkipUnchangedDaysIgnoresEntriesOnEarlierDays(t *testing.T) {
+	hashes := &memoryDayHashStore{hashes: make(map[string]string)}
+	options := submitExecuteOptions{dayHashes: hashes, skipUnchangedDays: true}
+
+	if err := runSubmit(newSubmitTestSession(&submitRecordingClient{}), submitPlanTestBatches(t), 0, false, options); err != nil {
+		t.Fatalf("first submit: %v", err)
+	}
+
+	entries := []worklog.Entry{
+		{
+			StartDateTime: time.Date(2026, 3, 5, 11, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 5, 12, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "Day one, added later",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		},
+		{
+			StartDateTime: time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 5, 10, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "Day one",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		},
+		{
+			StartDateTime: time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 6, 10, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "Day two",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		},
+	}
+	ids := map[submitNameTuple]submitResolvedIDs{
+		{Mapper: "epm", Project: "project a", Activity: "delivery", Skill: "go"}: {ProjectID: 100, ActivityID: 200, SkillID: 300},
+	}
+	batches, err := buildSubmitDayBatches(entries, ids)
+	if err != nil {
+		t.Fatalf("build day batches: %v", err)
+	}
+
+	second := &submitRecordingClient{}
+	if err := runSubmit(newSubmitTestSession(second), batches, 0, false, options); err != nil {
+		t.Fatalf("second submit: %v", err)
+	}
+	want := []string{"get 05-03-2026", "persist 05-03-2026"}
+	if strings.Join(second.calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected the later day to stay skipped, got %v", second.calls)
+	}
+}
+
+func TestSubmitNameOverride_PersistsOverriddenIDs(t *testing.T) {
+	t.Parallel()
+
+	doer := submitFakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		switch fmt.Sprintf("%s %s", r.Method, r.URL.Path) {
+		case "POST /OPServices/resources/OpProjects/getAllUserProjects":
+			return submitJSONResponse([]onepoint.Project{{ID: 22, Name: "Project B", Archived: "0"}}), nil
+		case "POST /OPServices/resources/OpProjects/getAllUserActivities":
+			return submitJSONResponse([]onepoint.Activity{
+				{ID: 33, Name: "Development", ProjectNodeID: 22},
+				{ID: 34, Name: "Support", ProjectNodeID: 22},
+			}), nil
+		case "POST /OPServices/resources/OpProjects/getAllUserSkills":
+			return submitJSONResponse([]onepoint.Skill{
+				{SkillID: 44, Name: "Go", ActivityID: 33},
+				{SkillID: 45, Name: "Go", ActivityID: 34},
+			}), nil
+		default:
+			return nil, fmt.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}}
+	lookupClient, err := onepoint.NewClient(onepoint.ClientConfig{
+		BaseURL:        "https://onepoint.virtual7.io",
+		RefererURL:     "https://onepoint.virtual7.io/onepoint/faces/home",
+		SessionCookies: "JSESSIONID=test",
+		HTTPClient:     doer,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	stored := []worklog.Entry{{
+		ID:            1,
+		StartDateTime: time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 5, 10, 0, 0, 0, time.Local),
+		Billable:      60,
+		Description:   "task",
+		Project:       "Project B",
+		Activity:      "Development",
+		Skill:         "Go",
+		SourceMapper:  "epm",
+		// Ids cached for the stored names must not leak into the override.
+		ProjectID:  22,
+		ActivityID: 33,
+		SkillID:    44,
+	}}
+
+	entries := applySubmitNameOverride(stored, submitNameOverride{Activity: "Support"})
+	if stored[0].Activity != "Development" || stored[0].ActivityID != 33 {
+		t.Fatalf("expected stored entries to stay unchanged, got %+v", stored[0])
+	}
+	idMap, err := resolveIDsForEntries(context.Background(), lookupClient, nil, entries, onepoint.ResolveOptions{})
+	if err != nil {
+		t.Fatalf("resolve ids: %v", err)
+	}
+	batches, err := buildSubmitDayBatches(entries, idMap)
+	if err != nil {
+		t.Fatalf("build batches: %v", err)
+	}
+
+	client := &submitRecordingClient{}
+	captureStdout(t, func() {
+		if err := runSubmit(newSubmitTestSession(client), batches, 0, false, submitExecuteOptions{}); err != nil {
+			t.Fatalf("run submit: %v", err)
+		}
+	})
+	payload := client.persisted[onepoint.FormatDay(time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local))]
+	if len(payload) != 1 {
+		t.Fatalf("expected one persisted worklog, got %+v", client.persisted)
+	}
+	if payload[0].ProjectID != onepoint.ID(22) || payload[0].ActivityID != onepoint.ID(34) || payload[0].SkillID != onepoint.ID(45) {
+		t.Fatalf("expected overridden ids 22/34/45, got %+v", payload[0])
+	}
+
+	unknown := applySubmitNameOverride(stored, submitNameOverride{Activity: "Unknown"})
+	if _, err := resolveIDsForEntries(context.Background(), lookupClient, nil, unknown, onepoint.ResolveOptions{}); err == nil {
+		t.Fatalf("expected an unresolvable override activity to fail")
+	}
+}
+
+func TestSubmitDateRemap_PersistsEntriesOnTargetDay(t *testing.T) {
+	t.Parallel()
+
+	stored := []worklog.Entry{
+		{
+			ID:            1,
+			StartDateTime: time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 4, 10, 30, 0, 0, time.Local),
+			Billable:      90,
+			Description:   "mis-dated",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		},
+		{
+			ID:            2,
+			StartDateTime: time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 6, 10, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "kept",
+			Project:       "Project A",
+			Activity:      "Delivery",
+			Skill:         "Go",
+			SourceMapper:  "epm",
+		},
+	}
+	remaps, err := parseSubmitDateRemaps([]string{"2026-03-04=2026-03-05", "2026-03-09=2026-03-10"}, time.Local)
+	if err != nil {
+		t.Fatalf("parse remaps: %v", err)
+	}
+	entries, unmatched := applySubmitDateRemaps(stored, remaps, time.Local)
+	if len(unmatched) != 1 || unmatched[0] != "2026-03-09=2026-03-10" {
+		t.Fatalf("expected the unused remap to be reported, got %v", unmatched)
+	}
+	if !stored[0].StartDateTime.Equal(time.Date(2026, 3, 4, 9, 0, 0, 0, time.Local)) {
+		t.Fatalf("expected stored entries to stay unchanged, got %+v", stored[0])
+	}
+
+	ids := map[submitNameTuple]submitResolvedIDs{
+		{Mapper: "epm", Project: "project a", Activity: "delivery", Skill: "go"}: {ProjectID: 100, ActivityID: 200, SkillID: 300},
+	}
+	batches, err := buildSubmitDayBatches(entries, ids)
+	if err != nil {
+		t.Fatalf("build batches: %v", err)
+	}
+	client := &submitRecordingClient{}
+	captureStdout(t, func() {
+		if err := runSubmit(newSubmitTestSession(client), batches, 0, false, submitExecuteOptions{}); err != nil {
+			t.Fatalf("run submit: %v", err)
+		}
+	})
+
+	if _, ok := client.persisted["04-03-2026"]; ok {
+		t.Fatalf("expected nothing to be persisted on the source day, got %+v", client.persisted)
+	}
+	moved := client.persisted["05-03-2026"]
+	if len(moved) != 1 {
+		t.Fatalf("expected one worklog on the target day, got %+v", client.persisted)
+	}
+	if moved[0].WorklogDate != "05-03-2026" || moved[0].StartTime == nil || *moved[0].StartTime != 9*60 || moved[0].FinishTime == nil || *moved[0].FinishTime != 10*60+30 {
+		t.Fatalf("expected 09:00-10:30 on 05-03-2026, got %+v", moved[0])
+	}
+	if len(client.persisted["06-03-2026"]) != 1 {
+		t.Fatalf("expected the other day to be persisted unchanged, got %+v", client.persisted)
+	}
+}
+
+func TestParseSubmitDateRemaps_RejectsInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	for _, values := range [][]string{
+		{"2026-03-04"},
+		{"2026-03-04=05.03.2026"},
+		{"2026-03-04=2026-03-05", "2026-03-04=2026-03-06"},
+	} {
+		if _, err := parseSubmitDateRemaps(values, time.Local); err == nil {
+			t.Fatalf("expected %v to be rejected", values)
+		}
+	}
+}
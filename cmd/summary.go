@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/output"
+	"github.com/riadshalaby/gohour/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	summaryDBPath string
+	summaryFrom   string
+	summaryTo     string
+	summaryJSON   bool
+)
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Print per-day summaries of local worklogs",
+	Long: `Print per-day summaries of the worklogs stored in the local SQLite database.
+
+Each day shows the first start and last end, worked, billable and break hours,
+and the number of worklogs, computed the same way as "export --mode daily".
+--from/--to limit the output to a day range.
+
+The default output is a text table. Use --json to print a JSON array instead,
+one object per day with date, start, end (RFC3339), workedHours, billableHours,
+breakHours and worklogCount.`,
+	Example: `
+  # Summarize all stored days
+  gohour summary
+
+  # Summarize one month as JSON
+  gohour summary --from 2026-03-01 --to 2026-03-31 --json
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadAndValidate()
+		if err != nil {
+			return err
+		}
+		loc := cfg.Location()
+		from, to, err := parseSubmitRange(summaryFrom, summaryTo, loc)
+		if err != nil {
+			return err
+		}
+
+		store, err := openConfiguredStoreWithNotices(summaryDBPath, *cfg, os.Stderr)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		return runSummary(os.Stdout, store, from, to, loc, summaryJSON)
+	},
+}
+
+// runSummary writes the daily summaries of stored worklogs within [from, to]
+// to out, as JSON when asJSON is set and as a text table otherwise. Days are
+// taken in loc.
+func runSummary(out io.Writer, store *storage.SQLiteStore, from, to *time.Time, loc *time.Location, asJSON bool) error {
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		return err
+	}
+	summaries := output.BuildDailySummaries(filterEntriesByDayRange(entriesInLocation(entries, loc), from, to, loc))
+
+	if asJSON {
+		return output.WriteDailySummariesJSON(out, summaries)
+	}
+	return output.WriteDailySummariesTable(out, summaries)
+}
+
+func init() {
+	rootCmd.AddCommand(summaryCmd)
+
+	summaryCmd.Flags().StringVar(&summaryDBPath, "db", "./gohour.db", "Path to local SQLite database")
+	summaryCmd.Flags().StringVar(&summaryFrom, "from", "", "Filter start day (inclusive), format YYYY-MM-DD")
+	summaryCmd.Flags().StringVar(&summaryTo, "to", "", "Filter end day (inclusive), format YYYY-MM-DD")
+	summaryCmd.Flags().BoolVar(&summaryJSON, "json", false, "Print summaries as JSON instead of a text table")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/storage"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestRunSummary_JSONForDayRange(t *testing.T) {
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "gohour.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.Local)
+	}
+	entries := []worklog.Entry{
+		{StartDateTime: at(2, 9, 0), EndDateTime: at(2, 10, 0), Billable: 60, Description: "Out of range", SourceFormat: "generic"},
+		{StartDateTime: at(3, 9, 0), EndDateTime: at(3, 11, 0), Billable: 120, Description: "Morning", SourceFormat: "generic"},
+		{StartDateTime: at(3, 12, 0), EndDateTime: at(3, 13, 30), Billable: 60, Description: "Afternoon", SourceFormat: "generic"},
+		{StartDateTime: at(4, 8, 0), EndDateTime: at(4, 8, 45), Billable: 0, Description: "Internal", SourceFormat: "generic"},
+	}
+	if _, err := store.InsertWorklogs(entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	from, to, err := parseSubmitRange("2026-03-03", "2026-03-04", time.Local)
+	if err != nil {
+		t.Fatalf("parse range: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runSummary(&out, store, from, to, time.Local, true); err != nil {
+		t.Fatalf("run summary: %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, out.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 days, got %d: %s", len(got), out.String())
+	}
+
+	first := got[0]
+	if first["date"] != "2026-03-03" {
+		t.Fatalf("unexpected first date: %v", first["date"])
+	}
+	if first["start"] != at(3, 9, 0).Format(time.RFC3339) || first["end"] != at(3, 13, 30).Format(time.RFC3339) {
+		t.Fatalf("unexpected first start/end: %v / %v", first["start"], first["end"])
+	}
+	if first["workedHours"] != 3.5 || first["billableHours"] != 3.0 || first["breakHours"] != 1.0 {
+		t.Fatalf("unexpected first hours: %v", first)
+	}
+	if first["worklogCount"] != 2.0 {
+		t.Fatalf("unexpected first worklog count: %v", first["worklogCount"])
+	}
+
+	second := got[1]
+	if second["date"] != "2026-03-04" || second["workedHours"] != 0.75 || second["billableHours"] != 0.0 || second["worklogCount"] != 1.0 {
+		t.Fatalf("unexpected second day: %v", second)
+	}
+}
+
+func TestRunSummary_TableIsDefault(t *testing.T) {
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "gohour.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	var out bytes.Buffer
+	if err := runSummary(&out, store, nil, nil, time.Local, false); err != nil {
+		t.Fatalf("run summary: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "Date") {
+		t.Fatalf("expected table header, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := runSummary(&out, store, nil, nil, time.Local, true); err != nil {
+		t.Fatalf("run summary json: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Fatalf("expected empty json array, got %q", out.String())
+	}
+}
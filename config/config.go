@@ -2,49 +2,399 @@ package config
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/riadshalaby/gohour/storage"
 	"github.com/spf13/viper"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	KeyOnePointURL              = "onepoint.url"
-	KeyImportAutoReconcileAfter = "import.auto_reconcile_after_import"
-	KeyRules                    = "rules"
+	KeyOnePointURL                  = "onepoint.url"
+	KeyOnePointProjectCode          = "onepoint.project_code_pattern"
+	KeyOnePointProjectStatuses      = "onepoint.selectable_project_statuses"
+	KeyOnePointIgnoreDiacritics     = "onepoint.ignore_diacritics"
+	KeyOnePointRequestsPerSec       = "onepoint.requests_per_second"
+	KeyOnePointMaxRetries           = "onepoint.max_retries"
+	KeyImportAutoReconcileAfter     = "import.auto_reconcile_after_import"
+	KeyImportInsertBatchSize        = "import.insert_batch_size"
+	KeyImportStoreSources           = "import.store_sources"
+	KeyImportEPMDayTotalCheck       = "import.epm_day_total_check"
+	KeyImportEPMDayTotalTol         = "import.epm_day_total_tolerance_minutes"
+	KeyImportEPMBreakThreshold      = "import.epm_break_threshold_minutes"
+	KeyImportEPMBreakMinutes        = "import.epm_break_minutes"
+	KeyImportFileDuplicateCheck     = "import.file_duplicate_check"
+	KeyImportDetectMapper           = "import.detect_mapper"
+	KeyReconcileSkipManualDays      = "reconcile.skip_days_with_manual_entries"
+	KeyReconcileFloatingMappers     = "reconcile.floating_mappers"
+	KeyReconcileWorkdayEnd          = "reconcile.workday_end"
+	KeySubmitVerifyPersist          = "submit.verify_persist_results"
+	KeySubmitCommentSanitize        = "submit.comment_sanitization"
+	KeySubmitDefaultRange           = "submit.default_range"
+	KeySubmitWebhookURL             = "submit.webhook_url"
+	KeySubmitSkipUnchangedDays      = "submit.skip_unchanged_days"
+	KeySubmitTicketPattern          = "submit.ticket_pattern"
+	KeySubmitTicketTemplate         = "submit.ticket_template"
+	KeySubmitRequireTicket          = "submit.require_ticket"
+	KeySubmitRetryOnConflict        = "submit.retry_on_conflict"
+	KeyWebMaxEntriesPerDay          = "web.max_entries_per_day"
+	KeyWebTagColors                 = "web.tag_colors"
+	KeyWebDailyTargetHours          = "web.daily_target_hours"
+	KeyWebWorkdays                  = "web.workdays"
+	KeyWebHideEmptyDays             = "web.hide_empty_days"
+	KeyWebDeleteLocalOnRemoteDelete = "web.delete_local_on_remote_delete"
+	KeyWebContractMonthlyHours      = "web.contract_monthly_hours"
+	KeyWebSessionPingMinutes        = "web.session_ping_minutes"
+	KeyTimezone                     = "timezone"
+	KeyActivityDefaultSkills        = "activity_default_skills"
+	KeyDryRunByDefault              = "dry_run_by_default"
+	KeyMaxDailyHours                = "max_daily_hours"
+	KeyProfiles                     = "profiles"
+	KeyRules                        = "rules"
 )
 
+// profileNamePattern limits profile names to what is safe in a file name,
+// since a profile without state_file gets its own auth state file.
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// tagColorPattern accepts #rgb and #rrggbb colors for web.tag_colors.
+var tagColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
 type Config struct {
-	OnePoint OnePointConfig `mapstructure:"onepoint" validate:"required"`
-	Import   ImportConfig   `mapstructure:"import"`
-	Rules    []Rule         `mapstructure:"rules"`
+	OnePoint  OnePointConfig  `mapstructure:"onepoint" validate:"required"`
+	Import    ImportConfig    `mapstructure:"import"`
+	Reconcile ReconcileConfig `mapstructure:"reconcile"`
+	Submit    SubmitConfig    `mapstructure:"submit"`
+	Web       WebConfig       `mapstructure:"web"`
+	Timezone  string          `mapstructure:"timezone"`
+	Rules     []Rule          `mapstructure:"rules"`
+
+	// ActivityDefaultSkills maps an activity name to the skill used for
+	// manual entries and generic import rows that name the activity but no
+	// skill. Activity names match case-insensitively.
+	ActivityDefaultSkills map[string]string `mapstructure:"activity_default_skills"`
+
+	// DryRunByDefault makes destructive commands (submit, delete, import undo) only
+	// report what they would do unless --commit is passed.
+	DryRunByDefault bool `mapstructure:"dry_run_by_default"`
+
+	// MaxDailyHours makes submit and reconcile warn about days whose entries
+	// add up to more worked hours. Zero disables the check.
+	MaxDailyHours float64 `mapstructure:"max_daily_hours" validate:"gte=0"`
+
+	// Profiles maps a profile name to a OnePoint tenant, selected with the
+	// global --profile flag instead of passing --url and --state-file.
+	Profiles map[string]ProfileConfig `mapstructure:"profiles"`
 
 	// Runtime-only values resolved per imported file (not loaded from config).
 	ImportProject  string `mapstructure:"-"`
 	ImportActivity string `mapstructure:"-"`
 	ImportSkill    string `mapstructure:"-"`
 	ImportBillable bool   `mapstructure:"-"`
+	// ImportSourceFormatLabel replaces the reader format as SourceFormat
+	// when the matching rule sets source_format_label.
+	ImportSourceFormatLabel string `mapstructure:"-"`
+
+	// TimeLocation is Timezone resolved once when the config is loaded.
+	TimeLocation *time.Location `mapstructure:"-"`
 }
 
 type OnePointConfig struct {
-	URL string `mapstructure:"url" validate:"required,url"`
+	URL                string `mapstructure:"url" validate:"required,url"`
+	ProjectCodePattern string `mapstructure:"project_code_pattern"`
+	// SelectableProjectStatuses lists the project Status values treated as
+	// active (default [0]). An explicit empty list disables status filtering.
+	SelectableProjectStatuses []int64 `mapstructure:"selectable_project_statuses"`
+	// IgnoreDiacritics matches project/activity/skill names accent-insensitively.
+	IgnoreDiacritics bool `mapstructure:"ignore_diacritics"`
+	// RequestsPerSecond caps the rate of OnePoint API calls. Zero disables pacing.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second" validate:"gte=0"`
+	// MaxRetries is how often read-only OnePoint requests are retried on
+	// 429/5xx or transient network errors. Zero disables retries.
+	MaxRetries int `mapstructure:"max_retries" validate:"gte=0"`
+}
+
+// ProjectCodeRegexp returns the compiled project code pattern, or nil when no
+// pattern is configured. Patterns are checked during validation.
+func (c OnePointConfig) ProjectCodeRegexp() *regexp.Regexp {
+	pattern := strings.TrimSpace(c.ProjectCodePattern)
+	if pattern == "" {
+		return nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	return compiled
 }
 
 type ImportConfig struct {
 	AutoReconcileAfterImport bool `mapstructure:"auto_reconcile_after_import"`
+	InsertBatchSize          int  `mapstructure:"insert_batch_size" validate:"gte=0"`
+	// StoreSources keeps imported files with their import batch in the
+	// database so the batch can be remapped later.
+	StoreSources bool `mapstructure:"store_sources"`
+	// EPMDayTotalCheck reports EPM days whose mapped entries do not add up
+	// to the declared Tagessumme within EPMDayTotalToleranceMins.
+	EPMDayTotalCheck         bool `mapstructure:"epm_day_total_check"`
+	EPMDayTotalToleranceMins int  `mapstructure:"epm_day_total_tolerance_minutes" validate:"gte=0"`
+	// EPMBreakMinutes, when positive, replaces the break the EPM mapper
+	// derives from the day span: a fixed break of this length is inserted
+	// on days whose Tagessumme exceeds EPMBreakThresholdMins, and no break
+	// on other days.
+	EPMBreakThresholdMins int `mapstructure:"epm_break_threshold_minutes" validate:"gte=0,lte=1440"`
+	EPMBreakMinutes       int `mapstructure:"epm_break_minutes" validate:"gte=0,lte=1440"`
+	// FileDuplicateCheck reports rows of one source file that map to the
+	// same entry, which the database would otherwise collapse silently.
+	FileDuplicateCheck bool `mapstructure:"file_duplicate_check"`
+	// DetectMapper picks the mapper from a file's header row when no rule
+	// matches the file and --mapper is not given.
+	DetectMapper bool `mapstructure:"detect_mapper"`
+}
+
+type ReconcileConfig struct {
+	SkipDaysWithManualEntries bool `mapstructure:"skip_days_with_manual_entries"`
+	// FloatingMappers lists the mappers whose entries reconcile shifts around
+	// entries from all other sources. Empty keeps the EPM-only default.
+	FloatingMappers []string `mapstructure:"floating_mappers"`
+	// WorkdayEnd ("HH:MM", after 00:00) is the latest time a shifted entry
+	// may end at. Entries that would end later keep their original position.
+	// Empty disables the limit.
+	WorkdayEnd string `mapstructure:"workday_end"`
+}
+
+// CommentSanitizationMode returns the normalized comment sanitization mode,
+// defaulting to "strip".
+func (c SubmitConfig) CommentSanitizationMode() string {
+	mode := strings.ToLower(strings.TrimSpace(c.CommentSanitization))
+	if mode == "" {
+		return "strip"
+	}
+	return mode
+}
+
+// TicketRegexp returns the compiled, case-insensitive ticket pattern, or nil
+// when no pattern is configured. Patterns are checked during validation.
+func (c SubmitConfig) TicketRegexp() *regexp.Regexp {
+	pattern := strings.TrimSpace(c.TicketPattern)
+	if pattern == "" {
+		return nil
+	}
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil
+	}
+	return compiled
+}
+
+// DefaultRangeMode returns the normalized default submit range, defaulting
+// to "all".
+func (c SubmitConfig) DefaultRangeMode() string {
+	mode := strings.ToLower(strings.TrimSpace(c.DefaultRange))
+	if mode == "" {
+		return "all"
+	}
+	return mode
+}
+
+// WorkdayEndOffset returns the configured workday end as an offset from
+// midnight, or 0 when unset or invalid.
+func (r ReconcileConfig) WorkdayEndOffset() time.Duration {
+	offset, err := parseClockOffset(r.WorkdayEnd)
+	if err != nil {
+		return 0
+	}
+	return offset
+}
+
+func parseClockOffset(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+type SubmitConfig struct {
+	// VerifyPersistResults warns when OnePoint confirms more or fewer new
+	// time records than entries were submitted for a day.
+	VerifyPersistResults bool `mapstructure:"verify_persist_results"`
+	// CommentSanitization handles comment characters OnePoint rejects:
+	// "strip" (default; line breaks become a space), "replace" (with a
+	// space) or "off".
+	CommentSanitization string `mapstructure:"comment_sanitization"`
+	// DefaultRange selects the days submitted when neither --from nor --to
+	// is given: "all" (default), "current-month" or "previous-month".
+	DefaultRange string `mapstructure:"default_range"`
+	// WebhookURL receives a JSON summary after each completed submit run.
+	// Empty disables the webhook.
+	WebhookURL string `mapstructure:"webhook_url" validate:"omitempty,url"`
+	// SkipUnchangedDays skips days whose local entries hash to the value
+	// recorded at their last successful submit.
+	SkipUnchangedDays bool `mapstructure:"skip_unchanged_days"`
+	// TicketPattern detects a ticket id (e.g. "[A-Z]+-[0-9]+") in comments,
+	// case-insensitively. Empty disables ticket formatting.
+	TicketPattern string `mapstructure:"ticket_pattern"`
+	// TicketTemplate rebuilds comments with a detected ticket from the
+	// "{ticket}" and "{comment}" placeholders.
+	TicketTemplate string `mapstructure:"ticket_template"`
+	// RequireTicket warns about submitted comments without a ticket id.
+	RequireTicket bool `mapstructure:"require_ticket"`
+	// RetryOnConflict re-fetches a day whose persist OnePoint rejected as a
+	// concurrent modification, merges the local entries again and retries once.
+	RetryOnConflict bool `mapstructure:"retry_on_conflict"`
+}
+
+type WebConfig struct {
+	// MaxEntriesPerDay caps how many local entries a single day may hold
+	// before the web create endpoint rejects new ones. 0 disables the cap.
+	MaxEntriesPerDay int `mapstructure:"max_entries_per_day" validate:"gte=0"`
+	// TagColors maps an entry tag (lower-case) to the CSS hex color of its
+	// chip in the day view. Unlisted tags use the neutral chip color.
+	TagColors map[string]string `mapstructure:"tag_colors"`
+	// DailyTargetHours is the hours expected on each workday, used by the
+	// month progress API.
+	DailyTargetHours float64 `mapstructure:"daily_target_hours" validate:"gte=0,lte=24"`
+	// Workdays lists the weekdays ("mon".."sun") that carry the daily
+	// target. Empty means Monday to Friday.
+	Workdays []string `mapstructure:"workdays"`
+	// HideEmptyDays omits days without local and remote hours from the
+	// month view unless the request passes ?hide-empty=0.
+	HideEmptyDays bool `mapstructure:"hide_empty_days"`
+	// DeleteLocalOnRemoteDelete also deletes local entries matching deleted
+	// remote entries unless the request passes ?delete_local=0. Otherwise
+	// the delete response only warns about them.
+	DeleteLocalOnRemoteDelete bool `mapstructure:"delete_local_on_remote_delete"`
+	// ContractMonthlyHours is the fixed monthly hours of a contract. When
+	// positive, the month view compares local worked hours against it; 0
+	// disables the comparison.
+	ContractMonthlyHours float64 `mapstructure:"contract_monthly_hours" validate:"gte=0,lte=744"`
+	// SessionPingMinutes is how often serve checks the OnePoint session in
+	// the background, so an expired session shows up before the next
+	// action. 0 disables the check.
+	SessionPingMinutes int `mapstructure:"session_ping_minutes" validate:"gte=0"`
+}
+
+// defaultWorkdays are used when web.workdays is empty.
+var defaultWorkdays = []string{"mon", "tue", "wed", "thu", "fri"}
+
+// IsWorkday reports whether day carries the daily target. Unknown names in
+// Workdays are rejected during validation and ignored here.
+func (c WebConfig) IsWorkday(day time.Weekday) bool {
+	names := c.Workdays
+	if len(names) == 0 {
+		names = defaultWorkdays
+	}
+	for _, name := range names {
+		if weekday, ok := parseWeekday(name); ok && weekday == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWeekday accepts English weekday names, full or abbreviated to three
+// letters, in any case.
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 3 {
+		return 0, false
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
 }
 
 type Rule struct {
-	Name         string `mapstructure:"name"`
-	Mapper       string `mapstructure:"mapper"`
-	FileTemplate string `mapstructure:"file_template"`
-	Billable     *bool  `mapstructure:"billable"`
-	ProjectID    int64  `mapstructure:"project_id"`
-	Project      string `mapstructure:"project"`
-	ActivityID   int64  `mapstructure:"activity_id"`
-	Activity     string `mapstructure:"activity"`
-	SkillID      int64  `mapstructure:"skill_id"`
-	Skill        string `mapstructure:"skill"`
+	Name         string `mapstructure:"name" json:"name"`
+	Mapper       string `mapstructure:"mapper" json:"mapper"`
+	FileTemplate string `mapstructure:"file_template" json:"file_template"`
+	Billable     *bool  `mapstructure:"billable" json:"billable,omitempty"`
+	ProjectID    int64  `mapstructure:"project_id" json:"project_id"`
+	Project      string `mapstructure:"project" json:"project"`
+	ActivityID   int64  `mapstructure:"activity_id" json:"activity_id"`
+	Activity     string `mapstructure:"activity" json:"activity"`
+	SkillID      int64  `mapstructure:"skill_id" json:"skill_id"`
+	Skill        string `mapstructure:"skill" json:"skill"`
+	// SourceFormatLabel, when set, is stored as source_format of the
+	// entries imported through this rule instead of "csv" or "excel".
+	SourceFormatLabel string `mapstructure:"source_format_label" json:"source_format_label,omitempty"`
+}
+
+// ProfileConfig is one OnePoint tenant selectable with --profile.
+type ProfileConfig struct {
+	// URL is the full OnePoint home URL of the tenant.
+	URL string `mapstructure:"url"`
+	// StateFile is the auth state JSON of the tenant. Empty uses
+	// $HOME/.gohour/onepoint-auth-state-<profile>.json.
+	StateFile string `mapstructure:"state_file"`
+}
+
+// Profile returns the profile configured under name. Viper lower-cases map
+// keys, so names match case-insensitively. Unknown names fail with the list
+// of available profiles.
+func (c Config) Profile(name string) (ProfileConfig, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if profile, ok := c.Profiles[key]; ok {
+		return profile, nil
+	}
+	if len(c.Profiles) == 0 {
+		return ProfileConfig{}, fmt.Errorf("unknown profile %q: no profiles configured", name)
+	}
+	names := make([]string, 0, len(c.Profiles))
+	for profileName := range c.Profiles {
+		names = append(names, profileName)
+	}
+	sort.Strings(names)
+	return ProfileConfig{}, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// DefaultSkillFor returns the configured default skill for activity, or ""
+// when none is set. Viper lower-cases map keys, so names match
+// case-insensitively.
+func (c Config) DefaultSkillFor(activity string) string {
+	activity = strings.TrimSpace(activity)
+	if activity == "" {
+		return ""
+	}
+	for name, skill := range c.ActivityDefaultSkills {
+		if strings.EqualFold(strings.TrimSpace(name), activity) {
+			return strings.TrimSpace(skill)
+		}
+	}
+	return ""
+}
+
+// Location returns the configured timezone, falling back to time.Local when
+// unset. Loaded configs carry the zone resolved during validation; a Config
+// built in code without it loads the zone by name.
+func (c Config) Location() *time.Location {
+	if c.TimeLocation != nil {
+		return c.TimeLocation
+	}
+	name := strings.TrimSpace(c.Timezone)
+	if name == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return loc
 }
 
 // IsBillable returns whether entries from this rule should be billable.
@@ -59,7 +409,45 @@ func (r Rule) IsBillable() bool {
 // SetDefaults sets default values if not provided
 func SetDefaults() {
 	viper.SetDefault(KeyOnePointURL, "https://onepoint.virtual7.io/onepoint/faces/home")
+	viper.SetDefault(KeyOnePointProjectCode, "")
+	viper.SetDefault(KeyOnePointProjectStatuses, []int64{0})
+	viper.SetDefault(KeyOnePointIgnoreDiacritics, false)
+	viper.SetDefault(KeyOnePointRequestsPerSec, 0)
+	viper.SetDefault(KeyOnePointMaxRetries, 2)
 	viper.SetDefault(KeyImportAutoReconcileAfter, true)
+	viper.SetDefault(KeyImportInsertBatchSize, storage.DefaultInsertBatchSize)
+	viper.SetDefault(KeyImportStoreSources, false)
+	viper.SetDefault(KeyImportEPMDayTotalCheck, true)
+	viper.SetDefault(KeyImportEPMDayTotalTol, 1)
+	viper.SetDefault(KeyImportEPMBreakThreshold, 0)
+	viper.SetDefault(KeyImportEPMBreakMinutes, 0)
+	viper.SetDefault(KeyImportFileDuplicateCheck, true)
+	viper.SetDefault(KeyImportDetectMapper, true)
+	viper.SetDefault(KeyReconcileSkipManualDays, false)
+	viper.SetDefault(KeyReconcileFloatingMappers, []string{})
+	viper.SetDefault(KeyReconcileWorkdayEnd, "")
+	viper.SetDefault(KeySubmitVerifyPersist, true)
+	viper.SetDefault(KeySubmitCommentSanitize, "strip")
+	viper.SetDefault(KeySubmitDefaultRange, "all")
+	viper.SetDefault(KeySubmitWebhookURL, "")
+	viper.SetDefault(KeySubmitSkipUnchangedDays, false)
+	viper.SetDefault(KeySubmitTicketPattern, "")
+	viper.SetDefault(KeySubmitTicketTemplate, "{ticket} {comment}")
+	viper.SetDefault(KeySubmitRequireTicket, false)
+	viper.SetDefault(KeySubmitRetryOnConflict, true)
+	viper.SetDefault(KeyWebMaxEntriesPerDay, 0)
+	viper.SetDefault(KeyWebTagColors, map[string]string{})
+	viper.SetDefault(KeyWebDailyTargetHours, 8)
+	viper.SetDefault(KeyWebWorkdays, []string{"mon", "tue", "wed", "thu", "fri"})
+	viper.SetDefault(KeyWebHideEmptyDays, false)
+	viper.SetDefault(KeyWebDeleteLocalOnRemoteDelete, false)
+	viper.SetDefault(KeyWebContractMonthlyHours, 0)
+	viper.SetDefault(KeyWebSessionPingMinutes, 0)
+	viper.SetDefault(KeyTimezone, "")
+	viper.SetDefault(KeyActivityDefaultSkills, map[string]string{})
+	viper.SetDefault(KeyDryRunByDefault, false)
+	viper.SetDefault(KeyMaxDailyHours, 16)
+	viper.SetDefault(KeyProfiles, map[string]any{})
 	viper.SetDefault(KeyRules, []map[string]any{})
 }
 
@@ -84,9 +472,112 @@ func ExampleYAML() string {
 	return `# gohour configuration
 onepoint:
   url: "https://onepoint.virtual7.io/onepoint/faces/home"
+  # Optional regex extracting a short project code from OnePoint project names,
+  # e.g. "^([a-z]+[0-9]+)" turns "bfa211102 - ISO RVSE9 Los2" into "bfa211102".
+  project_code_pattern: ""
+  # Project status values treated as active (0 is an active project).
+  # Projects with other statuses are skipped like archived ones. []: no filtering.
+  selectable_project_statuses: [0]
+  # Match project/activity/skill names ignoring accents ("Tatigkeit" finds "Tätigkeit").
+  ignore_diacritics: false
+  # Maximum OnePoint API calls per second (e.g. 2). 0 disables pacing.
+  requests_per_second: 0
+  # Retries for read-only OnePoint requests on 429/5xx or network errors. 0 disables retries.
+  max_retries: 2
 
 import:
   auto_reconcile_after_import: true
+  # Rows committed per SQLite transaction during import.
+  insert_batch_size: ` + strconv.Itoa(storage.DefaultInsertBatchSize) + `
+  # Keep imported files with their import batch so POST /api/import/{batch}/remap
+  # can re-run the mappers over them.
+  store_sources: false
+  # Warn when an EPM day's entries do not add up to its Tagessumme.
+  epm_day_total_check: true
+  epm_day_total_tolerance_minutes: 1
+  # Fixed EPM break policy, e.g. 30 minutes on days above 360 billable minutes.
+  # epm_break_minutes: 0 keeps the break derived from the day's Von/Bis span.
+  epm_break_threshold_minutes: 0
+  epm_break_minutes: 0
+  # Warn about rows of one file that map to the same entry (stored only once).
+  file_duplicate_check: true
+  # Pick epm, atwork, toggl or generic from a file's column headers when no rule
+  # matches the file and --mapper is not given.
+  detect_mapper: true
+
+reconcile:
+  # Leave days containing manually created (web UI) entries untouched.
+  skip_days_with_manual_entries: false
+  # Mappers whose entries are shifted around all other entries, e.g. ["generic"]
+  # when atwork times are authoritative. Empty: only EPM entries are shifted.
+  floating_mappers: []
+  # Latest end time ("HH:MM") for shifted entries, e.g. "18:00". Entries that would
+  # end later stay where they are and are reported as unresolved. Empty: no limit
+  # ("00:00" is rejected).
+  workday_end: ""
+
+submit:
+  # Warn when OnePoint confirms more or fewer new time records than entries were submitted.
+  verify_persist_results: true
+  # Characters OnePoint rejects in comments (control, zero-width, private-use):
+  # "strip" removes them and joins lines with a space, "replace" substitutes a space,
+  # "off" sends comments unchanged.
+  comment_sanitization: "strip"
+  # Days submitted when neither --from nor --to is given:
+  # "all", "current-month" or "previous-month". Explicit flags always win.
+  default_range: "all"
+  # Optional URL that receives a JSON summary (POST) after each completed submit run.
+  # A failing webhook only prints a warning. Empty: disabled.
+  webhook_url: ""
+  # Skip days whose local entries did not change since their last successful submit
+  # (hashes are stored in the local database). --force processes every day.
+  skip_unchanged_days: false
+  # Optional regex for ticket ids in comments, matched case-insensitively, e.g. "[A-Z]+-[0-9]+".
+  # A detected ticket is upper-cased and the comment rebuilt from ticket_template. Empty: off.
+  ticket_pattern: ""
+  ticket_template: "{ticket} {comment}"
+  # Warn about submitted comments without a ticket id (requires ticket_pattern).
+  require_ticket: false
+  # When OnePoint rejects a day as modified concurrently (409), re-fetch the day, merge
+  # the local entries again and retry once. Off: the day fails like any other error.
+  retry_on_conflict: true
+
+web:
+  # Maximum local entries per day accepted by the web create endpoint; 0 disables the cap.
+  max_entries_per_day: 0
+  # Optional chip colors for entry tags in the day view, e.g. { travel: "#2f80ed" }.
+  tag_colors: {}
+  # Hours expected per workday for GET /api/month/{month}/progress.
+  daily_target_hours: 8
+  # Weekdays carrying the daily target (mon, tue, ... or full names).
+  workdays: ["mon", "tue", "wed", "thu", "fri"]
+  # Omit days without local and remote hours from the month view (override with ?hide-empty=0|1).
+  hide_empty_days: false
+  # Also delete local entries matching deleted remote entries (override with ?delete_local=0|1).
+  delete_local_on_remote_delete: false
+  # Contracted hours per month shown against local worked hours in the month view; 0 disables.
+  contract_monthly_hours: 0
+  # Minutes between background OnePoint session checks in serve; 0 disables.
+  session_ping_minutes: 0
+
+# IANA timezone defining calendar days (e.g. "Europe/Berlin"); empty uses the system zone.
+timezone: ""
+
+# Skill used when a manual entry or generic import row names an activity but no skill,
+# e.g. { Development: "Go" }. Activity names match case-insensitively.
+activity_default_skills: {}
+
+# Safety switch: submit, delete and import undo only report what they would do unless --commit is passed.
+dry_run_by_default: false
+
+# Warn in submit and reconcile about days with more worked hours than this, e.g. after a
+# doubled import; submit --strict aborts instead. 0 disables the check.
+max_daily_hours: 16
+
+# OnePoint tenants selectable with --profile <name> instead of --url/--state-file, e.g.
+#   client-a: { url: "https://a.example.com/onepoint/faces/home" }
+# state_file defaults to $HOME/.gohour/onepoint-auth-state-<name>.json.
+profiles: {}
 
 rules: []
 `
@@ -102,6 +593,86 @@ func loadAndValidateFromViper(v *viper.Viper) (*Config, error) {
 	if err := validate.Struct(cfg); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if pattern := strings.TrimSpace(cfg.OnePoint.ProjectCodePattern); pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("validation failed: onepoint.project_code_pattern is not a valid regex: %w", err)
+		}
+	}
+	cfg.TimeLocation = time.Local
+	if name := strings.TrimSpace(cfg.Timezone); name != "" {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: timezone %q is not a known IANA zone: %w", name, err)
+		}
+		cfg.TimeLocation = loc
+	}
+	for activity, skill := range cfg.ActivityDefaultSkills {
+		if strings.TrimSpace(activity) == "" || strings.TrimSpace(skill) == "" {
+			return nil, fmt.Errorf("validation failed: activity_default_skills[%s] must map an activity to a non-empty skill", activity)
+		}
+	}
+	for name, profile := range cfg.Profiles {
+		if !profileNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("validation failed: profiles[%s] name may only contain letters, digits, '-' and '_'", name)
+		}
+		if err := validateProfileURL(profile.URL); err != nil {
+			return nil, fmt.Errorf("validation failed: profiles[%s].url %w", name, err)
+		}
+	}
+	for tag, color := range cfg.Web.TagColors {
+		if !tagColorPattern.MatchString(strings.TrimSpace(color)) {
+			return nil, fmt.Errorf("validation failed: web.tag_colors[%s] %q must be a hex color like #2f80ed", tag, color)
+		}
+	}
+	for i, name := range cfg.Web.Workdays {
+		if _, ok := parseWeekday(name); !ok {
+			return nil, fmt.Errorf("validation failed: web.workdays[%d] %q is not a weekday (use mon..sun)", i, name)
+		}
+	}
+	for i, mapper := range cfg.Reconcile.FloatingMappers {
+		switch strings.ToLower(strings.TrimSpace(mapper)) {
+		case "epm", "generic", "atwork", "toggl", "manual":
+		default:
+			return nil, fmt.Errorf(
+				"validation failed: reconcile.floating_mappers[%d] %q is not supported (valid: epm, generic, atwork, toggl, manual)",
+				i,
+				mapper,
+			)
+		}
+	}
+	workdayEnd, err := parseClockOffset(cfg.Reconcile.WorkdayEnd)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: reconcile.workday_end %q must use HH:MM: %w", cfg.Reconcile.WorkdayEnd, err)
+	}
+	if workdayEnd == 0 && strings.TrimSpace(cfg.Reconcile.WorkdayEnd) != "" {
+		return nil, fmt.Errorf("validation failed: reconcile.workday_end %q must be after 00:00; leave it empty to disable the limit", cfg.Reconcile.WorkdayEnd)
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Submit.CommentSanitization)) {
+	case "", "strip", "replace", "off":
+	default:
+		return nil, fmt.Errorf(
+			"validation failed: submit.comment_sanitization %q is not supported (valid: strip, replace, off)",
+			cfg.Submit.CommentSanitization,
+		)
+	}
+	if pattern := strings.TrimSpace(cfg.Submit.TicketPattern); pattern != "" {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			return nil, fmt.Errorf("validation failed: submit.ticket_pattern is not a valid regex: %w", err)
+		}
+		if template := strings.TrimSpace(cfg.Submit.TicketTemplate); template != "" && !strings.Contains(template, "{ticket}") {
+			return nil, fmt.Errorf("validation failed: submit.ticket_template %q must contain {ticket}", cfg.Submit.TicketTemplate)
+		}
+	} else if cfg.Submit.RequireTicket {
+		return nil, fmt.Errorf("validation failed: submit.require_ticket needs submit.ticket_pattern")
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Submit.DefaultRange)) {
+	case "", "all", "current-month", "previous-month":
+	default:
+		return nil, fmt.Errorf(
+			"validation failed: submit.default_range %q is not supported (valid: all, current-month, previous-month)",
+			cfg.Submit.DefaultRange,
+		)
+	}
 	if err := validateRules(cfg.Rules); err != nil {
 		return nil, err
 	}
@@ -111,15 +682,66 @@ func loadAndValidateFromViper(v *viper.Viper) (*Config, error) {
 
 func setDefaults(v *viper.Viper) {
 	v.SetDefault(KeyOnePointURL, "https://onepoint.virtual7.io/onepoint/faces/home")
+	v.SetDefault(KeyOnePointProjectCode, "")
+	v.SetDefault(KeyOnePointProjectStatuses, []int64{0})
+	v.SetDefault(KeyOnePointIgnoreDiacritics, false)
+	v.SetDefault(KeyOnePointRequestsPerSec, 0)
+	v.SetDefault(KeyOnePointMaxRetries, 2)
 	v.SetDefault(KeyImportAutoReconcileAfter, true)
+	v.SetDefault(KeyImportInsertBatchSize, storage.DefaultInsertBatchSize)
+	v.SetDefault(KeyImportStoreSources, false)
+	v.SetDefault(KeyImportEPMDayTotalCheck, true)
+	v.SetDefault(KeyImportEPMDayTotalTol, 1)
+	v.SetDefault(KeyImportEPMBreakThreshold, 0)
+	v.SetDefault(KeyImportEPMBreakMinutes, 0)
+	v.SetDefault(KeyImportFileDuplicateCheck, true)
+	v.SetDefault(KeyImportDetectMapper, true)
+	v.SetDefault(KeyReconcileSkipManualDays, false)
+	v.SetDefault(KeyReconcileFloatingMappers, []string{})
+	v.SetDefault(KeyReconcileWorkdayEnd, "")
+	v.SetDefault(KeySubmitVerifyPersist, true)
+	v.SetDefault(KeySubmitCommentSanitize, "strip")
+	v.SetDefault(KeySubmitDefaultRange, "all")
+	v.SetDefault(KeySubmitWebhookURL, "")
+	v.SetDefault(KeySubmitSkipUnchangedDays, false)
+	v.SetDefault(KeySubmitTicketPattern, "")
+	v.SetDefault(KeySubmitTicketTemplate, "{ticket} {comment}")
+	v.SetDefault(KeySubmitRequireTicket, false)
+	v.SetDefault(KeySubmitRetryOnConflict, true)
+	v.SetDefault(KeyWebMaxEntriesPerDay, 0)
+	v.SetDefault(KeyWebTagColors, map[string]string{})
+	v.SetDefault(KeyWebDailyTargetHours, 8)
+	v.SetDefault(KeyWebWorkdays, []string{"mon", "tue", "wed", "thu", "fri"})
+	v.SetDefault(KeyWebHideEmptyDays, false)
+	v.SetDefault(KeyWebDeleteLocalOnRemoteDelete, false)
+	v.SetDefault(KeyWebContractMonthlyHours, 0)
+	v.SetDefault(KeyWebSessionPingMinutes, 0)
+	v.SetDefault(KeyTimezone, "")
+	v.SetDefault(KeyActivityDefaultSkills, map[string]string{})
+	v.SetDefault(KeyDryRunByDefault, false)
+	v.SetDefault(KeyMaxDailyHours, 16)
+	v.SetDefault(KeyProfiles, map[string]any{})
 	v.SetDefault(KeyRules, []map[string]any{})
 }
 
+func validateProfileURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return errors.New("is required")
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("%q must be a full OnePoint home URL", raw)
+	}
+	return nil
+}
+
 func validateRules(rules []Rule) error {
 	validMappers := map[string]bool{
-		"epm":        true,
-		"generic":    true,
-		"atwork": true,
+		"epm":     true,
+		"generic": true,
+		"atwork":  true,
+		"toggl":   true,
 	}
 	seen := make(map[string]struct{}, len(rules))
 	for i, rule := range rules {
@@ -138,7 +760,7 @@ func validateRules(rules []Rule) error {
 		}
 		if !validMappers[mapper] {
 			return fmt.Errorf(
-				"validation failed: rules[%d].mapper %q is not supported (valid: epm, generic, atwork)",
+				"validation failed: rules[%d].mapper %q is not supported (valid: epm, generic, atwork, toggl)",
 				i,
 				rule.Mapper,
 			)
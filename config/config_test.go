@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateYAMLContent_RejectsUnsupportedMapper(t *testing.T) {
@@ -56,3 +57,644 @@ rules:
 		t.Fatalf("expected config to validate: %v", err)
 	}
 }
+
+func TestValidateYAMLContent_ProjectCodePattern(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+  project_code_pattern: "^([a-z]+[0-9]+)"
+rules: []
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	pattern := cfg.OnePoint.ProjectCodeRegexp()
+	if pattern == nil || pattern.FindStringSubmatch("bfa211102 - ISO RVSE9 Los2")[1] != "bfa211102" {
+		t.Fatalf("unexpected compiled pattern: %v", pattern)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+  project_code_pattern: "([a-z"
+rules: []
+`))
+	if err == nil || !strings.Contains(err.Error(), "project_code_pattern") {
+		t.Fatalf("expected invalid pattern error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_Timezone(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+timezone: "Europe/Berlin"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.TimeLocation == nil || cfg.Location() != cfg.TimeLocation || cfg.Location().String() != "Europe/Berlin" {
+		t.Fatalf("expected the zone resolved at load, got %v", cfg.TimeLocation)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+timezone: "Mars/Olympus"
+`))
+	if err == nil || !strings.Contains(err.Error(), "timezone") {
+		t.Fatalf("expected unknown timezone error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_ActivityDefaultSkills(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+activity_default_skills:
+  Development: "Go"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if got := cfg.DefaultSkillFor("development"); got != "Go" {
+		t.Fatalf("expected default skill Go, got %q", got)
+	}
+	if got := cfg.DefaultSkillFor("Support"); got != "" {
+		t.Fatalf("expected no default skill for an unmapped activity, got %q", got)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+activity_default_skills:
+  Development: ""
+`))
+	if err == nil || !strings.Contains(err.Error(), "activity_default_skills") {
+		t.Fatalf("expected empty default skill error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_OnePointSelectableProjectStatuses(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if len(cfg.OnePoint.SelectableProjectStatuses) != 1 || cfg.OnePoint.SelectableProjectStatuses[0] != 0 {
+		t.Fatalf("expected default active status [0], got %v", cfg.OnePoint.SelectableProjectStatuses)
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+  selectable_project_statuses: []
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if len(cfg.OnePoint.SelectableProjectStatuses) != 0 {
+		t.Fatalf("expected [] to disable status filtering, got %v", cfg.OnePoint.SelectableProjectStatuses)
+	}
+}
+
+func TestValidateYAMLContent_OnePointMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.OnePoint.MaxRetries != 2 {
+		t.Fatalf("expected default of 2 retries, got %d", cfg.OnePoint.MaxRetries)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+  max_retries: -1
+`))
+	if err == nil || !strings.Contains(err.Error(), "MaxRetries") {
+		t.Fatalf("expected negative retries error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_OnePointRequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+  requests_per_second: 2.5
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.OnePoint.RequestsPerSecond != 2.5 {
+		t.Fatalf("unexpected requests per second: %v", cfg.OnePoint.RequestsPerSecond)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+  requests_per_second: -1
+`))
+	if err == nil || !strings.Contains(err.Error(), "RequestsPerSecond") {
+		t.Fatalf("expected negative rate error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_ImportStoreSources(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Import.StoreSources {
+		t.Fatalf("expected store_sources to default to false")
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+import:
+  store_sources: true
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if !cfg.Import.StoreSources {
+		t.Fatalf("expected store_sources=true")
+	}
+}
+
+func TestValidateYAMLContent_ImportEPMDayTotalCheck(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if !cfg.Import.EPMDayTotalCheck || cfg.Import.EPMDayTotalToleranceMins != 1 {
+		t.Fatalf("unexpected day total defaults: %+v", cfg.Import)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+import:
+  epm_day_total_tolerance_minutes: -5
+`))
+	if err == nil || !strings.Contains(err.Error(), "EPMDayTotalToleranceMins") {
+		t.Fatalf("expected negative tolerance error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_ImportFileDuplicateCheck(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if !cfg.Import.FileDuplicateCheck {
+		t.Fatalf("expected file_duplicate_check to default to true")
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+import:
+  file_duplicate_check: false
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Import.FileDuplicateCheck {
+		t.Fatalf("expected file_duplicate_check to be read as false")
+	}
+}
+
+func TestValidateYAMLContent_ImportDetectMapper(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if !cfg.Import.DetectMapper {
+		t.Fatalf("expected detect_mapper to default to true")
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+import:
+  detect_mapper: false
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Import.DetectMapper {
+		t.Fatalf("expected detect_mapper to be read as false")
+	}
+}
+
+func TestValidateYAMLContent_Profiles(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+profiles:
+  Client-A:
+    url: "https://a.example.com/onepoint/faces/home"
+  client-b:
+    url: "https://b.example.com/onepoint/faces/home"
+    state_file: "/tmp/b.json"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	profile, err := cfg.Profile("client-a")
+	if err != nil || profile.URL != "https://a.example.com/onepoint/faces/home" || profile.StateFile != "" {
+		t.Fatalf("unexpected profile %+v, err %v", profile, err)
+	}
+	if profile, err := cfg.Profile("CLIENT-B"); err != nil || profile.StateFile != "/tmp/b.json" {
+		t.Fatalf("expected case-insensitive lookup, got %+v, err %v", profile, err)
+	}
+	if _, err := cfg.Profile("client-c"); err == nil || !strings.Contains(err.Error(), "available: client-a, client-b") {
+		t.Fatalf("expected unknown profile error listing profiles, got %v", err)
+	}
+
+	for name, content := range map[string]string{
+		"profiles[x].url":    "profiles:\n  x:\n    state_file: \"/tmp/x.json\"\n",
+		"profiles[y].url":    "profiles:\n  y:\n    url: \"onepoint\"\n",
+		"profiles[a b] name": "profiles:\n  \"a b\":\n    url: \"https://a.example.com/onepoint/faces/home\"\n",
+	} {
+		_, err := ValidateYAMLContent([]byte("onepoint:\n  url: \"https://onepoint.virtual7.io/onepoint/faces/home\"\n" + content))
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected %s error, got %v", name, err)
+		}
+	}
+}
+
+func TestValidateYAMLContent_SubmitRetryOnConflict(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if !cfg.Submit.RetryOnConflict {
+		t.Fatalf("expected retry_on_conflict to default to true")
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+submit:
+  retry_on_conflict: false
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Submit.RetryOnConflict {
+		t.Fatalf("expected retry_on_conflict to be read as false")
+	}
+}
+
+func TestValidateYAMLContent_MaxDailyHours(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.MaxDailyHours != 16 {
+		t.Fatalf("expected max_daily_hours to default to 16, got %g", cfg.MaxDailyHours)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+max_daily_hours: -1
+`))
+	if err == nil || !strings.Contains(err.Error(), "MaxDailyHours") {
+		t.Fatalf("expected negative max_daily_hours error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_ImportEPMBreakPolicy(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+import:
+  epm_break_threshold_minutes: 360
+  epm_break_minutes: 30
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Import.EPMBreakThresholdMins != 360 || cfg.Import.EPMBreakMinutes != 30 {
+		t.Fatalf("unexpected break policy: %+v", cfg.Import)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+import:
+  epm_break_minutes: -30
+`))
+	if err == nil || !strings.Contains(err.Error(), "EPMBreakMinutes") {
+		t.Fatalf("expected negative break error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_WebHideEmptyDays(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Web.HideEmptyDays {
+		t.Fatalf("expected empty days to be shown by default")
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  hide_empty_days: true
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if !cfg.Web.HideEmptyDays {
+		t.Fatalf("expected hide_empty_days to be read")
+	}
+}
+
+func TestValidateYAMLContent_WebDeleteLocalOnRemoteDelete(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Web.DeleteLocalOnRemoteDelete {
+		t.Fatalf("expected local entries to be kept by default")
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  delete_local_on_remote_delete: true
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if !cfg.Web.DeleteLocalOnRemoteDelete {
+		t.Fatalf("expected delete_local_on_remote_delete to be read")
+	}
+}
+
+func TestValidateYAMLContent_WebWorkdays(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  daily_target_hours: 7.5
+  workdays: ["Mon", "tuesday", "sat"]
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Web.DailyTargetHours != 7.5 {
+		t.Fatalf("unexpected daily target: %v", cfg.Web.DailyTargetHours)
+	}
+	if !cfg.Web.IsWorkday(time.Saturday) || cfg.Web.IsWorkday(time.Wednesday) {
+		t.Fatalf("unexpected workdays: %v", cfg.Web.Workdays)
+	}
+	if !(WebConfig{}).IsWorkday(time.Friday) || (WebConfig{}).IsWorkday(time.Sunday) {
+		t.Fatalf("expected empty workdays to mean Monday to Friday")
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  workdays: ["mo"]
+`))
+	if err == nil || !strings.Contains(err.Error(), "web.workdays[0]") {
+		t.Fatalf("expected invalid workday error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_ReconcileFloatingMappers(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+reconcile:
+  floating_mappers: ["Generic"]
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if len(cfg.Reconcile.FloatingMappers) != 1 || cfg.Reconcile.FloatingMappers[0] != "Generic" {
+		t.Fatalf("unexpected floating mappers: %v", cfg.Reconcile.FloatingMappers)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+reconcile:
+  floating_mappers: ["clockify"]
+`))
+	if err == nil || !strings.Contains(err.Error(), "reconcile.floating_mappers") {
+		t.Fatalf("expected unsupported floating mapper error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_ReconcileWorkdayEnd(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+reconcile:
+  workday_end: "18:30"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if got := cfg.Reconcile.WorkdayEndOffset(); got != 18*time.Hour+30*time.Minute {
+		t.Fatalf("unexpected workday end offset: %s", got)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+reconcile:
+  workday_end: "6pm"
+`))
+	if err == nil || !strings.Contains(err.Error(), "reconcile.workday_end") {
+		t.Fatalf("expected invalid workday end error, got %v", err)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+reconcile:
+  workday_end: "00:00"
+`))
+	if err == nil || !strings.Contains(err.Error(), "must be after 00:00") {
+		t.Fatalf("expected midnight workday end to be rejected, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_SubmitDefaultRange(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if got := cfg.Submit.DefaultRangeMode(); got != "all" {
+		t.Fatalf("expected default range all, got %q", got)
+	}
+
+	cfg, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+submit:
+  default_range: "Current-Month"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if got := cfg.Submit.DefaultRangeMode(); got != "current-month" {
+		t.Fatalf("expected current-month, got %q", got)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+submit:
+  default_range: "last-week"
+`))
+	if err == nil || !strings.Contains(err.Error(), "submit.default_range") {
+		t.Fatalf("expected unsupported default range error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_SubmitTicketPattern(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+submit:
+  ticket_pattern: "[A-Z]+-[0-9]+"
+  require_ticket: true
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if re := cfg.Submit.TicketRegexp(); re == nil || !re.MatchString("did jira-123 stuff") {
+		t.Fatalf("expected case-insensitive ticket regexp, got %v", re)
+	}
+
+	for name, content := range map[string]string{
+		"submit.ticket_pattern":  "  ticket_pattern: \"[A-Z\"\n",
+		"submit.ticket_template": "  ticket_pattern: \"[A-Z]+-[0-9]+\"\n  ticket_template: \"{comment}\"\n",
+		"submit.require_ticket":  "  require_ticket: true\n",
+	} {
+		_, err := ValidateYAMLContent([]byte("onepoint:\n  url: \"https://onepoint.virtual7.io/onepoint/faces/home\"\nsubmit:\n" + content))
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected %s error, got %v", name, err)
+		}
+	}
+}
+
+func TestValidateYAMLContent_SubmitWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+submit:
+  webhook_url: "https://hooks.example.com/gohour"
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Submit.WebhookURL != "https://hooks.example.com/gohour" {
+		t.Fatalf("unexpected webhook url: %q", cfg.Submit.WebhookURL)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+submit:
+  webhook_url: "not a url"
+`))
+	if err == nil || !strings.Contains(err.Error(), "WebhookURL") {
+		t.Fatalf("expected invalid webhook url error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_WebSessionPingMinutes(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  session_ping_minutes: 5
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Web.SessionPingMinutes != 5 {
+		t.Fatalf("expected session_ping_minutes to be read, got %d", cfg.Web.SessionPingMinutes)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  session_ping_minutes: -1
+`))
+	if err == nil || !strings.Contains(err.Error(), "SessionPingMinutes") {
+		t.Fatalf("expected negative session ping error, got %v", err)
+	}
+}
+
+func TestValidateYAMLContent_WebContractMonthlyHours(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  contract_monthly_hours: 160
+`))
+	if err != nil {
+		t.Fatalf("expected config to validate: %v", err)
+	}
+	if cfg.Web.ContractMonthlyHours != 160 {
+		t.Fatalf("expected contract_monthly_hours to be read, got %g", cfg.Web.ContractMonthlyHours)
+	}
+
+	_, err = ValidateYAMLContent([]byte(`onepoint:
+  url: "https://onepoint.virtual7.io/onepoint/faces/home"
+web:
+  contract_monthly_hours: -1
+`))
+	if err == nil || !strings.Contains(err.Error(), "ContractMonthlyHours") {
+		t.Fatalf("expected negative contract hours error, got %v", err)
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"os"
 )
 
-type CSVReader struct{}
+type CSVReader struct {
+	// RequiredHeaders lists header alias groups that must be present.
+	RequiredHeaders [][]string
+}
 
 func (r *CSVReader) Read(path string) ([]Record, error) {
 	file, err := os.Open(path)
@@ -28,6 +31,9 @@ func (r *CSVReader) Read(path string) ([]Record, error) {
 	for i, header := range headers {
 		normalizedHeaders[i] = normalizeHeader(header)
 	}
+	if err := checkRequiredHeaders(path, normalizedHeaders, r.RequiredHeaders); err != nil {
+		return nil, err
+	}
 
 	records := make([]Record, 0, 128)
 	rowNumber := 1
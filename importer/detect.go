@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// DetectMapper returns the mapper recognized from the header row of the file
+// at path, or "" when the file cannot be read or its columns match no mapper.
+// format may be empty to infer it from the extension.
+func DetectMapper(path, format string) string {
+	for _, headers := range readHeaderCandidates(path, format) {
+		if mapper := DetectMapperFromHeaders(headers); mapper != "" {
+			return mapper
+		}
+	}
+	return ""
+}
+
+// DetectMapperFromHeaders returns the mapper whose characteristic columns all
+// appear in headers: Beginn/Ende/Dauer for atwork, Start date/Start time/End
+// date/End time for toggl, Datum/Von/Bis with Tagessumme or Stunden for epm,
+// and a description with start and end columns for generic. EPM is checked
+// before generic because both accept Von/Bis. It returns "" otherwise.
+func DetectMapperFromHeaders(headers []string) string {
+	present := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		present[normalizeHeader(header)] = true
+	}
+	has := func(aliases ...string) bool {
+		for _, alias := range aliases {
+			if present[normalizeHeader(alias)] {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case has("Beginn") && has("Ende") && has("Dauer"):
+		return "atwork"
+	case has("Start date") && has("Start time") && has("End date") && has("End time"):
+		return "toggl"
+	case has("Datum") && has("Von") && has("Bis") && has("Tagessumme", "Stunden"):
+		return "epm"
+	case has("description", "beschreibung") && has(genericStartHeaders...) && has(genericEndHeaders...):
+		return "generic"
+	default:
+		return ""
+	}
+}
+
+// readHeaderCandidates returns the rows that may hold the column headers:
+// the first row of a CSV or Excel file, and the first two rows of a UTF-16
+// file because atwork exports start with a section title.
+func readHeaderCandidates(path, format string) [][]string {
+	sourceFormat, err := inferFormat(path, format)
+	if err != nil {
+		return nil
+	}
+	if sourceFormat == "excel" {
+		return readExcelHeaderRow(path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	buffered := bufio.NewReader(file)
+	bom, _ := buffered.Peek(2)
+	var source io.Reader = buffered
+	rows := 1
+	if bytes.Equal(bom, []byte{0xFF, 0xFE}) || bytes.Equal(bom, []byte{0xFE, 0xFF}) {
+		source = transform.NewReader(buffered, unicode.BOMOverride(unicode.UTF8.NewDecoder()))
+		rows = 2
+	}
+
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if rows == 2 {
+		reader.Comma = '\t'
+	}
+	candidates := make([][]string, 0, rows)
+	for range rows {
+		row, err := reader.Read()
+		if err != nil {
+			break
+		}
+		candidates = append(candidates, row)
+	}
+	return candidates
+}
+
+func readExcelHeaderRow(path string) [][]string {
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	rows, err := file.Rows(file.GetSheetName(0))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil
+	}
+	headers, err := rows.Columns()
+	if err != nil {
+		return nil
+	}
+	return [][]string{headers}
+}
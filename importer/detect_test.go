@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectMapperFromHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    string
+	}{
+		{
+			name:    "epm",
+			headers: []string{"Datum", "Von", "Bis", "Stunden", "Durchgeführte Arbeiten", "Tagessumme"},
+			want:    "epm",
+		},
+		{
+			name:    "atwork",
+			headers: []string{"#", "Beginn", "Ende", "Dauer", "Kunde", "Projekt", "Aufgabe", "Notiz"},
+			want:    "atwork",
+		},
+		{
+			name:    "toggl",
+			headers: []string{"Description", "Start date", "Start time", "End date", "End time", "Duration"},
+			want:    "toggl",
+		},
+		{
+			name:    "generic",
+			headers: []string{"startdatetime", "enddatetime", "billable", "description", "project", "activity", "skill"},
+			want:    "generic",
+		},
+		{
+			name:    "generic with von/bis and no day total",
+			headers: []string{" Von ", "Bis", "Beschreibung"},
+			want:    "generic",
+		},
+		{
+			name:    "unknown",
+			headers: []string{"Date", "Hours", "Comment"},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectMapperFromHeaders(tt.headers); got != tt.want {
+				t.Fatalf("DetectMapperFromHeaders(%q) = %q, want %q", tt.headers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectMapper_ReadsHeaderRowOfFile(t *testing.T) {
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "export.csv")
+	if err := os.WriteFile(csvPath, []byte("startdatetime,enddatetime,billable,description\n2026-03-05 09:00,2026-03-05 10:00,60,Review\n"), 0o644); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if got := DetectMapper(csvPath, ""); got != "generic" {
+		t.Fatalf("csv: expected generic, got %q", got)
+	}
+
+	atworkPath := writeUTF16LEFile(t, dir, "atwork.csv",
+		"Einträge\n"+
+			"#\tBeginn\tEnde\tDauer\tKunde\tProjekt\tAufgabe\tNotiz\n"+
+			"1\t03.03.2026 08:30\t03.03.2026 10:00\t1:30\tACME\tPortal\tDev\tReview\n")
+	if got := DetectMapper(atworkPath, ""); got != "atwork" {
+		t.Fatalf("atwork: expected atwork, got %q", got)
+	}
+
+	excelPath := writeTestWorkbook(t, [][]any{
+		{"Datum", "Von", "Bis", "Stunden", "Durchgeführte Arbeiten"},
+		{"05.03.2026", "09:00", "17:00", "7,5", "Review"},
+	})
+	if got := DetectMapper(excelPath, ""); got != "epm" {
+		t.Fatalf("excel: expected epm, got %q", got)
+	}
+
+	if got := DetectMapper(filepath.Join(dir, "missing.csv"), ""); got != "" {
+		t.Fatalf("missing file: expected no mapper, got %q", got)
+	}
+}
@@ -6,7 +6,12 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
-type ExcelReader struct{}
+// ExcelReader reads the first sheet; the first row holds the headers.
+// Blank rows are skipped.
+type ExcelReader struct {
+	// RequiredHeaders lists header alias groups that must be present.
+	RequiredHeaders [][]string
+}
 
 func (r *ExcelReader) Read(path string) ([]Record, error) {
 	file, err := excelize.OpenFile(path)
@@ -33,9 +38,15 @@ func (r *ExcelReader) Read(path string) ([]Record, error) {
 	for i, header := range headers {
 		normalizedHeaders[i] = normalizeHeader(header)
 	}
+	if err := checkRequiredHeaders(path, normalizedHeaders, r.RequiredHeaders); err != nil {
+		return nil, err
+	}
 
 	records := make([]Record, 0, len(rows)-1)
 	for i, row := range rows[1:] {
+		if isBlankRow(row) {
+			continue
+		}
 		values := make(map[string]string, len(normalizedHeaders))
 		for col := range normalizedHeaders {
 			if col < len(row) {
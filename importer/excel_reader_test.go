@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeTestWorkbook(t *testing.T, rows [][]any) string {
+	t.Helper()
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			t.Fatalf("cell name: %v", err)
+		}
+		if err := file.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatalf("set row %d: %v", i+1, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "generic.xlsx")
+	if err := file.SaveAs(path); err != nil {
+		t.Fatalf("save workbook: %v", err)
+	}
+	return path
+}
+
+func TestRun_GenericMapperReadsExcel(t *testing.T) {
+	t.Parallel()
+
+	path := writeTestWorkbook(t, [][]any{
+		{"StartDateTime", "EndDateTime", "Billable", "Description", "Project", "Activity", "Skill"},
+		{"2026-03-05 09:00", "2026-03-05 10:30", "90", "Workshop", "Project A", "Delivery", "Go"},
+		{" ", "", "", "", "", "", ""},
+		{"", "", "", "", "", "", ""},
+	})
+
+	result, err := Run([]string{path}, "", &GenericMapper{}, config.Config{}, RunOptions{})
+	if err != nil {
+		t.Fatalf("run import: %v", err)
+	}
+	if result.RowsRead != 1 || len(result.Entries) != 1 {
+		t.Fatalf("expected 1 read and mapped row, got read=%d mapped=%d", result.RowsRead, len(result.Entries))
+	}
+
+	entry := result.Entries[0]
+	wantStart := time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local)
+	if !entry.StartDateTime.Equal(wantStart) || entry.Billable != 90 {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if entry.Project != "Project A" || entry.Activity != "Delivery" || entry.Skill != "Go" || entry.SourceFormat != "excel" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestRun_GenericMapperExcelMissingHeaderNamesFile(t *testing.T) {
+	t.Parallel()
+
+	path := writeTestWorkbook(t, [][]any{
+		{"StartDateTime", "Description"},
+		{"2026-03-05 09:00", "Workshop"},
+	})
+
+	_, err := Run([]string{path}, "", &GenericMapper{}, config.Config{}, RunOptions{})
+	if err == nil {
+		t.Fatalf("expected missing header error")
+	}
+	if !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "enddatetime") {
+		t.Fatalf("expected error naming file and column, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/riadshalaby/gohour/config"
 	"github.com/riadshalaby/gohour/worklog"
+	"path/filepath"
 )
 
 type Mapper interface {
@@ -11,8 +12,50 @@ type Mapper interface {
 	Map(record Record, cfg config.Config, sourceFormat, sourceFile string) (*worklog.Entry, bool, error)
 }
 
+// DayTotalMismatch is a source day whose mapped entries do not add up to the
+// day total declared in the file (EPM Tagessumme).
+type DayTotalMismatch struct {
+	SourceFile      string `json:"sourceFile"`
+	Day             string `json:"day"`
+	ExpectedMinutes int    `json:"expectedMinutes"`
+	MappedMinutes   int    `json:"mappedMinutes"`
+}
+
+func (d DayTotalMismatch) String() string {
+	return fmt.Sprintf(
+		"%s %s: entries sum to %d min, day total declares %d min",
+		filepath.Base(d.SourceFile),
+		d.Day,
+		d.MappedMinutes,
+		d.ExpectedMinutes,
+	)
+}
+
+// FileDuplicate is a source row that maps to the same entry as an earlier
+// row of the same file. The database keeps only one of them.
+type FileDuplicate struct {
+	SourceFile     string `json:"sourceFile"`
+	Row            int    `json:"row"`
+	DuplicateOfRow int    `json:"duplicateOfRow"`
+}
+
+func (d FileDuplicate) String() string {
+	return fmt.Sprintf(
+		"%s row %d duplicates row %d and is stored only once",
+		filepath.Base(d.SourceFile),
+		d.Row,
+		d.DuplicateOfRow,
+	)
+}
+
+// dayTotalChecker is implemented by mappers that know a declared total per
+// source day.
+type dayTotalChecker interface {
+	DayTotalMismatches(toleranceMins int) []DayTotalMismatch
+}
+
 func SupportedMapperNames() []string {
-	return []string{"epm", "generic", "atwork"}
+	return []string{"epm", "generic", "atwork", "toggl"}
 }
 
 func MapperByName(name string) (Mapper, error) {
@@ -23,6 +66,8 @@ func MapperByName(name string) (Mapper, error) {
 		return &GenericMapper{}, nil
 	case "atwork":
 		return &ATWorkMapper{}, nil
+	case "toggl":
+		return &TogglMapper{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported mapper: %s", name)
 	}
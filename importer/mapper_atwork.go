@@ -22,12 +22,12 @@ func (m *ATWorkMapper) Map(record Record, cfg config.Config, sourceFormat, sourc
 	endRaw := record.Get("Ende", "ende", "end")
 	durationRaw := record.Get("Dauer", "dauer", "duration")
 
-	start, err := parseDateTime(startRaw)
+	start, err := parseDateTime(startRaw, cfg.Location())
 	if err != nil {
 		return nil, false, fmt.Errorf("row %d: parse start datetime: %w", record.RowNumber, err)
 	}
 
-	end, err := parseDateTime(endRaw)
+	end, err := parseDateTime(endRaw, cfg.Location())
 	if err != nil {
 		return nil, false, fmt.Errorf("row %d: parse end datetime: %w", record.RowNumber, err)
 	}
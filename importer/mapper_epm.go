@@ -12,11 +12,14 @@ import (
 
 type EPMMapper struct {
 	dayStateByKey    map[string]*epmDayState
+	dayKeys          []string
 	sourceRunByFile  map[string]int
 	sourceSeenByFile map[string]bool
 }
 
 type epmDayState struct {
+	sourceFile           string
+	day                  string
 	dayStart             time.Time
 	dayEndOriginal       time.Time
 	previousEnd          time.Time
@@ -43,10 +46,12 @@ func (m *EPMMapper) Map(record Record, cfg config.Config, sourceFormat, sourceFi
 	}
 	dayKey := m.buildDayKey(sourceFile, run, dayValue)
 
-	state, err := m.ensureDayState(dayKey, record)
+	state, err := m.ensureDayState(dayKey, record, cfg)
 	if err != nil {
 		return nil, false, fmt.Errorf("row %d: %w", record.RowNumber, err)
 	}
+	state.sourceFile = sourceFile
+	state.day = normalizeDayKey(dayValue)
 
 	if description == "" {
 		return nil, false, nil
@@ -128,16 +133,17 @@ func normalizeDayKey(day string) string {
 	return strings.TrimSpace(day)
 }
 
-func (m *EPMMapper) ensureDayState(dayKey string, record Record) (*epmDayState, error) {
+func (m *EPMMapper) ensureDayState(dayKey string, record Record, cfg config.Config) (*epmDayState, error) {
 	state, ok := m.dayStateByKey[dayKey]
 	if !ok {
 		state = &epmDayState{}
 		m.dayStateByKey[dayKey] = state
+		m.dayKeys = append(m.dayKeys, dayKey)
 	}
 
 	date := record.Get("Datum", "date")
-	startParsed, startErr := parseDateAndTime(date, record.Get("Von", "start", "starttime"))
-	endParsed, endErr := parseDateAndTime(date, record.Get("Bis", "end", "endtime"))
+	startParsed, startErr := parseDateAndTime(date, record.Get("Von", "start", "starttime"), cfg.Location())
+	endParsed, endErr := parseDateAndTime(date, record.Get("Bis", "end", "endtime"), cfg.Location())
 	if startErr != nil || endErr != nil {
 		if state.dayStart.IsZero() || state.dayEndOriginal.IsZero() {
 			if startErr != nil {
@@ -172,14 +178,50 @@ func (m *EPMMapper) ensureDayState(dayKey string, record Record) (*epmDayState,
 		}
 		if expectedBillableMins > 0 {
 			state.expectedBillableMins = expectedBillableMins
-			state.breakMins = m.computeBreakMinutes(state.dayStart, state.dayEndOriginal, state.expectedBillableMins)
+			state.breakMins = m.computeBreakMinutes(state.dayStart, state.dayEndOriginal, state.expectedBillableMins, cfg.Import)
 		}
 	}
 
 	return state, nil
 }
 
-func (m *EPMMapper) computeBreakMinutes(dayStart, dayEnd time.Time, expectedBillableMins int) int {
+// DayTotalMismatches returns the days with a declared Tagessumme whose mapped
+// entries add up to more than toleranceMins more or less, in source order.
+func (m *EPMMapper) DayTotalMismatches(toleranceMins int) []DayTotalMismatch {
+	var mismatches []DayTotalMismatch
+	for _, key := range m.dayKeys {
+		state := m.dayStateByKey[key]
+		if state.expectedBillableMins <= 0 {
+			continue
+		}
+		diff := state.consumedBillableMins - state.expectedBillableMins
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= toleranceMins {
+			continue
+		}
+		mismatches = append(mismatches, DayTotalMismatch{
+			SourceFile:      state.sourceFile,
+			Day:             state.day,
+			ExpectedMinutes: state.expectedBillableMins,
+			MappedMinutes:   state.consumedBillableMins,
+		})
+	}
+	return mismatches
+}
+
+// computeBreakMinutes returns the break inserted into a day. With
+// import.epm_break_minutes set, it is that fixed break on days whose billable
+// total exceeds import.epm_break_threshold_minutes; otherwise it is the part of
+// the Von/Bis span not covered by the billable total.
+func (m *EPMMapper) computeBreakMinutes(dayStart, dayEnd time.Time, expectedBillableMins int, importCfg config.ImportConfig) int {
+	if importCfg.EPMBreakMinutes > 0 {
+		if expectedBillableMins > importCfg.EPMBreakThresholdMins {
+			return importCfg.EPMBreakMinutes
+		}
+		return 0
+	}
 	spanMins := int(dayEnd.Sub(dayStart).Minutes())
 	if spanMins <= 0 {
 		return 0
@@ -33,7 +33,7 @@ func TestEPMMapper_SequentiallyAssignsTimesWithinDay(t *testing.T) {
 	entryC, ok, err := mapper.Map(records[3], cfg, "excel", "source.xlsx")
 	assertMapped(t, ok, err)
 
-	dayStart, _ := parseDateAndTime("05.01.2026", "08:00 AM")
+	dayStart, _ := parseDateAndTime("05.01.2026", "08:00 AM", time.Local)
 	assertTime(t, dayStart, entryA.StartDateTime, "entryA start")
 	assertTime(t, dayStart.Add(2*time.Hour), entryA.EndDateTime, "entryA end")
 
@@ -66,6 +66,63 @@ func TestEPMMapper_InsertsMiddayBreakBasedOnOriginalDayEnd(t *testing.T) {
 	assertTime(t, mustParseDateTime(t, "05.01.2026", "05:00 PM"), entryB.EndDateTime, "entryB end against original day end")
 }
 
+func TestEPMMapper_BreakPolicyUsesThreshold(t *testing.T) {
+	tests := []struct {
+		name          string
+		dayTotal      string
+		secondHours   string
+		wantBreakMins int
+	}{
+		{name: "exactly at threshold", dayTotal: "6,00", secondHours: "3,00", wantBreakMins: 0},
+		{name: "one minute above threshold", dayTotal: "6,0167", secondHours: "3,0167", wantBreakMins: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper := &EPMMapper{}
+			cfg := baseConfig()
+			cfg.Import.EPMBreakThresholdMins = 360
+			cfg.Import.EPMBreakMinutes = 30
+
+			// The Von/Bis span would imply a 3-hour break without the policy.
+			records := []Record{
+				newEPMRecord(2, "05.01.2026", "08:00 AM", "05:00 PM", tt.dayTotal, "", ""),
+				newEPMRecord(3, "05.01.2026", "08:00 AM", "05:00 PM", "", "3,00", "Task A"),
+				newEPMRecord(4, "05.01.2026", "08:00 AM", "05:00 PM", "", tt.secondHours, "Task B"),
+			}
+
+			_, _, _ = mapper.Map(records[0], cfg, "excel", "source.xlsx")
+			entryA, ok, err := mapper.Map(records[1], cfg, "excel", "source.xlsx")
+			assertMapped(t, ok, err)
+			entryB, ok, err := mapper.Map(records[2], cfg, "excel", "source.xlsx")
+			assertMapped(t, ok, err)
+
+			wantStart := entryA.EndDateTime.Add(time.Duration(tt.wantBreakMins) * time.Minute)
+			assertTime(t, wantStart, entryB.StartDateTime, "entryB start")
+		})
+	}
+}
+
+func TestEPMMapper_BreakPolicyUnsetKeepsSpanDerivedBreak(t *testing.T) {
+	mapper := &EPMMapper{}
+	cfg := baseConfig()
+	cfg.Import.EPMBreakThresholdMins = 360
+
+	records := []Record{
+		newEPMRecord(2, "05.01.2026", "08:00 AM", "05:00 PM", "6,00", "", ""),
+		newEPMRecord(3, "05.01.2026", "08:00 AM", "05:00 PM", "", "3,00", "Task A"),
+		newEPMRecord(4, "05.01.2026", "08:00 AM", "05:00 PM", "", "3,00", "Task B"),
+	}
+
+	_, _, _ = mapper.Map(records[0], cfg, "excel", "source.xlsx")
+	_, ok, err := mapper.Map(records[1], cfg, "excel", "source.xlsx")
+	assertMapped(t, ok, err)
+	entryB, ok, err := mapper.Map(records[2], cfg, "excel", "source.xlsx")
+	assertMapped(t, ok, err)
+
+	assertTime(t, mustParseDateTime(t, "05.01.2026", "02:00 PM"), entryB.StartDateTime, "entryB start after span-derived break")
+}
+
 func TestEPMMapper_PauseInsertedAtNearestBoundaryToMiddle(t *testing.T) {
 	mapper := &EPMMapper{}
 	cfg := baseConfig()
@@ -200,7 +257,7 @@ func baseConfig() config.Config {
 
 func mustParseDateTime(t *testing.T, date, clock string) time.Time {
 	t.Helper()
-	parsed, err := parseDateAndTime(date, clock)
+	parsed, err := parseDateAndTime(date, clock, time.Local)
 	if err != nil {
 		t.Fatalf("parse datetime %q %q: %v", date, clock, err)
 	}
@@ -223,3 +280,39 @@ func assertTime(t *testing.T, expected, actual time.Time, field string) {
 		t.Fatalf("unexpected %s: expected %s, got %s", field, expected.Format(time.RFC3339), actual.Format(time.RFC3339))
 	}
 }
+
+func TestEPMMapper_ReportsDayWhoseEntriesMissTagessumme(t *testing.T) {
+	mapper := &EPMMapper{}
+	cfg := baseConfig()
+
+	records := []Record{
+		newEPMRecord(2, "05.01.2026", "08:00 AM", "05:00 PM", "8,00", "", ""),
+		newEPMRecord(3, "05.01.2026", "08:00 AM", "05:00 PM", "", "4,00", "Task A"),
+		// Hours lost in the export: the row is skipped, so the day falls short.
+		newEPMRecord(4, "05.01.2026", "08:00 AM", "05:00 PM", "", "", "Task B"),
+		newEPMRecord(5, "05.01.2026", "08:00 AM", "05:00 PM", "", "3,00", "Task C"),
+		newEPMRecord(6, "06.01.2026", "08:00 AM", "05:00 PM", "8,00", "", ""),
+		newEPMRecord(7, "06.01.2026", "08:00 AM", "05:00 PM", "", "8,00", "Task D"),
+	}
+	for _, record := range records {
+		if _, _, err := mapper.Map(record, cfg, "excel", "source.xlsx"); err != nil {
+			t.Fatalf("map row %d: %v", record.RowNumber, err)
+		}
+	}
+
+	mismatches := mapper.DayTotalMismatches(1)
+	if len(mismatches) != 1 {
+		t.Fatalf("expected one mismatching day, got %+v", mismatches)
+	}
+	got := mismatches[0]
+	if got.Day != "05.01.2026" || got.ExpectedMinutes != 480 || got.MappedMinutes != 420 || got.SourceFile != "source.xlsx" {
+		t.Fatalf("unexpected mismatch: %+v", got)
+	}
+	if !strings.Contains(got.String(), "entries sum to 420 min, day total declares 480 min") {
+		t.Fatalf("unexpected mismatch message: %s", got)
+	}
+
+	if tolerant := mapper.DayTotalMismatches(60); len(tolerant) != 0 {
+		t.Fatalf("expected a 60 minute tolerance to accept the day, got %+v", tolerant)
+	}
+}
@@ -10,22 +10,28 @@ import (
 
 type GenericMapper struct{}
 
+// Header aliases accepted for the generic start/end columns.
+var (
+	genericStartHeaders = []string{"startdatetime", "start", "von"}
+	genericEndHeaders   = []string{"enddatetime", "end", "bis"}
+)
+
 func (m *GenericMapper) Name() string {
 	return "generic"
 }
 
-func (m *GenericMapper) Map(record Record, _ config.Config, sourceFormat, sourceFile string) (*worklog.Entry, bool, error) {
+func (m *GenericMapper) Map(record Record, cfg config.Config, sourceFormat, sourceFile string) (*worklog.Entry, bool, error) {
 	description := strings.TrimSpace(record.Get("description", "beschreibung"))
 	if description == "" {
 		return nil, false, nil
 	}
 
-	start, err := parseDateTime(record.Get("startdatetime", "start", "von"))
+	start, err := parseDateTime(record.Get(genericStartHeaders...), cfg.Location())
 	if err != nil {
 		return nil, false, fmt.Errorf("row %d: parse start datetime: %w", record.RowNumber, err)
 	}
 
-	end, err := parseDateTime(record.Get("enddatetime", "end", "bis"))
+	end, err := parseDateTime(record.Get(genericEndHeaders...), cfg.Location())
 	if err != nil {
 		return nil, false, fmt.Errorf("row %d: parse end datetime: %w", record.RowNumber, err)
 	}
@@ -46,14 +52,20 @@ func (m *GenericMapper) Map(record Record, _ config.Config, sourceFormat, source
 		}
 	}
 
+	activity := fallback(record.Get("activity", "aktivitaet", "aktivität"), "")
+	skill := fallback(record.Get("skill"), "")
+	if skill == "" {
+		skill = cfg.DefaultSkillFor(activity)
+	}
+
 	entry := &worklog.Entry{
 		StartDateTime: start,
 		EndDateTime:   end,
 		Billable:      billable,
 		Description:   description,
 		Project:       fallback(record.Get("project", "projekt"), ""),
-		Activity:      fallback(record.Get("activity", "aktivitaet", "aktivität"), ""),
-		Skill:         fallback(record.Get("skill"), ""),
+		Activity:      activity,
+		Skill:         skill,
 		SourceFormat:  sourceFormat,
 		SourceFile:    sourceFile,
 	}
@@ -2,6 +2,7 @@ package importer
 
 import (
 	"github.com/riadshalaby/gohour/config"
+	"strings"
 	"testing"
 	"time"
 )
@@ -37,6 +38,39 @@ func TestGenericMapper_BillableOverrideUsesMinutes(t *testing.T) {
 	}
 }
 
+func TestGenericMapper_EmptySkillUsesActivityDefault(t *testing.T) {
+	t.Parallel()
+
+	mapper := &GenericMapper{}
+	cfg := config.Config{ActivityDefaultSkills: map[string]string{"development": "Go"}}
+	record := Record{
+		RowNumber: 2,
+		Values: map[string]string{
+			normalizeHeader("description"):   "Task",
+			normalizeHeader("startdatetime"): "2026-03-05 09:00",
+			normalizeHeader("enddatetime"):   "2026-03-05 10:00",
+			normalizeHeader("activity"):      "Development",
+		},
+	}
+
+	entry, ok, err := mapper.Map(record, cfg, "csv", "source.csv")
+	if err != nil || !ok {
+		t.Fatalf("map record: ok=%t err=%v", ok, err)
+	}
+	if entry.Skill != "Go" {
+		t.Fatalf("expected default skill Go, got %q", entry.Skill)
+	}
+
+	record.Values[normalizeHeader("skill")] = "Python"
+	entry, _, err = mapper.Map(record, cfg, "csv", "source.csv")
+	if err != nil {
+		t.Fatalf("map record: %v", err)
+	}
+	if entry.Skill != "Python" {
+		t.Fatalf("expected the row skill to win, got %q", entry.Skill)
+	}
+}
+
 func TestGenericMapper_BillableOverrideParsesDecimalMinutes(t *testing.T) {
 	t.Parallel()
 
@@ -63,3 +97,66 @@ func TestGenericMapper_BillableOverrideParsesDecimalMinutes(t *testing.T) {
 		t.Fatalf("unexpected rounded billable: want 8, got %d", entry.Billable)
 	}
 }
+
+func TestGenericMapper_AcceptsEachDateTimeLayout(t *testing.T) {
+	t.Parallel()
+
+	mapper := &GenericMapper{}
+	tests := []struct {
+		name  string
+		start string
+		end   string
+	}{
+		{name: "space separated", start: "2026-03-05 09:00", end: "2026-03-05 10:30"},
+		{name: "rfc3339", start: "2026-03-05T09:00:00+01:00", end: "2026-03-05T10:30:00+01:00"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			record := Record{
+				RowNumber: 2,
+				Values: map[string]string{
+					normalizeHeader("description"):   "Task",
+					normalizeHeader("startdatetime"): tc.start,
+					normalizeHeader("enddatetime"):   tc.end,
+				},
+			}
+			entry, ok, err := mapper.Map(record, config.Config{}, "csv", "source.csv")
+			if err != nil {
+				t.Fatalf("map record: %v", err)
+			}
+			if !ok {
+				t.Fatalf("expected mapped entry")
+			}
+			if entry.Billable != 90 {
+				t.Fatalf("unexpected billable: want 90, got %d", entry.Billable)
+			}
+		})
+	}
+}
+
+func TestGenericMapper_MalformedDateTimeNamesRowAndValue(t *testing.T) {
+	t.Parallel()
+
+	mapper := &GenericMapper{}
+	record := Record{
+		RowNumber: 7,
+		Values: map[string]string{
+			normalizeHeader("description"):   "Task",
+			normalizeHeader("startdatetime"): "2026-03-05 09:00",
+			normalizeHeader("enddatetime"):   "05/03/2026 10:30",
+		},
+	}
+
+	_, _, err := mapper.Map(record, config.Config{}, "csv", "source.csv")
+	if err == nil {
+		t.Fatalf("expected malformed datetime error")
+	}
+	for _, want := range []string{"row 7", "parse end datetime", `"05/03/2026 10:30"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}
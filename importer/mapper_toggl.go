@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"fmt"
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/worklog"
+	"strconv"
+	"strings"
+)
+
+// TogglMapper maps rows of a Toggl Track "detailed report" CSV export.
+// It is stateless. Project, Activity and Skill are taken from the resolved
+// rule config (like the atwork mapper); the Toggl project is folded into the
+// description as context.
+type TogglMapper struct{}
+
+func (m *TogglMapper) Name() string {
+	return "toggl"
+}
+
+func (m *TogglMapper) Map(record Record, cfg config.Config, sourceFormat, sourceFile string) (*worklog.Entry, bool, error) {
+	start, err := parseDateAndTime(record.Get("Start date"), record.Get("Start time"), cfg.Location())
+	if err != nil {
+		return nil, false, fmt.Errorf("row %d: parse start datetime: %w", record.RowNumber, err)
+	}
+
+	end, err := parseDateAndTime(record.Get("End date"), record.Get("End time"), cfg.Location())
+	if err != nil {
+		return nil, false, fmt.Errorf("row %d: parse end datetime: %w", record.RowNumber, err)
+	}
+
+	if !end.After(start) {
+		return nil, false, fmt.Errorf("row %d: end datetime must be after start datetime", record.RowNumber)
+	}
+
+	billable, err := parseClockDurationToMinutes(record.Get("Duration"))
+	if err != nil {
+		return nil, false, fmt.Errorf("row %d: parse duration: %w", record.RowNumber, err)
+	}
+	if billable <= 0 {
+		return nil, false, nil // skip zero-duration rows
+	}
+
+	entry := &worklog.Entry{
+		StartDateTime: start,
+		EndDateTime:   end,
+		Billable:      billable,
+		Description:   buildTogglDescription(record.Get("Description"), record.Get("Project")),
+		Project:       cfg.ImportProject,
+		Activity:      cfg.ImportActivity,
+		Skill:         cfg.ImportSkill,
+		SourceFormat:  sourceFormat,
+		SourceFile:    sourceFile,
+	}
+
+	return entry, true, nil
+}
+
+// buildTogglDescription prefixes the Toggl project as context: "[Project] Description".
+// Without a description, the project name alone is used.
+func buildTogglDescription(description, project string) string {
+	description = strings.TrimSpace(description)
+	project = strings.TrimSpace(project)
+
+	switch {
+	case description == "":
+		return project
+	case project == "":
+		return description
+	default:
+		return "[" + project + "] " + description
+	}
+}
+
+// parseClockDurationToMinutes parses Toggl's "HH:MM:SS" (or "HH:MM") duration
+// into whole minutes, rounding seconds to the nearest minute.
+func parseClockDurationToMinutes(raw string) (int, error) {
+	cleaned := strings.TrimSpace(raw)
+	if cleaned == "" {
+		return 0, nil
+	}
+
+	parts := strings.Split(cleaned, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("unsupported duration format: %q", raw)
+	}
+
+	values := make([]int, 3)
+	for i, part := range parts {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("unsupported duration format: %q", raw)
+		}
+		values[i] = value
+	}
+
+	minutes := values[0]*60 + values[1]
+	if values[2] >= 30 {
+		minutes++
+	}
+	return minutes, nil
+}
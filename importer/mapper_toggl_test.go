@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTogglMapper_ImportsTwoRowCSV(t *testing.T) {
+	t.Parallel()
+
+	content := "User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags\n" +
+		"Jane,jane@example.com,ACME,Website,,Fix login bug,Yes,2026-03-03,08:30:00,2026-03-03,10:00:00,01:30:00,\n" +
+		"Jane,jane@example.com,ACME,Website,,,Yes,2026-03-03,10:15:00,2026-03-03,10:59:40,00:44:40,\n"
+	path := filepath.Join(t.TempDir(), "toggl-2026-03.csv")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	mapper, err := MapperByName("toggl")
+	if err != nil {
+		t.Fatalf("mapper by name: %v", err)
+	}
+	result, err := Run([]string{path}, "", mapper, atworkConfig(), RunOptions{
+		EPMProject:  "test-project",
+		EPMActivity: "test-activity",
+		EPMSkill:    "test-skill",
+	})
+	if err != nil {
+		t.Fatalf("run import: %v", err)
+	}
+	if result.RowsMapped != 2 || len(result.Entries) != 2 {
+		t.Fatalf("expected 2 mapped entries, got %+v", result)
+	}
+
+	first := result.Entries[0]
+	if got := first.StartDateTime.Format("2006-01-02 15:04"); got != "2026-03-03 08:30" {
+		t.Errorf("start = %s, want 2026-03-03 08:30", got)
+	}
+	if first.Billable != 90 {
+		t.Errorf("Billable = %d, want 90", first.Billable)
+	}
+	if first.Description != "[Website] Fix login bug" {
+		t.Errorf("Description = %q, want %q", first.Description, "[Website] Fix login bug")
+	}
+	if first.Project != "test-project" || first.Activity != "test-activity" || first.Skill != "test-skill" {
+		t.Errorf("unexpected project/activity/skill: %q/%q/%q", first.Project, first.Activity, first.Skill)
+	}
+	if first.SourceMapper != "toggl" {
+		t.Errorf("SourceMapper = %q, want toggl", first.SourceMapper)
+	}
+
+	second := result.Entries[1]
+	if second.Billable != 45 {
+		t.Errorf("Billable = %d, want 45 (44:40 rounded)", second.Billable)
+	}
+	if second.Description != "Website" {
+		t.Errorf("Description = %q, want project fallback %q", second.Description, "Website")
+	}
+}
+
+func TestTogglMapper_RequiresRuleConfig(t *testing.T) {
+	t.Parallel()
+
+	if !mapperNeedsRuleConfig("toggl") {
+		t.Fatal("expected toggl mapper to need rule config")
+	}
+}
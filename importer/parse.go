@@ -54,7 +54,7 @@ func parseMinutes(raw string) (int, error) {
 	return rounded, nil
 }
 
-func parseDateAndTime(dateValue, timeValue string) (time.Time, error) {
+func parseDateAndTime(dateValue, timeValue string, loc *time.Location) (time.Time, error) {
 	dateValue = strings.TrimSpace(dateValue)
 	timeValue = strings.TrimSpace(timeValue)
 	if dateValue == "" || timeValue == "" {
@@ -66,11 +66,12 @@ func parseDateAndTime(dateValue, timeValue string) (time.Time, error) {
 		"02.01.2006 03:04 PM",
 		"02.01.2006 15:04",
 		"2006-01-02 15:04",
+		"2006-01-02 15:04:05",
 		"2006-01-02 03:04 PM",
 	}
 
 	for _, layout := range layouts {
-		if parsed, err := time.ParseInLocation(layout, datetime, time.Local); err == nil {
+		if parsed, err := time.ParseInLocation(layout, datetime, loc); err == nil {
 			return parsed, nil
 		}
 	}
@@ -78,24 +79,28 @@ func parseDateAndTime(dateValue, timeValue string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unsupported date/time format: %q", datetime)
 }
 
-func parseDateTime(value string) (time.Time, error) {
+// dateTimeLayouts are the layouts accepted for single-column datetimes
+// (generic start/end, atwork Beginn/Ende), tried in order. Values without an
+// offset are read in the configured timezone.
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006 03:04 PM",
+}
+
+func parseDateTime(value string, loc *time.Location) (time.Time, error) {
 	value = strings.TrimSpace(value)
 	if value == "" {
 		return time.Time{}, fmt.Errorf("empty datetime")
 	}
 
-	layouts := []string{
-		time.RFC3339,
-		"2006-01-02 15:04",
-		"02.01.2006 15:04",
-		"02.01.2006 03:04 PM",
-	}
-
-	for _, layout := range layouts {
-		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+	for _, layout := range dateTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, loc); err == nil {
 			return parsed, nil
 		}
 	}
 
-	return time.Time{}, fmt.Errorf("unsupported datetime format: %q", value)
+	return time.Time{}, fmt.Errorf("unsupported datetime format: %q (accepted layouts: %s)", value, strings.Join(dateTimeLayouts, ", "))
 }
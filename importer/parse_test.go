@@ -1,6 +1,9 @@
 package importer
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestParseMinutes(t *testing.T) {
 	t.Parallel()
@@ -39,3 +42,28 @@ func TestParseMinutes(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDateTime_TriesLayoutsInOrder(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]string{
+		time.RFC3339:          "2026-03-05T09:15:00Z",
+		"2006-01-02 15:04":    "2026-03-05 09:15",
+		"2006-01-02 15:04:05": "2026-03-05 09:15:00",
+		"02.01.2006 15:04":    "05.03.2026 09:15",
+		"02.01.2006 03:04 PM": "05.03.2026 09:15 AM",
+	}
+	for _, layout := range dateTimeLayouts {
+		value, ok := values[layout]
+		if !ok {
+			t.Fatalf("no sample value for layout %q", layout)
+		}
+		parsed, err := parseDateTime(value, time.Local)
+		if err != nil {
+			t.Fatalf("parse %q (%s): %v", value, layout, err)
+		}
+		if parsed.Hour() != 9 || parsed.Minute() != 15 {
+			t.Fatalf("unexpected time for %q: %s", value, parsed)
+		}
+	}
+}
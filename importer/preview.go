@@ -0,0 +1,77 @@
+package importer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// ExistenceChecker reports whether an entry is already stored under the
+// store's UNIQUE key. *storage.SQLiteStore implements it.
+type ExistenceChecker interface {
+	ExistsWorklog(entry worklog.Entry) (bool, error)
+}
+
+// PreviewResult splits mapped entries into rows an insert would add and rows it
+// would ignore as duplicates.
+type PreviewResult struct {
+	New        int
+	Duplicates int
+}
+
+// PreviewAgainstStore counts which entries are new and which already exist in
+// the store. Repeated entries within the same import count as duplicates too,
+// matching the INSERT OR IGNORE behavior of the store.
+func PreviewAgainstStore(checker ExistenceChecker, entries []worklog.Entry) (PreviewResult, error) {
+	existing, err := ClassifyAgainstStore(checker, entries)
+	if err != nil {
+		return PreviewResult{}, err
+	}
+	result := PreviewResult{}
+	for _, exists := range existing {
+		if exists {
+			result.Duplicates++
+			continue
+		}
+		result.New++
+	}
+	return result, nil
+}
+
+// ClassifyAgainstStore reports for each entry, index-aligned, whether an
+// insert would ignore it because it is already stored or repeats an earlier
+// entry of the same import.
+func ClassifyAgainstStore(checker ExistenceChecker, entries []worklog.Entry) ([]bool, error) {
+	existing := make([]bool, len(entries))
+	seen := make(map[string]struct{}, len(entries))
+	for i, entry := range entries {
+		key := uniqueKey(entry)
+		if _, ok := seen[key]; ok {
+			existing[i] = true
+			continue
+		}
+		seen[key] = struct{}{}
+
+		exists, err := checker.ExistsWorklog(entry)
+		if err != nil {
+			return nil, err
+		}
+		existing[i] = exists
+	}
+	return existing, nil
+}
+
+func uniqueKey(entry worklog.Entry) string {
+	return fmt.Sprintf(
+		"%s|%s|%d|%s|%s|%s|%s|%s",
+		entry.StartDateTime.Format(time.RFC3339),
+		entry.EndDateTime.Format(time.RFC3339),
+		entry.Billable,
+		entry.Description,
+		entry.Project,
+		entry.Activity,
+		entry.Skill,
+		entry.SourceFile,
+	)
+}
@@ -0,0 +1,81 @@
+package importer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/storage"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestPreviewAgainstStore_HalfExisting(t *testing.T) {
+	t.Parallel()
+
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	base := time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local)
+	entries := make([]worklog.Entry, 0, 4)
+	for i := 0; i < 4; i++ {
+		start := base.Add(time.Duration(i) * time.Hour)
+		entries = append(entries, worklog.Entry{
+			StartDateTime: start,
+			EndDateTime:   start.Add(time.Hour),
+			Billable:      60,
+			Description:   "Task",
+			Project:       "p",
+			Activity:      "a",
+			Skill:         "s",
+			SourceFormat:  "csv",
+			SourceFile:    "import.csv",
+		})
+	}
+	if _, err := store.InsertWorklogs(entries[:2]); err != nil {
+		t.Fatalf("insert existing rows: %v", err)
+	}
+
+	preview, err := PreviewAgainstStore(store, entries)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if preview.New != 2 || preview.Duplicates != 2 {
+		t.Fatalf("expected 2 new and 2 duplicates, got %+v", preview)
+	}
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("expected preview not to insert rows, got %d stored", len(listed))
+	}
+}
+
+func TestPreviewAgainstStore_RepeatedEntryCountsAsDuplicate(t *testing.T) {
+	t.Parallel()
+
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	entry := worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local),
+		Description:   "Task",
+		SourceFile:    "import.csv",
+	}
+
+	preview, err := PreviewAgainstStore(store, []worklog.Entry{entry, entry})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if preview.New != 1 || preview.Duplicates != 1 {
+		t.Fatalf("expected 1 new and 1 duplicate, got %+v", preview)
+	}
+}
@@ -1,6 +1,9 @@
 package importer
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Reader interface {
 	Read(path string) ([]Record, error)
@@ -16,3 +19,40 @@ func ReaderForFormat(format string) (Reader, error) {
 		return nil, fmt.Errorf("unsupported input format: %s", format)
 	}
 }
+
+// checkRequiredHeaders verifies that every group in required has at least one
+// matching normalized header. Each group lists accepted aliases; the first
+// alias is used in the error message.
+func checkRequiredHeaders(path string, normalizedHeaders []string, required [][]string) error {
+	present := make(map[string]bool, len(normalizedHeaders))
+	for _, header := range normalizedHeaders {
+		present[header] = true
+	}
+
+	missing := make([]string, 0, len(required))
+	for _, aliases := range required {
+		found := false
+		for _, alias := range aliases {
+			if present[normalizeHeader(alias)] {
+				found = true
+				break
+			}
+		}
+		if !found && len(aliases) > 0 {
+			missing = append(missing, aliases[0])
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("file %s is missing required column(s): %s", path, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -14,12 +14,21 @@ type Result struct {
 	RowsMapped     int
 	RowsSkipped    int
 	Entries        []worklog.Entry
+	// DayTotalMismatches lists source days whose mapped entries differ from
+	// the declared day total (import.epm_day_total_check).
+	DayTotalMismatches []DayTotalMismatch
+	// FileDuplicates lists rows that map to the same entry as an earlier row
+	// of their file (import.file_duplicate_check).
+	FileDuplicates []FileDuplicate
 }
 
 type RunOptions struct {
 	EPMProject  string
 	EPMActivity string
 	EPMSkill    string
+	// Billable, when set, overrides the matching rule's billable flag for
+	// this run. false imports all entries with Billable=0.
+	Billable *bool
 }
 
 func Run(paths []string, format string, mapper Mapper, cfg config.Config, options RunOptions) (*Result, error) {
@@ -47,6 +56,7 @@ func Run(paths []string, format string, mapper Mapper, cfg config.Config, option
 
 		result.FilesProcessed++
 		result.RowsRead += len(records)
+		firstRowByKey := make(map[fileEntryKey]int)
 		for _, record := range records {
 			entry, ok, mapErr := mapper.Map(record, cfgForFile, sourceFormat, path)
 			if mapErr != nil {
@@ -62,13 +72,53 @@ func Run(paths []string, format string, mapper Mapper, cfg config.Config, option
 			if !cfgForFile.ImportBillable {
 				entry.Billable = 0
 			}
+			if cfgForFile.ImportSourceFormatLabel != "" {
+				entry.SourceFormat = cfgForFile.ImportSourceFormatLabel
+			}
+			if cfg.Import.FileDuplicateCheck {
+				key := fileEntryKeyOf(*entry)
+				if firstRow, seen := firstRowByKey[key]; seen {
+					result.FileDuplicates = append(result.FileDuplicates, FileDuplicate{
+						SourceFile:     path,
+						Row:            record.RowNumber,
+						DuplicateOfRow: firstRow,
+					})
+				} else {
+					firstRowByKey[key] = record.RowNumber
+				}
+			}
 			result.Entries = append(result.Entries, *entry)
 		}
 	}
 
+	if checker, ok := mapper.(dayTotalChecker); ok && cfg.Import.EPMDayTotalCheck {
+		result.DayTotalMismatches = checker.DayTotalMismatches(cfg.Import.EPMDayTotalToleranceMins)
+	}
+
 	return result, nil
 }
 
+// fileEntryKey holds the fields of the worklogs UNIQUE constraint except the
+// source file, which is the same for all rows of one file.
+type fileEntryKey struct {
+	start, end               int64
+	billable                 int
+	description              string
+	project, activity, skill string
+}
+
+func fileEntryKeyOf(entry worklog.Entry) fileEntryKey {
+	return fileEntryKey{
+		start:       entry.StartDateTime.UnixNano(),
+		end:         entry.EndDateTime.UnixNano(),
+		billable:    entry.Billable,
+		description: entry.Description,
+		project:     entry.Project,
+		activity:    entry.Activity,
+		skill:       entry.Skill,
+	}
+}
+
 func inferFormat(path string, format string) (string, error) {
 	if strings.TrimSpace(format) != "" {
 		return format, nil
@@ -89,7 +139,7 @@ func inferFormat(path string, format string) (string, error) {
 // to be supplied via rule config or CLI flags (rather than from CSV columns).
 func mapperNeedsRuleConfig(mapperName string) bool {
 	switch strings.ToLower(mapperName) {
-	case "epm", "atwork":
+	case "epm", "atwork", "toggl":
 		return true
 	default:
 		return false
@@ -102,6 +152,10 @@ func resolveConfigForFile(path, mapperName string, cfg config.Config, options Ru
 
 	rule := MatchRuleByTemplate(path, cfg.Rules)
 	resolved.ImportBillable = rule.IsBillable()
+	resolved.ImportSourceFormatLabel = strings.TrimSpace(rule.SourceFormatLabel)
+	if options.Billable != nil {
+		resolved.ImportBillable = *options.Billable
+	}
 
 	if !mapperNeedsRuleConfig(mapperName) {
 		return resolved, nil
@@ -162,12 +216,26 @@ func MatchRuleByTemplate(path string, rules []config.Rule) config.Rule {
 
 // readerForMapper returns a specialized reader when the mapper requires a
 // non-standard file format (e.g. atwork uses UTF-16 TSV). For all other
-// mappers it falls back to the format-based reader selection.
+// mappers it falls back to the format-based reader selection. The generic
+// mapper additionally requires start/end headers in CSV and Excel files.
 func readerForMapper(mapperName, sourceFormat string) (Reader, error) {
 	if strings.EqualFold(mapperName, "atwork") {
 		return &ATWorkReader{}, nil
 	}
-	return ReaderForFormat(sourceFormat)
+	reader, err := ReaderForFormat(sourceFormat)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(mapperName, "generic") {
+		required := [][]string{genericStartHeaders, genericEndHeaders}
+		switch typed := reader.(type) {
+		case *CSVReader:
+			typed.RequiredHeaders = required
+		case *ExcelReader:
+			typed.RequiredHeaders = required
+		}
+	}
+	return reader, nil
 }
 
 func firstNonEmpty(values ...string) string {
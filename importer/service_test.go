@@ -2,6 +2,9 @@ package importer
 
 import (
 	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/storage"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -178,3 +181,144 @@ func TestResolveConfigForFile_BillableTrueNoMatchingRule(t *testing.T) {
 		t.Fatalf("expected ImportBillable=true when no rule matches")
 	}
 }
+
+func TestResolveConfigForFile_BillableOptionOverridesRule(t *testing.T) {
+	cfg := config.Config{
+		Rules: []config.Rule{
+			{Mapper: "epm", FileTemplate: "EPM*.xlsx", Billable: boolPtr(false), ProjectID: 1, Project: "P", ActivityID: 2, Activity: "A", SkillID: 3, Skill: "S"},
+		},
+	}
+
+	resolved, err := resolveConfigForFile("EPMExport.xlsx", "epm", cfg, RunOptions{Billable: boolPtr(true)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resolved.ImportBillable {
+		t.Fatalf("expected RunOptions.Billable=true to override the rule")
+	}
+
+	resolved, err = resolveConfigForFile("generic.csv", "generic", config.Config{}, RunOptions{Billable: boolPtr(false)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.ImportBillable {
+		t.Fatalf("expected RunOptions.Billable=false to force non-billable")
+	}
+}
+
+func TestRun_ReportsEPMDayTotalMismatchesWhenEnabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "epm.csv")
+	content := "Datum,Von,Bis,Tagessumme,Stunden,Durchgeführte Arbeiten\n" +
+		"05.01.2026,08:00,17:00,\"8,00\",,\n" +
+		"05.01.2026,08:00,17:00,,\"4,00\",Task A\n" +
+		"05.01.2026,08:00,17:00,,\"2,00\",Task B\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write epm csv: %v", err)
+	}
+	options := RunOptions{EPMProject: "P", EPMActivity: "A", EPMSkill: "S"}
+
+	cfg := config.Config{Import: config.ImportConfig{EPMDayTotalCheck: true, EPMDayTotalToleranceMins: 1}}
+	result, err := Run([]string{path}, "", &EPMMapper{}, cfg, options)
+	if err != nil {
+		t.Fatalf("run import: %v", err)
+	}
+	if result.RowsMapped != 2 {
+		t.Fatalf("expected two mapped rows, got %d", result.RowsMapped)
+	}
+	if len(result.DayTotalMismatches) != 1 || result.DayTotalMismatches[0].MappedMinutes != 360 {
+		t.Fatalf("expected one day short of its total, got %+v", result.DayTotalMismatches)
+	}
+
+	cfg.Import.EPMDayTotalCheck = false
+	result, err = Run([]string{path}, "", &EPMMapper{}, cfg, options)
+	if err != nil {
+		t.Fatalf("run import: %v", err)
+	}
+	if len(result.DayTotalMismatches) != 0 {
+		t.Fatalf("expected no mismatches with the check disabled, got %+v", result.DayTotalMismatches)
+	}
+}
+
+func TestRun_ReportsDuplicateRowsWithinFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dupes.csv")
+	content := "description,startdatetime,enddatetime,project,activity,skill\n" +
+		"Task,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n" +
+		"Other,2026-03-01 10:00,2026-03-01 11:00,P,A,S\n" +
+		"Task,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	cfg := config.Config{Import: config.ImportConfig{FileDuplicateCheck: true}}
+	result, err := Run([]string{path}, "", &GenericMapper{}, cfg, RunOptions{})
+	if err != nil {
+		t.Fatalf("run import: %v", err)
+	}
+	if result.RowsMapped != 3 {
+		t.Fatalf("expected all rows to be mapped, got %d", result.RowsMapped)
+	}
+	if len(result.FileDuplicates) != 1 {
+		t.Fatalf("expected one intra-file duplicate, got %+v", result.FileDuplicates)
+	}
+	if got := result.FileDuplicates[0]; got.Row != 4 || got.DuplicateOfRow != 2 || got.SourceFile != path {
+		t.Fatalf("expected row 4 to duplicate row 2, got %+v", got)
+	}
+
+	cfg.Import.FileDuplicateCheck = false
+	result, err = Run([]string{path}, "", &GenericMapper{}, cfg, RunOptions{})
+	if err != nil {
+		t.Fatalf("run import: %v", err)
+	}
+	if len(result.FileDuplicates) != 0 {
+		t.Fatalf("expected no duplicates with the check disabled, got %+v", result.FileDuplicates)
+	}
+}
+
+func TestRun_RuleSourceFormatLabelIsStored(t *testing.T) {
+	dir := t.TempDir()
+	content := "description,startdatetime,enddatetime,project,activity,skill\nTask,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n"
+	labeled := filepath.Join(dir, "monthly-2026-03.csv")
+	plain := filepath.Join(dir, "other.csv")
+	for _, path := range []string{labeled, plain} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write csv: %v", err)
+		}
+	}
+	cfg := config.Config{
+		Rules: []config.Rule{
+			{Mapper: "generic", FileTemplate: "monthly-*.csv", SourceFormatLabel: " epm-monthly "},
+		},
+	}
+
+	result, err := Run([]string{labeled, plain}, "", &GenericMapper{}, cfg, RunOptions{})
+	if err != nil {
+		t.Fatalf("run import: %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("expected two entries, got %d", len(result.Entries))
+	}
+
+	store, err := storage.OpenSQLite(filepath.Join(dir, "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+	if _, err := store.InsertWorklogs(result.Entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+	stored, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+
+	formats := make(map[string]string, len(stored))
+	for _, entry := range stored {
+		formats[filepath.Base(entry.SourceFile)] = entry.SourceFormat
+	}
+	if formats["monthly-2026-03.csv"] != "epm-monthly" {
+		t.Fatalf("expected the rule label to be stored, got %q", formats["monthly-2026-03.csv"])
+	}
+	if formats["other.csv"] != "csv" {
+		t.Fatalf("expected the reader format without a matching rule, got %q", formats["other.csv"])
+	}
+}
@@ -0,0 +1,81 @@
+package importer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// SplitLongEntry splits entry at midnight and then into equally long
+// consecutive parts so that no part exceeds maxMinutes. Parts keep all
+// dimensions, share the billable minutes proportionally and number the
+// description ("text (1/2)"). Entries within the cap, or maxMinutes <= 0,
+// are returned unchanged as a single element.
+func SplitLongEntry(entry worklog.Entry, maxMinutes int) []worklog.Entry {
+	if maxMinutes <= 0 || !entry.EndDateTime.After(entry.StartDateTime) {
+		return []worklog.Entry{entry}
+	}
+	limit := time.Duration(maxMinutes) * time.Minute
+
+	type span struct{ start, end time.Time }
+	spans := make([]span, 0, 2)
+	for start := entry.StartDateTime; start.Before(entry.EndDateTime); {
+		nextMidnight := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location())
+		end := entry.EndDateTime
+		if nextMidnight.Before(end) {
+			end = nextMidnight
+		}
+
+		length := end.Sub(start)
+		parts := int64((length + limit - 1) / limit)
+		for i := int64(0); i < parts; i++ {
+			partStart := start.Add(time.Duration(int64(length) * i / parts).Truncate(time.Minute))
+			partEnd := end
+			if i < parts-1 {
+				partEnd = start.Add(time.Duration(int64(length) * (i + 1) / parts).Truncate(time.Minute))
+			}
+			spans = append(spans, span{start: partStart, end: partEnd})
+		}
+		start = end
+	}
+	if len(spans) == 1 {
+		return []worklog.Entry{entry}
+	}
+
+	total := entry.EndDateTime.Sub(entry.StartDateTime)
+	out := make([]worklog.Entry, 0, len(spans))
+	remainingBillable := entry.Billable
+	for i, part := range spans {
+		piece := entry
+		piece.StartDateTime = part.start
+		piece.EndDateTime = part.end
+		if i == len(spans)-1 {
+			piece.Billable = remainingBillable
+		} else {
+			piece.Billable = int(int64(entry.Billable) * int64(part.end.Sub(part.start)) / int64(total))
+			remainingBillable -= piece.Billable
+		}
+		piece.Description = fmt.Sprintf("%s (%d/%d)", entry.Description, i+1, len(spans))
+		out = append(out, piece)
+	}
+	return out
+}
+
+// SplitLongEntries applies SplitLongEntry to all entries and returns the
+// resulting entries plus the number of entries that were split.
+func SplitLongEntries(entries []worklog.Entry, maxMinutes int) ([]worklog.Entry, int) {
+	if maxMinutes <= 0 {
+		return entries, 0
+	}
+	out := make([]worklog.Entry, 0, len(entries))
+	split := 0
+	for _, entry := range entries {
+		parts := SplitLongEntry(entry, maxMinutes)
+		if len(parts) > 1 {
+			split++
+		}
+		out = append(out, parts...)
+	}
+	return out, split
+}
@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestSplitLongEntry_SplitsEvenlyUnderCap(t *testing.T) {
+	t.Parallel()
+
+	entry := worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 5, 8, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 5, 14, 0, 0, 0, time.Local),
+		Billable:      360,
+		Description:   "Workshop",
+		Project:       "Project A",
+		Activity:      "Delivery",
+		Skill:         "Go",
+		SourceMapper:  "generic",
+	}
+
+	parts := SplitLongEntry(entry, 240)
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d: %+v", len(parts), parts)
+	}
+	wantBounds := [][2]time.Time{
+		{time.Date(2026, 3, 5, 8, 0, 0, 0, time.Local), time.Date(2026, 3, 5, 11, 0, 0, 0, time.Local)},
+		{time.Date(2026, 3, 5, 11, 0, 0, 0, time.Local), time.Date(2026, 3, 5, 14, 0, 0, 0, time.Local)},
+	}
+	for i, part := range parts {
+		if !part.StartDateTime.Equal(wantBounds[i][0]) || !part.EndDateTime.Equal(wantBounds[i][1]) {
+			t.Fatalf("part %d: unexpected range %s - %s", i, part.StartDateTime, part.EndDateTime)
+		}
+		if part.Billable != 180 {
+			t.Fatalf("part %d: expected 180 billable minutes, got %d", i, part.Billable)
+		}
+		if part.Project != "Project A" || part.Activity != "Delivery" || part.Skill != "Go" || part.SourceMapper != "generic" {
+			t.Fatalf("part %d: dimensions not preserved: %+v", i, part)
+		}
+	}
+	if parts[0].Description != "Workshop (1/2)" || parts[1].Description != "Workshop (2/2)" {
+		t.Fatalf("unexpected descriptions: %q, %q", parts[0].Description, parts[1].Description)
+	}
+}
+
+func TestSplitLongEntry_RespectsDayBoundary(t *testing.T) {
+	t.Parallel()
+
+	entry := worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 5, 22, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 6, 1, 0, 0, 0, time.Local),
+		Billable:      180,
+		Description:   "Night deploy",
+	}
+
+	parts := SplitLongEntry(entry, 240)
+	if len(parts) != 2 {
+		t.Fatalf("expected split at midnight, got %d parts", len(parts))
+	}
+	midnight := time.Date(2026, 3, 6, 0, 0, 0, 0, time.Local)
+	if !parts[0].EndDateTime.Equal(midnight) || !parts[1].StartDateTime.Equal(midnight) {
+		t.Fatalf("expected parts to meet at midnight, got %s / %s", parts[0].EndDateTime, parts[1].StartDateTime)
+	}
+	if parts[0].Billable+parts[1].Billable != 180 || parts[0].Billable != 120 {
+		t.Fatalf("unexpected billable split: %d + %d", parts[0].Billable, parts[1].Billable)
+	}
+}
+
+func TestSplitLongEntries_KeepsShortEntriesAndCountsSplits(t *testing.T) {
+	t.Parallel()
+
+	short := worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 5, 8, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 5, 12, 0, 0, 0, time.Local),
+		Description:   "Exactly at cap",
+	}
+	long := worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 5, 13, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 5, 23, 0, 0, 0, time.Local),
+		Description:   "Long",
+	}
+
+	out, split := SplitLongEntries([]worklog.Entry{short, long}, 240)
+	if split != 1 {
+		t.Fatalf("expected 1 split entry, got %d", split)
+	}
+	if len(out) != 4 || out[0].Description != "Exactly at cap" {
+		t.Fatalf("expected short entry plus 3 parts, got %+v", out)
+	}
+	if out[3].Description != "Long (3/3)" || !out[3].EndDateTime.Equal(long.EndDateTime) {
+		t.Fatalf("unexpected last part: %+v", out[3])
+	}
+
+	unchanged, split := SplitLongEntries([]worklog.Entry{long}, 0)
+	if split != 0 || len(unchanged) != 1 {
+		t.Fatalf("expected no splitting with cap 0, got %d entries", len(unchanged))
+	}
+}
@@ -0,0 +1,40 @@
+package importer
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/riadshalaby/gohour/config"
+)
+
+// GenericTemplateHeaders are the columns of the generic CSV template, in
+// the spelling of the raw CSV export.
+var GenericTemplateHeaders = []string{"StartDateTime", "EndDateTime", "Billable", "Description", "Project", "Activity", "Skill"}
+
+// WriteGenericCSVTemplate writes a CSV file the generic mapper accepts: the
+// template headers and one example row. The example names the project,
+// activity and skill of the first generic rule in cfg, or placeholders when
+// no generic rule is configured.
+func WriteGenericCSVTemplate(w io.Writer, cfg config.Config) error {
+	project, activity, skill := "My Project", "Development", "Go"
+	for _, rule := range cfg.Rules {
+		if !strings.EqualFold(strings.TrimSpace(rule.Mapper), "generic") {
+			continue
+		}
+		project = firstNonEmpty(rule.Project, project)
+		activity = firstNonEmpty(rule.Activity, activity)
+		skill = firstNonEmpty(rule.Skill, skill)
+		break
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(GenericTemplateHeaders); err != nil {
+		return err
+	}
+	if err := writer.Write([]string{"2026-03-02 09:00", "2026-03-02 10:30", "90", "Sprint planning", project, activity, skill}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,67 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/riadshalaby/gohour/config"
+)
+
+func TestWriteGenericCSVTemplate_GenericMapperAcceptsIt(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteGenericCSVTemplate(&buf, config.Config{}); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse template: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected header and one example row, got %d rows", len(rows))
+	}
+	want := "StartDateTime,EndDateTime,Billable,Description,Project,Activity,Skill"
+	if got := strings.Join(rows[0], ","); got != want {
+		t.Fatalf("unexpected header: %s", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "template.csv")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if mapper := DetectMapper(path, ""); mapper != "generic" {
+		t.Fatalf("expected template to be detected as generic, got %q", mapper)
+	}
+	records, err := (&CSVReader{}).Read(path)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("read template: %d records, err %v", len(records), err)
+	}
+	entry, ok, err := (&GenericMapper{}).Map(records[0], config.Config{}, "csv", path)
+	if err != nil || !ok {
+		t.Fatalf("map example row: ok=%t err=%v", ok, err)
+	}
+	if entry.Billable != 90 || entry.Description != "Sprint planning" || entry.Project == "" || entry.Activity == "" || entry.Skill == "" {
+		t.Fatalf("unexpected example entry: %+v", entry)
+	}
+}
+
+func TestWriteGenericCSVTemplate_UsesFirstGenericRule(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Config{Rules: []config.Rule{
+		{Name: "rz", Mapper: "epm", Project: "EPM Project", Activity: "Delivery", Skill: "Java"},
+		{Name: "manual", Mapper: "generic", Project: "Client Project", Activity: "Support", Skill: "Go"},
+	}}
+	var buf bytes.Buffer
+	if err := WriteGenericCSVTemplate(&buf, cfg); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	if !strings.Contains(buf.String(), ",Client Project,Support,Go\n") {
+		t.Fatalf("expected example row from generic rule, got:\n%s", buf.String())
+	}
+}
@@ -6,6 +6,15 @@ func StartOfDay(value time.Time) time.Time {
 	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, value.Location())
 }
 
+// StartOfDayIn returns midnight of the calendar day value falls on in loc,
+// whatever zone value carries. A nil loc uses time.Local.
+func StartOfDayIn(value time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	return StartOfDay(value.In(loc))
+}
+
 func SameDay(a, b time.Time) bool {
 	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
 }
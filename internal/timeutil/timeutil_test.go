@@ -19,6 +19,29 @@ func TestStartOfDay(t *testing.T) {
 	}
 }
 
+func TestStartOfDayIn_KeepsCETCalendarDayInUTCProcess(t *testing.T) {
+	t.Parallel()
+
+	cet, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	// 00:30 CET on March 2 is still March 1 in UTC, the zone of a CI box.
+	logged := time.Date(2026, 3, 2, 0, 30, 0, 0, cet).In(time.UTC)
+	if logged.Day() != 1 {
+		t.Fatalf("expected UTC timestamp on March 1, got %v", logged)
+	}
+
+	got := StartOfDayIn(logged, cet)
+	want := time.Date(2026, 3, 2, 0, 0, 0, 0, cet)
+	if !got.Equal(want) || got.Location() != cet {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if utcDay := StartOfDayIn(logged, time.UTC); utcDay.Day() != 1 {
+		t.Fatalf("expected UTC bucketing to stay on March 1, got %v", utcDay)
+	}
+}
+
 func TestSameDay(t *testing.T) {
 	t.Parallel()
 
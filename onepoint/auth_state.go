@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
@@ -28,6 +29,9 @@ type stateCookie struct {
 	Value  string `json:"value"`
 	Domain string `json:"domain"`
 	Path   string `json:"path"`
+	// Expires is the expiry in Unix seconds; zero or negative marks a
+	// browser-session cookie.
+	Expires float64 `json:"expires"`
 }
 
 func DefaultAuthStatePath() (string, error) {
@@ -39,20 +43,53 @@ func DefaultAuthStatePath() (string, error) {
 }
 
 func SessionCookieHeaderFromStateFile(path, targetHost string) (string, error) {
+	state, err := readStorageState(path)
+	if err != nil {
+		return "", err
+	}
+	return sessionCookieHeaderFromState(state, targetHost)
+}
+
+// SessionCookieExpiryFromStateFile returns when the JSESSIONID cookie for
+// targetHost expires. ok is false when the cookie is missing or has no
+// expiry (a browser-session cookie).
+func SessionCookieExpiryFromStateFile(path, targetHost string) (expires time.Time, ok bool, err error) {
+	state, err := readStorageState(path)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	host := normalizeHost(targetHost)
+	for _, cookie := range state.Cookies {
+		if cookie.Name != SessionCookieJSESSIONID || cookie.Value == "" || !cookieDomainMatches(cookie.Domain, host) {
+			continue
+		}
+		if cookie.Path != "" && cookie.Path != "/" {
+			continue
+		}
+		if cookie.Expires <= 0 {
+			return time.Time{}, false, nil
+		}
+		seconds := int64(cookie.Expires)
+		nanos := int64((cookie.Expires - float64(seconds)) * float64(time.Second))
+		return time.Unix(seconds, nanos), true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+func readStorageState(path string) (storageState, error) {
 	content, err := os.ReadFile(strings.TrimSpace(path))
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", fmt.Errorf("%w: %w", ErrAuthStateNotFound, err)
+			return storageState{}, fmt.Errorf("%w: %w", ErrAuthStateNotFound, err)
 		}
-		return "", fmt.Errorf("read auth state file: %w", err)
+		return storageState{}, fmt.Errorf("read auth state file: %w", err)
 	}
 
 	var state storageState
 	if err := json.Unmarshal(content, &state); err != nil {
-		return "", fmt.Errorf("decode auth state file: %w", err)
+		return storageState{}, fmt.Errorf("decode auth state file: %w", err)
 	}
-
-	return sessionCookieHeaderFromState(state, targetHost)
+	return state, nil
 }
 
 func sessionCookieHeaderFromState(state storageState, targetHost string) (string, error) {
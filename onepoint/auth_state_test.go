@@ -104,3 +104,35 @@ func TestCookieDomainMatches(t *testing.T) {
 		t.Fatalf("did not expect unrelated domain to match")
 	}
 }
+
+func TestSessionCookieExpiryFromStateFile(t *testing.T) {
+	t.Parallel()
+
+	stateJSON := `{
+  "cookies": [
+    {"name":"JSESSIONID","value":"abc","domain":"onepoint.virtual7.io","path":"/","expires":1772452800.5},
+    {"name":"JSESSIONID","value":"other","domain":"example.com","path":"/","expires":-1}
+  ],
+  "origins": []
+}`
+	file := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(file, []byte(stateJSON), 0o600); err != nil {
+		t.Fatalf("write state file: %v", err)
+	}
+
+	expires, ok, err := SessionCookieExpiryFromStateFile(file, "onepoint.virtual7.io")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok || expires.Unix() != 1772452800 {
+		t.Fatalf("unexpected expiry: ok=%t expires=%v", ok, expires)
+	}
+
+	_, ok, err = SessionCookieExpiryFromStateFile(file, "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected session cookie without expiry")
+	}
+}
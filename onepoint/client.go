@@ -1,6 +1,7 @@
 package onepoint
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,10 +10,17 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -21,19 +29,46 @@ const (
 
 var ErrAuthUnauthorized = errors.New("onepoint request unauthorized (session may have expired)")
 
+// ErrSessionExpired signals that OnePoint answered a JSON request with an HTML
+// page and a 2xx status, which is what an expired SSO session returns instead
+// of a 401. It wraps ErrAuthUnauthorized, so callers that re-login on
+// unauthorized requests handle it too.
+var ErrSessionExpired = fmt.Errorf("%w: onepoint returned an HTML page instead of JSON; run \"gohour auth login\"", ErrAuthUnauthorized)
+
+// ErrConflict signals a 409 response: OnePoint rejected a write because the
+// day was modified concurrently since it was read.
+var ErrConflict = errors.New("onepoint rejected the write because the data changed concurrently")
+
+// ErrIncompleteLookupSnapshot signals that OnePoint returned projects but no
+// activities, which is usually a transient backend hiccup rather than real data.
+var ErrIncompleteLookupSnapshot = errors.New("onepoint lookup snapshot is incomplete (retry or refresh lookups)")
+
+// ErrWorklogNotFound signals that a day holds no entry with the requested
+// time record ID.
+var ErrWorklogNotFound = errors.New("onepoint worklog not found")
+
+// ErrWorklogLocked signals that the requested entry is locked and cannot be
+// removed.
+var ErrWorklogLocked = errors.New("onepoint worklog is locked")
+
 // Client defines the OnePoint API operations known from discovery.
 type Client interface {
 	ListProjects(ctx context.Context) ([]Project, error)
 	ListActivities(ctx context.Context) ([]Activity, error)
 	ListSkills(ctx context.Context) ([]Skill, error)
+	ListProjectActivities(ctx context.Context, projectID int64) ([]Activity, error)
+	ListActivitySkills(ctx context.Context, activityID int64) ([]Skill, error)
 	GetFilteredWorklogs(ctx context.Context, from, to time.Time) ([]DayWorklog, error)
 	GetDayWorklogs(ctx context.Context, day time.Time) ([]DayWorklog, error)
 	PersistWorklogs(ctx context.Context, day time.Time, worklogs []PersistWorklog) ([]PersistResult, error)
+	DeleteDayWorklog(ctx context.Context, day time.Time, timeRecordID int64) error
 	FetchLookupSnapshot(ctx context.Context) (LookupSnapshot, error)
 	ResolveIDs(ctx context.Context, projectName, activityName, skillName string, options ResolveOptions) (ResolvedIDs, error)
 }
 
-type httpDoer interface {
+// HTTPDoer executes HTTP requests. *http.Client satisfies it; decorators
+// such as LoggingDoer wrap another HTTPDoer.
+type HTTPDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
@@ -42,7 +77,18 @@ type ClientConfig struct {
 	RefererURL     string
 	SessionCookies string
 	UserAgent      string
-	HTTPClient     httpDoer
+	HTTPClient     HTTPDoer
+	// MaxRetries is the number of extra attempts for GET requests that fail
+	// with 429, 5xx or a transient network error. Zero disables retries.
+	MaxRetries int
+	// RetryBackoff is the base delay before the first retry; it doubles per
+	// attempt and is jittered. Zero uses DefaultRetryBackoff.
+	RetryBackoff time.Duration
+	// RateLimiter paces every request attempt. Nil disables pacing.
+	RateLimiter *RateLimiter
+	// MinRequestInterval spaces request attempts at least this far apart
+	// when RateLimiter is nil. Zero disables pacing.
+	MinRequestInterval time.Duration
 }
 
 type HTTPClient struct {
@@ -50,7 +96,11 @@ type HTTPClient struct {
 	refererURL     string
 	sessionCookies string
 	userAgent      string
-	httpClient     httpDoer
+	httpClient     HTTPDoer
+	maxRetries     int
+	retryBackoff   time.Duration
+	rateLimiter    *RateLimiter
+	calls          atomic.Int64
 }
 
 func NewClient(cfg ClientConfig) (*HTTPClient, error) {
@@ -75,12 +125,25 @@ func NewClient(cfg ClientConfig) (*HTTPClient, error) {
 		doer = &http.Client{Timeout: 30 * time.Second}
 	}
 
+	retryBackoff := cfg.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = DefaultRetryBackoff
+	}
+
+	rateLimiter := cfg.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = NewIntervalRateLimiter(cfg.MinRequestInterval)
+	}
+
 	return &HTTPClient{
 		baseURL:        baseURL,
 		refererURL:     refererURL,
 		sessionCookies: strings.TrimSpace(cfg.SessionCookies),
 		userAgent:      strings.TrimSpace(cfg.UserAgent),
 		httpClient:     doer,
+		maxRetries:     max(0, cfg.MaxRetries),
+		retryBackoff:   retryBackoff,
+		rateLimiter:    rateLimiter,
 	}, nil
 }
 
@@ -95,6 +158,20 @@ func (p Project) IsArchived() bool {
 	return strings.TrimSpace(p.Archived) == "1"
 }
 
+// HasSelectableStatus reports whether the project's Status is one of statuses.
+// An empty list means every status is selectable.
+func (p Project) HasSelectableStatus(statuses []int64) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, status := range statuses {
+		if p.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
 type Activity struct {
 	ID              int64  `json:"activityId"`
 	Locked          bool   `json:"locked"`
@@ -240,6 +317,16 @@ type LookupSnapshot struct {
 type ResolveOptions struct {
 	IncludeArchivedProjects bool
 	IncludeLockedActivities bool
+	// SelectableProjectStatuses limits projects to these Status values
+	// (empty: no status filtering). Other projects are treated as inactive.
+	SelectableProjectStatuses []int64
+	IncludeInactiveProjects   bool
+	// ProjectCodePattern, when set, allows resolving a project by the short
+	// code extracted from its name (see ExtractProjectCode).
+	ProjectCodePattern *regexp.Regexp
+	// IgnoreDiacritics makes name matching accent-insensitive, so "Tatigkeit"
+	// resolves "Tätigkeit".
+	IgnoreDiacritics bool
 }
 
 type ResolvedIDs struct {
@@ -275,6 +362,45 @@ func (c *HTTPClient) ListSkills(ctx context.Context) ([]Skill, error) {
 	return out, nil
 }
 
+// ListProjectActivities fetches only the activities of one project. The
+// project id is sent as a filter hint; results are filtered locally as well so
+// tenants whose backend ignores the hint still get the right subset.
+func (c *HTTPClient) ListProjectActivities(ctx context.Context, projectID int64) ([]Activity, error) {
+	query := url.Values{}
+	query.Set("mode", "project")
+	query.Set("projectId", strconv.FormatInt(projectID, 10))
+	var out []Activity
+	if err := c.doJSON(ctx, http.MethodPost, "/OPServices/resources/OpProjects/getAllUserActivities?"+query.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	filtered := make([]Activity, 0, len(out))
+	for _, activity := range out {
+		if activity.ProjectNodeID == projectID {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered, nil
+}
+
+// ListActivitySkills fetches only the skills of one activity, with the same
+// local filtering fallback as ListProjectActivities.
+func (c *HTTPClient) ListActivitySkills(ctx context.Context, activityID int64) ([]Skill, error) {
+	query := url.Values{}
+	query.Set("mode", "activity")
+	query.Set("activityId", strconv.FormatInt(activityID, 10))
+	var out []Skill
+	if err := c.doJSON(ctx, http.MethodPost, "/OPServices/resources/OpProjects/getAllUserSkills?"+query.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	filtered := make([]Skill, 0, len(out))
+	for _, skill := range out {
+		if skill.ActivityID == activityID {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered, nil
+}
+
 func (c *HTTPClient) GetFilteredWorklogs(ctx context.Context, from, to time.Time) ([]DayWorklog, error) {
 	path := fmt.Sprintf(
 		"/OPServices/resources/OpWorklogs/%s:%s/getFilteredWorklogs",
@@ -301,6 +427,47 @@ func (c *HTTPClient) PersistWorklogs(ctx context.Context, day time.Time, worklog
 	return out, nil
 }
 
+// DeleteDayWorklog removes one entry of day. OnePoint has no delete endpoint
+// and persist replaces the whole day, so the day is loaded and persisted again
+// without the entry. It fails with ErrWorklogNotFound or ErrWorklogLocked
+// before anything is persisted.
+func (c *HTTPClient) DeleteDayWorklog(ctx context.Context, day time.Time, timeRecordID int64) error {
+	existing, err := c.GetDayWorklogs(ctx, day)
+	if err != nil {
+		return fmt.Errorf("load day %s: %w", FormatDay(day), err)
+	}
+	remaining, err := WorklogsWithout(existing, timeRecordID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", FormatDay(day), err)
+	}
+	if _, err := c.PersistWorklogs(ctx, day, remaining); err != nil {
+		return fmt.Errorf("persist day %s: %w", FormatDay(day), err)
+	}
+	return nil
+}
+
+// WorklogsWithout returns the persist payload of existing without the entry
+// with timeRecordID. It fails with ErrWorklogNotFound when no entry matches
+// and with ErrWorklogLocked when the matching entry is locked.
+func WorklogsWithout(existing []DayWorklog, timeRecordID int64) ([]PersistWorklog, error) {
+	found := false
+	remaining := make([]PersistWorklog, 0, len(existing))
+	for _, item := range existing {
+		if item.TimeRecordID != timeRecordID {
+			remaining = append(remaining, item.ToPersistWorklog())
+			continue
+		}
+		if item.Locked != 0 {
+			return nil, fmt.Errorf("time record %d: %w", timeRecordID, ErrWorklogLocked)
+		}
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("time record %d: %w", timeRecordID, ErrWorklogNotFound)
+	}
+	return remaining, nil
+}
+
 func (c *HTTPClient) FetchLookupSnapshot(ctx context.Context) (LookupSnapshot, error) {
 	projects, err := c.ListProjects(ctx)
 	if err != nil {
@@ -316,6 +483,13 @@ func (c *HTTPClient) FetchLookupSnapshot(ctx context.Context) (LookupSnapshot, e
 	if err != nil {
 		return LookupSnapshot{}, err
 	}
+	if len(activities) == 0 {
+		return LookupSnapshot{}, fmt.Errorf(
+			"%w: ListActivities returned empty result for %d projects",
+			ErrIncompleteLookupSnapshot,
+			len(projects),
+		)
+	}
 	skills, err := c.ListSkills(ctx)
 	if err != nil {
 		return LookupSnapshot{}, err
@@ -335,36 +509,99 @@ func (c *HTTPClient) ResolveIDs(ctx context.Context, projectName, activityName,
 	return ResolveIDsFromSnapshot(snapshot, projectName, activityName, skillName, options)
 }
 
-func ResolveIDsFromSnapshot(snapshot LookupSnapshot, projectName, activityName, skillName string, options ResolveOptions) (ResolvedIDs, error) {
-	projectName = normalize(projectName)
-	activityName = normalize(activityName)
-	skillName = normalize(skillName)
-	if projectName == "" || activityName == "" || skillName == "" {
-		return ResolvedIDs{}, errors.New("project, activity and skill names are required")
+// ExtractProjectCode returns the short project code found in name using
+// pattern. The first capture group is used when present, otherwise the whole
+// match. It returns "" when pattern is nil or does not match.
+func ExtractProjectCode(pattern *regexp.Regexp, name string) string {
+	if pattern == nil {
+		return ""
 	}
+	match := pattern.FindStringSubmatch(name)
+	if match == nil {
+		return ""
+	}
+	if len(match) > 1 && strings.TrimSpace(match[1]) != "" {
+		return strings.TrimSpace(match[1])
+	}
+	return strings.TrimSpace(match[0])
+}
+
+type projectMatches struct {
+	candidates   []Project
+	archivedOnly []Project
+	inactiveOnly []Project
+}
+
+func (m projectMatches) empty() bool {
+	return len(m.candidates) == 0 && len(m.archivedOnly) == 0 && len(m.inactiveOnly) == 0
+}
 
-	projectCandidates := make([]Project, 0)
-	archivedOnly := make([]Project, 0)
-	for _, project := range snapshot.Projects {
-		if !equalName(project.Name, projectName) {
+func matchProjects(projects []Project, options ResolveOptions, matches func(Project) bool) projectMatches {
+	out := projectMatches{
+		candidates:   make([]Project, 0),
+		archivedOnly: make([]Project, 0),
+		inactiveOnly: make([]Project, 0),
+	}
+	for _, project := range projects {
+		if !matches(project) {
 			continue
 		}
 		if project.IsArchived() {
-			archivedOnly = append(archivedOnly, project)
+			out.archivedOnly = append(out.archivedOnly, project)
 			if !options.IncludeArchivedProjects {
 				continue
 			}
 		}
-		projectCandidates = append(projectCandidates, project)
+		if !project.HasSelectableStatus(options.SelectableProjectStatuses) {
+			out.inactiveOnly = append(out.inactiveOnly, project)
+			if !options.IncludeInactiveProjects {
+				continue
+			}
+		}
+		out.candidates = append(out.candidates, project)
 	}
-	projectCandidates = uniqueProjects(projectCandidates)
+	out.candidates = uniqueProjects(out.candidates)
+	return out
+}
+
+func ResolveIDsFromSnapshot(snapshot LookupSnapshot, projectName, activityName, skillName string, options ResolveOptions) (ResolvedIDs, error) {
+	projectName = normalize(projectName)
+	activityName = normalize(activityName)
+	skillName = normalize(skillName)
+	if projectName == "" || activityName == "" || skillName == "" {
+		return ResolvedIDs{}, errors.New("project, activity and skill names are required")
+	}
+	equalName := nameMatcher(options)
+
+	matched := matchProjects(snapshot.Projects, options, func(project Project) bool {
+		return equalName(project.Name, projectName)
+	})
+	if matched.empty() && options.ProjectCodePattern != nil {
+		code := ExtractProjectCode(options.ProjectCodePattern, projectName)
+		if code == "" {
+			code = projectName
+		}
+		matched = matchProjects(snapshot.Projects, options, func(project Project) bool {
+			projectCode := ExtractProjectCode(options.ProjectCodePattern, project.Name)
+			return projectCode != "" && equalName(projectCode, code)
+		})
+	}
+	projectCandidates := matched.candidates
 
 	if len(projectCandidates) == 0 {
-		if !options.IncludeArchivedProjects && len(archivedOnly) > 0 {
+		if !options.IncludeArchivedProjects && len(matched.archivedOnly) > 0 {
 			return ResolvedIDs{}, fmt.Errorf(
 				"project %q only matches archived projects (ids: %s); set IncludeArchivedProjects to true if this is intended",
 				projectName,
-				idsForProjects(archivedOnly),
+				idsForProjects(matched.archivedOnly),
+			)
+		}
+		if !options.IncludeInactiveProjects && len(matched.inactiveOnly) > 0 {
+			return ResolvedIDs{}, fmt.Errorf(
+				"project %q only matches inactive projects (ids: %s, statuses: %s); set IncludeInactiveProjects to true if this is intended",
+				projectName,
+				idsForProjects(matched.inactiveOnly),
+				statusesForProjects(matched.inactiveOnly),
 			)
 		}
 		return ResolvedIDs{}, fmt.Errorf("project %q not found", projectName)
@@ -448,27 +685,76 @@ func FormatDay(day time.Time) string {
 }
 
 func ParseDay(value string) (time.Time, error) {
-	parsed, err := time.ParseInLocation(dayLayout, strings.TrimSpace(value), time.Local)
+	return ParseDayIn(value, time.Local)
+}
+
+// ParseDayIn parses a OnePoint day ("dd-mm-yyyy") as midnight in loc.
+func ParseDayIn(value string, loc *time.Location) (time.Time, error) {
+	parsed, err := time.ParseInLocation(dayLayout, strings.TrimSpace(value), loc)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("parse day %q: %w", value, err)
 	}
 	return parsed, nil
 }
 
+// CallCount returns how many OnePoint API calls the client has made. Retries
+// of one call are not counted separately.
+func (c *HTTPClient) CallCount() int64 {
+	return c.calls.Load()
+}
+
 func (c *HTTPClient) doJSON(ctx context.Context, method, endpointPath string, body any, out any) error {
-	var bodyReader io.Reader
+	c.calls.Add(1)
+
+	var payload []byte
 	if body != nil {
-		payload, err := json.Marshal(body)
+		encoded, err := json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("marshal request body: %w", err)
 		}
+		payload = encoded
+	}
+
+	// Only idempotent GETs are retried; lookups and persist calls are POSTs.
+	attempts := 1
+	if method == http.MethodGet {
+		attempts += c.maxRetries
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if waitErr := waitForRetry(ctx, retryDelay(c.retryBackoff, attempt)); waitErr != nil {
+				return err
+			}
+		}
+		if waitErr := c.rateLimiter.Wait(ctx); waitErr != nil {
+			if err != nil {
+				return err
+			}
+			return waitErr
+		}
+		var retryable bool
+		retryable, err = c.doJSONAttempt(ctx, method, endpointPath, payload, out)
+		if err == nil || !retryable {
+			return err
+		}
+	}
+	return err
+}
+
+// doJSONAttempt performs a single request. The returned bool reports whether
+// a failure is transient (429, 5xx or a network error) and may be retried.
+func (c *HTTPClient) doJSONAttempt(ctx context.Context, method, endpointPath string, payload []byte, out any) (bool, error) {
+	var bodyReader io.Reader
+	if payload != nil {
 		bodyReader = bytes.NewReader(payload)
 	}
 
 	url := c.baseURL + endpointPath
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("create request %s %s: %w", method, endpointPath, err)
+		return false, fmt.Errorf("create request %s %s: %w", method, endpointPath, err)
 	}
 
 	req.Header.Set("Accept", "application/json, text/javascript, */*; q=0.01")
@@ -482,20 +768,20 @@ func (c *HTTPClient) doJSON(ctx context.Context, method, endpointPath string, bo
 	if c.userAgent != "" {
 		req.Header.Set("User-Agent", c.userAgent)
 	}
-	if body != nil {
+	if payload != nil {
 		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request %s %s failed: %w", method, endpointPath, err)
+		return isTransientNetworkError(ctx, err), fmt.Errorf("request %s %s failed: %w", method, endpointPath, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
 		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-			return fmt.Errorf(
+			return false, fmt.Errorf(
 				"%w: request %s %s failed with status %d: %s",
 				ErrAuthUnauthorized,
 				method,
@@ -504,7 +790,18 @@ func (c *HTTPClient) doJSON(ctx context.Context, method, endpointPath string, bo
 				strings.TrimSpace(string(responseBody)),
 			)
 		}
-		return fmt.Errorf(
+		if resp.StatusCode == http.StatusConflict {
+			return false, fmt.Errorf(
+				"%w: request %s %s failed with status %d: %s",
+				ErrConflict,
+				method,
+				endpointPath,
+				resp.StatusCode,
+				strings.TrimSpace(string(responseBody)),
+			)
+		}
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retryable, fmt.Errorf(
 			"request %s %s failed with status %d: %s",
 			method,
 			endpointPath,
@@ -514,32 +811,64 @@ func (c *HTTPClient) doJSON(ctx context.Context, method, endpointPath string, bo
 	}
 
 	if out == nil {
-		return nil
+		return false, nil
 	}
-	contentType := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Type")))
-	if strings.Contains(contentType, "text/html") {
-		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return fmt.Errorf(
-			"%w: request %s %s returned HTML response (possible SSO redirect): %s",
-			ErrAuthUnauthorized,
+	body := bufio.NewReader(resp.Body)
+	if isHTMLResponse(resp.Header.Get("Content-Type"), body) {
+		responseBody, _ := io.ReadAll(io.LimitReader(body, 512))
+		return false, fmt.Errorf(
+			"%w (request %s %s, status %d): %s",
+			ErrSessionExpired,
 			method,
 			endpointPath,
+			resp.StatusCode,
 			strings.TrimSpace(string(responseBody)),
 		)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+	if err := json.NewDecoder(body).Decode(out); err != nil {
 		if errors.Is(err, io.EOF) {
-			return nil
+			return false, nil
 		}
-		return fmt.Errorf("decode response %s %s: %w", method, endpointPath, err)
+		return false, fmt.Errorf("decode response %s %s: %w", method, endpointPath, err)
 	}
-	return nil
+	return false, nil
+}
+
+// isHTMLResponse reports whether a successful response carries an HTML page
+// instead of JSON: an HTML Content-Type, or a body whose first non-blank byte
+// is "<" whatever the declared type. body is peeked, not consumed.
+func isHTMLResponse(contentType string, body *bufio.Reader) bool {
+	if strings.Contains(strings.ToLower(contentType), "text/html") {
+		return true
+	}
+	peeked, _ := body.Peek(512)
+	return bytes.HasPrefix(bytes.TrimLeft(peeked, " \t\r\n\ufeff"), []byte("<"))
 }
 
 func equalName(a, b string) bool {
 	return strings.EqualFold(normalize(a), normalize(b))
 }
 
+// nameMatcher returns the name comparison used for resolution under options.
+func nameMatcher(options ResolveOptions) func(a, b string) bool {
+	if !options.IgnoreDiacritics {
+		return equalName
+	}
+	return func(a, b string) bool {
+		return equalName(foldDiacritics(a), foldDiacritics(b))
+	}
+}
+
+// foldDiacritics strips combining marks after canonical decomposition, e.g.
+// "Tätigkeit" becomes "Tatigkeit".
+func foldDiacritics(value string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+	if err != nil {
+		return value
+	}
+	return folded
+}
+
 func normalize(value string) string {
 	return strings.Join(strings.Fields(strings.TrimSpace(value)), " ")
 }
@@ -564,10 +893,32 @@ func persistWorklogsEquivalent(a, b PersistWorklog) bool {
 		a.SkillID.Value == b.SkillID.Value
 }
 
+// PersistWorklogsEquivalent reports whether a and b occupy the same worklog
+// slot: equal StartTime, FinishTime, ProjectID, ActivityID and SkillID.
+// Billable, valuable, duration and comment are deliberately not compared, so
+// a local entry with edited values replaces the equivalent remote one instead
+// of being written next to it. PersistWorklogsIdentical also compares values.
 func PersistWorklogsEquivalent(a, b PersistWorklog) bool {
 	return persistWorklogsEquivalent(a, b)
 }
 
+// PersistWorklogsIdentical reports whether a and b are equivalent and carry
+// the same Billable and Duration and the same comment after NormalizeComment.
+// Valuable is not compared: local entries never set it, so comparing it would
+// report every remote entry with a value as changed.
+func PersistWorklogsIdentical(a, b PersistWorklog) bool {
+	return persistWorklogsEquivalent(a, b) &&
+		a.Billable == b.Billable &&
+		a.Duration == b.Duration &&
+		NormalizeComment(a.Comment) == NormalizeComment(b.Comment)
+}
+
+// NormalizeComment trims value, collapses whitespace runs to one space and
+// lower-cases it, so comments differing only in spacing or case compare equal.
+func NormalizeComment(value string) string {
+	return strings.ToLower(normalize(value))
+}
+
 // WorklogTimeOverlaps reports whether a and b have overlapping time ranges
 // but are not duplicates (per persistWorklogsEquivalent).
 func WorklogTimeOverlaps(a, b PersistWorklog) bool {
@@ -634,6 +985,14 @@ func idsForProjects(values []Project) string {
 	return formatIDs(ids)
 }
 
+func statusesForProjects(values []Project) string {
+	statuses := make([]int64, 0, len(values))
+	for _, value := range values {
+		statuses = append(statuses, value.Status)
+	}
+	return formatIDs(statuses)
+}
+
 func idsForActivities(values []Activity) string {
 	ids := make([]int64, 0, len(values))
 	for _, value := range values {
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -211,6 +214,38 @@ func TestHTTPClient_UnauthorizedStatusWrapsSentinel(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_ConflictStatusWrapsSentinel(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	doer := fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusConflict,
+			Body:       io.NopCloser(strings.NewReader("modified concurrently")),
+			Header:     make(http.Header),
+		}, nil
+	}}
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:        "https://onepoint.virtual7.io",
+		RefererURL:     "https://onepoint.virtual7.io/onepoint/faces/home",
+		SessionCookies: "JSESSIONID=test",
+		HTTPClient:     doer,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = client.PersistWorklogs(context.Background(), time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), nil)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a conflict not to be retried by the client, got %d calls", calls)
+	}
+}
+
 func TestHTTPClient_HTMLResponseWrapsSentinel(t *testing.T) {
 	t.Parallel()
 
@@ -243,6 +278,52 @@ func TestHTTPClient_HTMLResponseWrapsSentinel(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_HTMLBodyWithJSONStatusIsSessionExpired(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(contentType, body string) Client {
+		t.Helper()
+		doer := fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+			header := make(http.Header)
+			if contentType != "" {
+				header.Set("Content-Type", contentType)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     header,
+			}, nil
+		}}
+		client, err := NewClient(ClientConfig{
+			BaseURL:        "https://onepoint.virtual7.io",
+			RefererURL:     "https://onepoint.virtual7.io/onepoint/faces/home",
+			SessionCookies: "JSESSIONID=test",
+			HTTPClient:     doer,
+		})
+		if err != nil {
+			t.Fatalf("new client: %v", err)
+		}
+		return client
+	}
+
+	// The login page is served with a misleading JSON content type.
+	_, err := newClient("application/json", "\n  <!DOCTYPE html><html><body>Sign in</body></html>").ListProjects(context.Background())
+	if !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("expected ErrSessionExpired, got %v", err)
+	}
+	if !errors.Is(err, ErrAuthUnauthorized) {
+		t.Fatalf("expected ErrSessionExpired to wrap ErrAuthUnauthorized, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "gohour auth login") {
+		t.Fatalf("expected re-login hint in error, got %v", err)
+	}
+
+	projects, err := newClient("text/plain", `[{"opId":1,"opName":"P"}]`).ListProjects(context.Background())
+	if err != nil || len(projects) != 1 {
+		t.Fatalf("expected JSON body with non-JSON content type to decode, got %v, %v", projects, err)
+	}
+}
+
 func TestFetchLookupSnapshot_EmptyProjectsWrapsSentinel(t *testing.T) {
 	t.Parallel()
 
@@ -275,6 +356,224 @@ func TestFetchLookupSnapshot_EmptyProjectsWrapsSentinel(t *testing.T) {
 	}
 }
 
+func TestFetchLookupSnapshot_ProjectsWithoutActivitiesIsIncomplete(t *testing.T) {
+	t.Parallel()
+
+	skillsCalled := false
+	doer := fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		key := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+		switch key {
+		case "POST /OPServices/resources/OpProjects/getAllUserProjects":
+			return jsonResponse([]Project{{ID: 1, Name: "Project A", Archived: "0"}}), nil
+		case "POST /OPServices/resources/OpProjects/getAllUserActivities":
+			return jsonResponse([]Activity{}), nil
+		case "POST /OPServices/resources/OpProjects/getAllUserSkills":
+			skillsCalled = true
+			return jsonResponse([]Skill{}), nil
+		default:
+			return nil, fmt.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}}
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:        "https://onepoint.virtual7.io",
+		RefererURL:     "https://onepoint.virtual7.io/onepoint/faces/home",
+		SessionCookies: "JSESSIONID=test",
+		HTTPClient:     doer,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = client.FetchLookupSnapshot(context.Background())
+	if err == nil {
+		t.Fatalf("expected incomplete snapshot error")
+	}
+	if !errors.Is(err, ErrIncompleteLookupSnapshot) {
+		t.Fatalf("expected ErrIncompleteLookupSnapshot, got %v", err)
+	}
+	if errors.Is(err, ErrAuthUnauthorized) {
+		t.Fatalf("incomplete snapshot must not be reported as unauthorized: %v", err)
+	}
+	if skillsCalled {
+		t.Fatalf("expected skills not to be fetched after empty activities")
+	}
+}
+
+func TestHTTPClient_ListProjectActivitiesAndSkillsSendFilter(t *testing.T) {
+	t.Parallel()
+
+	doer := fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		key := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+		query := r.URL.Query()
+		switch key {
+		case "POST /OPServices/resources/OpProjects/getAllUserActivities":
+			if got := query.Get("mode"); got != "project" {
+				t.Fatalf("unexpected activities mode: %q", got)
+			}
+			if got := query.Get("projectId"); got != "10" {
+				t.Fatalf("unexpected projectId: %q", got)
+			}
+			// Backend ignores the filter: the client must still narrow the result.
+			return jsonResponse([]Activity{
+				{ID: 100, Name: "Dev", ProjectNodeID: 10},
+				{ID: 200, Name: "Other", ProjectNodeID: 20},
+			}), nil
+		case "POST /OPServices/resources/OpProjects/getAllUserSkills":
+			if got := query.Get("mode"); got != "activity" {
+				t.Fatalf("unexpected skills mode: %q", got)
+			}
+			if got := query.Get("activityId"); got != "100" {
+				t.Fatalf("unexpected activityId: %q", got)
+			}
+			return jsonResponse([]Skill{
+				{ActivityID: 100, Name: "Senior", SkillID: 1000},
+				{ActivityID: 200, Name: "Junior", SkillID: 2000},
+			}), nil
+		default:
+			return nil, fmt.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}}
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:        "https://onepoint.virtual7.io",
+		SessionCookies: "JSESSIONID=test",
+		HTTPClient:     doer,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx := context.Background()
+	activities, err := client.ListProjectActivities(ctx, 10)
+	if err != nil {
+		t.Fatalf("list project activities: %v", err)
+	}
+	if len(activities) != 1 || activities[0].ID != 100 {
+		t.Fatalf("unexpected activities: %+v", activities)
+	}
+
+	skills, err := client.ListActivitySkills(ctx, 100)
+	if err != nil {
+		t.Fatalf("list activity skills: %v", err)
+	}
+	if len(skills) != 1 || skills[0].SkillID != 1000 {
+		t.Fatalf("unexpected skills: %+v", skills)
+	}
+}
+
+func TestExtractProjectCode(t *testing.T) {
+	t.Parallel()
+
+	pattern := regexp.MustCompile(`^([a-z]+[0-9]+)`)
+	if got := ExtractProjectCode(pattern, "bfa211102 - ISO RVSE9 Los2"); got != "bfa211102" {
+		t.Fatalf("expected bfa211102, got %q", got)
+	}
+	if got := ExtractProjectCode(pattern, "Internal"); got != "" {
+		t.Fatalf("expected no code for non-matching name, got %q", got)
+	}
+	if got := ExtractProjectCode(nil, "bfa211102 - ISO RVSE9 Los2"); got != "" {
+		t.Fatalf("expected no code without pattern, got %q", got)
+	}
+}
+
+func TestResolveIDsFromSnapshot_ResolvesProjectByCode(t *testing.T) {
+	t.Parallel()
+
+	snapshot := LookupSnapshot{
+		Projects: []Project{
+			{ID: 432904811, Name: "bfa211102 - ISO RVSE9 Los2", Archived: "0"},
+			{ID: 432904810, Name: "bfa211101 - ISO RVSE8 Los1", Archived: "0"},
+		},
+		Activities: []Activity{
+			{ID: 436142369, Name: "RISH - Travel", ProjectNodeID: 432904811},
+		},
+		Skills: []Skill{
+			{ActivityID: 436142369, Name: "Realisation (pm)", SkillID: 44498948},
+		},
+	}
+	options := ResolveOptions{ProjectCodePattern: regexp.MustCompile(`^([a-z]+[0-9]+)`)}
+
+	resolved, err := ResolveIDsFromSnapshot(snapshot, "bfa211102", "RISH - Travel", "Realisation (pm)", options)
+	if err != nil {
+		t.Fatalf("resolve ids by code: %v", err)
+	}
+	if resolved.ProjectID != 432904811 {
+		t.Fatalf("unexpected project id: %d", resolved.ProjectID)
+	}
+
+	if _, err := ResolveIDsFromSnapshot(snapshot, "bfa211102", "RISH - Travel", "Realisation (pm)", ResolveOptions{}); err == nil {
+		t.Fatalf("expected code lookup to require a configured pattern")
+	}
+}
+
+func TestResolveIDsFromSnapshot_IgnoreDiacritics(t *testing.T) {
+	t.Parallel()
+
+	snapshot := LookupSnapshot{
+		Projects: []Project{
+			{ID: 10, Name: "Projekt Süd", Archived: "0"},
+		},
+		Activities: []Activity{
+			{ID: 20, Name: "Tätigkeit", ProjectNodeID: 10},
+		},
+		Skills: []Skill{
+			{ActivityID: 20, Name: "Entwicklung (Go)", SkillID: 30},
+		},
+	}
+
+	resolved, err := ResolveIDsFromSnapshot(snapshot, "projekt sud", "Tatigkeit", "Entwicklung (Go)", ResolveOptions{IgnoreDiacritics: true})
+	if err != nil {
+		t.Fatalf("resolve ids ignoring diacritics: %v", err)
+	}
+	if resolved.ProjectID != 10 || resolved.ActivityID != 20 || resolved.SkillID != 30 {
+		t.Fatalf("unexpected resolved ids: %+v", resolved)
+	}
+
+	_, err = ResolveIDsFromSnapshot(snapshot, "Projekt Süd", "Tatigkeit", "Entwicklung (Go)", ResolveOptions{})
+	if err == nil || !strings.Contains(err.Error(), `activity "Tatigkeit" not found`) {
+		t.Fatalf("expected activity not found without IgnoreDiacritics, got %v", err)
+	}
+}
+
+func TestResolveIDsFromSnapshot_InactiveStatusExcludedUnlessIncluded(t *testing.T) {
+	t.Parallel()
+
+	snapshot := LookupSnapshot{
+		Projects: []Project{
+			{ID: 10, Name: "Project A", Archived: "0", Status: 2},
+		},
+		Activities: []Activity{
+			{ID: 20, Name: "Delivery", ProjectNodeID: 10},
+		},
+		Skills: []Skill{
+			{ActivityID: 20, Name: "Go", SkillID: 30},
+		},
+	}
+
+	_, err := ResolveIDsFromSnapshot(snapshot, "Project A", "Delivery", "Go", ResolveOptions{
+		SelectableProjectStatuses: []int64{0},
+	})
+	if err == nil || !strings.Contains(err.Error(), "only matches inactive projects") {
+		t.Fatalf("expected inactive project error, got %v", err)
+	}
+
+	resolved, err := ResolveIDsFromSnapshot(snapshot, "Project A", "Delivery", "Go", ResolveOptions{
+		SelectableProjectStatuses: []int64{0},
+		IncludeInactiveProjects:   true,
+	})
+	if err != nil {
+		t.Fatalf("resolve with inactive projects included: %v", err)
+	}
+	if resolved.ProjectID != 10 {
+		t.Fatalf("unexpected project id: %d", resolved.ProjectID)
+	}
+
+	if _, err := ResolveIDsFromSnapshot(snapshot, "Project A", "Delivery", "Go", ResolveOptions{}); err != nil {
+		t.Fatalf("expected no status filtering without configured statuses: %v", err)
+	}
+}
+
 func TestResolveIDsFromSnapshot_Success(t *testing.T) {
 	t.Parallel()
 
@@ -453,6 +752,44 @@ func TestPersistWorklogsEquivalent_DifferentSkillIsNotEquivalent(t *testing.T) {
 	}
 }
 
+func TestPersistWorklogsIdentical_NormalizesComment(t *testing.T) {
+	t.Parallel()
+
+	base := PersistWorklog{
+		StartTime:  intPtr(540),
+		FinishTime: intPtr(600),
+		Duration:   60,
+		Billable:   60,
+		ProjectID:  ID(10),
+		ActivityID: ID(20),
+		SkillID:    ID(30),
+		Comment:    "Fix login bug",
+	}
+	tests := []struct {
+		name   string
+		change func(*PersistWorklog)
+		want   bool
+	}{
+		{name: "trailing whitespace", change: func(w *PersistWorklog) { w.Comment = "Fix login bug \t\n" }, want: true},
+		{name: "inner whitespace", change: func(w *PersistWorklog) { w.Comment = "  Fix   login\tbug" }, want: true},
+		{name: "case", change: func(w *PersistWorklog) { w.Comment = "fix LOGIN bug" }, want: true},
+		{name: "different comment", change: func(w *PersistWorklog) { w.Comment = "Fix logout bug" }, want: false},
+		{name: "billable", change: func(w *PersistWorklog) { w.Billable = 30 }, want: false},
+		{name: "valuable ignored", change: func(w *PersistWorklog) { w.Valuable = 60 }, want: true},
+		{name: "duration", change: func(w *PersistWorklog) { w.Duration = 45 }, want: false},
+		{name: "time range", change: func(w *PersistWorklog) { w.FinishTime = intPtr(615) }, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base
+			tt.change(&other)
+			if got := PersistWorklogsIdentical(base, other); got != tt.want {
+				t.Fatalf("PersistWorklogsIdentical() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestWorklogTimeOverlaps_TrueForNonDuplicateOverlap(t *testing.T) {
 	t.Parallel()
 
@@ -547,7 +884,307 @@ func TestWorklogTimeOverlaps_FalseWithMissingTimes(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_DeleteDayWorklogPersistsDayWithoutEntry(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	existing := []DayWorklog{
+		{TimeRecordID: 11, WorklogDate: "10-03-2026", StartTime: 540, FinishTime: 600, Duration: 60, ProjectID: 1, ActivityID: 2, SkillID: 3},
+		{TimeRecordID: 12, WorklogDate: "10-03-2026", StartTime: 600, FinishTime: 660, Duration: 60, ProjectID: 1, ActivityID: 2, SkillID: 3},
+		{TimeRecordID: 13, WorklogDate: "10-03-2026", StartTime: 660, FinishTime: 720, Duration: 60, ProjectID: 1, ActivityID: 2, SkillID: 3, Locked: 1},
+	}
+	var persisted []PersistWorklog
+	persistCalls := 0
+	client, err := NewClient(ClientConfig{
+		BaseURL: "https://onepoint.virtual7.io",
+		HTTPClient: fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(r.URL.Path, "/getFilteredWorklogs") {
+				return jsonResponse(getFilteredWorklogsResponse{Worklogs: existing}), nil
+			}
+			if strings.HasSuffix(r.URL.Path, "/10-03-2026/persistWorklogs") {
+				persistCalls++
+				if err := json.NewDecoder(r.Body).Decode(&persisted); err != nil {
+					t.Errorf("decode persist payload: %v", err)
+				}
+				return jsonResponse([]PersistResult{}), nil
+			}
+			return nil, fmt.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if err := client.DeleteDayWorklog(context.Background(), day, 12); err != nil {
+		t.Fatalf("delete day worklog: %v", err)
+	}
+	if persistCalls != 1 {
+		t.Fatalf("expected 1 persist call, got %d", persistCalls)
+	}
+	if len(persisted) != 2 || persisted[0].TimeRecordID != 11 || persisted[1].TimeRecordID != 13 {
+		t.Fatalf("expected remaining entries 11 and 13 to be persisted, got %+v", persisted)
+	}
+
+	if err := client.DeleteDayWorklog(context.Background(), day, 13); !errors.Is(err, ErrWorklogLocked) {
+		t.Fatalf("expected ErrWorklogLocked for locked entry, got %v", err)
+	}
+	if err := client.DeleteDayWorklog(context.Background(), day, 99); !errors.Is(err, ErrWorklogNotFound) {
+		t.Fatalf("expected ErrWorklogNotFound for unknown entry, got %v", err)
+	}
+	if persistCalls != 1 {
+		t.Fatalf("expected failed deletes not to persist, got %d persist calls", persistCalls)
+	}
+}
+
 func intPtr(value int) *int {
 	out := value
 	return &out
 }
+
+func statusResponse(status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(http.StatusText(status))),
+		Header:     make(http.Header),
+	}
+}
+
+func newRetryTestClient(t *testing.T, doer fakeDoer) *HTTPClient {
+	t.Helper()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:      "https://onepoint.virtual7.io",
+		HTTPClient:   doer,
+		MaxRetries:   3,
+		RetryBackoff: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return client
+}
+
+func TestHTTPClient_RetriesGetOnServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	client := newRetryTestClient(t, fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		if attempts.Add(1) <= 2 {
+			return statusResponse(http.StatusServiceUnavailable), nil
+		}
+		return jsonResponse(getFilteredWorklogsResponse{Worklogs: []DayWorklog{{WorklogDate: "22-02-2026"}}}), nil
+	}})
+
+	worklogs, err := client.GetDayWorklogs(context.Background(), time.Date(2026, 2, 22, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("get day worklogs: %v", err)
+	}
+	if len(worklogs) != 1 {
+		t.Fatalf("expected 1 worklog, got %d", len(worklogs))
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestHTTPClient_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	client := newRetryTestClient(t, fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return statusResponse(http.StatusNotFound), nil
+	}})
+
+	if _, err := client.GetDayWorklogs(context.Background(), time.Date(2026, 2, 22, 0, 0, 0, 0, time.Local)); err == nil {
+		t.Fatalf("expected error")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestHTTPClient_DoesNotRetryPost(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	client := newRetryTestClient(t, fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return statusResponse(http.StatusServiceUnavailable), nil
+	}})
+
+	if _, err := client.PersistWorklogs(context.Background(), time.Date(2026, 2, 22, 0, 0, 0, 0, time.Local), nil); err == nil {
+		t.Fatalf("expected error")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestHTTPClient_RetryStopsAtContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	client, err := NewClient(ClientConfig{
+		BaseURL:      "https://onepoint.virtual7.io",
+		MaxRetries:   5,
+		RetryBackoff: time.Hour,
+		HTTPClient: fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+			attempts.Add(1)
+			return statusResponse(http.StatusBadGateway), nil
+		}},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = client.GetDayWorklogs(ctx, time.Date(2026, 2, 22, 0, 0, 0, 0, time.Local))
+	if err == nil || !strings.Contains(err.Error(), "status 502") {
+		t.Fatalf("expected last upstream error, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Fatalf("expected no retry past the deadline, got %d attempts", got)
+	}
+}
+
+func TestHTTPClient_CallCountIncrementsPerCall(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	client := newRetryTestClient(t, fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		if attempts.Add(1) == 1 {
+			return statusResponse(http.StatusServiceUnavailable), nil
+		}
+		return jsonResponse(getFilteredWorklogsResponse{}), nil
+	}})
+
+	day := time.Date(2026, 2, 22, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetDayWorklogs(context.Background(), day); err != nil {
+			t.Fatalf("get day worklogs: %v", err)
+		}
+	}
+	if got := client.CallCount(); got != 3 {
+		t.Fatalf("expected 3 counted calls, got %d", got)
+	}
+	if got := attempts.Load(); got != 4 {
+		t.Fatalf("expected 4 attempts including one retry, got %d", got)
+	}
+}
+
+func TestHTTPClient_RateLimiterSpacesCalls(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var sentAt []time.Time
+	client, err := NewClient(ClientConfig{
+		BaseURL: "https://onepoint.virtual7.io",
+		HTTPClient: fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			sentAt = append(sentAt, time.Now())
+			mu.Unlock()
+			return jsonResponse(getFilteredWorklogsResponse{}), nil
+		}},
+		RateLimiter: NewRateLimiter(20),
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	day := time.Date(2026, 2, 22, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 4; i++ {
+		if _, err := client.GetDayWorklogs(context.Background(), day); err != nil {
+			t.Fatalf("get day worklogs: %v", err)
+		}
+	}
+
+	// 20 requests/second means 50ms between calls; allow scheduler slack.
+	for i := 1; i < len(sentAt); i++ {
+		if gap := sentAt[i].Sub(sentAt[i-1]); gap < 40*time.Millisecond {
+			t.Fatalf("expected calls at least ~50ms apart, call %d came after %v", i, gap)
+		}
+	}
+}
+
+func TestHTTPClient_MinRequestIntervalSpacesCallsAndHonorsCancellation(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var sentAt []time.Time
+	client, err := NewClient(ClientConfig{
+		BaseURL: "https://onepoint.virtual7.io",
+		HTTPClient: fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			sentAt = append(sentAt, time.Now())
+			mu.Unlock()
+			return jsonResponse(getFilteredWorklogsResponse{}), nil
+		}},
+		MinRequestInterval: 60 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	day := time.Date(2026, 2, 22, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetDayWorklogs(context.Background(), day); err != nil {
+			t.Fatalf("get day worklogs: %v", err)
+		}
+	}
+	if len(sentAt) != 2 {
+		t.Fatalf("expected two sent requests, got %d", len(sentAt))
+	}
+	if gap := sentAt[1].Sub(sentAt[0]); gap < 60*time.Millisecond {
+		t.Fatalf("expected calls at least 60ms apart, got %v", gap)
+	}
+
+	// The next slot is still ahead; a cancelled caller gives up instead of
+	// waiting for it and nothing is sent.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetDayWorklogs(ctx, day); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation while waiting, got %v", err)
+	}
+	if len(sentAt) != 2 {
+		t.Fatalf("expected cancelled call not to be sent, got %d requests", len(sentAt))
+	}
+}
+
+func TestRateLimiter_ReservesSlotsInCallOrder(t *testing.T) {
+	t.Parallel()
+
+	if NewRateLimiter(0) != nil {
+		t.Fatalf("expected nil limiter for zero rate")
+	}
+	var nilLimiter *RateLimiter
+	if err := nilLimiter.Wait(context.Background()); err != nil {
+		t.Fatalf("nil limiter wait: %v", err)
+	}
+
+	limiter := NewRateLimiter(2)
+	clock := time.Date(2026, 2, 22, 9, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return clock }
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+	if !limiter.next.Equal(clock.Add(500 * time.Millisecond)) {
+		t.Fatalf("expected next slot 500ms ahead, got %v", limiter.next.Sub(clock))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error when the slot is past the deadline, got %v", err)
+	}
+
+	clock = clock.Add(5 * time.Second)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("wait after idle period: %v", err)
+	}
+	if !limiter.next.Equal(clock.Add(500 * time.Millisecond)) {
+		t.Fatalf("expected idle period not to bank slots, next is %v ahead", limiter.next.Sub(clock))
+	}
+}
@@ -0,0 +1,65 @@
+package onepoint
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const redactedHeaderValue = "[REDACTED]"
+
+// LoggingDoer decorates an HTTPDoer and logs one structured summary per
+// request: method, path, status, duration and request headers. Cookie
+// values are redacted, so session credentials never reach the log.
+type LoggingDoer struct {
+	next   HTTPDoer
+	logger *slog.Logger
+}
+
+// NewLoggingDoer wraps next, falling back to a default *http.Client when
+// next is nil, and logs through logger.
+func NewLoggingDoer(next HTTPDoer, logger *slog.Logger) *LoggingDoer {
+	if next == nil {
+		next = &http.Client{Timeout: 30 * time.Second}
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LoggingDoer{next: next, logger: logger}
+}
+
+func (d *LoggingDoer) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := d.next.Do(req)
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.RequestURI()),
+		slog.Duration("duration", time.Since(start)),
+		slog.Group("headers", redactedHeaderAttrs(req.Header)...),
+	}
+	if err != nil {
+		d.logger.Error("onepoint request failed", append(attrs, slog.String("error", err.Error()))...)
+		return resp, err
+	}
+	d.logger.Info("onepoint request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	return resp, nil
+}
+
+func redactedHeaderAttrs(header http.Header) []any {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attrs := make([]any, 0, len(names))
+	for _, name := range names {
+		value := header.Get(name)
+		if http.CanonicalHeaderKey(name) == "Cookie" {
+			value = redactedHeaderValue
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return attrs
+}
@@ -0,0 +1,65 @@
+package onepoint
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoggingDoer_LogsSummaryAndRedactsCookie(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	doer := NewLoggingDoer(fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		return jsonResponse([]Project{}), nil
+	}}, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:        "https://onepoint.virtual7.io",
+		SessionCookies: "JSESSIONID=super-secret",
+		HTTPClient:     doer,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if _, err := client.ListProjects(t.Context()); err != nil {
+		t.Fatalf("list projects: %v", err)
+	}
+
+	out := logs.String()
+	for _, want := range []string{
+		"method=POST",
+		"path=\"/OPServices/resources/OpProjects/getAllUserProjects?mode=all\"",
+		"status=200",
+		"duration=",
+		"headers.Cookie=" + redactedHeaderValue,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected log to contain %q, got %s", want, out)
+		}
+	}
+	if strings.Contains(out, "super-secret") {
+		t.Fatalf("cookie value leaked into log: %s", out)
+	}
+}
+
+func TestLoggingDoer_LogsTransportError(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	wantErr := errors.New("connection reset")
+	doer := NewLoggingDoer(fakeDoer{fn: func(r *http.Request) (*http.Response, error) {
+		return nil, wantErr
+	}}, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://onepoint.virtual7.io/OPServices/x", nil)
+	if _, err := doer.Do(req); !errors.Is(err, wantErr) {
+		t.Fatalf("expected transport error to pass through, got %v", err)
+	}
+	if out := logs.String(); !strings.Contains(out, "level=ERROR") || !strings.Contains(out, "connection reset") {
+		t.Fatalf("expected error log line, got %s", out)
+	}
+}
@@ -0,0 +1,57 @@
+package onepoint
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces OnePoint requests to a fixed maximum rate. It is safe for
+// concurrent use and may be shared by several clients, so a relogin that
+// builds a new client keeps the same pacing. A nil *RateLimiter never waits.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	now      func() time.Time
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerSecond requests per
+// second, or nil (no limit) when requestsPerSecond is not positive.
+func NewRateLimiter(requestsPerSecond float64) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return NewIntervalRateLimiter(time.Duration(float64(time.Second) / requestsPerSecond))
+}
+
+// NewIntervalRateLimiter returns a limiter spacing requests at least interval
+// apart, or nil (no limit) when interval is not positive.
+func NewIntervalRateLimiter(interval time.Duration) *RateLimiter {
+	if interval <= 0 {
+		return nil
+	}
+	return &RateLimiter{interval: interval, now: time.Now}
+}
+
+// Wait blocks until the caller's request slot is due or ctx ends. Slots are
+// reserved in call order, one interval apart.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := l.now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	return waitForRetry(ctx, delay)
+}
@@ -0,0 +1,57 @@
+package onepoint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+)
+
+// DefaultRetryBackoff is the base retry delay when ClientConfig.RetryBackoff is unset.
+const DefaultRetryBackoff = 500 * time.Millisecond
+
+// retryDelay returns the jittered delay before retry number attempt (1-based):
+// a random value between half and all of base * 2^(attempt-1).
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	delay := base << (attempt - 1)
+	if delay <= 0 {
+		return base
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// waitForRetry sleeps for delay unless ctx ends first. It fails fast when the
+// context deadline would pass before the delay elapses.
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return context.DeadlineExceeded
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransientNetworkError reports whether a transport error is worth retrying.
+// Errors caused by the caller's context are never retried.
+func isTransientNetworkError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
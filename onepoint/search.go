@@ -0,0 +1,129 @@
+package onepoint
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Lookup kinds reported by SearchLookup.
+const (
+	LookupKindProject  = "project"
+	LookupKindActivity = "activity"
+	LookupKindSkill    = "skill"
+)
+
+// LookupMatch is one project, activity or skill whose name matched a search.
+type LookupMatch struct {
+	Kind string
+	ID   int64
+	Name string
+	// Parent names the project of an activity or the activity of a skill,
+	// so equally named entries can be told apart. Empty for projects.
+	Parent string
+	// Score is the similarity to the query, from 0 (unrelated) to 1 (equal).
+	Score float64
+}
+
+// SearchLookup returns the projects, activities and skills of snapshot whose
+// names score at least minScore against query, best match first. Matching
+// ignores case, surrounding whitespace and diacritics. top > 0 limits the
+// number of matches.
+func SearchLookup(snapshot LookupSnapshot, query string, minScore float64, top int) []LookupMatch {
+	needle := searchKey(query)
+	if needle == "" {
+		return nil
+	}
+
+	projectNames := make(map[int64]string, len(snapshot.Projects))
+	for _, project := range snapshot.Projects {
+		projectNames[project.ID] = normalize(project.Name)
+	}
+	activityNames := make(map[int64]string, len(snapshot.Activities))
+	for _, activity := range snapshot.Activities {
+		activityNames[activity.ID] = normalize(activity.Name)
+	}
+
+	matches := make([]LookupMatch, 0)
+	add := func(kind string, id int64, name, parent string) {
+		score := nameSimilarity(needle, searchKey(name))
+		if score < minScore {
+			return
+		}
+		matches = append(matches, LookupMatch{Kind: kind, ID: id, Name: normalize(name), Parent: parent, Score: score})
+	}
+	for _, project := range snapshot.Projects {
+		add(LookupKindProject, project.ID, project.Name, "")
+	}
+	for _, activity := range uniqueActivities(snapshot.Activities) {
+		add(LookupKindActivity, activity.ID, activity.Name, projectNames[activity.ProjectNodeID])
+	}
+	for _, skill := range snapshot.Skills {
+		add(LookupKindSkill, skill.SkillID, skill.Name, activityNames[skill.ActivityID])
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return strings.ToLower(matches[i].Name) < strings.ToLower(matches[j].Name)
+	})
+	if top > 0 && len(matches) > top {
+		matches = matches[:top]
+	}
+	return matches
+}
+
+func searchKey(value string) string {
+	return strings.ToLower(foldDiacritics(normalize(value)))
+}
+
+// nameSimilarity scores name against query: 1 for equal names, at least 0.8
+// when name contains query, otherwise the edit-distance similarity to the
+// whole name or, slightly discounted, to its closest word.
+func nameSimilarity(query, name string) float64 {
+	if name == "" {
+		return 0
+	}
+	if query == name {
+		return 1
+	}
+	if strings.Contains(name, query) {
+		return 0.8 + 0.2*float64(utf8.RuneCountInString(query))/float64(utf8.RuneCountInString(name))
+	}
+	best := editSimilarity(query, name)
+	for _, word := range strings.Fields(name) {
+		if score := 0.9 * editSimilarity(query, word); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// editSimilarity is 1 minus the Levenshtein distance relative to the longer
+// of both strings.
+func editSimilarity(a, b string) float64 {
+	left, right := []rune(a), []rune(b)
+	longest := max(len(left), len(right))
+	if longest == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(right)+1)
+	current := make([]int, len(right)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(left); i++ {
+		current[0] = i
+		for j := 1; j <= len(right); j++ {
+			cost := 1
+			if left[i-1] == right[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return 1 - float64(previous[len(right)])/float64(longest)
+}
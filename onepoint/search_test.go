@@ -0,0 +1,57 @@
+package onepoint
+
+import "testing"
+
+func TestSearchLookup_RanksMatchesBySimilarity(t *testing.T) {
+	t.Parallel()
+
+	snapshot := LookupSnapshot{
+		Projects: []Project{
+			{ID: 1, Name: "Identity Platform"},
+			{ID: 2, Name: "Identity Platform Migration"},
+			{ID: 3, Name: "Payroll"},
+		},
+		Activities: []Activity{
+			{ID: 10, Name: "Entwicklung", ProjectNodeID: 1},
+			{ID: 11, Name: "Plattformbetrieb", ProjectNodeID: 2},
+		},
+		Skills: []Skill{
+			{SkillID: 100, Name: "Go", ActivityID: 10},
+			{SkillID: 101, Name: "Tätigkeit Plattform", ActivityID: 11},
+		},
+	}
+
+	matches := SearchLookup(snapshot, "identity platfrom", 0.5, 0)
+	if len(matches) != 2 {
+		t.Fatalf("expected both identity projects, got %+v", matches)
+	}
+	if matches[0].ID != 1 || matches[1].ID != 2 {
+		t.Fatalf("expected closer name first, got %+v", matches)
+	}
+	if matches[0].Kind != LookupKindProject || matches[0].Score <= matches[1].Score {
+		t.Fatalf("unexpected ranking: %+v", matches)
+	}
+
+	matches = SearchLookup(snapshot, "plattform", 0.85, 0)
+	if len(matches) != 2 {
+		t.Fatalf("expected activity and skill matches, got %+v", matches)
+	}
+	if matches[0].Kind != LookupKindActivity || matches[0].Parent != "Identity Platform Migration" {
+		t.Fatalf("expected activity with project parent first, got %+v", matches[0])
+	}
+	if matches[1].Kind != LookupKindSkill || matches[1].Parent != "Plattformbetrieb" {
+		t.Fatalf("expected skill with activity parent second, got %+v", matches[1])
+	}
+
+	matches = SearchLookup(snapshot, "tatigkeit plattform", 0.5, 1)
+	if len(matches) != 1 || matches[0].ID != 101 || matches[0].Score != 1 {
+		t.Fatalf("expected exact diacritic-insensitive skill match only, got %+v", matches)
+	}
+
+	if matches := SearchLookup(snapshot, "payrol", 0.9, 0); len(matches) != 1 || matches[0].ID != 3 {
+		t.Fatalf("expected substring match above min score, got %+v", matches)
+	}
+	if matches := SearchLookup(snapshot, "  ", 0, 0); matches != nil {
+		t.Fatalf("expected no matches for empty query, got %+v", matches)
+	}
+}
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/riadshalaby/gohour/worklog"
+	"io"
+	"strconv"
+)
+
+// Pseudonym records one replaced value of an anonymized export.
+type Pseudonym struct {
+	Field     string
+	Original  string
+	Pseudonym string
+}
+
+// Anonymizer replaces names in worklog entries with stable pseudonyms such as
+// "Project-1" or "Task-2". The same original value always maps to the same
+// pseudonym, numbered per field in order of first appearance.
+type Anonymizer struct {
+	byField map[string]map[string]string
+	order   []Pseudonym
+}
+
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{byField: make(map[string]map[string]string)}
+}
+
+// Anonymize returns a copy of entries with project, activity, skill,
+// description and source file replaced. Times, billable minutes, source
+// format and mapper are kept; local notes and tags are cleared. Empty values
+// stay empty.
+func (a *Anonymizer) Anonymize(entries []worklog.Entry) []worklog.Entry {
+	out := make([]worklog.Entry, len(entries))
+	for i, entry := range entries {
+		entry.Project = a.pseudonym("Project", entry.Project)
+		entry.Activity = a.pseudonym("Activity", entry.Activity)
+		entry.Skill = a.pseudonym("Skill", entry.Skill)
+		entry.Description = a.pseudonym("Task", entry.Description)
+		entry.SourceFile = a.pseudonym("File", entry.SourceFile)
+		entry.LocalNote = ""
+		entry.Tags = nil
+		out[i] = entry
+	}
+	return out
+}
+
+// Mapping returns every replaced value in the order pseudonyms were assigned.
+func (a *Anonymizer) Mapping() []Pseudonym {
+	return append([]Pseudonym(nil), a.order...)
+}
+
+func (a *Anonymizer) pseudonym(field, value string) string {
+	if value == "" {
+		return ""
+	}
+	names, ok := a.byField[field]
+	if !ok {
+		names = make(map[string]string)
+		a.byField[field] = names
+	}
+	if name, ok := names[value]; ok {
+		return name
+	}
+	name := field + "-" + strconv.Itoa(len(names)+1)
+	names[value] = name
+	a.order = append(a.order, Pseudonym{Field: field, Original: value, Pseudonym: name})
+	return name
+}
+
+// WriteMappingCSV writes mapping as CSV with Field, Pseudonym and Original
+// columns.
+func WriteMappingCSV(out io.Writer, mapping []Pseudonym) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write([]string{"Field", "Pseudonym", "Original"}); err != nil {
+		return fmt.Errorf("write mapping headers: %w", err)
+	}
+	for _, item := range mapping {
+		if err := writer.Write([]string{item.Field, item.Pseudonym, item.Original}); err != nil {
+			return fmt.Errorf("write mapping row: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flush mapping output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestAnonymizer_PreservesStructureWithConsistentPseudonyms(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local)
+	entries := []worklog.Entry{
+		{StartDateTime: day, EndDateTime: day.Add(time.Hour), Billable: 60, Description: "ACME rollout", Project: "ACME", Activity: "Delivery", Skill: "Go", SourceMapper: "generic", SourceFile: "acme.csv", LocalNote: "private"},
+		{StartDateTime: day.Add(time.Hour), EndDateTime: day.Add(90 * time.Minute), Billable: 30, Description: "Globex review", Project: "Globex", Activity: "Delivery", Skill: "Go", SourceMapper: "generic", SourceFile: "acme.csv", Tags: []string{"travel"}},
+		{StartDateTime: day.Add(2 * time.Hour), EndDateTime: day.Add(3 * time.Hour), Billable: 0, Description: "ACME rollout", Project: "ACME", Activity: "Support", Skill: ""},
+	}
+
+	anonymizer := NewAnonymizer()
+	got := anonymizer.Anonymize(entries)
+
+	if len(got) != len(entries) {
+		t.Fatalf("expected %d entries, got %d", len(entries), len(got))
+	}
+	want := []struct{ project, activity, skill, description, file string }{
+		{"Project-1", "Activity-1", "Skill-1", "Task-1", "File-1"},
+		{"Project-2", "Activity-1", "Skill-1", "Task-2", "File-1"},
+		{"Project-1", "Activity-2", "", "Task-1", ""},
+	}
+	for i, entry := range got {
+		w := want[i]
+		if entry.Project != w.project || entry.Activity != w.activity || entry.Skill != w.skill || entry.Description != w.description || entry.SourceFile != w.file {
+			t.Fatalf("entry %d: unexpected pseudonyms %+v", i, entry)
+		}
+		if !entry.StartDateTime.Equal(entries[i].StartDateTime) || !entry.EndDateTime.Equal(entries[i].EndDateTime) || entry.Billable != entries[i].Billable {
+			t.Fatalf("entry %d: expected times and billable to be preserved, got %+v", i, entry)
+		}
+		if entry.SourceMapper != entries[i].SourceMapper {
+			t.Fatalf("entry %d: expected source mapper to be kept", i)
+		}
+		if entry.LocalNote != "" || len(entry.Tags) != 0 {
+			t.Fatalf("entry %d: expected note and tags to be dropped, got %+v", i, entry)
+		}
+	}
+	if entries[0].Project != "ACME" {
+		t.Fatalf("expected input entries to stay untouched")
+	}
+
+	// A second export with the same anonymizer reuses the assigned names.
+	again := anonymizer.Anonymize(entries[2:])
+	if again[0].Project != "Project-1" || again[0].Description != "Task-1" {
+		t.Fatalf("expected stable pseudonyms across calls, got %+v", again[0])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMappingCSV(&buf, anonymizer.Mapping()); err != nil {
+		t.Fatalf("write mapping: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read mapping: %v", err)
+	}
+	// Header plus Project x2, Activity x2, Skill, Task x2, File.
+	if len(rows) != 9 {
+		t.Fatalf("expected 8 mapping rows, got %v", rows)
+	}
+	if rows[1][0] != "Project" || rows[1][1] != "Project-1" || rows[1][2] != "ACME" {
+		t.Fatalf("unexpected first mapping row: %v", rows[1])
+	}
+}
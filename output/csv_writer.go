@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"fmt"
 	"github.com/riadshalaby/gohour/worklog"
+	"io"
 	"os"
 	"strconv"
 	"time"
@@ -18,7 +19,16 @@ func (w *CSVWriter) Write(path string, entries []worklog.Entry) error {
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
+	return WriteCSV(file, entries)
+}
+
+// WriteCSV writes entries as CSV to out. The header names match the generic
+// importer columns and times use RFC3339. The generic mapper treats Billable
+// as a minutes override, so only rows whose billable minutes equal their
+// duration map back unchanged. An empty entries slice produces a header-only
+// file.
+func WriteCSV(out io.Writer, entries []worklog.Entry) error {
+	writer := csv.NewWriter(out)
 	defer writer.Flush()
 
 	headers := []string{"StartDateTime", "EndDateTime", "Billable", "Description", "Project", "Activity", "Skill", "SourceFormat", "SourceMapper", "SourceFile"}
@@ -44,6 +54,7 @@ func (w *CSVWriter) Write(path string, entries []worklog.Entry) error {
 		}
 	}
 
+	writer.Flush()
 	if err := writer.Error(); err != nil {
 		return fmt.Errorf("flush csv output: %w", err)
 	}
@@ -23,6 +23,8 @@ type interval struct {
 	end   time.Time
 }
 
+// BuildDailySummaries groups entries by the calendar day of their start time
+// in the entries' own timezone; callers convert to the configured zone first.
 func BuildDailySummaries(entries []worklog.Entry) []DailySummary {
 	if len(entries) == 0 {
 		return []DailySummary{}
@@ -30,7 +32,7 @@ func BuildDailySummaries(entries []worklog.Entry) []DailySummary {
 
 	byDay := make(map[string][]worklog.Entry)
 	for _, entry := range entries {
-		day := entry.StartDateTime.In(time.Local).Format("2006-01-02")
+		day := entry.StartDateTime.Format("2006-01-02")
 		byDay[day] = append(byDay[day], entry)
 	}
 
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+type dailySummaryJSON struct {
+	Date          string  `json:"date"`
+	Start         string  `json:"start"`
+	End           string  `json:"end"`
+	WorkedHours   float64 `json:"workedHours"`
+	BillableHours float64 `json:"billableHours"`
+	BreakHours    float64 `json:"breakHours"`
+	WorklogCount  int     `json:"worklogCount"`
+}
+
+// WriteDailySummariesJSON writes summaries as an indented JSON array with
+// RFC3339 start/end times. An empty slice is written as [].
+func WriteDailySummariesJSON(out io.Writer, summaries []DailySummary) error {
+	rows := make([]dailySummaryJSON, 0, len(summaries))
+	for _, summary := range summaries {
+		rows = append(rows, dailySummaryJSON{
+			Date:          summary.Date,
+			Start:         summary.StartDateTime.Format(time.RFC3339),
+			End:           summary.EndDateTime.Format(time.RFC3339),
+			WorkedHours:   summary.WorkedHours,
+			BillableHours: summary.BillableHours,
+			BreakHours:    summary.BreakHours,
+			WorklogCount:  summary.WorklogCount,
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(rows); err != nil {
+		return fmt.Errorf("write json summaries: %w", err)
+	}
+	return nil
+}
+
+// WriteDailySummariesTable writes summaries as an aligned text table with the
+// same columns as the daily CSV export.
+func WriteDailySummariesTable(out io.Writer, summaries []DailySummary) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Date\tStart\tEnd\tWorked\tBillable\tBreak\tWorklogs")
+	for _, summary := range summaries {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%.2f\t%.2f\t%.2f\t%d\n",
+			summary.Date,
+			summary.StartDateTime.Format("15:04"),
+			summary.EndDateTime.Format("15:04"),
+			summary.WorkedHours,
+			summary.BillableHours,
+			summary.BreakHours,
+			summary.WorklogCount,
+		)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("write summary table: %w", err)
+	}
+	return nil
+}
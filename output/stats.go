@@ -0,0 +1,200 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/riadshalaby/gohour/worklog"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// noGroupName labels worklogs without a project or activity in the stats.
+const noGroupName = "(none)"
+
+// GroupTotal is the worked time of one group of worklogs. NonBillableHours is
+// the worked time not covered by billable minutes, so zero-billable entries
+// count fully as non-billable.
+type GroupTotal struct {
+	Name             string
+	WorkedHours      float64
+	BillableHours    float64
+	NonBillableHours float64
+	WorklogCount     int
+}
+
+// Stats holds overall totals and totals grouped by project, activity and ISO
+// week. Each group list is sorted by worked hours, largest first.
+type Stats struct {
+	Total      GroupTotal
+	ByProject  []GroupTotal
+	ByActivity []GroupTotal
+	ByWeek     []GroupTotal
+}
+
+// BuildStats aggregates entries into Stats. Weeks are built from the daily
+// summaries and named like "2026-W10".
+func BuildStats(entries []worklog.Entry) Stats {
+	stats := Stats{
+		ByProject:  totalsBy(entries, func(entry worklog.Entry) string { return entry.Project }),
+		ByActivity: totalsBy(entries, func(entry worklog.Entry) string { return entry.Activity }),
+		ByWeek:     TotalsByWeek(BuildDailySummaries(entries)),
+	}
+	stats.Total = GroupTotal{Name: "Total"}
+	for _, week := range stats.ByWeek {
+		stats.Total.WorkedHours += week.WorkedHours
+		stats.Total.BillableHours += week.BillableHours
+		stats.Total.WorklogCount += week.WorklogCount
+	}
+	stats.Total = finishGroupTotal(stats.Total)
+	return stats
+}
+
+// TotalsByWeek sums daily summaries per ISO week of their own timezone.
+func TotalsByWeek(summaries []DailySummary) []GroupTotal {
+	byWeek := make(map[string]GroupTotal)
+	for _, summary := range summaries {
+		year, week := summary.StartDateTime.ISOWeek()
+		name := fmt.Sprintf("%04d-W%02d", year, week)
+		total := byWeek[name]
+		total.Name = name
+		total.WorkedHours += summary.WorkedHours
+		total.BillableHours += summary.BillableHours
+		total.WorklogCount += summary.WorklogCount
+		byWeek[name] = total
+	}
+	return sortedGroupTotals(byWeek)
+}
+
+func totalsBy(entries []worklog.Entry, key func(worklog.Entry) string) []GroupTotal {
+	byName := make(map[string]GroupTotal)
+	for _, entry := range entries {
+		name := strings.TrimSpace(key(entry))
+		if name == "" {
+			name = noGroupName
+		}
+		total := byName[name]
+		total.Name = name
+		if entry.EndDateTime.After(entry.StartDateTime) {
+			total.WorkedHours += entry.EndDateTime.Sub(entry.StartDateTime).Hours()
+		}
+		total.BillableHours += float64(entry.Billable) / 60.0
+		total.WorklogCount++
+		byName[name] = total
+	}
+	return sortedGroupTotals(byName)
+}
+
+func sortedGroupTotals(byName map[string]GroupTotal) []GroupTotal {
+	totals := make([]GroupTotal, 0, len(byName))
+	for _, total := range byName {
+		totals = append(totals, finishGroupTotal(total))
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].WorkedHours != totals[j].WorkedHours {
+			return totals[i].WorkedHours > totals[j].WorkedHours
+		}
+		return totals[i].Name < totals[j].Name
+	})
+	return totals
+}
+
+func finishGroupTotal(total GroupTotal) GroupTotal {
+	total.WorkedHours = roundHours(total.WorkedHours)
+	total.BillableHours = roundHours(total.BillableHours)
+	total.NonBillableHours = roundHours(total.WorkedHours - total.BillableHours)
+	if total.NonBillableHours < 0 {
+		total.NonBillableHours = 0
+	}
+	return total
+}
+
+type groupTotalJSON struct {
+	Name             string  `json:"name"`
+	WorkedHours      float64 `json:"workedHours"`
+	BillableHours    float64 `json:"billableHours"`
+	NonBillableHours float64 `json:"nonBillableHours"`
+	WorklogCount     int     `json:"worklogCount"`
+}
+
+type statsJSON struct {
+	Total      groupTotalJSON   `json:"total"`
+	ByProject  []groupTotalJSON `json:"byProject"`
+	ByActivity []groupTotalJSON `json:"byActivity"`
+	ByWeek     []groupTotalJSON `json:"byWeek"`
+}
+
+// WriteStatsJSON writes stats as an indented JSON object with total,
+// byProject, byActivity and byWeek. Empty groups are written as [].
+func WriteStatsJSON(out io.Writer, stats Stats) error {
+	payload := statsJSON{
+		Total:      toGroupTotalJSON(stats.Total),
+		ByProject:  toGroupTotalsJSON(stats.ByProject),
+		ByActivity: toGroupTotalsJSON(stats.ByActivity),
+		ByWeek:     toGroupTotalsJSON(stats.ByWeek),
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return fmt.Errorf("write json stats: %w", err)
+	}
+	return nil
+}
+
+// WriteStatsTable writes stats as three aligned text tables (project,
+// activity, week) followed by the overall total.
+func WriteStatsTable(out io.Writer, stats Stats) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	sections := []struct {
+		heading string
+		totals  []GroupTotal
+	}{
+		{heading: "Project", totals: stats.ByProject},
+		{heading: "Activity", totals: stats.ByActivity},
+		{heading: "Week", totals: stats.ByWeek},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(writer, "%s\tWorked\tBillable\tNon-billable\tWorklogs\n", section.heading)
+		for _, total := range section.totals {
+			writeGroupTotalRow(writer, total)
+		}
+		fmt.Fprintln(writer)
+	}
+	writeGroupTotalRow(writer, stats.Total)
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("write stats table: %w", err)
+	}
+	return nil
+}
+
+func writeGroupTotalRow(writer io.Writer, total GroupTotal) {
+	fmt.Fprintf(
+		writer,
+		"%s\t%.2f\t%.2f\t%.2f\t%d\n",
+		total.Name,
+		total.WorkedHours,
+		total.BillableHours,
+		total.NonBillableHours,
+		total.WorklogCount,
+	)
+}
+
+func toGroupTotalsJSON(totals []GroupTotal) []groupTotalJSON {
+	rows := make([]groupTotalJSON, 0, len(totals))
+	for _, total := range totals {
+		rows = append(rows, toGroupTotalJSON(total))
+	}
+	return rows
+}
+
+func toGroupTotalJSON(total GroupTotal) groupTotalJSON {
+	return groupTotalJSON{
+		Name:             total.Name,
+		WorkedHours:      total.WorkedHours,
+		BillableHours:    total.BillableHours,
+		NonBillableHours: total.NonBillableHours,
+		WorklogCount:     total.WorklogCount,
+	}
+}
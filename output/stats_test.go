@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestBuildStats_GroupsAndSortsByWorkedHours(t *testing.T) {
+	entries := []worklog.Entry{
+		// 2026-03-02 and 2026-03-04 are in ISO week 10, 2026-03-09 in week 11.
+		{StartDateTime: mustParse(t, "2026-03-02T09:00:00Z"), EndDateTime: mustParse(t, "2026-03-02T10:00:00Z"), Billable: 60, Project: "Alpha", Activity: "Dev"},
+		{StartDateTime: mustParse(t, "2026-03-04T09:00:00Z"), EndDateTime: mustParse(t, "2026-03-04T12:00:00Z"), Billable: 120, Project: "Beta", Activity: "Dev"},
+		{StartDateTime: mustParse(t, "2026-03-09T09:00:00Z"), EndDateTime: mustParse(t, "2026-03-09T09:30:00Z"), Billable: 0, Project: "Alpha", Activity: "Meeting"},
+		{StartDateTime: mustParse(t, "2026-03-09T10:00:00Z"), EndDateTime: mustParse(t, "2026-03-09T10:15:00Z"), Billable: 0},
+	}
+
+	stats := BuildStats(entries)
+
+	assertGroupTotals(t, "project", stats.ByProject, []GroupTotal{
+		{Name: "Beta", WorkedHours: 3, BillableHours: 2, NonBillableHours: 1, WorklogCount: 1},
+		{Name: "Alpha", WorkedHours: 1.5, BillableHours: 1, NonBillableHours: 0.5, WorklogCount: 2},
+		{Name: "(none)", WorkedHours: 0.25, BillableHours: 0, NonBillableHours: 0.25, WorklogCount: 1},
+	})
+	assertGroupTotals(t, "activity", stats.ByActivity, []GroupTotal{
+		{Name: "Dev", WorkedHours: 4, BillableHours: 3, NonBillableHours: 1, WorklogCount: 2},
+		{Name: "Meeting", WorkedHours: 0.5, BillableHours: 0, NonBillableHours: 0.5, WorklogCount: 1},
+		{Name: "(none)", WorkedHours: 0.25, BillableHours: 0, NonBillableHours: 0.25, WorklogCount: 1},
+	})
+	assertGroupTotals(t, "week", stats.ByWeek, []GroupTotal{
+		{Name: "2026-W10", WorkedHours: 4, BillableHours: 3, NonBillableHours: 1, WorklogCount: 2},
+		{Name: "2026-W11", WorkedHours: 0.75, BillableHours: 0, NonBillableHours: 0.75, WorklogCount: 2},
+	})
+	assertGroupTotals(t, "total", []GroupTotal{stats.Total}, []GroupTotal{
+		{Name: "Total", WorkedHours: 4.75, BillableHours: 3, NonBillableHours: 1.75, WorklogCount: 4},
+	})
+}
+
+func TestWriteStatsJSON_EmptyGroupsAreArrays(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteStatsJSON(&out, BuildStats(nil)); err != nil {
+		t.Fatalf("write stats: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, out.String())
+	}
+	for _, key := range []string{"byProject", "byActivity", "byWeek"} {
+		if groups, ok := got[key].([]any); !ok || len(groups) != 0 {
+			t.Fatalf("expected %s to be an empty array, got %v", key, got[key])
+		}
+	}
+}
+
+func assertGroupTotals(t *testing.T, label string, got, want []GroupTotal) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: expected %d groups, got %+v", label, len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s[%d]: expected %+v, got %+v", label, i, want[i], got[i])
+		}
+	}
+}
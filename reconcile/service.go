@@ -2,6 +2,7 @@ package reconcile
 
 import (
 	"fmt"
+	"github.com/riadshalaby/gohour/config"
 	"github.com/riadshalaby/gohour/storage"
 	"github.com/riadshalaby/gohour/worklog"
 	"sort"
@@ -11,10 +12,52 @@ import (
 
 type Result struct {
 	DaysProcessed      int
+	DaysSkipped        int
 	OverlapsBefore     int
 	OverlapsAfter      int
 	EPMEntriesAdjusted int
-	RowsUpdated        int
+	// EntriesUnresolved counts floating entries left at their original
+	// position because moving them would cross midnight or the workday end.
+	EntriesUnresolved int
+	RowsUpdated       int
+	// PlannedUpdates holds the adjusted entries reconciliation computed,
+	// whether or not they were persisted.
+	PlannedUpdates []worklog.Entry
+}
+
+// Options tunes how reconciliation groups and shifts entries.
+type Options struct {
+	// Location defines the calendar day boundaries used to group entries.
+	// Defaults to time.Local.
+	Location *time.Location
+	// SkipManualDays leaves days that contain manually created entries
+	// untouched, since the user may have arranged them on purpose.
+	SkipManualDays bool
+	// FloatingMappers names the source mappers whose entries are shifted
+	// around all other (fixed) entries. Empty means EPM only.
+	FloatingMappers []string
+	// WorkdayEnd is the latest time of day (offset from midnight) a shifted
+	// entry may end at. Zero disables the guard.
+	WorkdayEnd time.Duration
+	// DryRun computes the planned updates without writing them back.
+	DryRun bool
+}
+
+// OptionsFromConfig derives reconcile options from the application config.
+func OptionsFromConfig(cfg config.Config) Options {
+	return Options{
+		Location:        cfg.Location(),
+		SkipManualDays:  cfg.Reconcile.SkipDaysWithManualEntries,
+		FloatingMappers: cfg.Reconcile.FloatingMappers,
+		WorkdayEnd:      cfg.Reconcile.WorkdayEndOffset(),
+	}
+}
+
+func (o Options) location() *time.Location {
+	if o.Location == nil {
+		return time.Local
+	}
+	return o.Location
 }
 
 type interval struct {
@@ -22,18 +65,18 @@ type interval struct {
 	end   time.Time
 }
 
-func Run(store *storage.SQLiteStore) (*Result, error) {
-	return runWithEligibility(store, func(worklog.Entry) bool { return true })
+func Run(store storage.Store, options Options) (*Result, error) {
+	return runWithEligibility(store, options, func(worklog.Entry) bool { return true })
 }
 
-func RunForEligibleIDs(store *storage.SQLiteStore, eligibleIDs map[int64]struct{}) (*Result, error) {
-	return runWithEligibility(store, func(entry worklog.Entry) bool {
+func RunForEligibleIDs(store storage.Store, eligibleIDs map[int64]struct{}, options Options) (*Result, error) {
+	return runWithEligibility(store, options, func(entry worklog.Entry) bool {
 		_, ok := eligibleIDs[entry.ID]
 		return ok
 	})
 }
 
-func runWithEligibility(store *storage.SQLiteStore, canAdjust func(worklog.Entry) bool) (*Result, error) {
+func runWithEligibility(store storage.Store, options Options, canAdjust func(worklog.Entry) bool) (*Result, error) {
 	entries, err := store.ListWorklogs()
 	if err != nil {
 		return nil, err
@@ -44,7 +87,7 @@ func runWithEligibility(store *storage.SQLiteStore, canAdjust func(worklog.Entry
 		return result, nil
 	}
 
-	byDay := groupByDay(entries)
+	byDay := groupByDay(entries, options.location())
 	updates := make([]worklog.Entry, 0, 64)
 	days := sortedKeys(byDay)
 	result.DaysProcessed = len(days)
@@ -52,9 +95,15 @@ func runWithEligibility(store *storage.SQLiteStore, canAdjust func(worklog.Entry
 	for _, day := range days {
 		dayEntries := byDay[day]
 		result.OverlapsBefore += countConflicts(dayEntries)
+		if options.SkipManualDays && containsManualEntry(dayEntries) {
+			result.DaysSkipped++
+			result.OverlapsAfter += countConflicts(dayEntries)
+			continue
+		}
 
-		dayUpdates, adjusted := reconcileDayEligible(dayEntries, canAdjust)
+		dayUpdates, adjusted, unresolved := reconcileDayEligible(dayEntries, options, canAdjust)
 		result.EPMEntriesAdjusted += adjusted
+		result.EntriesUnresolved += unresolved
 		if len(dayUpdates) > 0 {
 			updates = append(updates, dayUpdates...)
 		}
@@ -63,6 +112,11 @@ func runWithEligibility(store *storage.SQLiteStore, canAdjust func(worklog.Entry
 		result.OverlapsAfter += countConflicts(updatedDay)
 	}
 
+	result.PlannedUpdates = updates
+	if options.DryRun {
+		return result, nil
+	}
+
 	updatedRows, err := store.UpdateWorklogTimes(updates)
 	if err != nil {
 		return nil, fmt.Errorf("persist reconciled worklogs: %w", err)
@@ -72,15 +126,24 @@ func runWithEligibility(store *storage.SQLiteStore, canAdjust func(worklog.Entry
 	return result, nil
 }
 
-func groupByDay(entries []worklog.Entry) map[string][]worklog.Entry {
+func groupByDay(entries []worklog.Entry, loc *time.Location) map[string][]worklog.Entry {
 	byDay := make(map[string][]worklog.Entry)
 	for _, entry := range entries {
-		day := entry.StartDateTime.In(time.Local).Format("2006-01-02")
+		day := entry.StartDateTime.In(loc).Format("2006-01-02")
 		byDay[day] = append(byDay[day], entry)
 	}
 	return byDay
 }
 
+func containsManualEntry(entries []worklog.Entry) bool {
+	for _, entry := range entries {
+		if strings.EqualFold(strings.TrimSpace(entry.SourceMapper), "manual") {
+			return true
+		}
+	}
+	return false
+}
+
 func sortedKeys(values map[string][]worklog.Entry) []string {
 	keys := make([]string, 0, len(values))
 	for key := range values {
@@ -91,12 +154,13 @@ func sortedKeys(values map[string][]worklog.Entry) []string {
 }
 
 func reconcileDay(entries []worklog.Entry) ([]worklog.Entry, int) {
-	return reconcileDayEligible(entries, func(worklog.Entry) bool { return true })
+	updates, adjusted, _ := reconcileDayEligible(entries, Options{}, func(worklog.Entry) bool { return true })
+	return updates, adjusted
 }
 
-func reconcileDayEligible(entries []worklog.Entry, canAdjust func(worklog.Entry) bool) ([]worklog.Entry, int) {
+func reconcileDayEligible(entries []worklog.Entry, options Options, canAdjust func(worklog.Entry) bool) ([]worklog.Entry, int, int) {
 	if len(entries) < 2 {
-		return nil, 0
+		return nil, 0, 0
 	}
 
 	dayEntries := append([]worklog.Entry(nil), entries...)
@@ -108,23 +172,24 @@ func reconcileDayEligible(entries []worklog.Entry, canAdjust func(worklog.Entry)
 	})
 
 	busy := make([]interval, 0, len(dayEntries))
-	epmEntries := make([]worklog.Entry, 0, len(dayEntries))
+	floatingEntries := make([]worklog.Entry, 0, len(dayEntries))
 
 	for _, entry := range dayEntries {
-		if isEPMEntry(entry) {
+		if options.isFloating(entry) {
 			if !canAdjust(entry) {
 				busy = addInterval(busy, interval{start: entry.StartDateTime, end: entry.EndDateTime})
 				continue
 			}
-			epmEntries = append(epmEntries, entry)
+			floatingEntries = append(floatingEntries, entry)
 			continue
 		}
 		busy = addInterval(busy, interval{start: entry.StartDateTime, end: entry.EndDateTime})
 	}
 
-	updates := make([]worklog.Entry, 0, len(epmEntries))
+	updates := make([]worklog.Entry, 0, len(floatingEntries))
 	adjusted := 0
-	for _, entry := range epmEntries {
+	unresolved := 0
+	for _, entry := range floatingEntries {
 		duration := entry.EndDateTime.Sub(entry.StartDateTime)
 		if duration <= 0 {
 			duration = time.Duration(entry.Billable) * time.Minute
@@ -135,11 +200,15 @@ func reconcileDayEligible(entries []worklog.Entry, canAdjust func(worklog.Entry)
 
 		newStart := findNextAvailableStart(busy, entry.StartDateTime, duration)
 		newEnd := newStart.Add(duration)
-		if !sameCalendarDay(entry.StartDateTime, newStart) || !sameCalendarDay(entry.StartDateTime, newEnd) {
+		moved := !newStart.Equal(entry.StartDateTime) || !newEnd.Equal(entry.EndDateTime)
+		if moved && (!sameCalendarDay(entry.StartDateTime, newStart, options.Location) ||
+			!sameCalendarDay(entry.StartDateTime, newEnd, options.Location) ||
+			endsAfterWorkday(entry.StartDateTime, newEnd, options)) {
 			busy = addInterval(busy, interval{start: entry.StartDateTime, end: entry.EndDateTime})
+			unresolved++
 			continue
 		}
-		if !newStart.Equal(entry.StartDateTime) || !newEnd.Equal(entry.EndDateTime) {
+		if moved {
 			entry.StartDateTime = newStart
 			entry.EndDateTime = newEnd
 			updates = append(updates, entry)
@@ -149,7 +218,22 @@ func reconcileDayEligible(entries []worklog.Entry, canAdjust func(worklog.Entry)
 		busy = addInterval(busy, interval{start: newStart, end: newEnd})
 	}
 
-	return updates, adjusted
+	return updates, adjusted, unresolved
+}
+
+// endsAfterWorkday reports whether end lies past the configured workday end
+// on the calendar day of dayStart.
+func endsAfterWorkday(dayStart, end time.Time, options Options) bool {
+	if options.WorkdayEnd <= 0 {
+		return false
+	}
+	loc := options.Location
+	if loc == nil {
+		loc = dayStart.Location()
+	}
+	local := dayStart.In(loc)
+	workdayEnd := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).Add(options.WorkdayEnd)
+	return end.After(workdayEnd)
 }
 
 func findNextAvailableStart(busy []interval, desiredStart time.Time, duration time.Duration) time.Time {
@@ -242,6 +326,25 @@ func applyUpdates(dayEntries []worklog.Entry, updates []worklog.Entry) []worklog
 	return result
 }
 
+// isFloating reports whether entry may be shifted by reconciliation. Without
+// configured floating mappers only EPM entries float.
+func (o Options) isFloating(entry worklog.Entry) bool {
+	if len(o.FloatingMappers) == 0 {
+		return isEPMEntry(entry)
+	}
+	mapper := strings.TrimSpace(entry.SourceMapper)
+	for _, floating := range o.FloatingMappers {
+		floating = strings.TrimSpace(floating)
+		if strings.EqualFold(floating, mapper) {
+			return true
+		}
+		if strings.EqualFold(floating, "epm") && isEPMEntry(entry) {
+			return true
+		}
+	}
+	return false
+}
+
 func isEPMEntry(entry worklog.Entry) bool {
 	if strings.EqualFold(strings.TrimSpace(entry.SourceMapper), "epm") {
 		return true
@@ -249,6 +352,12 @@ func isEPMEntry(entry worklog.Entry) bool {
 	return strings.Contains(strings.ToLower(entry.SourceFile), "epmexport")
 }
 
-func sameCalendarDay(a, b time.Time) bool {
+// sameCalendarDay compares calendar days in loc, or in the zone carried by
+// the times when loc is nil.
+func sameCalendarDay(a, b time.Time, loc *time.Location) bool {
+	if loc != nil {
+		a = a.In(loc)
+		b = b.In(loc)
+	}
 	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
 }
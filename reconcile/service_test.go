@@ -51,6 +51,88 @@ func TestReconcileDay_ShiftsEPMEntriesAfterNonEPMIntervals(t *testing.T) {
 	assertTime(t, mustParse(t, "2026-03-10T15:00:00+01:00"), updatedByID[3].EndDateTime, "entry 3 end")
 }
 
+func TestReconcileDayEligible_FloatingGenericShiftsAfterFixedAtwork(t *testing.T) {
+	entries := []worklog.Entry{
+		{
+			ID:            1,
+			StartDateTime: mustParse(t, "2026-03-10T09:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-10T11:00:00+01:00"),
+			SourceMapper:  "atwork",
+		},
+		{
+			ID:            2,
+			StartDateTime: mustParse(t, "2026-03-10T10:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-10T11:00:00+01:00"),
+			SourceMapper:  "generic",
+			Billable:      60,
+		},
+		{
+			ID:            3,
+			StartDateTime: mustParse(t, "2026-03-10T10:30:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-10T11:30:00+01:00"),
+			SourceMapper:  "epm",
+			Billable:      60,
+		},
+	}
+
+	options := Options{FloatingMappers: []string{"generic"}}
+	updates, adjusted, _ := reconcileDayEligible(entries, options, func(worklog.Entry) bool { return true })
+	if adjusted != 1 || len(updates) != 1 {
+		t.Fatalf("expected only the generic entry to move, got adjusted=%d updates=%d", adjusted, len(updates))
+	}
+	if updates[0].ID != 2 {
+		t.Fatalf("expected generic entry 2 to be shifted, got %d", updates[0].ID)
+	}
+	// EPM is fixed now, so the generic entry lands after both fixed intervals.
+	assertTime(t, mustParse(t, "2026-03-10T11:30:00+01:00"), updates[0].StartDateTime, "generic start")
+	assertTime(t, mustParse(t, "2026-03-10T12:30:00+01:00"), updates[0].EndDateTime, "generic end")
+}
+
+func TestReconcileDayEligible_WorkdayEndLeavesEntryAndFlagsUnresolved(t *testing.T) {
+	entries := []worklog.Entry{
+		{
+			ID:            1,
+			StartDateTime: mustParse(t, "2026-03-10T15:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-10T17:30:00+01:00"),
+			SourceMapper:  "generic",
+		},
+		{
+			ID:            2,
+			StartDateTime: mustParse(t, "2026-03-10T16:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-10T17:00:00+01:00"),
+			SourceMapper:  "epm",
+			Billable:      60,
+		},
+		{
+			ID:            3,
+			StartDateTime: mustParse(t, "2026-03-10T08:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-10T09:00:00+01:00"),
+			SourceMapper:  "epm",
+			Billable:      60,
+		},
+	}
+
+	options := Options{
+		Location:   time.FixedZone("CET", 3600),
+		WorkdayEnd: 18 * time.Hour,
+	}
+	updates, adjusted, unresolved := reconcileDayEligible(entries, options, func(worklog.Entry) bool { return true })
+	// Entry 2 would move to 17:30-18:30, past the 18:00 workday end.
+	if len(updates) != 0 || adjusted != 0 {
+		t.Fatalf("expected entry past workday end to stay in place, got adjusted=%d updates=%+v", adjusted, updates)
+	}
+	if unresolved != 1 {
+		t.Fatalf("expected 1 unresolved entry, got %d", unresolved)
+	}
+
+	options.WorkdayEnd = 19 * time.Hour
+	updates, adjusted, unresolved = reconcileDayEligible(entries, options, func(worklog.Entry) bool { return true })
+	if adjusted != 1 || unresolved != 0 || len(updates) != 1 {
+		t.Fatalf("expected entry to move before 19:00, got adjusted=%d unresolved=%d", adjusted, unresolved)
+	}
+	assertTime(t, mustParse(t, "2026-03-10T18:30:00+01:00"), updates[0].EndDateTime, "entry 2 end")
+}
+
 func TestReconcileDay_SkipsAdjustmentThatWouldCrossMidnight(t *testing.T) {
 	entries := []worklog.Entry{
 		{
@@ -140,7 +222,7 @@ func TestRun_PersistsAdjustedEPMRows(t *testing.T) {
 		t.Fatalf("expected 2 inserted rows, got %d", inserted)
 	}
 
-	result, err := Run(store)
+	result, err := Run(store, Options{})
 	if err != nil {
 		t.Fatalf("run reconcile: %v", err)
 	}
@@ -175,6 +257,70 @@ func TestRun_PersistsAdjustedEPMRows(t *testing.T) {
 	assertTime(t, mustParse(t, "2026-03-11T11:00:00+01:00"), epmEntry.EndDateTime, "persisted epm end")
 }
 
+func TestRun_DryRunReturnsPlanWithoutPersisting(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reconcile.db")
+	store, err := storage.OpenSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	entries := []worklog.Entry{
+		{
+			StartDateTime: mustParse(t, "2026-03-11T09:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-11T10:00:00+01:00"),
+			Billable:      60,
+			Description:   "Generic fixed",
+			Project:       "p",
+			Activity:      "a",
+			Skill:         "s",
+			SourceFormat:  "csv",
+			SourceMapper:  "generic",
+			SourceFile:    "generic.csv",
+		},
+		{
+			StartDateTime: mustParse(t, "2026-03-11T08:30:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-11T09:30:00+01:00"),
+			Billable:      60,
+			Description:   "EPM simulated",
+			Project:       "p",
+			Activity:      "a",
+			Skill:         "s",
+			SourceFormat:  "excel",
+			SourceMapper:  "epm",
+			SourceFile:    "EPMExportRZ202601.xlsx",
+		},
+	}
+	if _, err := store.InsertWorklogs(entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	result, err := Run(store, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("run reconcile: %v", err)
+	}
+	if result.RowsUpdated != 0 {
+		t.Fatalf("expected no rows updated in dry run, got %d", result.RowsUpdated)
+	}
+	if result.EPMEntriesAdjusted != 1 || len(result.PlannedUpdates) != 1 {
+		t.Fatalf("expected one planned adjustment, got adjusted=%d planned=%d", result.EPMEntriesAdjusted, len(result.PlannedUpdates))
+	}
+	if result.OverlapsBefore != 1 || result.OverlapsAfter != 0 {
+		t.Fatalf("unexpected conflict stats: before=%d after=%d", result.OverlapsBefore, result.OverlapsAfter)
+	}
+	assertTime(t, mustParse(t, "2026-03-11T10:00:00+01:00"), result.PlannedUpdates[0].StartDateTime, "planned epm start")
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	for _, entry := range listed {
+		if entry.SourceMapper == "epm" {
+			assertTime(t, mustParse(t, "2026-03-11T08:30:00+01:00"), entry.StartDateTime, "unchanged epm start")
+		}
+	}
+}
+
 func TestRunForEligibleIDs_UpdatesOnlyEligibleRows(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "reconcile-subset.db")
 	store, err := storage.OpenSQLite(dbPath)
@@ -240,7 +386,7 @@ func TestRunForEligibleIDs_UpdatesOnlyEligibleRows(t *testing.T) {
 		t.Fatalf("expected eligible epm row id")
 	}
 
-	result, err := RunForEligibleIDs(store, map[int64]struct{}{eligibleID: {}})
+	result, err := RunForEligibleIDs(store, map[int64]struct{}{eligibleID: {}}, Options{})
 	if err != nil {
 		t.Fatalf("run subset reconcile: %v", err)
 	}
@@ -286,3 +432,188 @@ func assertTime(t *testing.T, expected, actual time.Time, field string) {
 		t.Fatalf("unexpected %s: expected %s, got %s", field, expected.Format(time.RFC3339), actual.Format(time.RFC3339))
 	}
 }
+
+func TestGroupByDay_UsesConfiguredLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	entries := []worklog.Entry{
+		{ID: 1, StartDateTime: mustParse(t, "2026-03-10T23:30:00Z")},
+		{ID: 2, StartDateTime: mustParse(t, "2026-03-11T08:00:00Z")},
+	}
+
+	byDay := groupByDay(entries, berlin)
+	if len(byDay["2026-03-11"]) != 2 {
+		t.Fatalf("expected both entries on 2026-03-11 in Berlin, got %+v", byDay)
+	}
+
+	byDay = groupByDay(entries, time.UTC)
+	if len(byDay["2026-03-10"]) != 1 || len(byDay["2026-03-11"]) != 1 {
+		t.Fatalf("expected entries split across UTC days, got %+v", byDay)
+	}
+}
+
+func TestRun_GroupsNearMidnightEntriesInConfiguredLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "reconcile-zone.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	// 23:00Z-00:30Z and 00:15Z-01:15Z are 00:00-01:30 and 01:15-02:15 in Berlin.
+	entries := []worklog.Entry{
+		{
+			StartDateTime: mustParse(t, "2026-03-10T23:00:00Z"),
+			EndDateTime:   mustParse(t, "2026-03-11T00:30:00Z"),
+			Billable:      90,
+			Description:   "Night deployment",
+			SourceFormat:  "csv",
+			SourceMapper:  "generic",
+			SourceFile:    "generic.csv",
+		},
+		{
+			StartDateTime: mustParse(t, "2026-03-11T00:15:00Z"),
+			EndDateTime:   mustParse(t, "2026-03-11T01:15:00Z"),
+			Billable:      60,
+			Description:   "EPM simulated",
+			SourceFormat:  "excel",
+			SourceMapper:  "epm",
+			SourceFile:    "EPMExportRZ202603.xlsx",
+		},
+	}
+	if _, err := store.InsertWorklogs(entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	result, err := Run(store, Options{Location: berlin})
+	if err != nil {
+		t.Fatalf("run reconcile: %v", err)
+	}
+	if result.DaysProcessed != 1 {
+		t.Fatalf("expected one Berlin day, got %d", result.DaysProcessed)
+	}
+	if result.EPMEntriesAdjusted != 1 {
+		t.Fatalf("expected epm entry to be shifted, got %d adjustments", result.EPMEntriesAdjusted)
+	}
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	for _, entry := range listed {
+		if entry.SourceMapper == "epm" {
+			assertTime(t, mustParse(t, "2026-03-11T00:30:00Z"), entry.StartDateTime, "shifted epm start")
+		}
+	}
+}
+
+func TestRun_SkipManualDaysLeavesDayUnchanged(t *testing.T) {
+	store, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "reconcile-manual.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	entries := []worklog.Entry{
+		{
+			StartDateTime: mustParse(t, "2026-03-12T09:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-12T10:00:00+01:00"),
+			Billable:      60,
+			Description:   "Manual meeting",
+			SourceFormat:  "manual",
+			SourceMapper:  "manual",
+			SourceFile:    "",
+		},
+		{
+			StartDateTime: mustParse(t, "2026-03-12T09:30:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-12T10:30:00+01:00"),
+			Billable:      60,
+			Description:   "EPM simulated",
+			SourceFormat:  "excel",
+			SourceMapper:  "epm",
+			SourceFile:    "EPMExportRZ202603.xlsx",
+		},
+	}
+	if _, err := store.InsertWorklogs(entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	result, err := Run(store, Options{SkipManualDays: true})
+	if err != nil {
+		t.Fatalf("run reconcile: %v", err)
+	}
+	if result.DaysSkipped != 1 || result.EPMEntriesAdjusted != 0 || result.RowsUpdated != 0 {
+		t.Fatalf("expected manual day to be skipped untouched, got %+v", result)
+	}
+	if result.OverlapsBefore != 1 || result.OverlapsAfter != 1 {
+		t.Fatalf("expected overlap to remain reported, got %+v", result)
+	}
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	for _, entry := range listed {
+		if entry.SourceMapper == "epm" {
+			assertTime(t, mustParse(t, "2026-03-12T09:30:00+01:00"), entry.StartDateTime, "epm start")
+		}
+	}
+
+	result, err = Run(store, Options{})
+	if err != nil {
+		t.Fatalf("run reconcile without option: %v", err)
+	}
+	if result.EPMEntriesAdjusted != 1 {
+		t.Fatalf("expected epm entry to be shifted without the option, got %+v", result)
+	}
+}
+
+func TestRun_PersistsAdjustmentsInMemoryStore(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	entries := []worklog.Entry{
+		{
+			StartDateTime: mustParse(t, "2026-03-11T09:00:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-11T10:00:00+01:00"),
+			Billable:      60,
+			Description:   "Generic fixed",
+			SourceMapper:  "generic",
+			SourceFile:    "generic.csv",
+		},
+		{
+			StartDateTime: mustParse(t, "2026-03-11T08:30:00+01:00"),
+			EndDateTime:   mustParse(t, "2026-03-11T09:30:00+01:00"),
+			Billable:      60,
+			Description:   "EPM simulated",
+			SourceMapper:  "epm",
+			SourceFile:    "EPMExportRZ202601.xlsx",
+		},
+	}
+	if _, err := store.InsertWorklogs(entries); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	result, err := Run(store, Options{})
+	if err != nil {
+		t.Fatalf("run reconcile: %v", err)
+	}
+	if result.RowsUpdated != 1 {
+		t.Fatalf("expected one updated row, got %d", result.RowsUpdated)
+	}
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	for _, entry := range listed {
+		if entry.SourceMapper == "epm" {
+			assertTime(t, mustParse(t, "2026-03-11T10:00:00+01:00"), entry.StartDateTime, "shifted epm start")
+		}
+	}
+}
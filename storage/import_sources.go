@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// ImportSource is one source file of a recorded import, kept with the mapper
+// and import options it was first imported with so the import can be
+// remapped later.
+type ImportSource struct {
+	FileName string
+	Mapper   string
+	Project  string
+	Activity string
+	Skill    string
+	Billable *bool
+	Content  []byte
+}
+
+func (s *SQLiteStore) ensureImportSourcesSchema() error {
+	const schema = `
+CREATE TABLE IF NOT EXISTS import_sources (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	import_id TEXT NOT NULL,
+	file_name TEXT NOT NULL,
+	mapper TEXT NOT NULL,
+	project TEXT NOT NULL DEFAULT '',
+	activity TEXT NOT NULL DEFAULT '',
+	skill TEXT NOT NULL DEFAULT '',
+	billable INTEGER,
+	content BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_import_sources_import_id ON import_sources(import_id);
+`
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("create import sources schema: %w", err)
+	}
+	return nil
+}
+
+// SaveImportSources stores sources for the import recorded under batchID. It
+// returns ErrImportBatchNotFound when no such import is recorded.
+func (s *SQLiteStore) SaveImportSources(batchID string, sources []ImportSource) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	var exists int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM imports WHERE id = ?;`, batchID).Scan(&exists); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("query import %s: %w", batchID, err)
+	}
+	if exists == 0 {
+		_ = tx.Rollback()
+		return ErrImportBatchNotFound
+	}
+
+	for _, source := range sources {
+		var billable sql.NullBool
+		if source.Billable != nil {
+			billable = sql.NullBool{Bool: *source.Billable, Valid: true}
+		}
+		_, err := tx.Exec(
+			`INSERT INTO import_sources (import_id, file_name, mapper, project, activity, skill, billable, content)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+			batchID,
+			source.FileName,
+			source.Mapper,
+			source.Project,
+			source.Activity,
+			source.Skill,
+			billable,
+			source.Content,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("insert import source %s: %w", source.FileName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListImportSources returns the stored sources of the import recorded under
+// batchID in the order they were saved. It is empty when the import kept no
+// sources or does not exist.
+func (s *SQLiteStore) ListImportSources(batchID string) ([]ImportSource, error) {
+	rows, err := s.db.Query(
+		`SELECT file_name, mapper, project, activity, skill, billable, content
+FROM import_sources WHERE import_id = ? ORDER BY id;`,
+		batchID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query import sources: %w", err)
+	}
+	defer rows.Close()
+
+	sources := make([]ImportSource, 0)
+	for rows.Next() {
+		var (
+			source   ImportSource
+			billable sql.NullBool
+		)
+		if err := rows.Scan(&source.FileName, &source.Mapper, &source.Project, &source.Activity, &source.Skill, &billable, &source.Content); err != nil {
+			return nil, fmt.Errorf("scan import source: %w", err)
+		}
+		if billable.Valid {
+			value := billable.Bool
+			source.Billable = &value
+		}
+		sources = append(sources, source)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate import sources: %w", err)
+	}
+	return sources, nil
+}
+
+// ListImportBatchWorklogs returns the worklogs still tagged with batchID,
+// ordered by start time.
+func (s *SQLiteStore) ListImportBatchWorklogs(batchID string) ([]worklog.Entry, error) {
+	rows, err := s.db.Query(listWorklogsColumns+`WHERE import_batch = ?
+ORDER BY start_datetime, id;`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("query import batch worklogs: %w", err)
+	}
+	defer rows.Close()
+	return s.scanWorklogRows(rows)
+}
+
+// ReplaceImportBatchWorklogs deletes the worklogs tagged with batchID and
+// inserts entries under the same batch id in one transaction. It returns the
+// number of removed and inserted rows, and ErrImportBatchNotFound when no
+// such import is recorded. On error nothing is changed.
+func (s *SQLiteStore) ReplaceImportBatchWorklogs(batchID string, entries []worklog.Entry) (int, int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	res, err := tx.Exec(`DELETE FROM worklogs WHERE import_batch = ?;`, batchID)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, 0, fmt.Errorf("delete import batch worklogs: %w", err)
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, 0, fmt.Errorf("read deleted row count: %w", err)
+	}
+
+	stmt, err := tx.Prepare(insertWorklogStmt)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, 0, fmt.Errorf("prepare insert statement: %w", err)
+	}
+	defer stmt.Close()
+
+	inserted := 0
+	for _, entry := range entries {
+		res, err := stmt.Exec(s.worklogInsertArgs(entry)...)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, 0, fmt.Errorf("insert worklog: %w", err)
+		}
+		if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+			continue
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, 0, fmt.Errorf("read inserted row id: %w", err)
+		}
+		if _, err := tx.Exec(`UPDATE worklogs SET import_batch = ? WHERE id = ?;`, batchID, id); err != nil {
+			_ = tx.Rollback()
+			return 0, 0, fmt.Errorf("tag worklog %d with import batch: %w", id, err)
+		}
+		inserted++
+	}
+
+	res, err = tx.Exec(`UPDATE imports SET rows_inserted = ? WHERE id = ?;`, inserted, batchID)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, 0, fmt.Errorf("update import row count: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		_ = tx.Rollback()
+		if err != nil {
+			return 0, 0, fmt.Errorf("read updated import count: %w", err)
+		}
+		return 0, 0, ErrImportBatchNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return int(removed), inserted, nil
+}
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// ErrImportBatchNotFound is returned when no import with the given batch id
+// is recorded.
+var ErrImportBatchNotFound = errors.New("import batch not found")
+
+// ImportRecord describes one import run whose inserted worklogs are tagged
+// with its batch id, so the run can be undone as a whole.
+type ImportRecord struct {
+	ID        string
+	Files     []string
+	Mappers   []string
+	CreatedAt time.Time
+	// RowsInserted is the number of worklogs the import added.
+	RowsInserted int
+	// RowsRemaining is the number of those worklogs still stored.
+	RowsRemaining int
+}
+
+func (s *SQLiteStore) ensureImportsSchema() error {
+	const schema = `
+CREATE TABLE IF NOT EXISTS imports (
+	id TEXT PRIMARY KEY,
+	files TEXT NOT NULL DEFAULT '',
+	mappers TEXT NOT NULL DEFAULT '',
+	rows_inserted INTEGER NOT NULL DEFAULT 0,
+	created_at TEXT NOT NULL
+);
+`
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("create imports schema: %w", err)
+	}
+	if err := s.ensureWorklogsColumn("import_batch", `TEXT NOT NULL DEFAULT ''`); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_worklogs_import_batch ON worklogs(import_batch);`); err != nil {
+		return fmt.Errorf("create import batch index: %w", err)
+	}
+	return nil
+}
+
+// InsertImportWorklogs inserts entries like InsertWorklogs, tags the inserted
+// rows with a new batch id and records the import with files and mappers.
+// Entries ignored by the UNIQUE constraint stay untagged. When nothing is
+// inserted no import is recorded and the returned record has an empty ID. On
+// error the rows of committed chunks stay recorded under the batch id.
+func (s *SQLiteStore) InsertImportWorklogs(files, mappers []string, entries []worklog.Entry) (ImportRecord, error) {
+	record := ImportRecord{
+		Files:     append([]string(nil), files...),
+		Mappers:   append([]string(nil), mappers...),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	batchID, err := newImportBatchID(record.CreatedAt)
+	if err != nil {
+		return ImportRecord{}, err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO imports (id, files, mappers, rows_inserted, created_at) VALUES (?, ?, ?, 0, ?);`,
+		batchID,
+		strings.Join(record.Files, "\n"),
+		strings.Join(record.Mappers, "\n"),
+		record.CreatedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return ImportRecord{}, fmt.Errorf("record import: %w", err)
+	}
+
+	inserted, insertErr := s.insertWorklogsChunked(entries, batchID)
+	if inserted == 0 {
+		if _, err := s.db.Exec(`DELETE FROM imports WHERE id = ?;`, batchID); err != nil && insertErr == nil {
+			insertErr = fmt.Errorf("drop empty import record: %w", err)
+		}
+		return record, insertErr
+	}
+
+	record.ID = batchID
+	record.RowsInserted = inserted
+	record.RowsRemaining = inserted
+	if _, err := s.db.Exec(`UPDATE imports SET rows_inserted = ? WHERE id = ?;`, inserted, batchID); err != nil && insertErr == nil {
+		insertErr = fmt.Errorf("update import row count: %w", err)
+	}
+	return record, insertErr
+}
+
+const listImportRecordsQuery = `
+SELECT
+	imports.id,
+	imports.files,
+	imports.mappers,
+	imports.rows_inserted,
+	imports.created_at,
+	(SELECT COUNT(*) FROM worklogs WHERE worklogs.import_batch = imports.id)
+FROM imports
+`
+
+// ListImportRecords returns all recorded imports, newest first.
+func (s *SQLiteStore) ListImportRecords() ([]ImportRecord, error) {
+	rows, err := s.db.Query(listImportRecordsQuery + `ORDER BY imports.created_at DESC, imports.id DESC;`)
+	if err != nil {
+		return nil, fmt.Errorf("query imports: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]ImportRecord, 0)
+	for rows.Next() {
+		record, err := scanImportRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate imports: %w", err)
+	}
+	return records, nil
+}
+
+// GetImportRecord returns the import recorded under batchID. The second
+// return value is false when no such import exists.
+func (s *SQLiteStore) GetImportRecord(batchID string) (ImportRecord, bool, error) {
+	record, err := scanImportRecord(s.db.QueryRow(listImportRecordsQuery+`WHERE imports.id = ?;`, batchID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ImportRecord{}, false, nil
+	}
+	if err != nil {
+		return ImportRecord{}, false, err
+	}
+	return record, true, nil
+}
+
+// DeleteImportBatch deletes every worklog still tagged with batchID, the
+// import record itself and its stored sources, and returns the number of deleted worklogs. It
+// returns ErrImportBatchNotFound when no such import is recorded.
+func (s *SQLiteStore) DeleteImportBatch(batchID string) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	res, err := tx.Exec(`DELETE FROM imports WHERE id = ?;`, batchID)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("delete import record: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		_ = tx.Rollback()
+		if err != nil {
+			return 0, fmt.Errorf("read deleted import count: %w", err)
+		}
+		return 0, ErrImportBatchNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM import_sources WHERE import_id = ?;`, batchID); err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("delete import sources: %w", err)
+	}
+
+	res, err = tx.Exec(`DELETE FROM worklogs WHERE import_batch = ?;`, batchID)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("delete import batch worklogs: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("read deleted row count: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return deleted, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanImportRecord(row rowScanner) (ImportRecord, error) {
+	var (
+		record     ImportRecord
+		filesRaw   string
+		mappersRaw string
+		createdRaw string
+	)
+	if err := row.Scan(&record.ID, &filesRaw, &mappersRaw, &record.RowsInserted, &createdRaw, &record.RowsRemaining); err != nil {
+		return ImportRecord{}, fmt.Errorf("scan import: %w", err)
+	}
+	record.Files = splitList(filesRaw)
+	record.Mappers = splitList(mappersRaw)
+	createdAt, err := time.Parse(time.RFC3339, createdRaw)
+	if err != nil {
+		return ImportRecord{}, fmt.Errorf("parse import time %q: %w", createdRaw, err)
+	}
+	record.CreatedAt = createdAt
+	return record, nil
+}
+
+// splitList splits a newline-joined column; file names may contain commas.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, "\n")
+}
+
+// newImportBatchID returns an id like "20260316-150405-a1b2c3": the UTC import
+// time plus a random suffix, short enough to type into "import undo".
+func newImportBatchID(now time.Time) (string, error) {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("generate import batch id: %w", err)
+	}
+	return now.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(suffix), nil
+}
@@ -0,0 +1,525 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// errDuplicateWorklog mirrors the UNIQUE constraint failure SQLite reports
+// when an update would make a worklog identical to another one.
+var errDuplicateWorklog = errors.New("an identical worklog is already stored")
+
+// InMemoryStore is a Store kept in memory, for tests of code built on Store.
+// It follows the SQLiteStore semantics: timestamps are kept with second
+// precision and returned in the store zone, inserts ignore duplicates, and
+// IDs are never reused. It is safe for concurrent use.
+type InMemoryStore struct {
+	mu       sync.Mutex
+	location *time.Location
+
+	worklogs       map[int64]worklog.Entry
+	nextWorklogID  int64
+	templates      map[int64]Template
+	nextTemplateID int64
+	imports        map[string]ImportRecord
+	importSources  map[string][]ImportSource
+	// worklogBatches maps worklog IDs to their import batch id. Entries of
+	// deleted worklogs stay but are never read, since IDs are not reused.
+	worklogBatches map[int64]string
+}
+
+// NewInMemoryStore returns an empty store returning timestamps in time.Local.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		location:       time.Local,
+		worklogs:       make(map[int64]worklog.Entry),
+		templates:      make(map[int64]Template),
+		imports:        make(map[string]ImportRecord),
+		importSources:  make(map[string][]ImportSource),
+		worklogBatches: make(map[int64]string),
+	}
+}
+
+// SetLocation sets the zone timestamps are returned in, like
+// SQLiteStore.SetLocation. Nothing needs rewriting, so it always returns 0.
+// A nil loc uses time.Local.
+func (s *InMemoryStore) SetLocation(loc *time.Location) (int, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.location = loc
+	return 0, nil
+}
+
+// Ping always succeeds.
+func (s *InMemoryStore) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// memoryWorklogKey is the UNIQUE key of the SQLite worklogs table.
+type memoryWorklogKey struct {
+	start, end  int64
+	billable    int
+	description string
+	project     string
+	activity    string
+	skill       string
+	sourceFile  string
+}
+
+func worklogKeyOf(entry worklog.Entry) memoryWorklogKey {
+	return memoryWorklogKey{
+		start:       entry.StartDateTime.Unix(),
+		end:         entry.EndDateTime.Unix(),
+		billable:    entry.Billable,
+		description: entry.Description,
+		project:     entry.Project,
+		activity:    entry.Activity,
+		skill:       entry.Skill,
+		sourceFile:  entry.SourceFile,
+	}
+}
+
+// normalizeWorklog returns entry as SQLiteStore would return it after a
+// round trip: times in the store zone without sub-second parts and tags
+// normalized.
+func (s *InMemoryStore) normalizeWorklog(entry worklog.Entry) worklog.Entry {
+	entry.StartDateTime = entry.StartDateTime.Truncate(time.Second)
+	entry.EndDateTime = entry.EndDateTime.Truncate(time.Second)
+	entry.Tags = splitTags(joinTags(entry.Tags))
+	return entry
+}
+
+func (s *InMemoryStore) output(entry worklog.Entry) worklog.Entry {
+	entry.StartDateTime = entry.StartDateTime.In(s.location)
+	entry.EndDateTime = entry.EndDateTime.In(s.location)
+	entry.Tags = append([]string(nil), entry.Tags...)
+	return entry
+}
+
+// hasWorklogKey reports whether a worklog other than excludeID has key.
+func (s *InMemoryStore) hasWorklogKey(key memoryWorklogKey, excludeID int64) bool {
+	for id, stored := range s.worklogs {
+		if id != excludeID && worklogKeyOf(stored) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// insertWorklog stores entry unless its key is taken or its billable minutes
+// are negative, both of which INSERT OR IGNORE skips in SQLite. Resolved
+// OnePoint IDs are not inserted, matching the SQLite insert statement.
+func (s *InMemoryStore) insertWorklog(entry worklog.Entry) (int64, bool) {
+	entry = s.normalizeWorklog(entry)
+	if entry.Billable < 0 || s.hasWorklogKey(worklogKeyOf(entry), 0) {
+		return 0, false
+	}
+	s.nextWorklogID++
+	entry.ID = s.nextWorklogID
+	entry.ProjectID, entry.ActivityID, entry.SkillID = 0, 0, 0
+	s.worklogs[entry.ID] = entry
+	return entry.ID, true
+}
+
+func (s *InMemoryStore) InsertWorklog(entry worklog.Entry) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, inserted := s.insertWorklog(entry)
+	return id, inserted, nil
+}
+
+func (s *InMemoryStore) InsertWorklogs(entries []worklog.Entry) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inserted := 0
+	for _, entry := range entries {
+		if _, ok := s.insertWorklog(entry); ok {
+			inserted++
+		}
+	}
+	return inserted, nil
+}
+
+func (s *InMemoryStore) InsertWorklogsReturningIDs(entries []worklog.Entry) ([]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]int64, len(entries))
+	for i, entry := range entries {
+		ids[i], _ = s.insertWorklog(entry)
+	}
+	return ids, nil
+}
+
+func (s *InMemoryStore) ExistsWorklog(entry worklog.Entry) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hasWorklogKey(worklogKeyOf(s.normalizeWorklog(entry)), 0), nil
+}
+
+// sortedWorklogs returns the stored worklogs matching keep, ordered by start
+// and ID.
+func (s *InMemoryStore) sortedWorklogs(keep func(worklog.Entry) bool) []worklog.Entry {
+	entries := make([]worklog.Entry, 0, len(s.worklogs))
+	for _, entry := range s.worklogs {
+		if keep(entry) {
+			entries = append(entries, s.output(entry))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].StartDateTime.Equal(entries[j].StartDateTime) {
+			return entries[i].StartDateTime.Before(entries[j].StartDateTime)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+func (s *InMemoryStore) ListWorklogs() ([]worklog.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedWorklogs(func(worklog.Entry) bool { return true }), nil
+}
+
+func (s *InMemoryStore) ListWorklogsBetween(from, to time.Time) ([]worklog.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedWorklogs(startsWithin(from, to)), nil
+}
+
+func startsWithin(from, to time.Time) func(worklog.Entry) bool {
+	return func(entry worklog.Entry) bool {
+		return !entry.StartDateTime.Before(from) && !entry.StartDateTime.After(to)
+	}
+}
+
+func (s *InMemoryStore) GetWorklogByID(id int64) (worklog.Entry, bool, error) {
+	if id <= 0 {
+		return worklog.Entry{}, false, fmt.Errorf("worklog id must be > 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.worklogs[id]
+	if !ok {
+		return worklog.Entry{}, false, nil
+	}
+	return s.output(entry), true, nil
+}
+
+func (s *InMemoryStore) UpdateWorklog(entry worklog.Entry) error {
+	if entry.ID <= 0 {
+		return fmt.Errorf("worklog id must be > 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.worklogs[entry.ID]
+	if !ok {
+		return ErrWorklogNotFound
+	}
+
+	updated := stored
+	if stored.Project != entry.Project || stored.Activity != entry.Activity || stored.Skill != entry.Skill {
+		updated.ProjectID, updated.ActivityID, updated.SkillID = 0, 0, 0
+	}
+	updated.StartDateTime = entry.StartDateTime
+	updated.EndDateTime = entry.EndDateTime
+	updated.Billable = entry.Billable
+	updated.Description = entry.Description
+	updated.Project = entry.Project
+	updated.Activity = entry.Activity
+	updated.Skill = entry.Skill
+	updated.LocalNote = entry.LocalNote
+	updated.Tags = entry.Tags
+	updated = s.normalizeWorklog(updated)
+	if updated.Billable < 0 {
+		return fmt.Errorf("update worklog %d: billable must be >= 0, got %d", entry.ID, updated.Billable)
+	}
+	if s.hasWorklogKey(worklogKeyOf(updated), entry.ID) {
+		return fmt.Errorf("update worklog %d: %w", entry.ID, errDuplicateWorklog)
+	}
+	s.worklogs[entry.ID] = updated
+	return nil
+}
+
+// UpdateWorklogTimes updates the entries in order and, when one would
+// duplicate another worklog, rolls all of them back.
+func (s *InMemoryStore) UpdateWorklogTimes(entries []worklog.Entry) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := make(map[int64]worklog.Entry, len(entries))
+	updated := 0
+	for _, entry := range entries {
+		if entry.ID <= 0 {
+			continue
+		}
+		stored, ok := changed[entry.ID]
+		if !ok {
+			if stored, ok = s.worklogs[entry.ID]; !ok {
+				continue
+			}
+		}
+		stored.StartDateTime = entry.StartDateTime
+		stored.EndDateTime = entry.EndDateTime
+		stored = s.normalizeWorklog(stored)
+		key := worklogKeyOf(stored)
+		for id, other := range s.worklogs {
+			if next, ok := changed[id]; ok {
+				other = next
+			}
+			if id != entry.ID && worklogKeyOf(other) == key {
+				return updated, fmt.Errorf("update worklog %d: %w", entry.ID, errDuplicateWorklog)
+			}
+		}
+		changed[entry.ID] = stored
+		updated++
+	}
+
+	for id, entry := range changed {
+		s.worklogs[id] = entry
+	}
+	return updated, nil
+}
+
+func (s *InMemoryStore) SetResolvedIDs(id int64, projectID, activityID, skillID int64) error {
+	if id <= 0 {
+		return fmt.Errorf("worklog id must be > 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.worklogs[id]
+	if !ok {
+		return ErrWorklogNotFound
+	}
+	entry.ProjectID, entry.ActivityID, entry.SkillID = projectID, activityID, skillID
+	s.worklogs[id] = entry
+	return nil
+}
+
+func (s *InMemoryStore) DeleteWorklog(id int64) (bool, error) {
+	if id <= 0 {
+		return false, fmt.Errorf("worklog id must be > 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.worklogs[id]; !ok {
+		return false, nil
+	}
+	delete(s.worklogs, id)
+	return true, nil
+}
+
+// deleteWorklogsWhere removes the worklogs matching match and returns their number.
+func (s *InMemoryStore) deleteWorklogsWhere(match func(worklog.Entry) bool) int {
+	deleted := 0
+	for id, entry := range s.worklogs {
+		if match(entry) {
+			delete(s.worklogs, id)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+func (s *InMemoryStore) DeleteWorklogsByMonth(yearMonth string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	month, err := time.ParseInLocation("2006-01", strings.TrimSpace(yearMonth), s.location)
+	if err != nil {
+		return 0, fmt.Errorf("parse month %q: %w", yearMonth, err)
+	}
+	nextMonth := month.AddDate(0, 1, 0)
+	return s.deleteWorklogsWhere(func(entry worklog.Entry) bool {
+		return !entry.StartDateTime.Before(month) && entry.StartDateTime.Before(nextMonth)
+	}), nil
+}
+
+func (s *InMemoryStore) DeleteWorklogsBetween(from, to time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(s.deleteWorklogsWhere(startsWithin(from, to))), nil
+}
+
+func (s *InMemoryStore) DeleteAllWorklogs() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := len(s.worklogs)
+	s.worklogs = make(map[int64]worklog.Entry)
+	return int64(deleted), nil
+}
+
+func (s *InMemoryStore) ListTemplates() ([]Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	templates := make([]Template, 0, len(s.templates))
+	for _, tpl := range s.templates {
+		templates = append(templates, tpl)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].ID < templates[j].ID
+	})
+	return templates, nil
+}
+
+func (s *InMemoryStore) GetTemplateByID(id int64) (Template, bool, error) {
+	if id <= 0 {
+		return Template{}, false, fmt.Errorf("template id must be > 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tpl, ok := s.templates[id]
+	return tpl, ok, nil
+}
+
+func (s *InMemoryStore) InsertTemplate(tpl Template) (int64, error) {
+	if tpl.Billable < 0 || tpl.DurationMins <= 0 {
+		return 0, fmt.Errorf("insert template: billable must be >= 0 and duration > 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tpl.Name = strings.TrimSpace(tpl.Name)
+	for _, stored := range s.templates {
+		if stored.Name == tpl.Name {
+			return 0, ErrTemplateExists
+		}
+	}
+	s.nextTemplateID++
+	tpl.ID = s.nextTemplateID
+	s.templates[tpl.ID] = tpl
+	return tpl.ID, nil
+}
+
+func (s *InMemoryStore) DeleteTemplate(id int64) (bool, error) {
+	if id <= 0 {
+		return false, fmt.Errorf("template id must be > 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.templates[id]; !ok {
+		return false, nil
+	}
+	delete(s.templates, id)
+	return true, nil
+}
+
+func (s *InMemoryStore) InsertImportWorklogs(files, mappers []string, entries []worklog.Entry) (ImportRecord, error) {
+	record := ImportRecord{
+		Files:     append([]string(nil), files...),
+		Mappers:   append([]string(nil), mappers...),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	batchID, err := newImportBatchID(record.CreatedAt)
+	if err != nil {
+		return ImportRecord{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range entries {
+		if id, ok := s.insertWorklog(entry); ok {
+			s.worklogBatches[id] = batchID
+			record.RowsInserted++
+		}
+	}
+	if record.RowsInserted == 0 {
+		return record, nil
+	}
+	record.ID = batchID
+	record.RowsRemaining = record.RowsInserted
+	s.imports[batchID] = record
+	return record, nil
+}
+
+// batchWorklogIDs returns the IDs of the stored worklogs tagged with batchID.
+func (s *InMemoryStore) batchWorklogIDs(batchID string) []int64 {
+	ids := make([]int64, 0)
+	for id, batch := range s.worklogBatches {
+		if _, ok := s.worklogs[id]; ok && batch == batchID {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *InMemoryStore) GetImportRecord(batchID string) (ImportRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.imports[batchID]
+	if !ok {
+		return ImportRecord{}, false, nil
+	}
+	record.Files = append([]string(nil), record.Files...)
+	record.Mappers = append([]string(nil), record.Mappers...)
+	record.RowsRemaining = len(s.batchWorklogIDs(batchID))
+	return record, true, nil
+}
+
+func (s *InMemoryStore) ListImportBatchWorklogs(batchID string) ([]worklog.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedWorklogs(func(entry worklog.Entry) bool {
+		return s.worklogBatches[entry.ID] == batchID
+	}), nil
+}
+
+func (s *InMemoryStore) SaveImportSources(batchID string, sources []ImportSource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.imports[batchID]; !ok {
+		return ErrImportBatchNotFound
+	}
+	for _, source := range sources {
+		source.Content = append([]byte(nil), source.Content...)
+		if source.Billable != nil {
+			billable := *source.Billable
+			source.Billable = &billable
+		}
+		s.importSources[batchID] = append(s.importSources[batchID], source)
+	}
+	return nil
+}
+
+func (s *InMemoryStore) ListImportSources(batchID string) ([]ImportSource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sources := make([]ImportSource, 0, len(s.importSources[batchID]))
+	for _, source := range s.importSources[batchID] {
+		source.Content = append([]byte(nil), source.Content...)
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func (s *InMemoryStore) ReplaceImportBatchWorklogs(batchID string, entries []worklog.Entry) (int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.imports[batchID]
+	if !ok {
+		return 0, 0, ErrImportBatchNotFound
+	}
+	removedIDs := s.batchWorklogIDs(batchID)
+	for _, id := range removedIDs {
+		delete(s.worklogs, id)
+	}
+	inserted := 0
+	for _, entry := range entries {
+		if id, ok := s.insertWorklog(entry); ok {
+			s.worklogBatches[id] = batchID
+			inserted++
+		}
+	}
+	record.RowsInserted = inserted
+	s.imports[batchID] = record
+	return len(removedIDs), inserted, nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// onePointTenantKey is the store_settings key recording the OnePoint base URL
+// the cached resolved ids and submitted day hashes belong to.
+const onePointTenantKey = "onepoint_base_url"
+
+func (s *SQLiteStore) ensureSettingsSchema() error {
+	const schema = `
+CREATE TABLE IF NOT EXISTS store_settings (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("create settings schema: %w", err)
+	}
+	return nil
+}
+
+// setting returns the value stored under key, "" when none is stored.
+func (s *SQLiteStore) setting(key string) (string, error) {
+	var value string
+	err := s.db.QueryRow(`SELECT value FROM store_settings WHERE key = ?;`, key).Scan(&value)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("read setting %s: %w", key, err)
+	}
+	return value, nil
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func saveSetting(db execer, key, value string) error {
+	const upsertStmt = `
+INSERT INTO store_settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value;`
+	if _, err := db.Exec(upsertStmt, key, value); err != nil {
+		return fmt.Errorf("save setting %s: %w", key, err)
+	}
+	return nil
+}
+
+// UseOnePointTenant scopes the OnePoint caches of the database to baseURL.
+// Resolved project/activity/skill ids and submitted day hashes are only valid
+// for the OnePoint instance they were recorded against, so when the database
+// was last used with another base URL (or with none recorded) both caches are
+// cleared in one transaction. It reports whether anything was cleared.
+func (s *SQLiteStore) UseOnePointTenant(baseURL string) (bool, error) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return false, fmt.Errorf("onepoint base url is required")
+	}
+	recorded, err := s.setting(onePointTenantKey)
+	if err != nil {
+		return false, err
+	}
+	if recorded == baseURL {
+		return false, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	res, err := tx.Exec(`UPDATE worklogs SET project_id = NULL, activity_id = NULL, skill_id = NULL WHERE project_id IS NOT NULL OR activity_id IS NOT NULL OR skill_id IS NOT NULL;`)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, fmt.Errorf("clear resolved ids: %w", err)
+	}
+	clearedIDs, _ := res.RowsAffected()
+	res, err = tx.Exec(`DELETE FROM submitted_days;`)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, fmt.Errorf("clear submitted days: %w", err)
+	}
+	clearedDays, _ := res.RowsAffected()
+	if err := saveSetting(tx, onePointTenantKey, baseURL); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return clearedIDs > 0 || clearedDays > 0, nil
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -11,8 +12,19 @@ import (
 	_ "modernc.org/sqlite"
 )
 
+// DefaultInsertBatchSize is the number of rows InsertWorklogs commits per transaction.
+const DefaultInsertBatchSize = 1000
+
+// busyTimeoutMillis is how long a statement waits for a lock held by another
+// connection or process (e.g. serve and import on the same file) before
+// failing with "database is locked".
+const busyTimeoutMillis = 5000
+
 type SQLiteStore struct {
-	db *sql.DB
+	db              *sql.DB
+	insertBatchSize int
+	// location is the zone timestamps are stored and returned in.
+	location *time.Location
 }
 
 var ErrWorklogNotFound = errors.New("worklog not found")
@@ -23,12 +35,21 @@ func OpenSQLite(path string) (*SQLiteStore, error) {
 		return nil, fmt.Errorf("open sqlite db: %w", err)
 	}
 
+	// SQLite allows a single writer; one pooled connection serializes writers
+	// of this process and keeps the per-connection pragmas below in effect.
+	db.SetMaxOpenConns(1)
+
 	if err := db.Ping(); err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("ping sqlite db: %w", err)
 	}
 
-	store := &SQLiteStore{db: db}
+	if err := configureConnection(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	store := &SQLiteStore{db: db, insertBatchSize: DefaultInsertBatchSize, location: time.Local}
 	if err := store.ensureSchema(); err != nil {
 		_ = db.Close()
 		return nil, err
@@ -37,10 +58,44 @@ func OpenSQLite(path string) (*SQLiteStore, error) {
 	return store, nil
 }
 
+// configureConnection sets the busy timeout and switches the database to WAL
+// so readers (serve) do not block a concurrent writer (import).
+func configureConnection(db *sql.DB) error {
+	if _, err := db.Exec(fmt.Sprintf(`PRAGMA busy_timeout = %d;`, busyTimeoutMillis)); err != nil {
+		return fmt.Errorf("set sqlite busy timeout: %w", err)
+	}
+	if _, err := db.Exec(`PRAGMA journal_mode = WAL;`); err != nil {
+		return fmt.Errorf("enable sqlite WAL mode: %w", err)
+	}
+	return nil
+}
+
+// joinTags stores tags as a normalized comma-separated list.
+func joinTags(tags []string) string {
+	return strings.Join(worklog.NormalizeTags(tags), ",")
+}
+
+// splitTags reverses joinTags; an empty column yields nil tags.
+func splitTags(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	return worklog.ParseTags(raw)
+}
+
 func (s *SQLiteStore) Close() error {
 	return s.db.Close()
 }
 
+// Ping checks that the database is reachable and answers a query.
+func (s *SQLiteStore) Ping(ctx context.Context) error {
+	var one int
+	if err := s.db.QueryRowContext(ctx, `SELECT 1;`).Scan(&one); err != nil {
+		return fmt.Errorf("query sqlite db: %w", err)
+	}
+	return nil
+}
+
 func (s *SQLiteStore) ensureSchema() error {
 	// NOTE: billable changed from CHECK(billable > 0) to CHECK(billable >= 0).
 	// Existing databases are not auto-migrated; delete gohour.db and re-import
@@ -58,28 +113,62 @@ CREATE TABLE IF NOT EXISTS worklogs (
 	source_format TEXT NOT NULL,
 	source_mapper TEXT NOT NULL DEFAULT '',
 	source_file TEXT NOT NULL,
+	local_note TEXT NOT NULL DEFAULT '',
+	tags TEXT NOT NULL DEFAULT '',
+	project_id INTEGER,
+	activity_id INTEGER,
+	skill_id INTEGER,
 	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	UNIQUE(start_datetime, end_datetime, billable, description, project, activity, skill, source_file)
 );
+CREATE INDEX IF NOT EXISTS idx_worklogs_start_datetime ON worklogs(start_datetime);
 `
 	if _, err := s.db.Exec(schema); err != nil {
 		return fmt.Errorf("create schema: %w", err)
 	}
-	if err := s.ensureSourceMapperColumn(); err != nil {
+	if err := s.ensureWorklogsColumn("source_mapper", `TEXT NOT NULL DEFAULT ''`); err != nil {
+		return err
+	}
+	if err := s.ensureWorklogsColumn("local_note", `TEXT NOT NULL DEFAULT ''`); err != nil {
+		return err
+	}
+	if err := s.ensureWorklogsColumn("tags", `TEXT NOT NULL DEFAULT ''`); err != nil {
+		return err
+	}
+	for _, column := range []string{"project_id", "activity_id", "skill_id"} {
+		if err := s.ensureWorklogsColumn(column, `INTEGER`); err != nil {
+			return err
+		}
+	}
+	if err := s.ensureTemplatesSchema(); err != nil {
+		return err
+	}
+	if err := s.ensureSubmittedDaysSchema(); err != nil {
+		return err
+	}
+	if err := s.ensureImportsSchema(); err != nil {
+		return err
+	}
+	if err := s.ensureImportSourcesSchema(); err != nil {
+		return err
+	}
+	if err := s.ensureSettingsSchema(); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *SQLiteStore) ensureSourceMapperColumn() error {
+// ensureWorklogsColumn adds column to worklogs in databases created before
+// the column existed.
+func (s *SQLiteStore) ensureWorklogsColumn(column, definition string) error {
 	rows, err := s.db.Query(`PRAGMA table_info(worklogs);`)
 	if err != nil {
 		return fmt.Errorf("query table info: %w", err)
 	}
 	defer rows.Close()
 
-	hasSourceMapper := false
+	hasColumn := false
 	for rows.Next() {
 		var (
 			cid       int
@@ -92,8 +181,8 @@ func (s *SQLiteStore) ensureSourceMapperColumn() error {
 		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
 			return fmt.Errorf("scan table info: %w", err)
 		}
-		if strings.EqualFold(name, "source_mapper") {
-			hasSourceMapper = true
+		if strings.EqualFold(name, column) {
+			hasColumn = true
 			break
 		}
 	}
@@ -101,28 +190,27 @@ func (s *SQLiteStore) ensureSourceMapperColumn() error {
 		return fmt.Errorf("iterate table info: %w", err)
 	}
 
-	if hasSourceMapper {
+	if hasColumn {
 		return nil
 	}
 
-	if _, err := s.db.Exec(`ALTER TABLE worklogs ADD COLUMN source_mapper TEXT NOT NULL DEFAULT '';`); err != nil {
-		return fmt.Errorf("add source_mapper column: %w", err)
+	if _, err := s.db.Exec(`ALTER TABLE worklogs ADD COLUMN ` + column + ` ` + definition + `;`); err != nil {
+		return fmt.Errorf("add %s column: %w", column, err)
 	}
 
 	return nil
 }
 
-func (s *SQLiteStore) InsertWorklogs(entries []worklog.Entry) (int, error) {
-	if len(entries) == 0 {
-		return 0, nil
-	}
-
-	tx, err := s.db.Begin()
-	if err != nil {
-		return 0, fmt.Errorf("begin transaction: %w", err)
+// SetInsertBatchSize sets how many rows InsertWorklogs commits per transaction.
+// Values <= 0 restore DefaultInsertBatchSize.
+func (s *SQLiteStore) SetInsertBatchSize(size int) {
+	if size <= 0 {
+		size = DefaultInsertBatchSize
 	}
+	s.insertBatchSize = size
+}
 
-	const insertStmt = `
+const insertWorklogStmt = `
 INSERT OR IGNORE INTO worklogs (
 	start_datetime,
 	end_datetime,
@@ -133,78 +221,169 @@ INSERT OR IGNORE INTO worklogs (
 	skill,
 	source_format,
 	source_mapper,
-	source_file
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	source_file,
+	local_note,
+	tags
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+func (s *SQLiteStore) worklogInsertArgs(entry worklog.Entry) []any {
+	return []any{
+		s.formatTime(entry.StartDateTime),
+		s.formatTime(entry.EndDateTime),
+		entry.Billable,
+		entry.Description,
+		entry.Project,
+		entry.Activity,
+		entry.Skill,
+		entry.SourceFormat,
+		entry.SourceMapper,
+		entry.SourceFile,
+		entry.LocalNote,
+		joinTags(entry.Tags),
+	}
+}
 
-	stmt, err := tx.Prepare(insertStmt)
+// InsertWorklogs inserts entries in chunked transactions and returns the number
+// of rows actually inserted (duplicates are ignored by the UNIQUE constraint).
+// On error, batches committed before the failing one are kept.
+func (s *SQLiteStore) InsertWorklogs(entries []worklog.Entry) (int, error) {
+	return s.insertWorklogsChunked(entries, "")
+}
+
+// insertWorklogsChunked inserts entries in transactions of insertBatchSize
+// rows and tags inserted rows with importBatch unless it is empty.
+func (s *SQLiteStore) insertWorklogsChunked(entries []worklog.Entry, importBatch string) (int, error) {
+	batchSize := s.insertBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultInsertBatchSize
+	}
+
+	inserted := 0
+	for start := 0; start < len(entries); start += batchSize {
+		end := min(start+batchSize, len(entries))
+		count, err := s.insertWorklogsBatch(entries[start:end], importBatch)
+		inserted += count
+		if err != nil {
+			return inserted, err
+		}
+	}
+	return inserted, nil
+}
+
+func (s *SQLiteStore) insertWorklogsBatch(entries []worklog.Entry, importBatch string) (int, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	stmt, err := tx.Prepare(insertWorklogStmt)
 	if err != nil {
 		_ = tx.Rollback()
 		return 0, fmt.Errorf("prepare insert statement: %w", err)
 	}
 	defer stmt.Close()
 
+	var tagStmt *sql.Stmt
+	if importBatch != "" {
+		tagStmt, err = tx.Prepare(`UPDATE worklogs SET import_batch = ? WHERE id = ?;`)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("prepare import batch statement: %w", err)
+		}
+		defer tagStmt.Close()
+	}
+
 	inserted := 0
 	for _, entry := range entries {
-		res, err := stmt.Exec(
-			entry.StartDateTime.Format(time.RFC3339),
-			entry.EndDateTime.Format(time.RFC3339),
-			entry.Billable,
-			entry.Description,
-			entry.Project,
-			entry.Activity,
-			entry.Skill,
-			entry.SourceFormat,
-			entry.SourceMapper,
-			entry.SourceFile,
-		)
+		res, err := stmt.Exec(s.worklogInsertArgs(entry)...)
 		if err != nil {
 			_ = tx.Rollback()
-			return inserted, fmt.Errorf("insert worklog: %w", err)
+			return 0, fmt.Errorf("insert worklog: %w", err)
 		}
 
 		rows, err := res.RowsAffected()
-		if err == nil && rows > 0 {
-			inserted++
+		if err != nil || rows == 0 {
+			continue
+		}
+		inserted++
+		if tagStmt == nil {
+			continue
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("read inserted row id: %w", err)
+		}
+		if _, err := tagStmt.Exec(importBatch, id); err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("tag worklog %d with import batch: %w", id, err)
 		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return inserted, fmt.Errorf("commit transaction: %w", err)
+		return 0, fmt.Errorf("commit transaction: %w", err)
 	}
 
 	return inserted, nil
 }
 
+// InsertWorklogsReturningIDs inserts entries in one transaction and returns
+// the new row ID per entry, in input order. An ID of 0 marks an entry ignored
+// by the UNIQUE constraint. On error nothing is inserted.
+func (s *SQLiteStore) InsertWorklogsReturningIDs(entries []worklog.Entry) ([]int64, error) {
+	ids := make([]int64, len(entries))
+	if len(entries) == 0 {
+		return ids, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	stmt, err := tx.Prepare(insertWorklogStmt)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("prepare insert statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, entry := range entries {
+		res, err := stmt.Exec(s.worklogInsertArgs(entry)...)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("insert worklog: %w", err)
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("read inserted row count: %w", err)
+		}
+		if rows == 0 {
+			continue
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, fmt.Errorf("read inserted row id: %w", err)
+		}
+		ids[i] = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return ids, nil
+}
+
 // InsertWorklog inserts one worklog entry and returns the new row ID when inserted.
 // The second return value is false when the row is ignored by the UNIQUE constraint.
 func (s *SQLiteStore) InsertWorklog(entry worklog.Entry) (int64, bool, error) {
-	const insertStmt = `
-INSERT OR IGNORE INTO worklogs (
-	start_datetime,
-	end_datetime,
-	billable,
-	description,
-	project,
-	activity,
-	skill,
-	source_format,
-	source_mapper,
-	source_file
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
-
-	res, err := s.db.Exec(
-		insertStmt,
-		entry.StartDateTime.Format(time.RFC3339),
-		entry.EndDateTime.Format(time.RFC3339),
-		entry.Billable,
-		entry.Description,
-		entry.Project,
-		entry.Activity,
-		entry.Skill,
-		entry.SourceFormat,
-		entry.SourceMapper,
-		entry.SourceFile,
-	)
+	res, err := s.db.Exec(insertWorklogStmt, s.worklogInsertArgs(entry)...)
 	if err != nil {
 		return 0, false, fmt.Errorf("insert worklog: %w", err)
 	}
@@ -227,8 +406,7 @@ INSERT OR IGNORE INTO worklogs (
 	return id, true, nil
 }
 
-func (s *SQLiteStore) ListWorklogs() ([]worklog.Entry, error) {
-	const query = `
+const listWorklogsColumns = `
 SELECT
 	id,
 	start_datetime,
@@ -240,24 +418,69 @@ SELECT
 	skill,
 	source_format,
 	source_mapper,
-	source_file
+	source_file,
+	local_note,
+	tags,
+	project_id,
+	activity_id,
+	skill_id
 FROM worklogs
-ORDER BY start_datetime, id;
 `
 
-	rows, err := s.db.Query(query)
+func (s *SQLiteStore) ListWorklogs() ([]worklog.Entry, error) {
+	rows, err := s.db.Query(listWorklogsColumns + `ORDER BY start_datetime, id;`)
 	if err != nil {
 		return nil, fmt.Errorf("query worklogs: %w", err)
 	}
 	defer rows.Close()
 
+	return s.scanWorklogRows(rows)
+}
+
+// ListWorklogsBetween returns worklogs whose start lies within [from, to],
+// ordered by start_datetime and id.
+//
+// Timestamps are stored as RFC3339 text in the store zone; offsets still vary
+// with DST, so the SQL predicate compares date prefixes padded by one day on
+// each side and the exact bounds are applied after parsing.
+func (s *SQLiteStore) ListWorklogsBetween(from, to time.Time) ([]worklog.Entry, error) {
+	lower := from.AddDate(0, 0, -1).Format("2006-01-02")
+	upper := to.AddDate(0, 0, 2).Format("2006-01-02")
+
+	rows, err := s.db.Query(listWorklogsColumns+`WHERE start_datetime BETWEEN ? AND ?
+ORDER BY start_datetime, id;`, lower, upper)
+	if err != nil {
+		return nil, fmt.Errorf("query worklogs between: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := s.scanWorklogRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := entries[:0]
+	for _, entry := range entries {
+		if entry.StartDateTime.Before(from) || entry.StartDateTime.After(to) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered, nil
+}
+
+func (s *SQLiteStore) scanWorklogRows(rows *sql.Rows) ([]worklog.Entry, error) {
 	entries := make([]worklog.Entry, 0, 256)
 	for rows.Next() {
 		var (
 			id       int64
 			startRaw string
 			endRaw   string
+			tagsRaw  string
 			entry    worklog.Entry
+			err      error
+
+			projectID, activityID, skillID sql.NullInt64
 		)
 
 		if err := rows.Scan(
@@ -272,16 +495,23 @@ ORDER BY start_datetime, id;
 			&entry.SourceFormat,
 			&entry.SourceMapper,
 			&entry.SourceFile,
+			&entry.LocalNote,
+			&tagsRaw,
+			&projectID,
+			&activityID,
+			&skillID,
 		); err != nil {
 			return nil, fmt.Errorf("scan worklog: %w", err)
 		}
 		entry.ID = id
+		entry.Tags = splitTags(tagsRaw)
+		entry.ProjectID, entry.ActivityID, entry.SkillID = projectID.Int64, activityID.Int64, skillID.Int64
 
-		entry.StartDateTime, err = time.Parse(time.RFC3339, startRaw)
+		entry.StartDateTime, err = s.parseTime(startRaw)
 		if err != nil {
 			return nil, fmt.Errorf("parse start datetime %q: %w", startRaw, err)
 		}
-		entry.EndDateTime, err = time.Parse(time.RFC3339, endRaw)
+		entry.EndDateTime, err = s.parseTime(endRaw)
 		if err != nil {
 			return nil, fmt.Errorf("parse end datetime %q: %w", endRaw, err)
 		}
@@ -296,6 +526,43 @@ ORDER BY start_datetime, id;
 	return entries, nil
 }
 
+// ExistsWorklog reports whether a row with the same UNIQUE key as entry
+// (times, billable, description, project/activity/skill, source file) is stored.
+func (s *SQLiteStore) ExistsWorklog(entry worklog.Entry) (bool, error) {
+	const query = `
+SELECT 1
+FROM worklogs
+WHERE start_datetime = ?
+	AND end_datetime = ?
+	AND billable = ?
+	AND description = ?
+	AND project = ?
+	AND activity = ?
+	AND skill = ?
+	AND source_file = ?
+LIMIT 1;`
+
+	var found int
+	err := s.db.QueryRow(
+		query,
+		s.formatTime(entry.StartDateTime),
+		s.formatTime(entry.EndDateTime),
+		entry.Billable,
+		entry.Description,
+		entry.Project,
+		entry.Activity,
+		entry.Skill,
+		entry.SourceFile,
+	).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("query worklog existence: %w", err)
+	}
+	return true, nil
+}
+
 // GetWorklogByID returns one worklog by ID.
 func (s *SQLiteStore) GetWorklogByID(id int64) (worklog.Entry, bool, error) {
 	if id <= 0 {
@@ -314,7 +581,12 @@ SELECT
 	skill,
 	source_format,
 	source_mapper,
-	source_file
+	source_file,
+	local_note,
+	tags,
+	project_id,
+	activity_id,
+	skill_id
 FROM worklogs
 WHERE id = ?;
 `
@@ -323,6 +595,9 @@ WHERE id = ?;
 		entry    worklog.Entry
 		startRaw string
 		endRaw   string
+		tagsRaw  string
+
+		projectID, activityID, skillID sql.NullInt64
 	)
 
 	err := s.db.QueryRow(query, id).Scan(
@@ -337,6 +612,11 @@ WHERE id = ?;
 		&entry.SourceFormat,
 		&entry.SourceMapper,
 		&entry.SourceFile,
+		&entry.LocalNote,
+		&tagsRaw,
+		&projectID,
+		&activityID,
+		&skillID,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -344,12 +624,14 @@ WHERE id = ?;
 		}
 		return worklog.Entry{}, false, fmt.Errorf("query worklog %d: %w", id, err)
 	}
+	entry.Tags = splitTags(tagsRaw)
+	entry.ProjectID, entry.ActivityID, entry.SkillID = projectID.Int64, activityID.Int64, skillID.Int64
 
-	entry.StartDateTime, err = time.Parse(time.RFC3339, startRaw)
+	entry.StartDateTime, err = s.parseTime(startRaw)
 	if err != nil {
 		return worklog.Entry{}, false, fmt.Errorf("parse start datetime %q: %w", startRaw, err)
 	}
-	entry.EndDateTime, err = time.Parse(time.RFC3339, endRaw)
+	entry.EndDateTime, err = s.parseTime(endRaw)
 	if err != nil {
 		return worklog.Entry{}, false, fmt.Errorf("parse end datetime %q: %w", endRaw, err)
 	}
@@ -357,32 +639,76 @@ WHERE id = ?;
 	return entry, true, nil
 }
 
+// worklogIDChunkSize bounds the ids per query in GetWorklogsByIDs, staying
+// well below SQLite's bound parameter limit.
+const worklogIDChunkSize = 500
+
+// GetWorklogsByIDs returns the worklogs with the given ids keyed by id. Ids
+// that do not exist are missing from the map.
+func (s *SQLiteStore) GetWorklogsByIDs(ids []int64) (map[int64]worklog.Entry, error) {
+	byID := make(map[int64]worklog.Entry, len(ids))
+	for start := 0; start < len(ids); start += worklogIDChunkSize {
+		chunk := ids[start:min(start+worklogIDChunkSize, len(ids))]
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(chunk)), ",")
+
+		rows, err := s.db.Query(listWorklogsColumns+`WHERE id IN (`+placeholders+`);`, args...)
+		if err != nil {
+			return nil, fmt.Errorf("query worklogs by ids: %w", err)
+		}
+		entries, err := s.scanWorklogRows(rows)
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			byID[entry.ID] = entry
+		}
+	}
+	return byID, nil
+}
+
 // UpdateWorklog replaces all user-editable fields for the row with the given ID.
+// Cached OnePoint ids are cleared when project, activity or skill change.
 func (s *SQLiteStore) UpdateWorklog(entry worklog.Entry) error {
 	if entry.ID <= 0 {
 		return fmt.Errorf("worklog id must be > 0")
 	}
 
+	// SET expressions see the old row, so the CASE compares the stored names.
 	const updateStmt = `
 UPDATE worklogs
-SET start_datetime = ?,
+SET project_id = CASE WHEN project = ? AND activity = ? AND skill = ? THEN project_id END,
+	activity_id = CASE WHEN project = ? AND activity = ? AND skill = ? THEN activity_id END,
+	skill_id = CASE WHEN project = ? AND activity = ? AND skill = ? THEN skill_id END,
+	start_datetime = ?,
 	end_datetime = ?,
 	billable = ?,
 	description = ?,
 	project = ?,
 	activity = ?,
-	skill = ?
+	skill = ?,
+	local_note = ?,
+	tags = ?
 WHERE id = ?;`
 
 	res, err := s.db.Exec(
 		updateStmt,
-		entry.StartDateTime.Format(time.RFC3339),
-		entry.EndDateTime.Format(time.RFC3339),
+		entry.Project, entry.Activity, entry.Skill,
+		entry.Project, entry.Activity, entry.Skill,
+		entry.Project, entry.Activity, entry.Skill,
+		s.formatTime(entry.StartDateTime),
+		s.formatTime(entry.EndDateTime),
 		entry.Billable,
 		entry.Description,
 		entry.Project,
 		entry.Activity,
 		entry.Skill,
+		entry.LocalNote,
+		joinTags(entry.Tags),
 		entry.ID,
 	)
 	if err != nil {
@@ -400,6 +726,29 @@ WHERE id = ?;`
 	return nil
 }
 
+// SetResolvedIDs stores the OnePoint project, activity and skill ids resolved
+// for the row's names, so later submits can skip resolving them again.
+func (s *SQLiteStore) SetResolvedIDs(id int64, projectID, activityID, skillID int64) error {
+	if id <= 0 {
+		return fmt.Errorf("worklog id must be > 0")
+	}
+	res, err := s.db.Exec(
+		`UPDATE worklogs SET project_id = ?, activity_id = ?, skill_id = ? WHERE id = ?;`,
+		projectID, activityID, skillID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("set resolved ids for worklog %d: %w", id, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("read updated row count: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrWorklogNotFound
+	}
+	return nil
+}
+
 // DeleteWorklog removes the row with the given ID.
 func (s *SQLiteStore) DeleteWorklog(id int64) (bool, error) {
 	if id <= 0 {
@@ -422,18 +771,18 @@ func (s *SQLiteStore) DeleteWorklog(id int64) (bool, error) {
 // the given month. yearMonth must be in "YYYY-MM" format.
 // Returns the number of rows deleted.
 func (s *SQLiteStore) DeleteWorklogsByMonth(yearMonth string) (int, error) {
-	month, err := time.ParseInLocation("2006-01", strings.TrimSpace(yearMonth), time.Local)
+	month, err := time.ParseInLocation("2006-01", strings.TrimSpace(yearMonth), s.location)
 	if err != nil {
 		return 0, fmt.Errorf("parse month %q: %w", yearMonth, err)
 	}
 
-	monthStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	monthStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, s.location)
 	nextMonthStart := monthStart.AddDate(0, 1, 0)
 
 	res, err := s.db.Exec(
 		`DELETE FROM worklogs WHERE start_datetime >= ? AND start_datetime < ?;`,
-		monthStart.Format(time.RFC3339),
-		nextMonthStart.Format(time.RFC3339),
+		s.formatTime(monthStart),
+		s.formatTime(nextMonthStart),
 	)
 	if err != nil {
 		return 0, fmt.Errorf("delete worklogs by month %q: %w", yearMonth, err)
@@ -446,6 +795,49 @@ func (s *SQLiteStore) DeleteWorklogsByMonth(yearMonth string) (int, error) {
 	return int(rows), nil
 }
 
+// DeleteWorklogsBetween deletes all worklogs whose start lies within
+// [from, to] and returns the number of rows deleted. Bounds are matched the
+// same way as ListWorklogsBetween.
+func (s *SQLiteStore) DeleteWorklogsBetween(from, to time.Time) (int64, error) {
+	entries, err := s.ListWorklogsBetween(from, to)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`DELETE FROM worklogs WHERE id = ?;`)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("prepare delete statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var deleted int64
+	for _, entry := range entries {
+		res, err := stmt.Exec(entry.ID)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("delete worklog %d: %w", entry.ID, err)
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err == nil {
+			deleted += rowsAffected
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit delete transaction: %w", err)
+	}
+	return deleted, nil
+}
+
 func (s *SQLiteStore) UpdateWorklogTimes(entries []worklog.Entry) (int, error) {
 	if len(entries) == 0 {
 		return 0, nil
@@ -475,8 +867,8 @@ WHERE id = ?;
 			continue
 		}
 		res, err := stmt.Exec(
-			entry.StartDateTime.Format(time.RFC3339),
-			entry.EndDateTime.Format(time.RFC3339),
+			s.formatTime(entry.StartDateTime),
+			s.formatTime(entry.EndDateTime),
 			entry.ID,
 		)
 		if err != nil {
@@ -1,9 +1,12 @@
 package storage
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/riadshalaby/gohour/worklog"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 )
@@ -455,3 +458,897 @@ func TestInsertWorklogs_ZeroBillable(t *testing.T) {
 		t.Fatalf("expected billable=0, got %d", entries[0].Billable)
 	}
 }
+
+func TestInsertWorklogs_BatchesAndIgnoresDuplicates(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+	store.SetInsertBatchSize(4)
+
+	base := time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local)
+	entries := make([]worklog.Entry, 0, 8)
+	for i := 0; i < 7; i++ {
+		start := base.Add(time.Duration(i) * time.Hour)
+		entries = append(entries, worklog.Entry{
+			StartDateTime: start,
+			EndDateTime:   start.Add(30 * time.Minute),
+			Billable:      30,
+			Description:   "batched",
+			Project:       "p",
+			Activity:      "a",
+			Skill:         "s",
+			SourceFormat:  "csv",
+			SourceFile:    "batch.csv",
+		})
+	}
+	// Duplicate of the first row lands in the middle of the second batch.
+	entries = append(entries[:7:7], entries[0])
+	entries[6], entries[7] = entries[7], entries[6]
+
+	inserted, err := store.InsertWorklogs(entries)
+	if err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+	if inserted != 7 {
+		t.Fatalf("expected 7 inserted rows, got %d", inserted)
+	}
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(listed) != 7 {
+		t.Fatalf("expected 7 stored rows, got %d", len(listed))
+	}
+}
+
+func TestInsertWorklogsReturningIDs_MarksDuplicatesWithZero(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	first := worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local),
+		Billable:      60,
+		Description:   "planned",
+		Project:       "p",
+		Activity:      "a",
+		Skill:         "s",
+		SourceFile:    "web-ui",
+	}
+	second := first
+	second.StartDateTime = first.EndDateTime
+	second.EndDateTime = first.EndDateTime.Add(time.Hour)
+
+	ids, err := store.InsertWorklogsReturningIDs([]worklog.Entry{first, second, first})
+	if err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+	if len(ids) != 3 || ids[0] <= 0 || ids[1] <= 0 || ids[0] == ids[1] || ids[2] != 0 {
+		t.Fatalf("expected two distinct ids and a trailing duplicate, got %v", ids)
+	}
+
+	stored, found, err := store.GetWorklogByID(ids[1])
+	if err != nil || !found {
+		t.Fatalf("get worklog by id: found=%t err=%v", found, err)
+	}
+	if !stored.StartDateTime.Equal(second.StartDateTime) {
+		t.Fatalf("expected id %d to belong to the second entry, got %+v", ids[1], stored)
+	}
+}
+
+func TestGetWorklogsByIDs_ReturnsFoundRowsOnly(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	base := worklog.Entry{
+		StartDateTime: time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local),
+		EndDateTime:   time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local),
+		Billable:      60,
+		Project:       "p",
+		Activity:      "a",
+		Skill:         "s",
+		SourceFile:    "web-ui",
+		Tags:          []string{"ops"},
+	}
+	entries := make([]worklog.Entry, 3)
+	for i := range entries {
+		entries[i] = base
+		entries[i].Description = fmt.Sprintf("task %d", i+1)
+		entries[i].StartDateTime = base.StartDateTime.Add(time.Duration(i) * time.Hour)
+		entries[i].EndDateTime = base.EndDateTime.Add(time.Duration(i) * time.Hour)
+	}
+	ids, err := store.InsertWorklogsReturningIDs(entries)
+	if err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	missing := ids[2] + 100
+	byID, err := store.GetWorklogsByIDs([]int64{ids[0], ids[2], missing})
+	if err != nil {
+		t.Fatalf("get worklogs by ids: %v", err)
+	}
+	if len(byID) != 2 {
+		t.Fatalf("expected two found worklogs, got %+v", byID)
+	}
+	if _, ok := byID[missing]; ok {
+		t.Fatalf("expected missing id %d to be absent", missing)
+	}
+	for _, index := range []int{0, 2} {
+		got, ok := byID[ids[index]]
+		if !ok {
+			t.Fatalf("expected id %d in result", ids[index])
+		}
+		single, _, err := store.GetWorklogByID(ids[index])
+		if err != nil {
+			t.Fatalf("get worklog by id: %v", err)
+		}
+		if got.Description != entries[index].Description || !got.StartDateTime.Equal(single.StartDateTime) ||
+			got.StartDateTime.Location().String() != single.StartDateTime.Location().String() || len(got.Tags) != 1 {
+			t.Fatalf("expected %+v to match GetWorklogByID result %+v", got, single)
+		}
+	}
+
+	// More ids than one query may bind are fetched in several chunks.
+	many := make([]int64, 0, 2*worklogIDChunkSize+1)
+	for id := missing; len(many) < 2*worklogIDChunkSize; id++ {
+		many = append(many, id)
+	}
+	many = append(many, ids[1])
+	byID, err = store.GetWorklogsByIDs(many)
+	if err != nil {
+		t.Fatalf("get worklogs by many ids: %v", err)
+	}
+	if len(byID) != 1 || byID[ids[1]].Description != "task 2" {
+		t.Fatalf("expected only the second entry across chunks, got %+v", byID)
+	}
+}
+
+func TestImportWorklogs_RecordsAndDeletesBatch(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+	// One row per transaction checks that tagging works across chunks.
+	store.SetInsertBatchSize(1)
+
+	entry := func(hour int, description string) worklog.Entry {
+		return worklog.Entry{
+			StartDateTime: time.Date(2026, 3, 2, hour, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 2, hour+1, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   description,
+			Project:       "p",
+			Activity:      "a",
+			Skill:         "s",
+			SourceFile:    "export.csv",
+		}
+	}
+	existing := entry(8, "already stored")
+	if _, err := store.InsertWorklogs([]worklog.Entry{existing}); err != nil {
+		t.Fatalf("insert existing worklog: %v", err)
+	}
+
+	record, err := store.InsertImportWorklogs(
+		[]string{"export, march.csv"},
+		[]string{"generic"},
+		[]worklog.Entry{existing, entry(9, "first"), entry(10, "second")},
+	)
+	if err != nil {
+		t.Fatalf("insert import worklogs: %v", err)
+	}
+	if record.ID == "" || record.RowsInserted != 2 {
+		t.Fatalf("expected a recorded batch with two rows, got %+v", record)
+	}
+
+	records, err := store.ListImportRecords()
+	if err != nil {
+		t.Fatalf("list imports: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != record.ID || records[0].RowsRemaining != 2 ||
+		len(records[0].Files) != 1 || records[0].Files[0] != "export, march.csv" || records[0].Mappers[0] != "generic" {
+		t.Fatalf("unexpected import records: %+v", records)
+	}
+
+	empty, err := store.InsertImportWorklogs([]string{"again.csv"}, []string{"generic"}, []worklog.Entry{existing})
+	if err != nil {
+		t.Fatalf("insert duplicate import: %v", err)
+	}
+	if empty.ID != "" {
+		t.Fatalf("expected no batch for an import without new rows, got %+v", empty)
+	}
+
+	if err := store.SaveImportSources(record.ID, []ImportSource{{FileName: "export, march.csv", Mapper: "generic", Content: []byte("x")}}); err != nil {
+		t.Fatalf("save import sources: %v", err)
+	}
+
+	deleted, err := store.DeleteImportBatch(record.ID)
+	if err != nil {
+		t.Fatalf("delete import batch: %v", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("expected two deleted rows, got %d", deleted)
+	}
+	remaining, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Description != "already stored" {
+		t.Fatalf("expected only the pre-existing row to remain, got %+v", remaining)
+	}
+	if _, found, err := store.GetImportRecord(record.ID); err != nil || found {
+		t.Fatalf("expected the import record to be gone: found=%t err=%v", found, err)
+	}
+	if sources, err := store.ListImportSources(record.ID); err != nil || len(sources) != 0 {
+		t.Fatalf("expected the stored sources to be gone, got %d err=%v", len(sources), err)
+	}
+	if _, err := store.DeleteImportBatch(record.ID); !errors.Is(err, ErrImportBatchNotFound) {
+		t.Fatalf("expected ErrImportBatchNotFound, got %v", err)
+	}
+}
+
+func TestListWorklogsBetween_FiltersAndOrders(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "gohour_test.db")
+	store, err := OpenSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	entry := func(start, end, description string) worklog.Entry {
+		return worklog.Entry{
+			StartDateTime: mustParseRFC3339(t, start),
+			EndDateTime:   mustParseRFC3339(t, end),
+			Billable:      60,
+			Description:   description,
+			Project:       "p",
+			Activity:      "a",
+			Skill:         "s",
+			SourceFormat:  "csv",
+			SourceFile:    "range.csv",
+		}
+	}
+	if _, err := store.InsertWorklogs([]worklog.Entry{
+		entry("2026-03-06T13:00:00+01:00", "2026-03-06T14:00:00+01:00", "afternoon"),
+		entry("2026-03-04T09:00:00+01:00", "2026-03-04T10:00:00+01:00", "before"),
+		entry("2026-03-06T08:00:00+01:00", "2026-03-06T09:00:00+01:00", "morning"),
+		// 23:30 UTC on the 5th is already the 6th at +01:00.
+		entry("2026-03-05T23:30:00Z", "2026-03-06T00:30:00Z", "utc late"),
+		entry("2026-03-07T09:00:00+01:00", "2026-03-07T10:00:00+01:00", "after"),
+	}); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	loc := time.FixedZone("CET", 3600)
+	from := time.Date(2026, 3, 6, 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	listed, err := store.ListWorklogsBetween(from, to)
+	if err != nil {
+		t.Fatalf("list worklogs between: %v", err)
+	}
+
+	got := make([]string, 0, len(listed))
+	for _, item := range listed {
+		got = append(got, item.Description)
+	}
+	// Ordering follows the stored start_datetime text, like ListWorklogs.
+	want := []string{"utc late", "morning", "afternoon"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected entries: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected entries: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDeleteWorklogsBetween_DeletesOnlyRange(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "gohour_test.db")
+	store, err := OpenSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	entry := func(start, end, description string) worklog.Entry {
+		return worklog.Entry{
+			StartDateTime: mustParseRFC3339(t, start),
+			EndDateTime:   mustParseRFC3339(t, end),
+			Billable:      60,
+			Description:   description,
+			Project:       "p",
+			Activity:      "a",
+			Skill:         "s",
+			SourceFormat:  "csv",
+			SourceFile:    "range.csv",
+		}
+	}
+	if _, err := store.InsertWorklogs([]worklog.Entry{
+		entry("2026-03-05T09:00:00+01:00", "2026-03-05T10:00:00+01:00", "before"),
+		entry("2026-03-06T08:00:00+01:00", "2026-03-06T09:00:00+01:00", "morning"),
+		entry("2026-03-05T23:30:00Z", "2026-03-06T00:30:00Z", "utc late"),
+		entry("2026-03-07T09:00:00+01:00", "2026-03-07T10:00:00+01:00", "after"),
+	}); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	loc := time.FixedZone("CET", 3600)
+	from := time.Date(2026, 3, 6, 0, 0, 0, 0, loc)
+	deleted, err := store.DeleteWorklogsBetween(from, from.AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		t.Fatalf("delete worklogs between: %v", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("expected 2 deleted rows, got %d", deleted)
+	}
+
+	remaining, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(remaining) != 2 || remaining[0].Description != "before" || remaining[1].Description != "after" {
+		t.Fatalf("unexpected remaining entries: %+v", remaining)
+	}
+}
+
+func TestLocalNote_RoundTripsAndUpdates(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "gohour_test.db")
+	store, err := OpenSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	id, _, err := store.InsertWorklog(worklog.Entry{
+		StartDateTime: mustParseRFC3339(t, "2026-03-05T08:00:00+01:00"),
+		EndDateTime:   mustParseRFC3339(t, "2026-03-05T09:00:00+01:00"),
+		Billable:      60,
+		Description:   "task",
+		Project:       "p",
+		Activity:      "a",
+		Skill:         "s",
+		SourceFormat:  "manual",
+		SourceMapper:  "manual",
+		SourceFile:    "web-ui",
+		LocalNote:     "ask about overtime",
+	})
+	if err != nil {
+		t.Fatalf("insert worklog: %v", err)
+	}
+
+	entry, _, err := store.GetWorklogByID(id)
+	if err != nil {
+		t.Fatalf("get worklog by id: %v", err)
+	}
+	if entry.LocalNote != "ask about overtime" {
+		t.Fatalf("expected local note to round-trip, got %q", entry.LocalNote)
+	}
+
+	entry.LocalNote = "resolved"
+	if err := store.UpdateWorklog(entry); err != nil {
+		t.Fatalf("update worklog: %v", err)
+	}
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(listed) != 1 || listed[0].LocalNote != "resolved" {
+		t.Fatalf("expected updated local note, got %+v", listed)
+	}
+}
+
+func TestOpenSQLite_AddsLocalNoteColumnToExistingDatabase(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "gohour_test.db")
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open legacy db: %v", err)
+	}
+	if _, err := legacy.Exec(`
+CREATE TABLE worklogs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	start_datetime TEXT NOT NULL,
+	end_datetime TEXT NOT NULL,
+	billable INTEGER NOT NULL CHECK(billable >= 0),
+	description TEXT NOT NULL,
+	project TEXT NOT NULL,
+	activity TEXT NOT NULL,
+	skill TEXT NOT NULL,
+	source_format TEXT NOT NULL,
+	source_mapper TEXT NOT NULL DEFAULT '',
+	source_file TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(start_datetime, end_datetime, billable, description, project, activity, skill, source_file)
+);
+INSERT INTO worklogs (start_datetime, end_datetime, billable, description, project, activity, skill, source_format, source_file)
+VALUES ('2026-03-05T08:00:00+01:00', '2026-03-05T09:00:00+01:00', 60, 'task', 'p', 'a', 's', 'csv', 'a.csv');`); err != nil {
+		t.Fatalf("create legacy schema: %v", err)
+	}
+	_ = legacy.Close()
+
+	store, err := OpenSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(listed) != 1 || listed[0].LocalNote != "" {
+		t.Fatalf("expected legacy row with empty local note, got %+v", listed)
+	}
+}
+
+func TestOpenSQLite_AddsResolvedIDColumnsToExistingDatabase(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "gohour_test.db")
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open legacy db: %v", err)
+	}
+	if _, err := legacy.Exec(`
+CREATE TABLE worklogs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	start_datetime TEXT NOT NULL,
+	end_datetime TEXT NOT NULL,
+	billable INTEGER NOT NULL CHECK(billable >= 0),
+	description TEXT NOT NULL,
+	project TEXT NOT NULL,
+	activity TEXT NOT NULL,
+	skill TEXT NOT NULL,
+	source_format TEXT NOT NULL,
+	source_mapper TEXT NOT NULL DEFAULT '',
+	source_file TEXT NOT NULL,
+	local_note TEXT NOT NULL DEFAULT '',
+	tags TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(start_datetime, end_datetime, billable, description, project, activity, skill, source_file)
+);
+INSERT INTO worklogs (start_datetime, end_datetime, billable, description, project, activity, skill, source_format, source_file)
+VALUES ('2026-03-05T08:00:00+01:00', '2026-03-05T09:00:00+01:00', 60, 'task', 'p', 'a', 's', 'csv', 'a.csv');`); err != nil {
+		t.Fatalf("create legacy schema: %v", err)
+	}
+	_ = legacy.Close()
+
+	store, err := OpenSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	columns := make(map[string]bool)
+	rows, err := store.db.Query(`PRAGMA table_info(worklogs);`)
+	if err != nil {
+		t.Fatalf("query table info: %v", err)
+	}
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			t.Fatalf("scan table info: %v", err)
+		}
+		columns[name] = notNull == 0
+	}
+	_ = rows.Close()
+	for _, column := range []string{"project_id", "activity_id", "skill_id"} {
+		nullable, ok := columns[column]
+		if !ok || !nullable {
+			t.Fatalf("expected nullable column %s, got columns %v", column, columns)
+		}
+	}
+
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ProjectID != 0 || listed[0].ActivityID != 0 || listed[0].SkillID != 0 {
+		t.Fatalf("expected legacy row without resolved ids, got %+v", listed)
+	}
+}
+
+func TestSetResolvedIDs_StoredUntilNamesChange(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	start := time.Date(2026, 3, 5, 8, 0, 0, 0, time.Local)
+	id, _, err := store.InsertWorklog(worklog.Entry{
+		StartDateTime: start,
+		EndDateTime:   start.Add(time.Hour),
+		Billable:      60,
+		Description:   "task",
+		Project:       "p",
+		Activity:      "a",
+		Skill:         "s",
+		SourceFormat:  "csv",
+		SourceFile:    "a.csv",
+	})
+	if err != nil {
+		t.Fatalf("insert worklog: %v", err)
+	}
+	if err := store.SetResolvedIDs(id, 11, 22, 33); err != nil {
+		t.Fatalf("set resolved ids: %v", err)
+	}
+
+	entry, found, err := store.GetWorklogByID(id)
+	if err != nil || !found {
+		t.Fatalf("get worklog: found=%t err=%v", found, err)
+	}
+	if entry.ProjectID != 11 || entry.ActivityID != 22 || entry.SkillID != 33 {
+		t.Fatalf("expected stored ids, got %+v", entry)
+	}
+
+	// Editing other fields keeps the ids.
+	entry.Description = "edited"
+	if err := store.UpdateWorklog(entry); err != nil {
+		t.Fatalf("update worklog: %v", err)
+	}
+	listed, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if listed[0].ProjectID != 11 || listed[0].SkillID != 33 {
+		t.Fatalf("expected ids to survive a description edit, got %+v", listed[0])
+	}
+
+	// Renaming the project clears them.
+	entry.Project = "other"
+	if err := store.UpdateWorklog(entry); err != nil {
+		t.Fatalf("update worklog: %v", err)
+	}
+	entry, _, err = store.GetWorklogByID(id)
+	if err != nil {
+		t.Fatalf("get worklog: %v", err)
+	}
+	if entry.ProjectID != 0 || entry.ActivityID != 0 || entry.SkillID != 0 {
+		t.Fatalf("expected ids to be cleared after a rename, got %+v", entry)
+	}
+
+	if err := store.SetResolvedIDs(id+100, 1, 2, 3); !errors.Is(err, ErrWorklogNotFound) {
+		t.Fatalf("expected ErrWorklogNotFound for unknown id, got %v", err)
+	}
+}
+
+func TestSubmittedDayHashes_SaveAndReplace(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SaveSubmittedDayHash("2026-03-05", "first"); err != nil {
+		t.Fatalf("save hash: %v", err)
+	}
+	if err := store.SaveSubmittedDayHash("2026-03-05", "second"); err != nil {
+		t.Fatalf("replace hash: %v", err)
+	}
+	if err := store.SaveSubmittedDayHash("2026-03-06", "other"); err != nil {
+		t.Fatalf("save hash: %v", err)
+	}
+
+	hashes, err := store.ListSubmittedDayHashes()
+	if err != nil {
+		t.Fatalf("list hashes: %v", err)
+	}
+	if len(hashes) != 2 || hashes["2026-03-05"] != "second" || hashes["2026-03-06"] != "other" {
+		t.Fatalf("unexpected hashes: %v", hashes)
+	}
+}
+
+func TestSQLiteStore_ConcurrentWritersDoNotFailWithLock(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "gohour_test.db")
+	// Two stores on one file mimic serve and import running side by side.
+	stores := make([]*SQLiteStore, 2)
+	for i := range stores {
+		store, err := OpenSQLite(dbPath)
+		if err != nil {
+			t.Fatalf("open sqlite %d: %v", i, err)
+		}
+		defer store.Close()
+		stores[i] = store
+	}
+
+	const writers, perWriter = 4, 50
+	base := time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local)
+	errs := make(chan error, writers)
+	var wg sync.WaitGroup
+	for writer := 0; writer < writers; writer++ {
+		wg.Add(1)
+		go func(writer int) {
+			defer wg.Done()
+			store := stores[writer%len(stores)]
+			for i := 0; i < perWriter; i++ {
+				start := base.Add(time.Duration(i) * time.Minute)
+				_, _, err := store.InsertWorklog(worklog.Entry{
+					StartDateTime: start,
+					EndDateTime:   start.Add(time.Minute),
+					Billable:      1,
+					Description:   "concurrent",
+					Project:       "p",
+					Activity:      "a",
+					Skill:         "s",
+					SourceFormat:  "csv",
+					SourceFile:    fmt.Sprintf("writer-%d.csv", writer),
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(writer)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent insert failed: %v", err)
+	}
+
+	listed, err := stores[1].ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if want := writers * perWriter; len(listed) != want {
+		t.Fatalf("expected %d rows, got %d", want, len(listed))
+	}
+}
+
+func TestSetLocation_StoresTimesFromDifferentZonesConsistently(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	if _, err := store.SetLocation(berlin); err != nil {
+		t.Fatalf("set location: %v", err)
+	}
+
+	// 23:30 UTC on March 4th is 00:30 on March 5th in Berlin.
+	utcStart := time.Date(2026, 3, 4, 23, 30, 0, 0, time.UTC)
+	fixedStart := time.Date(2026, 3, 5, 9, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	base := worklog.Entry{Billable: 30, Description: "zone", Project: "p", Activity: "a", Skill: "s", SourceFile: "zones.csv"}
+	first, second := base, base
+	first.StartDateTime, first.EndDateTime = utcStart, utcStart.Add(30*time.Minute)
+	second.StartDateTime, second.EndDateTime = fixedStart, fixedStart.Add(30*time.Minute)
+	if _, err := store.InsertWorklogs([]worklog.Entry{first, second}); err != nil {
+		t.Fatalf("insert worklogs: %v", err)
+	}
+
+	rows, err := store.db.Query(`SELECT start_datetime FROM worklogs ORDER BY start_datetime;`)
+	if err != nil {
+		t.Fatalf("query raw timestamps: %v", err)
+	}
+	var stored []string
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			t.Fatalf("scan raw timestamp: %v", err)
+		}
+		stored = append(stored, raw)
+	}
+	_ = rows.Close()
+	want := []string{"2026-03-05T00:30:00+01:00", "2026-03-05T05:00:00+01:00"}
+	if fmt.Sprint(stored) != fmt.Sprint(want) {
+		t.Fatalf("expected timestamps stored in Berlin time %v, got %v", want, stored)
+	}
+
+	day := time.Date(2026, 3, 5, 0, 0, 0, 0, berlin)
+	listed, err := store.ListWorklogsBetween(day, day.Add(24*time.Hour-time.Second))
+	if err != nil {
+		t.Fatalf("list worklogs between: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("expected both entries on March 5th in Berlin, got %d", len(listed))
+	}
+	for _, entry := range listed {
+		if entry.StartDateTime.Location() != berlin {
+			t.Fatalf("expected entries returned in Berlin time, got %v", entry.StartDateTime.Location())
+		}
+	}
+}
+
+func TestSetLocation_MigratesExistingRowsAndMergesDuplicates(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	const insertRaw = `INSERT INTO worklogs (start_datetime, end_datetime, billable, description, project, activity, skill, source_format, source_mapper, source_file, local_note, tags, import_batch, project_id, activity_id, skill_id)
+VALUES (?, ?, 60, 'legacy', 'p', 'a', 's', 'csv', 'generic', 'legacy.csv', ?, ?, ?, ?, ?, ?);`
+	for _, row := range []struct {
+		start, end, note, tags, batch string
+		projectID                     any
+	}{
+		{start: "2026-03-05T08:00:00Z", end: "2026-03-05T09:00:00Z", note: "utc note", tags: "review", batch: "20260305-090000-abcdef", projectID: int64(7)},
+		{start: "2026-03-05T10:00:00+01:00", end: "2026-03-05T11:00:00+01:00"},
+		// Same instant as the first row, only written with another offset.
+		{start: "2026-03-05T09:00:00+01:00", end: "2026-03-05T10:00:00+01:00", note: "cet note", tags: "client"},
+	} {
+		var activityID, skillID any
+		if row.projectID != nil {
+			activityID, skillID = int64(8), int64(9)
+		}
+		if _, err := store.db.Exec(insertRaw, row.start, row.end, row.note, row.tags, row.batch, row.projectID, activityID, skillID); err != nil {
+			t.Fatalf("insert legacy row: %v", err)
+		}
+	}
+
+	normalized, err := store.SetLocation(time.FixedZone("CET", 60*60))
+	if err != nil {
+		t.Fatalf("set location: %v", err)
+	}
+	if normalized != 1 {
+		t.Fatalf("expected one rewritten row, got %d", normalized)
+	}
+
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the duplicate instant to collapse into one row, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if got := entry.StartDateTime.Format(time.RFC3339); got[len(got)-6:] != "+01:00" {
+			t.Fatalf("expected normalized +01:00 offset, got %s", got)
+		}
+	}
+	merged := entries[0]
+	if merged.LocalNote != "cet note\nutc note" {
+		t.Fatalf("expected joined local notes, got %q", merged.LocalNote)
+	}
+	if fmt.Sprint(merged.Tags) != "[client review]" {
+		t.Fatalf("expected united tags, got %v", merged.Tags)
+	}
+	if merged.ProjectID != 7 || merged.ActivityID != 8 || merged.SkillID != 9 {
+		t.Fatalf("expected resolved ids of the duplicate, got %d/%d/%d", merged.ProjectID, merged.ActivityID, merged.SkillID)
+	}
+	var batch string
+	if err := store.db.QueryRow(`SELECT import_batch FROM worklogs WHERE id = ?;`, merged.ID).Scan(&batch); err != nil {
+		t.Fatalf("read import batch: %v", err)
+	}
+	if batch != "20260305-090000-abcdef" {
+		t.Fatalf("expected import batch of the duplicate, got %q", batch)
+	}
+
+	again, err := store.SetLocation(time.FixedZone("CET", 60*60))
+	if err != nil || again != 0 {
+		t.Fatalf("expected normalization to be idempotent, got %d, %v", again, err)
+	}
+}
+
+func TestSetLocation_RunsOncePerZone(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	cet := time.FixedZone("CET", 60*60)
+	if _, err := store.SetLocation(cet); err != nil {
+		t.Fatalf("set location: %v", err)
+	}
+	// Written behind the store's back; a recorded migration is not repeated.
+	if _, err := store.db.Exec(`INSERT INTO worklogs (start_datetime, end_datetime, billable, description, project, activity, skill, source_format, source_file)
+VALUES ('2026-03-05T08:00:00Z', '2026-03-05T09:00:00Z', 60, 'raw', 'p', 'a', 's', 'csv', 'raw.csv');`); err != nil {
+		t.Fatalf("insert raw row: %v", err)
+	}
+	if normalized, err := store.SetLocation(cet); err != nil || normalized != 0 {
+		t.Fatalf("expected no rewrite for an already migrated zone, got %d, %v", normalized, err)
+	}
+
+	if normalized, err := store.SetLocation(time.UTC); err != nil || normalized != 0 {
+		t.Fatalf("expected the UTC row to need no rewrite, got %d, %v", normalized, err)
+	}
+	if normalized, err := store.SetLocation(cet); err != nil || normalized != 1 {
+		t.Fatalf("expected a zone change to migrate again, got %d, %v", normalized, err)
+	}
+}
+
+func TestUseOnePointTenant_ClearsCachesOfAnotherTenant(t *testing.T) {
+	t.Parallel()
+
+	store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer store.Close()
+
+	if _, err := store.UseOnePointTenant("https://tenant-a.example.com/"); err != nil {
+		t.Fatalf("use tenant a: %v", err)
+	}
+	id, _, err := store.InsertWorklog(worklog.Entry{
+		StartDateTime: mustParseRFC3339(t, "2026-03-05T09:00:00+01:00"),
+		EndDateTime:   mustParseRFC3339(t, "2026-03-05T10:00:00+01:00"),
+		Billable:      60,
+		Description:   "tenant",
+		Project:       "p",
+		Activity:      "a",
+		Skill:         "s",
+		SourceFile:    "tenant.csv",
+	})
+	if err != nil {
+		t.Fatalf("insert worklog: %v", err)
+	}
+	if err := store.SetResolvedIDs(id, 1, 2, 3); err != nil {
+		t.Fatalf("set resolved ids: %v", err)
+	}
+	if err := store.SaveSubmittedDayHash("2026-03-05", "hash-a"); err != nil {
+		t.Fatalf("save day hash: %v", err)
+	}
+
+	cleared, err := store.UseOnePointTenant("https://tenant-a.example.com")
+	if err != nil || cleared {
+		t.Fatalf("expected the same tenant to keep its caches, cleared=%v err=%v", cleared, err)
+	}
+	entry, _, err := store.GetWorklogByID(id)
+	if err != nil || entry.ProjectID != 1 {
+		t.Fatalf("expected cached ids of tenant a, got %+v err=%v", entry, err)
+	}
+
+	cleared, err = store.UseOnePointTenant("https://tenant-b.example.com")
+	if err != nil || !cleared {
+		t.Fatalf("expected another tenant to clear the caches, cleared=%v err=%v", cleared, err)
+	}
+	entry, _, err = store.GetWorklogByID(id)
+	if err != nil || entry.ProjectID != 0 || entry.ActivityID != 0 || entry.SkillID != 0 {
+		t.Fatalf("expected cleared ids, got %+v err=%v", entry, err)
+	}
+	hashes, err := store.ListSubmittedDayHashes()
+	if err != nil || len(hashes) != 0 {
+		t.Fatalf("expected cleared day hashes, got %v err=%v", hashes, err)
+	}
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// Store is the local storage used by the web server and reconciliation.
+// SQLiteStore keeps it in a database file; InMemoryStore keeps it in memory
+// for tests. Worklogs are unique by start, end, billable, description,
+// project, activity, skill and source file; inserts ignore duplicates.
+type Store interface {
+	// InsertWorklog inserts entry and returns its new ID. The second return
+	// value is false when an identical entry is already stored.
+	InsertWorklog(entry worklog.Entry) (int64, bool, error)
+	// InsertWorklogs inserts entries and returns how many were not
+	// duplicates of stored entries.
+	InsertWorklogs(entries []worklog.Entry) (int, error)
+	// InsertWorklogsReturningIDs inserts entries and returns the new ID per
+	// entry, 0 for duplicates. On error nothing is inserted.
+	InsertWorklogsReturningIDs(entries []worklog.Entry) ([]int64, error)
+	// ExistsWorklog reports whether an entry with the same unique key is stored.
+	ExistsWorklog(entry worklog.Entry) (bool, error)
+	// ListWorklogs returns all worklogs ordered by start and ID.
+	ListWorklogs() ([]worklog.Entry, error)
+	// ListWorklogsBetween returns the worklogs starting within [from, to],
+	// ordered by start and ID.
+	ListWorklogsBetween(from, to time.Time) ([]worklog.Entry, error)
+	// GetWorklogByID returns one worklog. The second return value is false
+	// when no worklog has the ID.
+	GetWorklogByID(id int64) (worklog.Entry, bool, error)
+	// UpdateWorklog replaces the user-editable fields of the worklog with
+	// entry.ID and returns ErrWorklogNotFound when it does not exist.
+	UpdateWorklog(entry worklog.Entry) error
+	// UpdateWorklogTimes sets start and end of the worklogs with the IDs of
+	// entries and returns the number of updated rows.
+	UpdateWorklogTimes(entries []worklog.Entry) (int, error)
+	// SetResolvedIDs caches the OnePoint IDs the worklog's names resolved to.
+	SetResolvedIDs(id int64, projectID, activityID, skillID int64) error
+	// DeleteWorklog removes one worklog and reports whether it existed.
+	DeleteWorklog(id int64) (bool, error)
+	// DeleteWorklogsByMonth removes the worklogs starting in yearMonth
+	// ("YYYY-MM") and returns their number.
+	DeleteWorklogsByMonth(yearMonth string) (int, error)
+	// DeleteWorklogsBetween removes the worklogs starting within [from, to]
+	// and returns their number.
+	DeleteWorklogsBetween(from, to time.Time) (int64, error)
+	// DeleteAllWorklogs removes every worklog and returns their number.
+	DeleteAllWorklogs() (int64, error)
+
+	// ListTemplates returns all templates ordered by name and ID.
+	ListTemplates() ([]Template, error)
+	// GetTemplateByID returns one template. The second return value is false
+	// when no template has the ID.
+	GetTemplateByID(id int64) (Template, bool, error)
+	// InsertTemplate stores tpl and returns its ID, or ErrTemplateExists when
+	// the name is taken.
+	InsertTemplate(tpl Template) (int64, error)
+	// DeleteTemplate removes one template and reports whether it existed.
+	DeleteTemplate(id int64) (bool, error)
+
+	// InsertImportWorklogs inserts entries like InsertWorklogs and records
+	// the inserted rows as one import batch of files and mappers. The
+	// returned record has an empty ID when nothing was inserted.
+	InsertImportWorklogs(files, mappers []string, entries []worklog.Entry) (ImportRecord, error)
+	// GetImportRecord returns one import batch. The second return value is
+	// false when no batch has the ID.
+	GetImportRecord(batchID string) (ImportRecord, bool, error)
+	// ListImportBatchWorklogs returns the worklogs still tagged with batchID.
+	ListImportBatchWorklogs(batchID string) ([]worklog.Entry, error)
+	// SaveImportSources keeps the source files of an import batch for a
+	// later remap, or returns ErrImportBatchNotFound.
+	SaveImportSources(batchID string, sources []ImportSource) error
+	// ListImportSources returns the stored sources of an import batch.
+	ListImportSources(batchID string) ([]ImportSource, error)
+	// ReplaceImportBatchWorklogs replaces the worklogs of an import batch with
+	// entries and returns the removed and inserted counts. On error nothing
+	// is changed.
+	ReplaceImportBatchWorklogs(batchID string, entries []worklog.Entry) (int, int, error)
+
+	// Ping checks that the storage answers.
+	Ping(ctx context.Context) error
+}
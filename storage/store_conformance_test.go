@@ -0,0 +1,389 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// storeFactories are the Store implementations the conformance tests run
+// against; both must behave the same.
+var storeFactories = map[string]func(t *testing.T) Store{
+	"sqlite": func(t *testing.T) Store {
+		store, err := OpenSQLite(filepath.Join(t.TempDir(), "gohour_test.db"))
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	},
+	"memory": func(t *testing.T) Store {
+		return NewInMemoryStore()
+	},
+}
+
+func runStoreConformance(t *testing.T, test func(t *testing.T, store Store)) {
+	t.Helper()
+	for name, newStore := range storeFactories {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			test(t, newStore(t))
+		})
+	}
+}
+
+func conformanceEntry(t *testing.T, start, end, description string) worklog.Entry {
+	t.Helper()
+	return worklog.Entry{
+		StartDateTime: mustParseRFC3339(t, start),
+		EndDateTime:   mustParseRFC3339(t, end),
+		Billable:      60,
+		Description:   description,
+		Project:       "p",
+		Activity:      "a",
+		Skill:         "s",
+		SourceFormat:  "csv",
+		SourceFile:    "conformance.csv",
+	}
+}
+
+func TestStoreConformance_InsertIgnoresDuplicatesAndListsByStart(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		late := conformanceEntry(t, "2026-03-02T14:00:00+01:00", "2026-03-02T15:00:00+01:00", "late")
+		late.Tags = []string{"b", "a", "b"}
+		early := conformanceEntry(t, "2026-03-02T09:00:00+01:00", "2026-03-02T10:00:00+01:00", "early")
+
+		id, inserted, err := store.InsertWorklog(late)
+		if err != nil || !inserted || id <= 0 {
+			t.Fatalf("insert worklog: id=%d inserted=%v err=%v", id, inserted, err)
+		}
+		if _, inserted, err := store.InsertWorklog(late); err != nil || inserted {
+			t.Fatalf("expected duplicate to be ignored, inserted=%v err=%v", inserted, err)
+		}
+		count, err := store.InsertWorklogs([]worklog.Entry{early, late})
+		if err != nil || count != 1 {
+			t.Fatalf("insert worklogs: count=%d err=%v", count, err)
+		}
+		ids, err := store.InsertWorklogsReturningIDs([]worklog.Entry{early, conformanceEntry(t, "2026-03-02T11:00:00+01:00", "2026-03-02T12:00:00+01:00", "mid")})
+		if err != nil {
+			t.Fatalf("insert returning ids: %v", err)
+		}
+		if len(ids) != 2 || ids[0] != 0 || ids[1] <= id {
+			t.Fatalf("unexpected ids %v", ids)
+		}
+
+		exists, err := store.ExistsWorklog(early)
+		if err != nil || !exists {
+			t.Fatalf("expected early entry to exist, exists=%v err=%v", exists, err)
+		}
+
+		listed, err := store.ListWorklogs()
+		if err != nil {
+			t.Fatalf("list worklogs: %v", err)
+		}
+		var descriptions []string
+		for _, entry := range listed {
+			descriptions = append(descriptions, entry.Description)
+		}
+		if len(descriptions) != 3 || descriptions[0] != "early" || descriptions[1] != "mid" || descriptions[2] != "late" {
+			t.Fatalf("unexpected order %v", descriptions)
+		}
+		if !listed[2].StartDateTime.Equal(late.StartDateTime) || listed[2].ID != id {
+			t.Fatalf("unexpected late entry %+v", listed[2])
+		}
+		if len(listed[2].Tags) != 2 || listed[2].Tags[0] != "b" || listed[2].Tags[1] != "a" {
+			t.Fatalf("expected deduplicated tags [b a], got %v", listed[2].Tags)
+		}
+
+		negative := conformanceEntry(t, "2026-03-03T09:00:00+01:00", "2026-03-03T10:00:00+01:00", "negative")
+		negative.Billable = -1
+		if _, inserted, err := store.InsertWorklog(negative); err != nil || inserted {
+			t.Fatalf("expected negative billable to be ignored, inserted=%v err=%v", inserted, err)
+		}
+	})
+}
+
+func TestStoreConformance_BetweenRangesAreInclusive(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		_, err := store.InsertWorklogs([]worklog.Entry{
+			conformanceEntry(t, "2026-03-01T23:00:00Z", "2026-03-01T23:30:00Z", "before"),
+			conformanceEntry(t, "2026-03-02T08:00:00Z", "2026-03-02T09:00:00Z", "from"),
+			conformanceEntry(t, "2026-03-03T08:00:00Z", "2026-03-03T09:00:00Z", "to"),
+			conformanceEntry(t, "2026-03-04T08:00:00Z", "2026-03-04T09:00:00Z", "after"),
+		})
+		if err != nil {
+			t.Fatalf("insert worklogs: %v", err)
+		}
+		from := mustParseRFC3339(t, "2026-03-02T08:00:00Z")
+		to := mustParseRFC3339(t, "2026-03-03T08:00:00Z")
+
+		listed, err := store.ListWorklogsBetween(from, to)
+		if err != nil {
+			t.Fatalf("list between: %v", err)
+		}
+		if len(listed) != 2 || listed[0].Description != "from" || listed[1].Description != "to" {
+			t.Fatalf("unexpected entries %+v", listed)
+		}
+
+		deleted, err := store.DeleteWorklogsBetween(from, to)
+		if err != nil || deleted != 2 {
+			t.Fatalf("delete between: deleted=%d err=%v", deleted, err)
+		}
+		deleted, err = store.DeleteAllWorklogs()
+		if err != nil || deleted != 2 {
+			t.Fatalf("delete all: deleted=%d err=%v", deleted, err)
+		}
+		listed, err = store.ListWorklogs()
+		if err != nil || len(listed) != 0 {
+			t.Fatalf("expected empty store, got %d entries err=%v", len(listed), err)
+		}
+	})
+}
+
+func TestStoreConformance_DeleteWorklogsByMonth(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		loc := time.Local
+		_, err := store.InsertWorklogs([]worklog.Entry{
+			{StartDateTime: time.Date(2026, 2, 28, 23, 0, 0, 0, loc), EndDateTime: time.Date(2026, 2, 28, 23, 30, 0, 0, loc), Description: "february"},
+			{StartDateTime: time.Date(2026, 3, 1, 0, 0, 0, 0, loc), EndDateTime: time.Date(2026, 3, 1, 1, 0, 0, 0, loc), Description: "march"},
+			{StartDateTime: time.Date(2026, 4, 1, 0, 0, 0, 0, loc), EndDateTime: time.Date(2026, 4, 1, 1, 0, 0, 0, loc), Description: "april"},
+		})
+		if err != nil {
+			t.Fatalf("insert worklogs: %v", err)
+		}
+		deleted, err := store.DeleteWorklogsByMonth("2026-03")
+		if err != nil || deleted != 1 {
+			t.Fatalf("delete by month: deleted=%d err=%v", deleted, err)
+		}
+		if _, err := store.DeleteWorklogsByMonth("March"); err == nil {
+			t.Fatalf("expected invalid month to fail")
+		}
+		listed, err := store.ListWorklogs()
+		if err != nil || len(listed) != 2 || listed[0].Description != "february" || listed[1].Description != "april" {
+			t.Fatalf("unexpected remaining entries %+v err=%v", listed, err)
+		}
+	})
+}
+
+func TestStoreConformance_UpdateWorklog(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		first := conformanceEntry(t, "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z", "first")
+		second := conformanceEntry(t, "2026-03-02T11:00:00Z", "2026-03-02T12:00:00Z", "second")
+		ids, err := store.InsertWorklogsReturningIDs([]worklog.Entry{first, second})
+		if err != nil {
+			t.Fatalf("insert worklogs: %v", err)
+		}
+		if err := store.SetResolvedIDs(ids[0], 1, 2, 3); err != nil {
+			t.Fatalf("set resolved ids: %v", err)
+		}
+
+		stored, found, err := store.GetWorklogByID(ids[0])
+		if err != nil || !found || stored.ProjectID != 1 || stored.ActivityID != 2 || stored.SkillID != 3 {
+			t.Fatalf("unexpected stored entry %+v found=%v err=%v", stored, found, err)
+		}
+
+		stored.Description = "edited"
+		stored.LocalNote = "note"
+		stored.SourceFile = "ignored.csv"
+		if err := store.UpdateWorklog(stored); err != nil {
+			t.Fatalf("update worklog: %v", err)
+		}
+		updated, _, err := store.GetWorklogByID(ids[0])
+		if err != nil {
+			t.Fatalf("get worklog: %v", err)
+		}
+		if updated.Description != "edited" || updated.LocalNote != "note" || updated.SourceFile != "conformance.csv" || updated.ProjectID != 1 {
+			t.Fatalf("unexpected updated entry %+v", updated)
+		}
+
+		updated.Project = "other"
+		if err := store.UpdateWorklog(updated); err != nil {
+			t.Fatalf("update project: %v", err)
+		}
+		updated, _, err = store.GetWorklogByID(ids[0])
+		if err != nil || updated.ProjectID != 0 || updated.ActivityID != 0 || updated.SkillID != 0 {
+			t.Fatalf("expected resolved ids to be cleared, got %+v err=%v", updated, err)
+		}
+
+		clash := updated
+		clash.ID = ids[1]
+		if err := store.UpdateWorklog(clash); err == nil {
+			t.Fatalf("expected update duplicating another worklog to fail")
+		}
+		if err := store.UpdateWorklog(worklog.Entry{ID: 999}); !errors.Is(err, ErrWorklogNotFound) {
+			t.Fatalf("expected ErrWorklogNotFound, got %v", err)
+		}
+		if err := store.SetResolvedIDs(999, 1, 2, 3); !errors.Is(err, ErrWorklogNotFound) {
+			t.Fatalf("expected ErrWorklogNotFound, got %v", err)
+		}
+		if _, found, err := store.GetWorklogByID(999); err != nil || found {
+			t.Fatalf("expected missing worklog, found=%v err=%v", found, err)
+		}
+	})
+}
+
+func TestStoreConformance_UpdateWorklogTimes(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		ids, err := store.InsertWorklogsReturningIDs([]worklog.Entry{
+			conformanceEntry(t, "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z", "moved"),
+		})
+		if err != nil {
+			t.Fatalf("insert worklogs: %v", err)
+		}
+		start := mustParseRFC3339(t, "2026-03-02T10:00:00Z")
+		end := mustParseRFC3339(t, "2026-03-02T11:00:00Z")
+		updated, err := store.UpdateWorklogTimes([]worklog.Entry{
+			{ID: ids[0], StartDateTime: start, EndDateTime: end},
+			{ID: 0, StartDateTime: start, EndDateTime: end},
+			{ID: 999, StartDateTime: start, EndDateTime: end},
+		})
+		if err != nil || updated != 1 {
+			t.Fatalf("update times: updated=%d err=%v", updated, err)
+		}
+		entry, _, err := store.GetWorklogByID(ids[0])
+		if err != nil || !entry.StartDateTime.Equal(start) || !entry.EndDateTime.Equal(end) {
+			t.Fatalf("unexpected times %+v err=%v", entry, err)
+		}
+
+		// Moving a worklog onto an identical one fails and keeps earlier moves.
+		twin, _, err := store.InsertWorklog(conformanceEntry(t, "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z", "moved"))
+		if err != nil {
+			t.Fatalf("insert twin: %v", err)
+		}
+		later := mustParseRFC3339(t, "2026-03-02T12:00:00Z")
+		_, err = store.UpdateWorklogTimes([]worklog.Entry{
+			{ID: twin, StartDateTime: later, EndDateTime: later.Add(time.Hour)},
+			{ID: ids[0], StartDateTime: later, EndDateTime: later.Add(time.Hour)},
+		})
+		if err == nil {
+			t.Fatalf("expected duplicate times to fail")
+		}
+		entry, _, err = store.GetWorklogByID(twin)
+		if err != nil || !entry.StartDateTime.Equal(mustParseRFC3339(t, "2026-03-02T09:00:00Z")) {
+			t.Fatalf("expected rolled back twin, got %+v err=%v", entry, err)
+		}
+	})
+}
+
+func TestStoreConformance_DeleteWorklog(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		id, _, err := store.InsertWorklog(conformanceEntry(t, "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z", "gone"))
+		if err != nil {
+			t.Fatalf("insert worklog: %v", err)
+		}
+		if deleted, err := store.DeleteWorklog(id); err != nil || !deleted {
+			t.Fatalf("delete worklog: deleted=%v err=%v", deleted, err)
+		}
+		if deleted, err := store.DeleteWorklog(id); err != nil || deleted {
+			t.Fatalf("second delete: deleted=%v err=%v", deleted, err)
+		}
+		if _, err := store.DeleteWorklog(0); err == nil {
+			t.Fatalf("expected id 0 to be rejected")
+		}
+		next, _, err := store.InsertWorklog(conformanceEntry(t, "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z", "gone"))
+		if err != nil || next <= id {
+			t.Fatalf("expected a fresh id after %d, got %d err=%v", id, next, err)
+		}
+	})
+}
+
+func TestStoreConformance_Templates(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		standup := Template{Name: " Standup ", Project: "p", Activity: "a", Skill: "s", Billable: 15, Description: "Daily", DurationMins: 15}
+		id, err := store.InsertTemplate(standup)
+		if err != nil {
+			t.Fatalf("insert template: %v", err)
+		}
+		if _, err := store.InsertTemplate(Template{Name: "Standup", DurationMins: 15}); !errors.Is(err, ErrTemplateExists) {
+			t.Fatalf("expected ErrTemplateExists, got %v", err)
+		}
+		if _, err := store.InsertTemplate(Template{Name: "Review", DurationMins: 30}); err != nil {
+			t.Fatalf("insert second template: %v", err)
+		}
+
+		templates, err := store.ListTemplates()
+		if err != nil || len(templates) != 2 || templates[0].Name != "Review" || templates[1].Name != "Standup" {
+			t.Fatalf("unexpected templates %+v err=%v", templates, err)
+		}
+		got, found, err := store.GetTemplateByID(id)
+		if err != nil || !found || got.Name != "Standup" || got.Description != "Daily" || got.DurationMins != 15 {
+			t.Fatalf("unexpected template %+v found=%v err=%v", got, found, err)
+		}
+		if deleted, err := store.DeleteTemplate(id); err != nil || !deleted {
+			t.Fatalf("delete template: deleted=%v err=%v", deleted, err)
+		}
+		if _, found, err := store.GetTemplateByID(id); err != nil || found {
+			t.Fatalf("expected deleted template, found=%v err=%v", found, err)
+		}
+		if _, _, err := store.GetTemplateByID(0); err == nil {
+			t.Fatalf("expected id 0 to be rejected")
+		}
+	})
+}
+
+func TestStoreConformance_ImportBatches(t *testing.T) {
+	t.Parallel()
+	runStoreConformance(t, func(t *testing.T, store Store) {
+		fromBatch := conformanceEntry(t, "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z", "old")
+		other := conformanceEntry(t, "2026-03-02T11:00:00Z", "2026-03-02T12:00:00Z", "other")
+		if _, err := store.InsertWorklogs([]worklog.Entry{other}); err != nil {
+			t.Fatalf("insert worklogs: %v", err)
+		}
+		record, err := store.InsertImportWorklogs([]string{"hours.csv"}, []string{"generic"}, []worklog.Entry{fromBatch, other})
+		if err != nil || record.ID == "" || record.RowsInserted != 1 {
+			t.Fatalf("insert import worklogs: record=%+v err=%v", record, err)
+		}
+		if got, found, err := store.GetImportRecord(record.ID); err != nil || !found || got.RowsRemaining != 1 || got.Mappers[0] != "generic" {
+			t.Fatalf("unexpected import record %+v found=%v err=%v", got, found, err)
+		}
+		if _, found, err := store.GetImportRecord("missing"); err != nil || found {
+			t.Fatalf("expected missing import record, found=%v err=%v", found, err)
+		}
+
+		billable := false
+		source := ImportSource{FileName: "hours.csv", Mapper: "generic", Billable: &billable, Content: []byte("a,b\n")}
+		if err := store.SaveImportSources(record.ID, []ImportSource{source}); err != nil {
+			t.Fatalf("save import sources: %v", err)
+		}
+		if err := store.SaveImportSources("missing", []ImportSource{source}); !errors.Is(err, ErrImportBatchNotFound) {
+			t.Fatalf("expected ErrImportBatchNotFound, got %v", err)
+		}
+		sources, err := store.ListImportSources(record.ID)
+		if err != nil || len(sources) != 1 || sources[0].Mapper != "generic" || sources[0].Billable == nil || *sources[0].Billable || string(sources[0].Content) != "a,b\n" {
+			t.Fatalf("unexpected sources %+v err=%v", sources, err)
+		}
+
+		batchEntries, err := store.ListImportBatchWorklogs(record.ID)
+		if err != nil || len(batchEntries) != 1 || batchEntries[0].Description != "old" {
+			t.Fatalf("unexpected batch worklogs %+v err=%v", batchEntries, err)
+		}
+
+		replacement := fromBatch
+		replacement.Description = "new"
+		removed, inserted, err := store.ReplaceImportBatchWorklogs(record.ID, []worklog.Entry{replacement, replacement})
+		if err != nil || removed != 1 || inserted != 1 {
+			t.Fatalf("replace: removed=%d inserted=%d err=%v", removed, inserted, err)
+		}
+		listed, err := store.ListWorklogs()
+		if err != nil || len(listed) != 2 || listed[0].Description != "new" || listed[1].Description != "other" {
+			t.Fatalf("unexpected entries %+v err=%v", listed, err)
+		}
+		batchEntries, err = store.ListImportBatchWorklogs(record.ID)
+		if err != nil || len(batchEntries) != 1 || batchEntries[0].Description != "new" {
+			t.Fatalf("expected the replacement to stay in the batch, got %+v err=%v", batchEntries, err)
+		}
+		if _, _, err := store.ReplaceImportBatchWorklogs("missing", []worklog.Entry{other}); !errors.Is(err, ErrImportBatchNotFound) {
+			t.Fatalf("expected ErrImportBatchNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (s *SQLiteStore) ensureSubmittedDaysSchema() error {
+	const schema = `
+CREATE TABLE IF NOT EXISTS submitted_days (
+	day TEXT PRIMARY KEY,
+	hash TEXT NOT NULL,
+	submitted_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("create submitted days schema: %w", err)
+	}
+	return nil
+}
+
+// ListSubmittedDayHashes returns the hash recorded for each submitted day,
+// keyed by day (YYYY-MM-DD).
+func (s *SQLiteStore) ListSubmittedDayHashes() (map[string]string, error) {
+	rows, err := s.db.Query(`SELECT day, hash FROM submitted_days;`)
+	if err != nil {
+		return nil, fmt.Errorf("query submitted days: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var day, hash string
+		if err := rows.Scan(&day, &hash); err != nil {
+			return nil, fmt.Errorf("scan submitted day: %w", err)
+		}
+		hashes[day] = hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate submitted days: %w", err)
+	}
+	return hashes, nil
+}
+
+// SaveSubmittedDayHash records hash as the last submitted state of day
+// (YYYY-MM-DD), replacing an earlier record.
+func (s *SQLiteStore) SaveSubmittedDayHash(day, hash string) error {
+	day = strings.TrimSpace(day)
+	if day == "" {
+		return fmt.Errorf("submitted day is required")
+	}
+
+	const upsertStmt = `
+INSERT INTO submitted_days (day, hash, submitted_at)
+VALUES (?, ?, CURRENT_TIMESTAMP)
+ON CONFLICT(day) DO UPDATE SET hash = excluded.hash, submitted_at = excluded.submitted_at;`
+	if _, err := s.db.Exec(upsertStmt, day, hash); err != nil {
+		return fmt.Errorf("save submitted day %s: %w", day, err)
+	}
+	return nil
+}
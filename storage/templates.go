@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTemplateExists is returned when a template with the same name is stored.
+var ErrTemplateExists = errors.New("template already exists")
+
+// Template is a named blueprint for recurring worklogs such as a daily standup.
+type Template struct {
+	ID           int64
+	Name         string
+	Project      string
+	Activity     string
+	Skill        string
+	Billable     int
+	Description  string
+	DurationMins int
+}
+
+func (s *SQLiteStore) ensureTemplatesSchema() error {
+	const schema = `
+CREATE TABLE IF NOT EXISTS worklog_templates (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL UNIQUE,
+	project TEXT NOT NULL,
+	activity TEXT NOT NULL,
+	skill TEXT NOT NULL,
+	billable INTEGER NOT NULL CHECK(billable >= 0),
+	description TEXT NOT NULL,
+	duration_mins INTEGER NOT NULL CHECK(duration_mins > 0),
+	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("create templates schema: %w", err)
+	}
+	return nil
+}
+
+// ListTemplates returns all templates ordered by name.
+func (s *SQLiteStore) ListTemplates() ([]Template, error) {
+	const query = `
+SELECT id, name, project, activity, skill, billable, description, duration_mins
+FROM worklog_templates
+ORDER BY name, id;
+`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("query templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]Template, 0, 16)
+	for rows.Next() {
+		var tpl Template
+		if err := rows.Scan(
+			&tpl.ID,
+			&tpl.Name,
+			&tpl.Project,
+			&tpl.Activity,
+			&tpl.Skill,
+			&tpl.Billable,
+			&tpl.Description,
+			&tpl.DurationMins,
+		); err != nil {
+			return nil, fmt.Errorf("scan template: %w", err)
+		}
+		templates = append(templates, tpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetTemplateByID returns one template by ID.
+func (s *SQLiteStore) GetTemplateByID(id int64) (Template, bool, error) {
+	if id <= 0 {
+		return Template{}, false, fmt.Errorf("template id must be > 0")
+	}
+
+	const query = `
+SELECT id, name, project, activity, skill, billable, description, duration_mins
+FROM worklog_templates
+WHERE id = ?;
+`
+	var tpl Template
+	err := s.db.QueryRow(query, id).Scan(
+		&tpl.ID,
+		&tpl.Name,
+		&tpl.Project,
+		&tpl.Activity,
+		&tpl.Skill,
+		&tpl.Billable,
+		&tpl.Description,
+		&tpl.DurationMins,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Template{}, false, nil
+		}
+		return Template{}, false, fmt.Errorf("query template %d: %w", id, err)
+	}
+	return tpl, true, nil
+}
+
+// InsertTemplate stores tpl and returns its ID. Names are unique; a duplicate
+// name returns ErrTemplateExists.
+func (s *SQLiteStore) InsertTemplate(tpl Template) (int64, error) {
+	const insertStmt = `
+INSERT INTO worklog_templates (name, project, activity, skill, billable, description, duration_mins)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(name) DO NOTHING;`
+
+	res, err := s.db.Exec(
+		insertStmt,
+		strings.TrimSpace(tpl.Name),
+		tpl.Project,
+		tpl.Activity,
+		tpl.Skill,
+		tpl.Billable,
+		tpl.Description,
+		tpl.DurationMins,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("insert template: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return 0, ErrTemplateExists
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("read inserted template id: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteTemplate removes one template by ID.
+func (s *SQLiteStore) DeleteTemplate(id int64) (bool, error) {
+	if id <= 0 {
+		return false, fmt.Errorf("template id must be > 0")
+	}
+
+	res, err := s.db.Exec(`DELETE FROM worklog_templates WHERE id = ?;`, id)
+	if err != nil {
+		return false, fmt.Errorf("delete template %d: %w", id, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("read affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// timestampMigrationVersion identifies the normalization below. Bump it when
+// the rewrite changes so databases normalized by an older version run it
+// again.
+const timestampMigrationVersion = 1
+
+// timestampMigrationKey is the store_settings key recording the version and
+// zone existing timestamps were last normalized to.
+const timestampMigrationKey = "timestamps_normalized"
+
+// SetLocation sets the zone all worklog timestamps are stored and returned
+// in. The first time a database is opened with a zone, existing rows whose
+// stored offset differs are rewritten once; mixed offsets (e.g. entries
+// parsed in time.Local next to entries in a fixed zone) would otherwise
+// compare inconsistently as text and shift day grouping. A row that becomes
+// identical to another row is merged into it, keeping its local note, tags,
+// import batch and resolved ids. It returns the number of rewritten or merged
+// rows. A nil loc uses time.Local.
+func (s *SQLiteStore) SetLocation(loc *time.Location) (int, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	s.location = loc
+
+	marker := fmt.Sprintf("v%d:%s", timestampMigrationVersion, loc.String())
+	recorded, err := s.setting(timestampMigrationKey)
+	if err != nil {
+		return 0, err
+	}
+	if recorded == marker {
+		return 0, nil
+	}
+	return s.normalizeStoredTimestamps(marker)
+}
+
+// normalizeStoredTimestamps rewrites rows to the store zone and records
+// marker, all in one transaction.
+func (s *SQLiteStore) normalizeStoredTimestamps(marker string) (int, error) {
+	type storedTimes struct {
+		id         int64
+		start, end string
+	}
+
+	rows, err := s.db.Query(`SELECT id, start_datetime, end_datetime FROM worklogs ORDER BY id;`)
+	if err != nil {
+		return 0, fmt.Errorf("query worklog timestamps: %w", err)
+	}
+	var pending []storedTimes
+	for rows.Next() {
+		var item storedTimes
+		if err := rows.Scan(&item.id, &item.start, &item.end); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("scan worklog timestamps: %w", err)
+		}
+		start, err := s.parseTime(item.start)
+		if err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("parse start datetime %q: %w", item.start, err)
+		}
+		end, err := s.parseTime(item.end)
+		if err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("parse end datetime %q: %w", item.end, err)
+		}
+		normalized := storedTimes{id: item.id, start: s.formatTime(start), end: s.formatTime(end)}
+		if normalized != item {
+			pending = append(pending, normalized)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, fmt.Errorf("iterate worklog timestamps: %w", err)
+	}
+	_ = rows.Close()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	changed := 0
+	for _, item := range pending {
+		res, err := tx.Exec(
+			`UPDATE OR IGNORE worklogs SET start_datetime = ?, end_datetime = ? WHERE id = ?;`,
+			item.start, item.end, item.id,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("normalize worklog %d: %w", item.id, err)
+		}
+		changed++
+		if affected, err := res.RowsAffected(); err == nil && affected > 0 {
+			continue
+		}
+		// Another row already holds the same instant with identical fields.
+		if err := mergeDuplicateWorklog(tx, item.id, item.start, item.end); err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+	}
+	if err := saveSetting(tx, timestampMigrationKey, marker); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return changed, nil
+}
+
+// worklogMergeFields are the columns outside the UNIQUE key that a merge
+// must not lose.
+type worklogMergeFields struct {
+	localNote   string
+	tags        string
+	importBatch string
+	projectID   sql.NullInt64
+	activityID  sql.NullInt64
+	skillID     sql.NullInt64
+}
+
+// mergeDuplicateWorklog folds the row id, whose normalized times start and
+// end collide with another row, into that row and deletes it. Local notes
+// are joined, tags united, and the import batch and resolved ids are taken
+// from the duplicate where the kept row has none.
+func mergeDuplicateWorklog(tx *sql.Tx, id int64, start, end string) error {
+	const selectFields = `SELECT local_note, tags, import_batch, project_id, activity_id, skill_id FROM worklogs WHERE id = ?;`
+	scan := func(rowID int64) (worklogMergeFields, error) {
+		var fields worklogMergeFields
+		err := tx.QueryRow(selectFields, rowID).Scan(
+			&fields.localNote, &fields.tags, &fields.importBatch,
+			&fields.projectID, &fields.activityID, &fields.skillID,
+		)
+		return fields, err
+	}
+
+	var keptID int64
+	err := tx.QueryRow(`
+SELECT kept.id
+FROM worklogs dup
+JOIN worklogs kept ON kept.id <> dup.id
+	AND kept.start_datetime = ? AND kept.end_datetime = ?
+	AND kept.billable = dup.billable AND kept.description = dup.description
+	AND kept.project = dup.project AND kept.activity = dup.activity
+	AND kept.skill = dup.skill AND kept.source_file = dup.source_file
+WHERE dup.id = ?;`, start, end, id).Scan(&keptID)
+	if err != nil {
+		return fmt.Errorf("find duplicate of worklog %d: %w", id, err)
+	}
+	dup, err := scan(id)
+	if err != nil {
+		return fmt.Errorf("read duplicate worklog %d: %w", id, err)
+	}
+	kept, err := scan(keptID)
+	if err != nil {
+		return fmt.Errorf("read worklog %d: %w", keptID, err)
+	}
+
+	switch {
+	case kept.localNote == "":
+		kept.localNote = dup.localNote
+	case dup.localNote != "" && dup.localNote != kept.localNote:
+		kept.localNote += "\n" + dup.localNote
+	}
+	kept.tags = joinTags(append(splitTags(kept.tags), splitTags(dup.tags)...))
+	if kept.importBatch == "" {
+		kept.importBatch = dup.importBatch
+	}
+	if !kept.projectID.Valid {
+		kept.projectID, kept.activityID, kept.skillID = dup.projectID, dup.activityID, dup.skillID
+	}
+
+	if _, err := tx.Exec(`DELETE FROM worklogs WHERE id = ?;`, id); err != nil {
+		return fmt.Errorf("remove duplicate worklog %d: %w", id, err)
+	}
+	if _, err := tx.Exec(
+		`UPDATE worklogs SET local_note = ?, tags = ?, import_batch = ?, project_id = ?, activity_id = ?, skill_id = ? WHERE id = ?;`,
+		kept.localNote, kept.tags, kept.importBatch, kept.projectID, kept.activityID, kept.skillID, keptID,
+	); err != nil {
+		return fmt.Errorf("merge duplicate worklog %d into %d: %w", id, keptID, err)
+	}
+	return nil
+}
+
+// formatTime renders t in the store zone as stored in the database.
+func (s *SQLiteStore) formatTime(t time.Time) string {
+	return t.In(s.location).Format(time.RFC3339)
+}
+
+// parseTime parses a stored timestamp and returns it in the store zone.
+func (s *SQLiteStore) parseTime(raw string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parsed.In(s.location), nil
+}
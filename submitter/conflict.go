@@ -0,0 +1,71 @@
+package submitter
+
+import (
+	"fmt"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+// ConflictMerge is the payload of a day rebuilt after OnePoint rejected a
+// write with a conflict.
+type ConflictMerge struct {
+	// Payload is the re-fetched day with the remaining local entries merged in.
+	Payload []onepoint.PersistWorklog
+	// ToWrite are the local entries still written by Payload.
+	ToWrite []onepoint.PersistWorklog
+	// Duplicates are local entries that were written concurrently meanwhile.
+	Duplicates []onepoint.PersistWorklog
+	// Skipped are local entries that now overlap an entry written concurrently.
+	Skipped []onepoint.OverlapInfo
+}
+
+// MergeAfterConflict re-classifies toWrite against the re-fetched day fresh.
+// previous is the remote state the rejected payload was built from: overlaps
+// with those entries were already resolved, while local entries overlapping
+// a remote entry added concurrently are skipped so that entry is kept. It
+// fails when the day was locked meanwhile.
+func MergeAfterConflict(fresh []onepoint.DayWorklog, previous, toWrite []onepoint.PersistWorklog) (ConflictMerge, error) {
+	if locked := CountLockedDayWorklogs(fresh); locked > 0 {
+		return ConflictMerge{}, fmt.Errorf("day was locked meanwhile (%d locked entries)", locked)
+	}
+
+	freshPayload := DayWorklogsToPersistPayload(fresh)
+	merge := ConflictMerge{ToWrite: make([]onepoint.PersistWorklog, 0, len(toWrite))}
+	for _, candidate := range toWrite {
+		if equivalent, found := findEquivalentWorklog(freshPayload, candidate); found {
+			if onepoint.PersistWorklogsIdentical(equivalent, candidate) {
+				merge.Duplicates = append(merge.Duplicates, candidate)
+			} else {
+				merge.ToWrite = append(merge.ToWrite, candidate)
+			}
+			continue
+		}
+
+		skipped := false
+		for _, existing := range freshPayload {
+			if !onepoint.WorklogTimeOverlaps(candidate, existing) {
+				continue
+			}
+			if _, known := findEquivalentWorklog(previous, existing); !known {
+				merge.Skipped = append(merge.Skipped, onepoint.OverlapInfo{Local: candidate, Existing: existing})
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			merge.ToWrite = append(merge.ToWrite, candidate)
+		}
+	}
+
+	merge.Payload = BuildPersistPayload(freshPayload, merge.ToWrite)
+	return merge, nil
+}
+
+func findEquivalentWorklog(entries []onepoint.PersistWorklog, candidate onepoint.PersistWorklog) (onepoint.PersistWorklog, bool) {
+	for _, entry := range entries {
+		if onepoint.PersistWorklogsEquivalent(entry, candidate) {
+			return entry, true
+		}
+	}
+	return onepoint.PersistWorklog{}, false
+}
@@ -0,0 +1,62 @@
+package submitter
+
+import (
+	"testing"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+func TestMergeAfterConflict_KeepsConcurrentEntriesAndResolvedOverlaps(t *testing.T) {
+	t.Parallel()
+
+	entry := func(start, finish int, comment string) onepoint.PersistWorklog {
+		return onepoint.PersistWorklog{
+			StartTime:  submitterIntPtr(start),
+			FinishTime: submitterIntPtr(finish),
+			ProjectID:  onepoint.ID(1),
+			ActivityID: onepoint.ID(2),
+			SkillID:    onepoint.ID(3),
+			Comment:    comment,
+		}
+	}
+	remote := func(id int64, start, finish int, comment string) onepoint.DayWorklog {
+		return onepoint.DayWorklog{TimeRecordID: id, StartTime: start, FinishTime: finish, ProjectID: 1, ActivityID: 2, SkillID: 3, Comment: comment}
+	}
+
+	previousRemote := remote(1, 8*60, 9*60, "Known")
+	previous := DayWorklogsToPersistPayload([]onepoint.DayWorklog{previousRemote})
+	fresh := []onepoint.DayWorklog{
+		previousRemote,
+		remote(2, 12*60, 13*60, "Concurrent"),
+		remote(3, 15*60, 16*60, "Already written"),
+	}
+	toWrite := []onepoint.PersistWorklog{
+		entry(8*60+30, 9*60+30, "Approved overlap with a known entry"),
+		entry(12*60+30, 13*60+30, "Overlaps the concurrent entry"),
+		entry(15*60, 16*60, "Already written"),
+		entry(17*60, 18*60, "New"),
+	}
+
+	merge, err := MergeAfterConflict(fresh, previous, toWrite)
+	if err != nil {
+		t.Fatalf("merge after conflict: %v", err)
+	}
+	if len(merge.ToWrite) != 2 || merge.ToWrite[0].Comment != "Approved overlap with a known entry" || merge.ToWrite[1].Comment != "New" {
+		t.Fatalf("unexpected entries to write: %+v", merge.ToWrite)
+	}
+	if len(merge.Duplicates) != 1 || len(merge.Skipped) != 1 || merge.Skipped[0].Existing.TimeRecordID != 2 {
+		t.Fatalf("unexpected duplicates %+v or skipped %+v", merge.Duplicates, merge.Skipped)
+	}
+	if len(merge.Payload) != 5 {
+		t.Fatalf("expected 3 remote and 2 local entries in payload, got %d", len(merge.Payload))
+	}
+}
+
+func TestMergeAfterConflict_FailsWhenDayWasLocked(t *testing.T) {
+	t.Parallel()
+
+	fresh := []onepoint.DayWorklog{{TimeRecordID: 1, StartTime: 9 * 60, FinishTime: 10 * 60, Locked: 1}}
+	if _, err := MergeAfterConflict(fresh, nil, nil); err == nil {
+		t.Fatalf("expected locked day to fail")
+	}
+}
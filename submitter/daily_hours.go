@@ -0,0 +1,32 @@
+package submitter
+
+import (
+	"fmt"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+// DailyHoursWarnings returns one warning per batch whose worklog durations add
+// up to more than maxHours, e.g. after a file was imported twice under
+// different mappers. maxHours <= 0 disables the check.
+func DailyHoursWarnings(batches []DayBatch, maxHours float64) []string {
+	if maxHours <= 0 {
+		return nil
+	}
+	var warnings []string
+	for _, batch := range batches {
+		minutes := 0
+		for _, item := range batch.Worklogs {
+			minutes += item.Duration
+		}
+		hours := float64(minutes) / 60.0
+		if hours <= maxHours {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"day %s has %.2f worked hours, more than max_daily_hours %g",
+			onepoint.FormatDay(batch.Day), hours, maxHours,
+		))
+	}
+	return warnings
+}
@@ -0,0 +1,52 @@
+package submitter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestDailyHoursWarnings_WarnsForDaysAboveThreshold(t *testing.T) {
+	t.Parallel()
+
+	entry := func(id int64, day, startHour, endHour int) worklog.Entry {
+		return worklog.Entry{
+			ID:            id,
+			StartDateTime: time.Date(2026, 3, day, startHour, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, day, endHour, 0, 0, 0, time.Local),
+			Billable:      (endHour - startHour) * 60,
+			Project:       "P",
+			Activity:      "A",
+			Skill:         "S",
+			SourceMapper:  "epm",
+		}
+	}
+	// 2 March adds up to 26 hours (a doubled import), 3 March to 8 hours.
+	entries := []worklog.Entry{
+		entry(1, 2, 0, 10),
+		entry(2, 2, 8, 18),
+		entry(3, 2, 14, 20),
+		entry(4, 3, 9, 17),
+	}
+	ids := map[NameTuple]ResolvedIDs{
+		{Mapper: "epm", Project: "p", Activity: "a", Skill: "s"}: {ProjectID: 1, ActivityID: 2, SkillID: 3},
+	}
+	batches, err := BuildDayBatches(entries, ids)
+	if err != nil {
+		t.Fatalf("build day batches: %v", err)
+	}
+
+	warnings := DailyHoursWarnings(batches, 16)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "02-03-2026") || !strings.Contains(warnings[0], "26.00 worked hours") {
+		t.Fatalf("unexpected warning: %q", warnings[0])
+	}
+
+	if warnings := DailyHoursWarnings(batches, 0); warnings != nil {
+		t.Fatalf("expected disabled check to return no warnings, got %v", warnings)
+	}
+}
@@ -0,0 +1,97 @@
+package submitter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CommentSanitization selects how characters OnePoint rejects in worklog
+// comments are handled before persisting.
+type CommentSanitization string
+
+const (
+	// SanitizeStrip removes disallowed characters and joins lines with a
+	// space (default).
+	SanitizeStrip CommentSanitization = "strip"
+	// SanitizeReplace replaces each disallowed character with a space.
+	SanitizeReplace CommentSanitization = "replace"
+	// SanitizeOff sends comments unchanged.
+	SanitizeOff CommentSanitization = "off"
+)
+
+// SanitizeComment cleans characters OnePoint rejects on persist: control,
+// format (e.g. zero-width), private-use and surrogate code points as well as
+// invalid UTF-8. It returns
+// the cleaned comment and how many characters were removed or replaced.
+// Strip mode turns each line break (including \r\n) into one space, so
+// multi-line comments keep their words apart.
+func SanitizeComment(comment string, mode CommentSanitization) (string, int) {
+	if mode == SanitizeOff {
+		return comment, 0
+	}
+
+	changed := 0
+	lineBreak := false
+	var b strings.Builder
+	b.Grow(len(comment))
+	for _, r := range comment {
+		if !isDisallowedCommentRune(r) {
+			b.WriteRune(r)
+			lineBreak = false
+			continue
+		}
+		changed++
+		switch {
+		case mode == SanitizeReplace:
+			b.WriteByte(' ')
+		case r == '\n' || r == '\r':
+			if !lineBreak {
+				b.WriteByte(' ')
+			}
+			lineBreak = true
+		}
+	}
+	if changed == 0 {
+		return comment, 0
+	}
+	return strings.TrimSpace(b.String()), changed
+}
+
+// SanitizeDayBatchComments sanitizes all comments of batch in place and
+// returns one warning per changed worklog.
+func SanitizeDayBatchComments(batch *DayBatch, mode CommentSanitization) []string {
+	var warnings []string
+	for i := range batch.Worklogs {
+		item := &batch.Worklogs[i]
+		cleaned, changed := SanitizeComment(item.Comment, mode)
+		if changed == 0 {
+			continue
+		}
+		item.Comment = cleaned
+		action := "removed"
+		if mode == SanitizeReplace {
+			action = "replaced"
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"comment of %s entry %s: %d disallowed character(s) %s, sending %q",
+			item.WorklogDate,
+			formatWorklogTime(item.StartTime),
+			changed,
+			action,
+			cleaned,
+		))
+	}
+	return warnings
+}
+
+func isDisallowedCommentRune(r rune) bool {
+	return unicode.IsControl(r) || unicode.In(r, unicode.Cf, unicode.Co, unicode.Cs) || r == unicode.ReplacementChar
+}
+
+func formatWorklogTime(minutes *int) string {
+	if minutes == nil {
+		return "--:--"
+	}
+	return fmt.Sprintf("%02d:%02d", *minutes/60, *minutes%60)
+}
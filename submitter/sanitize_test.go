@@ -0,0 +1,60 @@
+package submitter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+func TestSanitizeComment(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		comment     string
+		mode        CommentSanitization
+		want        string
+		wantChanged int
+	}{
+		{name: "strip control char", comment: "Review\x07 PR", mode: SanitizeStrip, want: "Review PR", wantChanged: 1},
+		{name: "strip zero width", comment: "Stand\u200bup", mode: SanitizeStrip, want: "Standup", wantChanged: 1},
+		{name: "strip joins lines", comment: "Line one\r\nLine two\nLine three", mode: SanitizeStrip, want: "Line one Line two Line three", wantChanged: 3},
+		{name: "replace newline", comment: "Line one\nLine two", mode: SanitizeReplace, want: "Line one Line two", wantChanged: 1},
+		{name: "off keeps control char", comment: "Review\x07", mode: SanitizeOff, want: "Review\x07", wantChanged: 0},
+		{name: "clean comment untouched", comment: "Überprüfung – Ticket #12", mode: SanitizeStrip, want: "Überprüfung – Ticket #12", wantChanged: 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, changed := SanitizeComment(tc.comment, tc.mode)
+			if got != tc.want || changed != tc.wantChanged {
+				t.Fatalf("SanitizeComment(%q, %s) = (%q, %d), want (%q, %d)", tc.comment, tc.mode, got, changed, tc.want, tc.wantChanged)
+			}
+		})
+	}
+}
+
+func TestSanitizeDayBatchComments_WarnsPerChangedWorklog(t *testing.T) {
+	t.Parallel()
+
+	batch := DayBatch{
+		Worklogs: []onepoint.PersistWorklog{
+			{WorklogDate: "02-03-2026", StartTime: submitterIntPtr(9 * 60), Comment: "Deploy\x00 fix"},
+			{WorklogDate: "02-03-2026", StartTime: submitterIntPtr(10 * 60), Comment: "Clean"},
+		},
+	}
+
+	warnings := SanitizeDayBatchComments(&batch, SanitizeStrip)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "02-03-2026") || !strings.Contains(warnings[0], "09:00") {
+		t.Fatalf("expected warning to name day and start, got %q", warnings[0])
+	}
+	if batch.Worklogs[0].Comment != "Deploy fix" {
+		t.Fatalf("expected sanitized comment in batch, got %q", batch.Worklogs[0].Comment)
+	}
+	if batch.Worklogs[1].Comment != "Clean" {
+		t.Fatalf("expected clean comment unchanged, got %q", batch.Worklogs[1].Comment)
+	}
+}
@@ -2,6 +2,10 @@ package submitter
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -102,39 +106,77 @@ func ResolveIDsForEntries(
 	entries []worklog.Entry,
 	options onepoint.ResolveOptions,
 ) (map[NameTuple]ResolvedIDs, error) {
+	resolved, _, err := resolveIDsForEntries(ctx, client, rules, entries, options, false)
+	return resolved, err
+}
+
+// ResolveIDsForEntriesVerifyingRules resolves like ResolveIDsForEntries but
+// also checks rule-provided ids against the lookup snapshot. Rule ids are
+// still used; each tuple whose names no longer resolve to the same ids (for
+// example after a project was renamed or merged) yields one drift warning.
+func ResolveIDsForEntriesVerifyingRules(
+	ctx context.Context,
+	client onepoint.Client,
+	rules []config.Rule,
+	entries []worklog.Entry,
+	options onepoint.ResolveOptions,
+) (map[NameTuple]ResolvedIDs, []string, error) {
+	return resolveIDsForEntries(ctx, client, rules, entries, options, true)
+}
+
+func resolveIDsForEntries(
+	ctx context.Context,
+	client onepoint.Client,
+	rules []config.Rule,
+	entries []worklog.Entry,
+	options onepoint.ResolveOptions,
+	verifyRules bool,
+) (map[NameTuple]ResolvedIDs, []string, error) {
 	requiredTuples, err := CollectRequiredNameTuples(entries)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(requiredTuples) == 0 {
-		return map[NameTuple]ResolvedIDs{}, nil
+		return map[NameTuple]ResolvedIDs{}, nil, nil
 	}
 
 	ruleIDs := BuildRuleIDMap(rules)
+	cachedIDs := cachedIDsByTuple(entries)
 	resolved := make(map[NameTuple]ResolvedIDs, len(requiredTuples))
 	missing := make([]NameTuple, 0)
+	fromRules := make([]NameTuple, 0)
 
 	for _, tuple := range requiredTuples {
 		if ids, ok := ruleIDs[tuple]; ok {
+			resolved[tuple] = ids
+			fromRules = append(fromRules, tuple)
+			continue
+		}
+		if ids, ok := cachedIDs[tuple]; ok {
 			resolved[tuple] = ids
 			continue
 		}
 		missing = append(missing, tuple)
 	}
 
-	if len(missing) == 0 {
-		return resolved, nil
+	if len(missing) == 0 && (!verifyRules || len(fromRules) == 0) {
+		return resolved, nil, nil
 	}
 
 	snapshot, err := client.FetchLookupSnapshot(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("fetch onepoint lookup snapshot: %w", err)
+		return nil, nil, fmt.Errorf("fetch onepoint lookup snapshot: %w", err)
+	}
+
+	var warnings []string
+	if verifyRules {
+		warnings = verifyRuleIDs(snapshot, fromRules, resolved, options)
 	}
 
 	for _, tuple := range missing {
 		ids, err := onepoint.ResolveIDsFromSnapshot(snapshot, tuple.Project, tuple.Activity, tuple.Skill, options)
 		if err != nil {
-			return nil, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"resolve ids for mapper=%q project=%q activity=%q skill=%q: %w",
 				tuple.Mapper,
 				tuple.Project,
@@ -150,7 +192,87 @@ func ResolveIDsForEntries(
 		}
 	}
 
-	return resolved, nil
+	return resolved, warnings, nil
+}
+
+// cachedIDsByTuple returns the ids stored on the entries by an earlier
+// resolve, for tuples whose entries all carry the same valid ids. A tuple with
+// any entry lacking ids, or with conflicting ids, is resolved again.
+func cachedIDsByTuple(entries []worklog.Entry) map[NameTuple]ResolvedIDs {
+	cached := make(map[NameTuple]ResolvedIDs)
+	stale := make(map[NameTuple]bool)
+	for _, entry := range entries {
+		tuple := entryNameTuple(entry)
+		if stale[tuple] {
+			continue
+		}
+		ids := ResolvedIDs{ProjectID: entry.ProjectID, ActivityID: entry.ActivityID, SkillID: entry.SkillID}
+		if ids.ProjectID <= 0 || ids.ActivityID <= 0 || ids.SkillID <= 0 {
+			stale[tuple] = true
+			delete(cached, tuple)
+			continue
+		}
+		if existing, ok := cached[tuple]; ok && existing != ids {
+			stale[tuple] = true
+			delete(cached, tuple)
+			continue
+		}
+		cached[tuple] = ids
+	}
+	return cached
+}
+
+// EntriesWithChangedIDs returns copies of the entries whose stored OnePoint
+// ids differ from idsByTuple, with the resolved ids set, so callers can
+// persist them for the next submit.
+func EntriesWithChangedIDs(entries []worklog.Entry, idsByTuple map[NameTuple]ResolvedIDs) []worklog.Entry {
+	changed := make([]worklog.Entry, 0)
+	for _, entry := range entries {
+		ids, ok := idsByTuple[entryNameTuple(entry)]
+		if !ok || entry.ID <= 0 {
+			continue
+		}
+		if entry.ProjectID == ids.ProjectID && entry.ActivityID == ids.ActivityID && entry.SkillID == ids.SkillID {
+			continue
+		}
+		entry.ProjectID, entry.ActivityID, entry.SkillID = ids.ProjectID, ids.ActivityID, ids.SkillID
+		changed = append(changed, entry)
+	}
+	return changed
+}
+
+func entryNameTuple(entry worklog.Entry) NameTuple {
+	return NameTuple{
+		Mapper:   normalizeMapper(entry.SourceMapper),
+		Project:  normalizeName(entry.Project),
+		Activity: normalizeName(entry.Activity),
+		Skill:    normalizeName(entry.Skill),
+	}
+}
+
+// verifyRuleIDs returns one warning per rule tuple whose names resolve to
+// different ids in snapshot, or no longer resolve at all.
+func verifyRuleIDs(snapshot onepoint.LookupSnapshot, tuples []NameTuple, resolved map[NameTuple]ResolvedIDs, options onepoint.ResolveOptions) []string {
+	var warnings []string
+	for _, tuple := range tuples {
+		ruleIDs := resolved[tuple]
+		label := fmt.Sprintf("mapper=%q project=%q activity=%q skill=%q", tuple.Mapper, tuple.Project, tuple.Activity, tuple.Skill)
+		ids, err := onepoint.ResolveIDsFromSnapshot(snapshot, tuple.Project, tuple.Activity, tuple.Skill, options)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("rule ids for %s could not be verified: %v", label, err))
+			continue
+		}
+		if ids.ProjectID == ruleIDs.ProjectID && ids.ActivityID == ruleIDs.ActivityID && ids.SkillID == ruleIDs.SkillID {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"rule ids for %s drifted: rule has %d/%d/%d, OnePoint resolves %d/%d/%d (project/activity/skill)",
+			label,
+			ruleIDs.ProjectID, ruleIDs.ActivityID, ruleIDs.SkillID,
+			ids.ProjectID, ids.ActivityID, ids.SkillID,
+		))
+	}
+	return warnings
 }
 
 func BuildDayBatches(entries []worklog.Entry, idsByTuple map[NameTuple]ResolvedIDs) ([]DayBatch, error) {
@@ -197,22 +319,17 @@ func BuildDayBatches(entries []worklog.Entry, idsByTuple map[NameTuple]ResolvedI
 			)
 		}
 
-		day := timeutil.StartOfDay(entry.StartDateTime)
-		if !timeutil.SameDay(entry.StartDateTime, entry.EndDateTime) {
-			return nil, fmt.Errorf("worklog id=%d crosses day boundaries and cannot be submitted", entry.ID)
-		}
-
-		startMins := timeutil.MinutesFromMidnight(entry.StartDateTime)
-		finishMins := timeutil.MinutesFromMidnight(entry.EndDateTime)
-		duration := int(entry.EndDateTime.Sub(entry.StartDateTime).Minutes())
-		if duration <= 0 || finishMins <= startMins {
-			return nil, fmt.Errorf("worklog id=%d has invalid time range", entry.ID)
-		}
-
-		billable := entry.Billable
-		if billable < 0 {
-			return nil, fmt.Errorf("worklog id=%d has negative billable value (%d)", entry.ID, billable)
+		if err := entry.Validate(); err != nil {
+			switch {
+			case errors.Is(err, worklog.ErrCrossesDayBoundary):
+				return nil, fmt.Errorf("worklog id=%d crosses day boundaries and cannot be submitted", entry.ID)
+			case errors.Is(err, worklog.ErrNegativeBillable):
+				return nil, fmt.Errorf("worklog id=%d has negative billable value (%d)", entry.ID, entry.Billable)
+			default:
+				return nil, fmt.Errorf("worklog id=%d has invalid time range", entry.ID)
+			}
 		}
+		day := timeutil.StartOfDay(entry.StartDateTime)
 
 		dayKey := onepoint.FormatDay(day)
 		batch, exists := byDay[dayKey]
@@ -222,8 +339,8 @@ func BuildDayBatches(entries []worklog.Entry, idsByTuple map[NameTuple]ResolvedI
 			dayKeys = append(dayKeys, dayKey)
 		}
 
-		start := startMins
-		finish := finishMins
+		start := timeutil.MinutesFromMidnight(entry.StartDateTime)
+		finish := timeutil.MinutesFromMidnight(entry.EndDateTime)
 		batch.Worklogs = append(batch.Worklogs, onepoint.PersistWorklog{
 			TimeRecordID: nextTempID,
 			WorkSlipID:   -1,
@@ -231,8 +348,8 @@ func BuildDayBatches(entries []worklog.Entry, idsByTuple map[NameTuple]ResolvedI
 			WorklogDate:  onepoint.FormatDay(day),
 			StartTime:    &start,
 			FinishTime:   &finish,
-			Duration:     duration,
-			Billable:     billable,
+			Duration:     entry.DurationMinutes(),
+			Billable:     entry.Billable,
 			Valuable:     0,
 			ProjectID:    onepoint.ID(ids.ProjectID),
 			ActivityID:   onepoint.ID(ids.ActivityID),
@@ -266,10 +383,7 @@ func ClassifyWorklogs(local, existing []onepoint.PersistWorklog) (toAdd []onepoi
 		for _, existingEntry := range existing {
 			if onepoint.PersistWorklogsEquivalent(existingEntry, candidate) {
 				equivalentFound = true
-				if existingEntry.Billable != candidate.Billable ||
-					strings.TrimSpace(existingEntry.Comment) != strings.TrimSpace(candidate.Comment) {
-					requiresUpdate = true
-				}
+				requiresUpdate = !onepoint.PersistWorklogsIdentical(existingEntry, candidate)
 				break
 			}
 		}
@@ -303,6 +417,71 @@ func ClassifyWorklogs(local, existing []onepoint.PersistWorklog) (toAdd []onepoi
 	return toAdd, overlaps, duplicates
 }
 
+// CollapseLocalDuplicates keeps only the first of equivalent local worklogs
+// in batch and returns the removed ones. ClassifyWorklogs only compares local
+// against remote, so without this two identical imported rows would both be sent.
+func CollapseLocalDuplicates(batch *DayBatch) []onepoint.PersistWorklog {
+	kept := make([]onepoint.PersistWorklog, 0, len(batch.Worklogs))
+	var removed []onepoint.PersistWorklog
+	for _, candidate := range batch.Worklogs {
+		duplicate := false
+		for _, item := range kept {
+			if onepoint.PersistWorklogsEquivalent(item, candidate) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			removed = append(removed, candidate)
+			continue
+		}
+		kept = append(kept, candidate)
+	}
+	batch.Worklogs = kept
+	return removed
+}
+
+// dayHashWorklog holds the content fields of a worklog that DayBatchHash
+// covers. Temporary TimeRecordIDs are numbered across the whole run, so they
+// are left out to keep a day's hash independent of other days.
+type dayHashWorklog struct {
+	WorklogDate string                 `json:"worklogDate"`
+	StartTime   *int                   `json:"startTime"`
+	FinishTime  *int                   `json:"finishTime"`
+	Duration    int                    `json:"duration"`
+	Billable    int                    `json:"billable"`
+	ProjectID   onepoint.FlexibleInt64 `json:"projectId"`
+	ActivityID  onepoint.FlexibleInt64 `json:"activityId"`
+	SkillID     onepoint.FlexibleInt64 `json:"skillId"`
+	Comment     string                 `json:"comment"`
+}
+
+// DayBatchHash returns a stable hex digest of the batch's worklogs. Any
+// change to times, ids, billable or comment of a day yields a new hash;
+// entries on other days do not affect it.
+func DayBatchHash(batch DayBatch) (string, error) {
+	content := make([]dayHashWorklog, 0, len(batch.Worklogs))
+	for _, entry := range batch.Worklogs {
+		content = append(content, dayHashWorklog{
+			WorklogDate: entry.WorklogDate,
+			StartTime:   entry.StartTime,
+			FinishTime:  entry.FinishTime,
+			Duration:    entry.Duration,
+			Billable:    entry.Billable,
+			ProjectID:   entry.ProjectID,
+			ActivityID:  entry.ActivityID,
+			SkillID:     entry.SkillID,
+			Comment:     entry.Comment,
+		})
+	}
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("hash day %s: %w", onepoint.FormatDay(batch.Day), err)
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // BuildPersistPayload merges existing remote entries with local entries to write.
 // For equivalent keys, local entries replace existing entries so billable/comment edits are propagated.
 func BuildPersistPayload(existing, toWrite []onepoint.PersistWorklog) []onepoint.PersistWorklog {
@@ -325,6 +504,113 @@ func BuildPersistPayload(existing, toWrite []onepoint.PersistWorklog) []onepoint
 	return payload
 }
 
+// CountConfirmedRecords returns the number of persist results confirming a
+// new entry: a positive NewTimeRecordID for a payload worklog that had no
+// time record yet (temporary ID zero or below). Existing entries persisted
+// along with the new ones are not counted.
+func CountConfirmedRecords(results []onepoint.PersistResult) int {
+	count := 0
+	for _, result := range results {
+		if result.NewTimeRecordID > 0 && result.OldTimeRecordID <= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// PersistFailure is a persist result OnePoint reported as failed, with the
+// payload worklog it belongs to when that could be determined.
+type PersistFailure struct {
+	Result  onepoint.PersistResult
+	Worklog *onepoint.PersistWorklog
+}
+
+// PartitionPersistResults splits results into successes and failures. A
+// result fails when OnePoint flags it with an error or warning messageType,
+// its message naming the reason, and it belongs to a new payload entry
+// (TimeRecordID zero or below). The existing entries persisted along with the
+// new ones never fail, whether or not OnePoint answers them with a new id.
+// Results are matched to payload by position when OnePoint returned one
+// result per payload entry, otherwise by OldTimeRecordID.
+func PartitionPersistResults(payload []onepoint.PersistWorklog, results []onepoint.PersistResult) (succeeded []onepoint.PersistResult, failed []PersistFailure) {
+	succeeded = make([]onepoint.PersistResult, 0, len(results))
+	for i, result := range results {
+		worklog := persistResultWorklog(payload, results, i)
+		if !isFailedPersistMessageType(result.MessageType) || !isNewPersistResult(worklog, result) {
+			succeeded = append(succeeded, result)
+			continue
+		}
+		failed = append(failed, PersistFailure{Result: result, Worklog: worklog})
+	}
+	return succeeded, failed
+}
+
+// CountAddedRecords returns the number of new payload entries OnePoint
+// persisted: results belonging to an entry without a time record yet that
+// PartitionPersistResults does not report as failed.
+func CountAddedRecords(payload []onepoint.PersistWorklog, results []onepoint.PersistResult) int {
+	count := 0
+	for i, result := range results {
+		if isFailedPersistMessageType(result.MessageType) {
+			continue
+		}
+		if isNewPersistResult(persistResultWorklog(payload, results, i), result) {
+			count++
+		}
+	}
+	return count
+}
+
+// persistResultWorklog returns the payload worklog results[i] answers, or nil
+// when it cannot be determined.
+func persistResultWorklog(payload []onepoint.PersistWorklog, results []onepoint.PersistResult, i int) *onepoint.PersistWorklog {
+	if len(results) == len(payload) {
+		return &payload[i]
+	}
+	if results[i].OldTimeRecordID == 0 {
+		return nil
+	}
+	for j := range payload {
+		if payload[j].TimeRecordID == results[i].OldTimeRecordID {
+			return &payload[j]
+		}
+	}
+	return nil
+}
+
+// isNewPersistResult reports whether a result belongs to a payload entry
+// without a time record yet. Without a matched worklog, the result's
+// OldTimeRecordID decides.
+func isNewPersistResult(worklog *onepoint.PersistWorklog, result onepoint.PersistResult) bool {
+	if worklog != nil {
+		return worklog.TimeRecordID <= 0
+	}
+	return result.OldTimeRecordID <= 0
+}
+
+func isFailedPersistMessageType(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "error", "warning":
+		return true
+	default:
+		return false
+	}
+}
+
+// VerifyPersistResults returns an error when OnePoint confirmed a different
+// number of new time records than entries were added: fewer indicates a
+// silent partial persist, more indicates entries created twice.
+func VerifyPersistResults(added int, results []onepoint.PersistResult) error {
+	confirmed := CountConfirmedRecords(results)
+	switch {
+	case confirmed < added:
+		return fmt.Errorf("OnePoint confirmed %d time record(s) for %d added entries (possible partial persist)", confirmed, added)
+	case confirmed > added:
+		return fmt.Errorf("OnePoint confirmed %d time record(s) for %d added entries (unexpected extra records)", confirmed, added)
+	}
+	return nil
+}
+
 func CountLockedDayWorklogs(existing []onepoint.DayWorklog) int {
 	count := 0
 	for _, item := range existing {
@@ -1,6 +1,10 @@
 package submitter
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -140,6 +144,33 @@ func TestClassifyWorklogs_EquivalentChangedFields_TreatedAsUpdate(t *testing.T)
 	}
 }
 
+func TestClassifyWorklogs_CommentWhitespaceAndCaseAreDuplicates(t *testing.T) {
+	t.Parallel()
+
+	entry := func(comment string) onepoint.PersistWorklog {
+		return onepoint.PersistWorklog{
+			StartTime:  submitterIntPtr(9 * 60),
+			FinishTime: submitterIntPtr(10 * 60),
+			Duration:   60,
+			Billable:   60,
+			ProjectID:  onepoint.ID(1),
+			ActivityID: onepoint.ID(2),
+			SkillID:    onepoint.ID(3),
+			Comment:    comment,
+		}
+	}
+	local := []onepoint.PersistWorklog{entry("Review  PR 42 ")}
+	existing := []onepoint.PersistWorklog{entry("review pr 42")}
+
+	toAdd, overlaps, duplicates := ClassifyWorklogs(local, existing)
+	if len(toAdd) != 0 || len(overlaps) != 0 {
+		t.Fatalf("expected no writes, got toAdd=%d overlaps=%d", len(toAdd), len(overlaps))
+	}
+	if len(duplicates) != 1 {
+		t.Fatalf("expected 1 duplicate, got %d", len(duplicates))
+	}
+}
+
 func TestBuildPersistPayload_ReplacesEquivalentExisting(t *testing.T) {
 	t.Parallel()
 
@@ -251,3 +282,248 @@ func submitterIntPtr(value int) *int {
 	out := value
 	return &out
 }
+
+func TestVerifyPersistResults(t *testing.T) {
+	t.Parallel()
+
+	// The existing entry 10 is persisted along with the new ones and not counted.
+	results := []onepoint.PersistResult{
+		{OldTimeRecordID: 10, NewTimeRecordID: 11},
+		{OldTimeRecordID: -1, NewTimeRecordID: 501},
+		{OldTimeRecordID: -2, NewTimeRecordID: 0},
+	}
+	if err := VerifyPersistResults(2, results); err == nil || !strings.Contains(err.Error(), "possible partial persist") {
+		t.Fatalf("expected mismatch error for fewer confirmed records, got %v", err)
+	}
+	if err := VerifyPersistResults(1, results); err != nil {
+		t.Fatalf("expected matching count to pass, got %v", err)
+	}
+	if err := VerifyPersistResults(0, results); err == nil || !strings.Contains(err.Error(), "unexpected extra records") {
+		t.Fatalf("expected mismatch error for more confirmed records, got %v", err)
+	}
+	if err := VerifyPersistResults(0, nil); err != nil {
+		t.Fatalf("expected empty submit to pass, got %v", err)
+	}
+}
+
+func TestCollapseLocalDuplicates_KeepsFirstEquivalent(t *testing.T) {
+	t.Parallel()
+
+	entry := onepoint.PersistWorklog{
+		StartTime:  submitterIntPtr(9 * 60),
+		FinishTime: submitterIntPtr(10 * 60),
+		ProjectID:  onepoint.ID(1),
+		ActivityID: onepoint.ID(2),
+		SkillID:    onepoint.ID(3),
+		Comment:    "first",
+	}
+	duplicate := entry
+	duplicate.Comment = "second"
+	other := entry
+	other.StartTime = submitterIntPtr(10 * 60)
+	other.FinishTime = submitterIntPtr(11 * 60)
+
+	batch := DayBatch{
+		Day:      time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local),
+		Worklogs: []onepoint.PersistWorklog{entry, duplicate, other},
+	}
+	removed := CollapseLocalDuplicates(&batch)
+
+	if len(removed) != 1 || removed[0].Comment != "second" {
+		t.Fatalf("expected the second entry to be removed, got %+v", removed)
+	}
+	if len(batch.Worklogs) != 2 || batch.Worklogs[0].Comment != "first" || *batch.Worklogs[1].StartTime != 10*60 {
+		t.Fatalf("unexpected remaining worklogs: %+v", batch.Worklogs)
+	}
+}
+
+func TestPartitionPersistResults_MatchesFailuresToPayload(t *testing.T) {
+	t.Parallel()
+
+	payload := []onepoint.PersistWorklog{
+		{TimeRecordID: 7, StartTime: submitterIntPtr(8 * 60), FinishTime: submitterIntPtr(9 * 60)},
+		{TimeRecordID: -1, StartTime: submitterIntPtr(9 * 60), FinishTime: submitterIntPtr(10 * 60)},
+	}
+
+	succeeded, failed := PartitionPersistResults(payload, []onepoint.PersistResult{
+		{NewTimeRecordID: 7},
+		{MessageType: "error", Message: "invalid skill"},
+	})
+	if len(succeeded) != 1 || len(failed) != 1 {
+		t.Fatalf("expected 1 success and 1 failure, got %d/%d", len(succeeded), len(failed))
+	}
+	if failed[0].Worklog == nil || *failed[0].Worklog.StartTime != 9*60 || failed[0].Result.Message != "invalid skill" {
+		t.Fatalf("expected failure matched by position, got %+v", failed[0])
+	}
+
+	// Fewer results than payload entries: match by OldTimeRecordID.
+	_, failed = PartitionPersistResults(payload, []onepoint.PersistResult{
+		{MessageType: "WARNING", Message: "rejected", OldTimeRecordID: -1},
+	})
+	if len(failed) != 1 || failed[0].Worklog == nil || failed[0].Worklog.TimeRecordID != -1 {
+		t.Fatalf("expected failure matched by old time record id, got %+v", failed)
+	}
+}
+
+func TestPartitionPersistResults_ExistingEntriesNeverFail(t *testing.T) {
+	t.Parallel()
+
+	payload := []onepoint.PersistWorklog{
+		{TimeRecordID: 7, StartTime: submitterIntPtr(8 * 60), FinishTime: submitterIntPtr(9 * 60)},
+		{TimeRecordID: -1, StartTime: submitterIntPtr(9 * 60), FinishTime: submitterIntPtr(10 * 60)},
+	}
+	results := []onepoint.PersistResult{
+		{Message: "Worklog unchanged", OldTimeRecordID: 7},
+		{MessageType: "info", Message: "Worklog successfully created", NewTimeRecordID: 12, OldTimeRecordID: -1},
+	}
+
+	succeeded, failed := PartitionPersistResults(payload, results)
+	if len(succeeded) != 2 || len(failed) != 0 {
+		t.Fatalf("expected an existing entry without new id to succeed, got %d/%+v", len(succeeded), failed)
+	}
+	if added := CountAddedRecords(payload, results); added != 1 {
+		t.Fatalf("expected 1 added record, got %d", added)
+	}
+
+	// An error on an existing entry does not reject it either.
+	results[0].MessageType = "error"
+	if _, failed := PartitionPersistResults(payload, results); len(failed) != 0 {
+		t.Fatalf("expected no failure for an existing entry, got %+v", failed)
+	}
+}
+
+func TestPartitionPersistResults_DecodedResponse(t *testing.T) {
+	t.Parallel()
+
+	// The first record is a real OnePoint persist answer; it carries no messageType.
+	body := `[
+		{"message":"Worklog successfully created","newTimeRecordId":437654923,"oldTimeRecordId":437654918,"workRecordId":436227248,"workSlipId":437043599,"worklogDate":"2026-02-22T00:00:00+01:00"},
+		{"message":"Skill does not belong to activity","messageType":"ERROR","oldTimeRecordId":-1,"worklogDate":"2026-02-22T00:00:00+01:00"}
+	]`
+	var results []onepoint.PersistResult
+	if err := json.Unmarshal([]byte(body), &results); err != nil {
+		t.Fatalf("decode persist response: %v", err)
+	}
+	payload := []onepoint.PersistWorklog{
+		{TimeRecordID: 437654918, StartTime: submitterIntPtr(8 * 60), FinishTime: submitterIntPtr(9 * 60)},
+		{TimeRecordID: -1, StartTime: submitterIntPtr(9 * 60), FinishTime: submitterIntPtr(10 * 60)},
+	}
+
+	succeeded, failed := PartitionPersistResults(payload, results)
+	if len(succeeded) != 1 || succeeded[0].NewTimeRecordID != 437654923 {
+		t.Fatalf("expected the created record to succeed, got %+v", succeeded)
+	}
+	if len(failed) != 1 || failed[0].Worklog == nil || failed[0].Worklog.TimeRecordID != -1 ||
+		failed[0].Result.Message != "Skill does not belong to activity" {
+		t.Fatalf("expected the record flagged as error to fail, got %+v", failed)
+	}
+	if added := CountAddedRecords(payload, results); added != 0 {
+		t.Fatalf("expected no added records, got %d", added)
+	}
+}
+
+func TestBuildDayBatches_ExcludesLocalNoteFromComment(t *testing.T) {
+	t.Parallel()
+
+	entries := []worklog.Entry{
+		{
+			ID:            7,
+			StartDateTime: time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local),
+			EndDateTime:   time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local),
+			Billable:      60,
+			Description:   "Review",
+			Project:       "P",
+			Activity:      "A",
+			Skill:         "S",
+			SourceMapper:  "epm",
+			LocalNote:     "private: check with lead",
+		},
+	}
+	ids := map[NameTuple]ResolvedIDs{
+		{Mapper: "epm", Project: "p", Activity: "a", Skill: "s"}: {
+			ProjectID:  1,
+			ActivityID: 2,
+			SkillID:    3,
+		},
+	}
+
+	batches, err := BuildDayBatches(entries, ids)
+	if err != nil {
+		t.Fatalf("build day batches: %v", err)
+	}
+	if len(batches) != 1 || len(batches[0].Worklogs) != 1 {
+		t.Fatalf("expected one worklog, got %+v", batches)
+	}
+	if comment := batches[0].Worklogs[0].Comment; comment != "Review" {
+		t.Fatalf("expected comment without local note, got %q", comment)
+	}
+}
+
+type snapshotCountingClient struct {
+	onepoint.Client
+	calls int
+}
+
+func (c *snapshotCountingClient) FetchLookupSnapshot(ctx context.Context) (onepoint.LookupSnapshot, error) {
+	c.calls++
+	return onepoint.LookupSnapshot{}, errors.New("lookup snapshot unavailable")
+}
+
+func TestResolveIDsForEntries_UsesStoredIDs(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	cached := worklog.Entry{
+		ID:            1,
+		StartDateTime: start,
+		EndDateTime:   start.Add(time.Hour),
+		Project:       "P",
+		Activity:      "A",
+		Skill:         "S",
+		SourceMapper:  "epm",
+		ProjectID:     11,
+		ActivityID:    22,
+		SkillID:       33,
+	}
+	second := cached
+	second.ID = 2
+	second.StartDateTime = start.Add(time.Hour)
+	second.EndDateTime = start.Add(2 * time.Hour)
+
+	client := &snapshotCountingClient{}
+	resolved, err := ResolveIDsForEntries(context.Background(), client, nil, []worklog.Entry{cached, second}, onepoint.ResolveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.calls != 0 {
+		t.Fatalf("expected stored ids to skip the lookup snapshot, got %d fetches", client.calls)
+	}
+	want := ResolvedIDs{ProjectID: 11, ActivityID: 22, SkillID: 33}
+	if got := resolved[NameTuple{Mapper: "epm", Project: "p", Activity: "a", Skill: "s"}]; got != want {
+		t.Fatalf("expected stored ids %+v, got %+v", want, got)
+	}
+
+	// One entry of the tuple without stored ids forces a fresh resolve.
+	second.ProjectID, second.ActivityID, second.SkillID = 0, 0, 0
+	if _, err := ResolveIDsForEntries(context.Background(), client, nil, []worklog.Entry{cached, second}, onepoint.ResolveOptions{}); err == nil {
+		t.Fatalf("expected the lookup snapshot to be fetched")
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected one lookup snapshot fetch, got %d", client.calls)
+	}
+}
+
+func TestEntriesWithChangedIDs_ReturnsOnlyChangedEntries(t *testing.T) {
+	t.Parallel()
+
+	unchanged := worklog.Entry{ID: 1, Project: "P", Activity: "A", Skill: "S", SourceMapper: "epm", ProjectID: 11, ActivityID: 22, SkillID: 33}
+	missing := worklog.Entry{ID: 2, Project: "P", Activity: "A", Skill: "S", SourceMapper: "epm"}
+	idsByTuple := map[NameTuple]ResolvedIDs{
+		{Mapper: "epm", Project: "p", Activity: "a", Skill: "s"}: {ProjectID: 11, ActivityID: 22, SkillID: 33},
+	}
+
+	changed := EntriesWithChangedIDs([]worklog.Entry{unchanged, missing}, idsByTuple)
+	if len(changed) != 1 || changed[0].ID != 2 || changed[0].ProjectID != 11 || changed[0].ActivityID != 22 || changed[0].SkillID != 33 {
+		t.Fatalf("expected only entry 2 with resolved ids, got %+v", changed)
+	}
+}
@@ -0,0 +1,143 @@
+package submitter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultTicketTemplate puts the ticket id in front of the remaining comment.
+const DefaultTicketTemplate = "{ticket} {comment}"
+
+// TicketFormat rewrites worklog comments so a detected ticket id is always
+// sent in the same form. A zero TicketFormat (nil Pattern) is a no-op.
+type TicketFormat struct {
+	// Pattern detects the ticket id in a comment, e.g. (?i)[a-z]+-[0-9]+.
+	Pattern *regexp.Regexp
+	// Template builds the comment from the upper-cased "{ticket}" and the
+	// rest of the comment "{comment}". Empty uses DefaultTicketTemplate.
+	Template string
+	// Required warns about comments without a ticket id.
+	Required bool
+}
+
+// FormatTicketComment applies format to comment. It returns the rewritten
+// comment and whether a ticket id was found; comments without one are
+// returned unchanged. Comments already in the template's form are kept as
+// they are, so formatting twice gives the same result.
+func FormatTicketComment(comment string, format TicketFormat) (string, bool) {
+	if format.Pattern == nil {
+		return comment, false
+	}
+	loc := format.Pattern.FindStringIndex(comment)
+	if loc == nil {
+		return comment, false
+	}
+
+	template := format.Template
+	if strings.TrimSpace(template) == "" {
+		template = DefaultTicketTemplate
+	}
+	if isFormattedTicketComment(comment, template, format.Pattern) {
+		return comment, true
+	}
+	ticket := strings.ToUpper(comment[loc[0]:loc[1]])
+	rest := strings.Join(strings.Fields(comment[:loc[0]]+" "+comment[loc[1]:]), " ")
+	return applyTicketTemplate(template, ticket, rest), true
+}
+
+func applyTicketTemplate(template, ticket, rest string) string {
+	formatted := strings.NewReplacer("{ticket}", ticket, "{comment}", rest).Replace(template)
+	return strings.TrimSpace(formatted)
+}
+
+// isFormattedTicketComment reports whether comment is exactly what template
+// produces for the upper-cased ticket and rest it contains.
+func isFormattedTicketComment(comment, template string, pattern *regexp.Regexp) bool {
+	var expr strings.Builder
+	expr.WriteString("^")
+	ticketGroup, commentGroup := 0, 0
+	groups := 0
+	for remaining := template; remaining != ""; {
+		ticketAt := strings.Index(remaining, "{ticket}")
+		commentAt := strings.Index(remaining, "{comment}")
+		next, placeholder := -1, ""
+		switch {
+		case ticketAt >= 0 && (commentAt < 0 || ticketAt < commentAt):
+			next, placeholder = ticketAt, "{ticket}"
+		case commentAt >= 0:
+			next, placeholder = commentAt, "{comment}"
+		}
+		if next < 0 {
+			expr.WriteString(quoteTemplateLiteral(remaining))
+			break
+		}
+		expr.WriteString(quoteTemplateLiteral(remaining[:next]))
+		groups++
+		if placeholder == "{ticket}" {
+			expr.WriteString("(" + pattern.String() + ")")
+			if ticketGroup == 0 {
+				ticketGroup = groups
+			}
+		} else {
+			expr.WriteString("(.*?)")
+			if commentGroup == 0 {
+				commentGroup = groups
+			}
+		}
+		remaining = remaining[next+len(placeholder):]
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil || ticketGroup == 0 {
+		return false
+	}
+	match := re.FindStringSubmatch(strings.TrimSpace(comment))
+	if match == nil {
+		return false
+	}
+	ticket := match[ticketGroup]
+	if ticket != strings.ToUpper(ticket) {
+		return false
+	}
+	rest := ""
+	if commentGroup > 0 {
+		rest = strings.Join(strings.Fields(match[commentGroup]), " ")
+	}
+	return applyTicketTemplate(template, ticket, rest) == comment
+}
+
+// quoteTemplateLiteral escapes template text for a regexp; whitespace may be
+// missing, since formatted comments are trimmed when the rest is empty.
+func quoteTemplateLiteral(literal string) string {
+	return templateSpaceRun.ReplaceAllString(regexp.QuoteMeta(literal), `\s*`)
+}
+
+var templateSpaceRun = regexp.MustCompile(`\s+`)
+
+// FormatDayBatchTickets rewrites all comments of batch in place and returns
+// one warning per worklog missing a ticket id when format.Required is set.
+func FormatDayBatchTickets(batch *DayBatch, format TicketFormat) []string {
+	if format.Pattern == nil {
+		return nil
+	}
+	var warnings []string
+	for i := range batch.Worklogs {
+		item := &batch.Worklogs[i]
+		formatted, found := FormatTicketComment(item.Comment, format)
+		if found {
+			item.Comment = formatted
+			continue
+		}
+		if format.Required {
+			warnings = append(warnings, fmt.Sprintf(
+				"comment of %s entry %s has no ticket id: %q",
+				item.WorklogDate,
+				formatWorklogTime(item.StartTime),
+				item.Comment,
+			))
+		}
+	}
+	return warnings
+}
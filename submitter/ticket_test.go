@@ -0,0 +1,80 @@
+package submitter
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+func TestFormatTicketComment(t *testing.T) {
+	t.Parallel()
+
+	pattern := regexp.MustCompile(`(?i)[a-z]+-[0-9]+`)
+	cases := []struct {
+		name      string
+		comment   string
+		template  string
+		want      string
+		wantFound bool
+	}{
+		{name: "moves ticket to front", comment: "did jira-123 stuff", want: "JIRA-123 did stuff", wantFound: true},
+		{name: "already formatted", comment: "JIRA-123 did stuff", want: "JIRA-123 did stuff", wantFound: true},
+		{name: "custom template", comment: "did jira-123 stuff", template: "[{ticket}] {comment}", want: "[JIRA-123] did stuff", wantFound: true},
+		{name: "ticket only", comment: "ops-7", want: "OPS-7", wantFound: true},
+		{name: "no ticket", comment: "daily standup", want: "daily standup", wantFound: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found := FormatTicketComment(tc.comment, TicketFormat{Pattern: pattern, Template: tc.template})
+			if got != tc.want || found != tc.wantFound {
+				t.Fatalf("FormatTicketComment(%q) = (%q, %t), want (%q, %t)", tc.comment, got, found, tc.want, tc.wantFound)
+			}
+		})
+	}
+}
+
+func TestFormatTicketComment_IsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	pattern := regexp.MustCompile(`(?i)[a-z]+-[0-9]+`)
+	for _, template := range []string{"", "[{ticket}] {comment}", "{comment} ({ticket})", "{ticket}: {comment}"} {
+		for _, comment := range []string{"did jira-123 stuff", "ops-7", "fix ops-7"} {
+			format := TicketFormat{Pattern: pattern, Template: template}
+			once, _ := FormatTicketComment(comment, format)
+			twice, found := FormatTicketComment(once, format)
+			if twice != once || !found {
+				t.Fatalf("template %q: formatting %q twice gave %q, once %q", template, comment, twice, once)
+			}
+		}
+	}
+}
+
+func TestFormatDayBatchTickets_WarnsAboutMissingRequiredTicket(t *testing.T) {
+	t.Parallel()
+
+	batch := DayBatch{
+		Worklogs: []onepoint.PersistWorklog{
+			{WorklogDate: "02-03-2026", StartTime: submitterIntPtr(9 * 60), Comment: "did jira-123 stuff"},
+			{WorklogDate: "02-03-2026", StartTime: submitterIntPtr(10 * 60), Comment: "daily standup"},
+		},
+	}
+	format := TicketFormat{Pattern: regexp.MustCompile(`(?i)[a-z]+-[0-9]+`), Required: true}
+
+	warnings := FormatDayBatchTickets(&batch, format)
+	if batch.Worklogs[0].Comment != "JIRA-123 did stuff" {
+		t.Fatalf("expected formatted comment, got %q", batch.Worklogs[0].Comment)
+	}
+	if batch.Worklogs[1].Comment != "daily standup" {
+		t.Fatalf("expected comment without ticket unchanged, got %q", batch.Worklogs[1].Comment)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "10:00") || !strings.Contains(warnings[0], "no ticket id") {
+		t.Fatalf("expected one missing-ticket warning for 10:00, got %v", warnings)
+	}
+
+	format.Required = false
+	if warnings := FormatDayBatchTickets(&batch, format); len(warnings) != 0 {
+		t.Fatalf("expected no warnings without required, got %v", warnings)
+	}
+}
@@ -0,0 +1,57 @@
+package submitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RunSummary is the JSON payload posted to the submit webhook after a
+// submit run completed.
+type RunSummary struct {
+	Days             int      `json:"days"`
+	LockedDays       []string `json:"lockedDays"`
+	LocalEntries     int      `json:"localEntries"`
+	EntriesSubmitted int      `json:"entriesSubmitted"`
+	Duplicates       int      `json:"duplicates"`
+	LocalDuplicates  int      `json:"localDuplicates"`
+	Overlaps         int      `json:"overlaps"`
+	RejectedEntries  int      `json:"rejectedEntries"`
+	FailedDays       []string `json:"failedDays"`
+}
+
+// PostRunSummary POSTs summary as JSON to url. Any non-2xx response is
+// returned as an error; callers decide whether that is fatal.
+func PostRunSummary(ctx context.Context, client *http.Client, url string, summary RunSummary) error {
+	if summary.LockedDays == nil {
+		summary.LockedDays = []string{}
+	}
+	if summary.FailedDays == nil {
+		summary.FailedDays = []string{}
+	}
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package web
+
+import (
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+)
+
+// monthContractView compares a month's local worked hours with the hours of
+// a fixed-hour contract (web.contract_monthly_hours). The contracted hours
+// are the same every month, so months with more workdays expect fewer hours
+// per workday.
+type monthContractView struct {
+	ContractHours   float64 `json:"contractHours"`
+	ActualHours     float64 `json:"actualHours"`
+	DifferenceHours float64 `json:"differenceHours"`
+	Workdays        int     `json:"workdays"`
+	HoursPerWorkday float64 `json:"hoursPerWorkday"`
+}
+
+// buildMonthContract returns the contract comparison for the month starting
+// at monthStart, or nil when no contract is configured. DifferenceHours is
+// positive when more than the contracted hours were worked.
+func buildMonthContract(monthStart time.Time, actualHours float64, webCfg config.WebConfig) *monthContractView {
+	if webCfg.ContractMonthlyHours <= 0 {
+		return nil
+	}
+	contract := &monthContractView{
+		ContractHours:   webCfg.ContractMonthlyHours,
+		ActualHours:     actualHours,
+		DifferenceHours: actualHours - webCfg.ContractMonthlyHours,
+	}
+	for _, day := range rangeDays(monthStart, endOfMonth(monthStart)) {
+		if webCfg.IsWorkday(day.Weekday()) {
+			contract.Workdays++
+		}
+	}
+	if contract.Workdays > 0 {
+		contract.HoursPerWorkday = webCfg.ContractMonthlyHours / float64(contract.Workdays)
+	}
+	return contract
+}
@@ -0,0 +1,92 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestBuildMonthContract_DifferenceAndWorkdaysPerMonth(t *testing.T) {
+	t.Parallel()
+
+	webCfg := config.WebConfig{ContractMonthlyHours: 160}
+	tests := []struct {
+		name           string
+		monthStart     time.Time
+		actualHours    float64
+		wantDifference float64
+		wantWorkdays   int
+		wantPerWorkday float64
+	}{
+		{name: "february has 20 workdays", monthStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local), actualHours: 150, wantDifference: -10, wantWorkdays: 20, wantPerWorkday: 8},
+		{name: "march has 22 workdays", monthStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), actualHours: 165.5, wantDifference: 5.5, wantWorkdays: 22, wantPerWorkday: 160.0 / 22},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract := buildMonthContract(tt.monthStart, tt.actualHours, webCfg)
+			if contract == nil {
+				t.Fatalf("expected a contract comparison")
+			}
+			if contract.ContractHours != 160 || contract.ActualHours != tt.actualHours || contract.DifferenceHours != tt.wantDifference {
+				t.Fatalf("unexpected contract totals: %+v", contract)
+			}
+			if contract.Workdays != tt.wantWorkdays || contract.HoursPerWorkday != tt.wantPerWorkday {
+				t.Fatalf("unexpected workdays: %+v", contract)
+			}
+		})
+	}
+
+	if contract := buildMonthContract(time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), 10, config.WebConfig{}); contract != nil {
+		t.Fatalf("expected no contract without web.contract_monthly_hours, got %+v", contract)
+	}
+}
+
+func TestServer_MonthAPIIncludesContract(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 3, 3, 9, 0, 0, 0, time.Local)),
+	})
+	cfg := testConfig(nil)
+	cfg.Web.ContractMonthlyHours = 160
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, cfg))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/month/2026-03")
+	if err != nil {
+		t.Fatalf("request month api: %v", err)
+	}
+	defer resp.Body.Close()
+	var payload monthAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Contract == nil {
+		t.Fatalf("expected contract in month payload: %+v", payload)
+	}
+	if payload.Contract.ActualHours != 2 || payload.Contract.DifferenceHours != -158 || payload.Contract.Workdays != 22 {
+		t.Fatalf("unexpected contract payload: %+v", payload.Contract)
+	}
+
+	page, err := http.Get(ts.URL + "/month/2026-03")
+	if err != nil {
+		t.Fatalf("request month page: %v", err)
+	}
+	defer page.Body.Close()
+	var body strings.Builder
+	if _, err := io.Copy(&body, page.Body); err != nil {
+		t.Fatalf("read month page: %v", err)
+	}
+	if !strings.Contains(body.String(), `id="month-stat-contract"`) {
+		t.Fatalf("expected contract stat card on month page")
+	}
+}
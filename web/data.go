@@ -3,6 +3,7 @@ package web
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/riadshalaby/gohour/internal/timeutil"
@@ -25,11 +26,26 @@ type EntryRow struct {
 	Start        string
 	End          string
 	DurationMins int
+	// WorkedMins is the worked time of the row, counted from its times and
+	// independent of billable minutes.
+	WorkedMins   int
 	Project      string
 	Activity     string
 	Skill        string
 	BillableMins int
-	Description  string
+	// Billable reports whether any of the row's time is billable, so a
+	// worked non-billable block is not mistaken for missing data.
+	Billable    bool
+	Description string
+	// LocalNote is the private note of a local entry; remote rows have none.
+	LocalNote string
+	// Tags are the local-only labels of a local entry; remote rows have none.
+	Tags []string
+	// TimeRecordID identifies a remote row in OnePoint; it is zero for local
+	// rows and merged remote rows.
+	TimeRecordID int64
+	// Locked marks a remote row OnePoint no longer allows to change.
+	Locked bool
 }
 
 type MonthDayRow struct {
@@ -114,11 +130,15 @@ func BuildDailyView(local []worklog.Entry, remote []onepoint.DayWorklog) []DayRo
 				Start:        entry.StartDateTime.Format("15:04"),
 				End:          entry.EndDateTime.Format("15:04"),
 				DurationMins: max(0, timeutil.MinutesFromMidnight(entry.EndDateTime)-timeutil.MinutesFromMidnight(entry.StartDateTime)),
+				WorkedMins:   entry.DurationMinutes(),
 				Project:      entry.Project,
 				Activity:     entry.Activity,
 				Skill:        entry.Skill,
 				BillableMins: entry.Billable,
+				Billable:     entry.Billable > 0,
 				Description:  entry.Description,
+				LocalNote:    entry.LocalNote,
+				Tags:         entry.Tags,
 			})
 			localHours += hoursFromMinutes(entry.Billable)
 			localWorkedHours += entry.EndDateTime.Sub(entry.StartDateTime).Hours()
@@ -141,11 +161,15 @@ func BuildDailyView(local []worklog.Entry, remote []onepoint.DayWorklog) []DayRo
 				Start:        minutesToClock(item.StartTime),
 				End:          minutesToClock(item.FinishTime),
 				DurationMins: max(0, item.FinishTime-item.StartTime),
+				WorkedMins:   max(0, item.FinishTime-item.StartTime),
 				Project:      fmt.Sprintf("%d", item.ProjectID),
 				Activity:     fmt.Sprintf("%d", item.ActivityID),
 				Skill:        fmt.Sprintf("%d", item.SkillID),
 				BillableMins: item.Billable,
+				Billable:     item.Billable > 0,
 				Description:  item.Comment,
+				TimeRecordID: item.TimeRecordID,
+				Locked:       item.Locked != 0,
 			})
 		}
 
@@ -169,6 +193,51 @@ func BuildDailyView(local []worklog.Entry, remote []onepoint.DayWorklog) []DayRo
 	return out
 }
 
+// MergeConsecutiveRemoteRows collapses contiguous remote rows that share
+// project, activity and skill into one row: a row is merged only when it
+// starts where the previous one ends, so a gap breaks the run. The merged
+// row spans from the first start to the last end and sums duration and
+// billable minutes. Local rows are kept as-is and break a merge run.
+func MergeConsecutiveRemoteRows(rows []EntryRow) []EntryRow {
+	out := make([]EntryRow, 0, len(rows))
+	for _, row := range rows {
+		if len(out) > 0 {
+			last := &out[len(out)-1]
+			if row.Source == "remote" && last.Source == "remote" && row.Start == last.End &&
+				row.Project == last.Project && row.Activity == last.Activity && row.Skill == last.Skill {
+				last.End = row.End
+				last.DurationMins += row.DurationMins
+				last.WorkedMins += row.WorkedMins
+				last.BillableMins += row.BillableMins
+				last.Billable = last.BillableMins > 0
+				last.Description = joinDescriptions(last.Description, row.Description)
+				last.TimeRecordID = 0
+				last.Locked = last.Locked || row.Locked
+				continue
+			}
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+func joinDescriptions(a, b string) string {
+	a = strings.TrimSpace(a)
+	b = strings.TrimSpace(b)
+	if b == "" {
+		return a
+	}
+	if a == "" {
+		return b
+	}
+	for _, part := range strings.Split(a, "; ") {
+		if part == b {
+			return a
+		}
+	}
+	return a + "; " + b
+}
+
 func BuildMonthlyView(days []DayRow) MonthSummary {
 	sorted := append([]DayRow(nil), days...)
 	sort.Slice(sorted, func(i, j int) bool {
@@ -232,10 +301,6 @@ func hasSameTimeRange(a, b onepoint.PersistWorklog) bool {
 func localEntryToPersistWorklog(entry worklog.Entry) onepoint.PersistWorklog {
 	start := timeutil.MinutesFromMidnight(entry.StartDateTime)
 	finish := timeutil.MinutesFromMidnight(entry.EndDateTime)
-	duration := int(entry.EndDateTime.Sub(entry.StartDateTime).Minutes())
-	if duration < 0 {
-		duration = 0
-	}
 	return onepoint.PersistWorklog{
 		TimeRecordID: -1,
 		WorkSlipID:   -1,
@@ -243,7 +308,7 @@ func localEntryToPersistWorklog(entry worklog.Entry) onepoint.PersistWorklog {
 		WorklogDate:  onepoint.FormatDay(timeutil.StartOfDay(entry.StartDateTime)),
 		StartTime:    &start,
 		FinishTime:   &finish,
-		Duration:     duration,
+		Duration:     entry.DurationMinutes(),
 		Billable:     entry.Billable,
 		Valuable:     0,
 		ProjectID:    onepoint.ID(0),
@@ -137,6 +137,84 @@ func TestBuildDailyView_RemoteOnly(t *testing.T) {
 	}
 }
 
+func TestMergeConsecutiveRemoteRows_MergesSameActivity(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	remote := make([]onepoint.DayWorklog, 0, 3)
+	for i, comment := range []string{"Standup", "Review", "Review"} {
+		remote = append(remote, onepoint.DayWorklog{
+			WorklogDate: onepoint.FormatDay(day),
+			StartTime:   9*60 + i*30,
+			FinishTime:  9*60 + (i+1)*30,
+			Billable:    30,
+			ProjectID:   101,
+			ActivityID:  202,
+			SkillID:     303,
+			Comment:     comment,
+		})
+	}
+
+	rows := BuildDailyView(nil, remote)
+	if len(rows) != 1 || len(rows[0].Entries) != 3 {
+		t.Fatalf("expected 3 raw remote rows, got %+v", rows)
+	}
+
+	merged := MergeConsecutiveRemoteRows(rows[0].Entries)
+	if len(merged) != 1 {
+		t.Fatalf("expected 1 merged row, got %d: %+v", len(merged), merged)
+	}
+	got := merged[0]
+	if got.Start != "09:00" || got.End != "10:30" {
+		t.Fatalf("unexpected merged range: %s-%s", got.Start, got.End)
+	}
+	if got.DurationMins != 90 || got.BillableMins != 90 {
+		t.Fatalf("unexpected merged minutes: duration=%d billable=%d", got.DurationMins, got.BillableMins)
+	}
+	if got.Description != "Standup; Review" {
+		t.Fatalf("unexpected merged description: %q", got.Description)
+	}
+	if len(rows[0].Entries) != 3 {
+		t.Fatalf("expected raw rows to stay untouched")
+	}
+}
+
+func TestMergeConsecutiveRemoteRows_KeepsDifferentAndLocalRows(t *testing.T) {
+	t.Parallel()
+
+	rows := []EntryRow{
+		{Source: "remote", Start: "09:00", End: "09:30", DurationMins: 30, Project: "1", Activity: "2", Skill: "3"},
+		{Source: "local", Start: "09:30", End: "10:00", DurationMins: 30, Project: "1", Activity: "2", Skill: "3"},
+		{Source: "remote", Start: "10:00", End: "10:30", DurationMins: 30, Project: "1", Activity: "2", Skill: "3"},
+		{Source: "remote", Start: "10:30", End: "11:00", DurationMins: 30, Project: "1", Activity: "9", Skill: "3"},
+	}
+
+	if merged := MergeConsecutiveRemoteRows(rows); len(merged) != 4 {
+		t.Fatalf("expected no rows merged, got %d: %+v", len(merged), merged)
+	}
+}
+
+func TestMergeConsecutiveRemoteRows_KeepsRowsSeparatedByGap(t *testing.T) {
+	t.Parallel()
+
+	rows := []EntryRow{
+		{Source: "remote", Start: "09:00", End: "09:30", DurationMins: 30, Project: "1", Activity: "2", Skill: "3"},
+		{Source: "remote", Start: "09:30", End: "10:00", DurationMins: 30, Project: "1", Activity: "2", Skill: "3"},
+		{Source: "remote", Start: "13:00", End: "14:00", DurationMins: 60, Project: "1", Activity: "2", Skill: "3"},
+	}
+
+	merged := MergeConsecutiveRemoteRows(rows)
+	if len(merged) != 2 {
+		t.Fatalf("expected the block after the gap to stay separate, got %d: %+v", len(merged), merged)
+	}
+	if merged[0].Start != "09:00" || merged[0].End != "10:00" || merged[0].DurationMins != 60 {
+		t.Fatalf("unexpected merged contiguous row: %+v", merged[0])
+	}
+	if merged[1].Start != "13:00" || merged[1].End != "14:00" {
+		t.Fatalf("unexpected row after gap: %+v", merged[1])
+	}
+}
+
 func TestBuildMonthlyView(t *testing.T) {
 	t.Parallel()
 
@@ -219,6 +297,28 @@ func TestBuildDailyView_DurationIndependentOfBillable(t *testing.T) {
 	}
 }
 
+func TestBuildDailyView_NonBillableEntryKeepsWorkedMins(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	local := []worklog.Entry{
+		{StartDateTime: day, EndDateTime: day.Add(90 * time.Minute), Billable: 0, Project: "P", Activity: "A", Skill: "S"},
+		{StartDateTime: day.Add(2 * time.Hour), EndDateTime: day.Add(3 * time.Hour), Billable: 60, Project: "P", Activity: "A", Skill: "S"},
+	}
+
+	rows := BuildDailyView(local, nil)
+	if len(rows) != 1 || len(rows[0].Entries) != 2 {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+	nonBillable := rows[0].Entries[0]
+	if nonBillable.Billable || nonBillable.WorkedMins != 90 || nonBillable.BillableMins != 0 {
+		t.Fatalf("expected Billable=false WorkedMins=90 BillableMins=0, got %+v", nonBillable)
+	}
+	if billable := rows[0].Entries[1]; !billable.Billable || billable.WorkedMins != 60 {
+		t.Fatalf("expected billable row with 60 worked mins, got %+v", billable)
+	}
+}
+
 func TestBuildDailyView_WorkedHours(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,183 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/riadshalaby/gohour/storage"
+)
+
+type templateRequest struct {
+	Name         string `json:"name"`
+	Project      string `json:"project"`
+	Activity     string `json:"activity"`
+	Skill        string `json:"skill"`
+	Billable     int    `json:"billable"`
+	Description  string `json:"description"`
+	DurationMins int    `json:"durationMins"`
+}
+
+type templateResponse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Project      string `json:"project"`
+	Activity     string `json:"activity"`
+	Skill        string `json:"skill"`
+	Billable     int    `json:"billable"`
+	Description  string `json:"description"`
+	DurationMins int    `json:"durationMins"`
+}
+
+// templateInstantiateRequest places a template on a day. End is optional and
+// defaults to start plus the template duration.
+type templateInstantiateRequest struct {
+	Date  string `json:"date"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func (s *Server) handleAPITemplatesList(w http.ResponseWriter, r *http.Request) {
+	templates, err := s.store.ListTemplates()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("list templates: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]templateResponse, 0, len(templates))
+	for _, tpl := range templates {
+		resp = append(resp, templateResponse(tpl))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleAPITemplateCreate(w http.ResponseWriter, r *http.Request) {
+	var body templateRequest
+	if err := s.decodeJSON(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tpl, err := buildTemplateFromRequest(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := s.store.InsertTemplate(tpl)
+	if err != nil {
+		if errors.Is(err, storage.ErrTemplateExists) {
+			http.Error(w, "template already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("insert template: %v", err), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]int64{"id": id})
+}
+
+func (s *Server) handleAPITemplateDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := parsePositiveInt64(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid template id", http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := s.store.DeleteTemplate(id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("delete template: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !deleted {
+		http.Error(w, "template not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAPITemplateInstantiate(w http.ResponseWriter, r *http.Request) {
+	id, err := parsePositiveInt64(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid template id", http.StatusBadRequest)
+		return
+	}
+
+	tpl, found, err := s.store.GetTemplateByID(id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("get template by id: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "template not found", http.StatusNotFound)
+		return
+	}
+
+	var body templateInstantiateRequest
+	if err := s.decodeJSON(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	end := strings.TrimSpace(body.End)
+	if end == "" {
+		startMinutes, err := parseClockMinutes(body.Start)
+		if err != nil {
+			http.Error(w, "invalid start time (expected HH:MM)", http.StatusBadRequest)
+			return
+		}
+		endMinutes := startMinutes + tpl.DurationMins
+		if endMinutes >= 24*60 {
+			http.Error(w, "template duration would end after midnight", http.StatusBadRequest)
+			return
+		}
+		end = minutesToClock(endMinutes)
+	}
+
+	entry, err := buildEntryFromMutation(worklogMutationRequest{
+		Start:       body.Start,
+		End:         end,
+		Project:     tpl.Project,
+		Activity:    tpl.Activity,
+		Skill:       tpl.Skill,
+		Billable:    tpl.Billable,
+		Description: tpl.Description,
+		Date:        body.Date,
+	}, s.cfg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.insertManualWorklog(w, r, entry)
+}
+
+func buildTemplateFromRequest(body templateRequest) (storage.Template, error) {
+	tpl := storage.Template{
+		Name:         strings.TrimSpace(body.Name),
+		Project:      strings.TrimSpace(body.Project),
+		Activity:     strings.TrimSpace(body.Activity),
+		Skill:        strings.TrimSpace(body.Skill),
+		Billable:     body.Billable,
+		Description:  strings.TrimSpace(body.Description),
+		DurationMins: body.DurationMins,
+	}
+	switch {
+	case tpl.Name == "":
+		return storage.Template{}, fmt.Errorf("name must not be empty")
+	case tpl.Project == "":
+		return storage.Template{}, fmt.Errorf("project must not be empty")
+	case tpl.Activity == "":
+		return storage.Template{}, fmt.Errorf("activity must not be empty")
+	case tpl.Skill == "":
+		return storage.Template{}, fmt.Errorf("skill must not be empty")
+	case tpl.Billable < 0:
+		return storage.Template{}, fmt.Errorf("billable must be >= 0")
+	case tpl.DurationMins <= 0:
+		return storage.Template{}, fmt.Errorf("durationMins must be > 0")
+	case tpl.DurationMins >= int(24*time.Hour/time.Minute):
+		return storage.Template{}, fmt.Errorf("durationMins must be less than a day")
+	}
+	return tpl, nil
+}
@@ -0,0 +1,124 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func postTemplateJSON(t *testing.T, url, body string, wantStatus int) map[string]int64 {
+	t.Helper()
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("post %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected %d from %s, got %d body=%s", wantStatus, url, resp.StatusCode, string(payload))
+	}
+	if wantStatus != http.StatusCreated {
+		return nil
+	}
+	var payload map[string]int64
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return payload
+}
+
+func TestTemplates_CreateListAndInstantiate(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	created := postTemplateJSON(t, ts.URL+"/api/templates",
+		`{"name":"Standup","project":"Project X","activity":"Meeting","skill":"Scrum","billable":15,"description":"Daily standup","durationMins":15}`,
+		http.StatusCreated)
+	templateID := created["id"]
+	if templateID <= 0 {
+		t.Fatalf("expected positive template id, got %d", templateID)
+	}
+
+	resp, err := http.Get(ts.URL + "/api/templates")
+	if err != nil {
+		t.Fatalf("list templates: %v", err)
+	}
+	defer resp.Body.Close()
+	var templates []templateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&templates); err != nil {
+		t.Fatalf("decode templates: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Name != "Standup" || templates[0].DurationMins != 15 {
+		t.Fatalf("unexpected templates: %+v", templates)
+	}
+
+	instantiated := postTemplateJSON(t, fmt.Sprintf("%s/api/templates/%d/instantiate", ts.URL, templateID),
+		`{"date":"2026-03-02","start":"09:45"}`,
+		http.StatusCreated)
+
+	entry, found, err := store.GetWorklogByID(instantiated["id"])
+	if err != nil {
+		t.Fatalf("get worklog by id: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected instantiated worklog to exist")
+	}
+	wantStart := time.Date(2026, 3, 2, 9, 45, 0, 0, time.Local)
+	if !entry.StartDateTime.Equal(wantStart) || !entry.EndDateTime.Equal(wantStart.Add(15*time.Minute)) {
+		t.Fatalf("unexpected times: %s - %s", entry.StartDateTime, entry.EndDateTime)
+	}
+	if entry.Project != "Project X" || entry.Activity != "Meeting" || entry.Skill != "Scrum" ||
+		entry.Billable != 15 || entry.Description != "Daily standup" || entry.SourceMapper != "manual" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestTemplates_DuplicateNameConflict(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	body := `{"name":"Standup","project":"P","activity":"A","skill":"S","billable":15,"description":"d","durationMins":15}`
+	postTemplateJSON(t, ts.URL+"/api/templates", body, http.StatusCreated)
+	postTemplateJSON(t, ts.URL+"/api/templates", body, http.StatusConflict)
+}
+
+func TestTemplates_Delete(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	created := postTemplateJSON(t, ts.URL+"/api/templates",
+		`{"name":"Standup","project":"P","activity":"A","skill":"S","billable":15,"description":"d","durationMins":15}`,
+		http.StatusCreated)
+
+	url := fmt.Sprintf("%s/api/templates/%d", ts.URL, created["id"])
+	for _, want := range []int{http.StatusNoContent, http.StatusNotFound} {
+		req, err := http.NewRequest(http.MethodDelete, url, nil)
+		if err != nil {
+			t.Fatalf("build delete request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("delete template: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("expected %d, got %d", want, resp.StatusCode)
+		}
+	}
+}
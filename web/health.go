@@ -0,0 +1,73 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// healthCheckTimeout bounds each dependency check of GET /healthz.
+	healthCheckTimeout = 5 * time.Second
+	// onePointHealthTTL is how long a OnePoint check result is reused, so
+	// frequent readiness probes do not hit OnePoint on every request.
+	onePointHealthTTL = 30 * time.Second
+)
+
+type healthResponse struct {
+	DB       string `json:"db"`
+	OnePoint string `json:"onepoint"`
+	// Errors holds the failure message per failed dependency.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// onePointHealthCache keeps the last OnePoint connectivity result.
+type onePointHealthCache struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	response := healthResponse{DB: "ok", OnePoint: "ok"}
+	errs := make(map[string]string)
+
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+	if err := s.store.Ping(ctx); err != nil {
+		response.DB = "failed"
+		errs["db"] = err.Error()
+	}
+	if err := s.checkOnePointHealth(ctx, time.Now()); err != nil {
+		response.OnePoint = "failed"
+		errs["onepoint"] = err.Error()
+	}
+
+	status := http.StatusOK
+	if len(errs) > 0 {
+		response.Errors = errs
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, response)
+}
+
+// checkOnePointHealth verifies the session with a cheap ListProjects call,
+// reusing the previous result for onePointHealthTTL.
+func (s *Server) checkOnePointHealth(ctx context.Context, now time.Time) error {
+	s.health.mu.Lock()
+	defer s.health.mu.Unlock()
+
+	if !s.health.checkedAt.IsZero() && now.Sub(s.health.checkedAt) < onePointHealthTTL {
+		return s.health.err
+	}
+
+	projects, err := s.client.ListProjects(ctx)
+	if err == nil && len(projects) == 0 {
+		err = errors.New("ListProjects returned no projects (session may have expired)")
+	}
+	s.health.checkedAt = now
+	s.health.err = err
+	return err
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+// projectsClient is a fakeClient whose ListProjects result is configurable.
+type projectsClient struct {
+	*fakeClient
+	projects []onepoint.Project
+	err      error
+	calls    int
+}
+
+func (c *projectsClient) ListProjects(ctx context.Context) ([]onepoint.Project, error) {
+	c.calls++
+	return c.projects, c.err
+}
+
+func TestHealthz_ReportsOKAndCachesOnePointCheck(t *testing.T) {
+	t.Parallel()
+
+	client := &projectsClient{fakeClient: &fakeClient{}, projects: []onepoint.Project{{ID: 1, Name: "P"}}}
+	ts := httptest.NewServer(NewServer(openTestStore(t), client, testConfig(nil)))
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		resp, err := http.Get(ts.URL + "/healthz")
+		if err != nil {
+			t.Fatalf("healthz request: %v", err)
+		}
+		var body healthResponse
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("decode healthz: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || body.DB != "ok" || body.OnePoint != "ok" {
+			t.Fatalf("expected healthy response, got %d %+v", resp.StatusCode, body)
+		}
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected one cached OnePoint check, got %d calls", client.calls)
+	}
+}
+
+func TestHealthz_ReportsFailedOnePointWith503(t *testing.T) {
+	t.Parallel()
+
+	client := &projectsClient{fakeClient: &fakeClient{}, err: onepoint.ErrAuthUnauthorized}
+	ts := httptest.NewServer(NewServer(openTestStore(t), client, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("healthz request: %v", err)
+	}
+	defer resp.Body.Close()
+	var body healthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode healthz: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || body.DB != "ok" || body.OnePoint != "failed" || body.Errors["onepoint"] == "" {
+		t.Fatalf("expected 503 naming onepoint, got %d %+v", resp.StatusCode, body)
+	}
+}
+
+func TestCheckOnePointHealth_RechecksAfterTTL(t *testing.T) {
+	t.Parallel()
+
+	client := &projectsClient{fakeClient: &fakeClient{}, err: errors.New("offline")}
+	server := &Server{client: client}
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	if err := server.checkOnePointHealth(context.Background(), start); err == nil {
+		t.Fatalf("expected first check to fail")
+	}
+	client.err = nil
+	client.projects = []onepoint.Project{{ID: 1}}
+	if err := server.checkOnePointHealth(context.Background(), start.Add(10*time.Second)); err == nil {
+		t.Fatalf("expected cached failure within TTL")
+	}
+	if err := server.checkOnePointHealth(context.Background(), start.Add(onePointHealthTTL)); err != nil {
+		t.Fatalf("expected recheck after TTL to succeed, got %v", err)
+	}
+	if client.calls != 2 {
+		t.Fatalf("expected 2 ListProjects calls, got %d", client.calls)
+	}
+}
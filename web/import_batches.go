@@ -0,0 +1,103 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/riadshalaby/gohour/importer"
+	"github.com/riadshalaby/gohour/storage"
+)
+
+type importRemapResponse struct {
+	BatchID          string `json:"batchId"`
+	RowsRead         int    `json:"rowsRead"`
+	RowsMapped       int    `json:"rowsMapped"`
+	RowsSkipped      int    `json:"rowsSkipped"`
+	RowsRemoved      int    `json:"rowsRemoved"`
+	RowsPersisted    int    `json:"rowsPersisted"`
+	OverlapsSkipped  int    `json:"overlapsSkipped,omitempty"`
+	ReconcileWarning string `json:"reconcileWarning,omitempty"`
+}
+
+// storeImportSource keeps the uploaded file of formResult together with its
+// mapper and options as the source of import batch batchID.
+func (s *Server) storeImportSource(batchID string, formResult importFormResult) error {
+	content, err := os.ReadFile(formResult.tmpPath)
+	if err != nil {
+		return fmt.Errorf("read upload: %w", err)
+	}
+	return s.store.SaveImportSources(batchID, []storage.ImportSource{{
+		FileName: formResult.fileName,
+		Mapper:   formResult.mapper,
+		Project:  formResult.options.EPMProject,
+		Activity: formResult.options.EPMActivity,
+		Skill:    formResult.options.EPMSkill,
+		Billable: formResult.options.Billable,
+		Content:  content,
+	}})
+}
+
+// handleAPIImportRemap re-runs the current mappers and rules over the stored
+// sources of an import batch and replaces the batch's local entries with the
+// result, applying the same duplicate and overlap checks as POST /api/import.
+func (s *Server) handleAPIImportRemap(w http.ResponseWriter, r *http.Request) {
+	batchID := strings.TrimSpace(r.PathValue("batch"))
+	_, found, err := s.store.GetImportRecord(batchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("get import batch: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "import batch not found", http.StatusNotFound)
+		return
+	}
+
+	sources, err := s.store.ListImportSources(batchID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("list import sources: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if len(sources) == 0 {
+		http.Error(w, "import batch kept no source files (import.store_sources was off)", http.StatusConflict)
+		return
+	}
+
+	result := &importer.Result{}
+	for _, source := range sources {
+		formResult, err := s.runImportSource(source.FileName, bytes.NewReader(source.Content), source.Mapper, importer.RunOptions{
+			EPMProject:  source.Project,
+			EPMActivity: source.Activity,
+			EPMSkill:    source.Skill,
+			Billable:    source.Billable,
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("remap %s: %v", source.FileName, err), http.StatusBadRequest)
+			return
+		}
+		defer os.Remove(formResult.tmpPath)
+
+		result.RowsRead += formResult.result.RowsRead
+		result.RowsMapped += formResult.result.RowsMapped
+		result.RowsSkipped += formResult.result.RowsSkipped
+		result.Entries = append(result.Entries, formResult.result.Entries...)
+	}
+
+	persisted, ok := s.persistImportEntries(w, r, result.Entries, importBatchTarget{replaceID: batchID})
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, importRemapResponse{
+		BatchID:          batchID,
+		RowsRead:         result.RowsRead,
+		RowsMapped:       result.RowsMapped,
+		RowsSkipped:      result.RowsSkipped + persisted.duplicates + persisted.overlapsSkipped,
+		RowsRemoved:      persisted.removed,
+		RowsPersisted:    persisted.inserted,
+		OverlapsSkipped:  persisted.overlapsSkipped,
+		ReconcileWarning: persisted.reconcileWarning,
+	})
+}
@@ -0,0 +1,224 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+const remapTestCSV = "description,startdatetime,enddatetime,project,activity,skill\nTask,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n"
+
+// postGenericImport uploads content through POST /api/import with the
+// generic mapper and returns the decoded response.
+func postGenericImport(t *testing.T, serverURL, content string) importResponse {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "import.csv")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	_, _ = part.Write([]byte(content))
+	_ = writer.WriteField("mapper", "generic")
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+
+	resp, err := http.Post(serverURL+"/api/import", writer.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatalf("import request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(payload))
+	}
+	var imported importResponse
+	if err := json.NewDecoder(resp.Body).Decode(&imported); err != nil {
+		t.Fatalf("decode import response: %v", err)
+	}
+	return imported
+}
+
+func TestImportRemap_ReplacesBatchEntriesWithNewMapperOutput(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	cfg := testConfig(nil)
+	cfg.Import.StoreSources = true
+	importServer := httptest.NewServer(NewServer(store, &fakeClient{}, cfg))
+	defer importServer.Close()
+
+	imported := postGenericImport(t, importServer.URL, remapTestCSV)
+	if imported.BatchID == "" {
+		t.Fatalf("expected a recorded import batch, got %+v", imported)
+	}
+
+	// A rule added after the import makes the same file non-billable.
+	billable := false
+	remapCfg := testConfig([]config.Rule{{Name: "non-billable", FileTemplate: "import-*.csv", Billable: &billable}})
+	remapServer := httptest.NewServer(NewServer(store, &fakeClient{}, remapCfg))
+	defer remapServer.Close()
+
+	remapURL := remapServer.URL + "/api/import/" + imported.BatchID + "/remap"
+	remapResp, err := http.Post(remapURL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("remap request: %v", err)
+	}
+	defer remapResp.Body.Close()
+	if remapResp.StatusCode != http.StatusOK {
+		payload, _ := io.ReadAll(remapResp.Body)
+		t.Fatalf("expected 200, got %d body=%s", remapResp.StatusCode, string(payload))
+	}
+	var remapped importRemapResponse
+	if err := json.NewDecoder(remapResp.Body).Decode(&remapped); err != nil {
+		t.Fatalf("decode remap response: %v", err)
+	}
+	if remapped.RowsRemoved != 1 || remapped.RowsPersisted != 1 {
+		t.Fatalf("expected one removed and one persisted row, got %+v", remapped)
+	}
+
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected the batch entry to be replaced, got %d entries", len(entries))
+	}
+	if entries[0].Billable != 0 {
+		t.Fatalf("expected remapped entry to be non-billable, got billable=%d", entries[0].Billable)
+	}
+
+	// The remapped rows still belong to the batch, so a second remap
+	// replaces them again instead of adding duplicates.
+	againResp, err := http.Post(remapURL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("second remap request: %v", err)
+	}
+	defer againResp.Body.Close()
+	var again importRemapResponse
+	if err := json.NewDecoder(againResp.Body).Decode(&again); err != nil {
+		t.Fatalf("decode second remap response: %v", err)
+	}
+	if again.RowsRemoved != 1 || again.RowsPersisted != 1 {
+		t.Fatalf("expected second remap to replace the same row, got %+v", again)
+	}
+
+	// Web imports are recorded like CLI imports, so "import undo" removes them.
+	deleted, err := store.DeleteImportBatch(imported.BatchID)
+	if err != nil || deleted != 1 {
+		t.Fatalf("undo web import: deleted=%d err=%v", deleted, err)
+	}
+}
+
+func TestImportRemap_AppliesOverlapPolicy(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	cfg := testConfig(nil)
+	cfg.Import.StoreSources = true
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, cfg))
+	defer ts.Close()
+
+	imported := postGenericImport(t, ts.URL, remapTestCSV)
+
+	// An entry added after the import overlaps the batch's row; the batch's
+	// own row must not count as a conflict.
+	start := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+	if _, err := store.InsertWorklogs([]worklog.Entry{{
+		StartDateTime: start,
+		EndDateTime:   start.Add(time.Hour),
+		Billable:      60,
+		Description:   "Manual",
+		Project:       "P",
+		Activity:      "A",
+		Skill:         "S",
+	}}); err != nil {
+		t.Fatalf("insert manual worklog: %v", err)
+	}
+
+	remapURL := ts.URL + "/api/import/" + imported.BatchID + "/remap"
+	conflictResp, err := http.Post(remapURL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("remap request: %v", err)
+	}
+	defer conflictResp.Body.Close()
+	if conflictResp.StatusCode != http.StatusConflict {
+		payload, _ := io.ReadAll(conflictResp.Body)
+		t.Fatalf("expected 409, got %d body=%s", conflictResp.StatusCode, string(payload))
+	}
+	if entries, err := store.ListImportBatchWorklogs(imported.BatchID); err != nil || len(entries) != 1 {
+		t.Fatalf("expected a rejected remap to keep the batch rows, got %d err=%v", len(entries), err)
+	}
+
+	skipResp, err := http.Post(remapURL+"?skipOverlapping=true", "application/json", nil)
+	if err != nil {
+		t.Fatalf("remap request: %v", err)
+	}
+	defer skipResp.Body.Close()
+	var skipped importRemapResponse
+	if err := json.NewDecoder(skipResp.Body).Decode(&skipped); err != nil {
+		t.Fatalf("decode remap response: %v", err)
+	}
+	if skipped.RowsRemoved != 1 || skipped.RowsPersisted != 0 || skipped.OverlapsSkipped != 1 {
+		t.Fatalf("expected the overlapping row to be skipped, got %+v", skipped)
+	}
+}
+
+func TestImportRemap_BatchWithoutSourcesReturns409(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	record, err := store.InsertImportWorklogs([]string{"hours.csv"}, []string{"generic"}, []worklog.Entry{{
+		StartDateTime: start,
+		EndDateTime:   start.Add(time.Hour),
+		Billable:      60,
+		Description:   "Task",
+		Project:       "P",
+		Activity:      "A",
+		Skill:         "S",
+	}})
+	if err != nil {
+		t.Fatalf("insert import worklogs: %v", err)
+	}
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/import/"+record.ID+"/remap", "application/json", nil)
+	if err != nil {
+		t.Fatalf("remap request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 409, got %d body=%s", resp.StatusCode, string(payload))
+	}
+}
+
+func TestImportRemap_UnknownBatchReturns404(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/import/20260301-090000-abcdef/remap", "application/json", nil)
+	if err != nil {
+		t.Fatalf("remap request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 404, got %d body=%s", resp.StatusCode, string(payload))
+	}
+}
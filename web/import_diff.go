@@ -0,0 +1,112 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/riadshalaby/gohour/importer"
+	"github.com/riadshalaby/gohour/internal/timeutil"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+type importDiffEntry struct {
+	Index       int    `json:"index"`
+	Date        string `json:"date"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Project     string `json:"project"`
+	Activity    string `json:"activity"`
+	Skill       string `json:"skill"`
+	Description string `json:"description"`
+	// Status is "new" or "existing".
+	Status string `json:"status"`
+}
+
+type importDiffResponse struct {
+	Preview     bool              `json:"preview"`
+	RowsRead    int               `json:"rowsRead"`
+	RowsMapped  int               `json:"rowsMapped"`
+	RowsSkipped int               `json:"rowsSkipped"`
+	New         int               `json:"new"`
+	Existing    int               `json:"existing"`
+	Entries     []importDiffEntry `json:"entries"`
+}
+
+// localKeyChecker answers importer.ExistenceChecker with the web import's
+// duplicate key (times plus project/activity/skill). Web uploads are mapped
+// from a temporary file, so the store's UNIQUE key, which includes the
+// source file, never matches rows from an earlier upload.
+type localKeyChecker []worklog.Entry
+
+func (c localKeyChecker) ExistsWorklog(entry worklog.Entry) (bool, error) {
+	return containsSameLocalWorklogKey(entry, c), nil
+}
+
+// writeImportDiff answers POST /api/import?preview=1: it reports which mapped
+// entries an import would add and which are already stored, without writing
+// anything.
+func (s *Server) writeImportDiff(w http.ResponseWriter, result *importer.Result) {
+	response := importDiffResponse{
+		Preview:     true,
+		RowsRead:    result.RowsRead,
+		RowsMapped:  result.RowsMapped,
+		RowsSkipped: result.RowsSkipped,
+		Entries:     make([]importDiffEntry, 0, len(result.Entries)),
+	}
+	if len(result.Entries) == 0 {
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+
+	minDay, maxDay := entriesDayRange(result.Entries)
+	existingEntries, err := s.loadLocalRange(minDay, maxDay)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("load local worklogs: %v", err), http.StatusInternalServerError)
+		return
+	}
+	existing, err := importer.ClassifyAgainstStore(localKeyChecker(existingEntries), result.Entries)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("classify import entries: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	for i, entry := range result.Entries {
+		item := importDiffEntry{
+			Index:       i,
+			Date:        timeutil.StartOfDay(entry.StartDateTime).Format("2006-01-02"),
+			Start:       entry.StartDateTime.Format("15:04"),
+			End:         entry.EndDateTime.Format("15:04"),
+			Project:     entry.Project,
+			Activity:    entry.Activity,
+			Skill:       entry.Skill,
+			Description: entry.Description,
+			Status:      "new",
+		}
+		if existing[i] {
+			item.Status = "existing"
+			response.Existing++
+		} else {
+			response.New++
+		}
+		response.Entries = append(response.Entries, item)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// entriesDayRange returns the first and last calendar day of entries, which
+// must not be empty.
+func entriesDayRange(entries []worklog.Entry) (time.Time, time.Time) {
+	minDay := timeutil.StartOfDay(entries[0].StartDateTime)
+	maxDay := minDay
+	for _, entry := range entries[1:] {
+		day := timeutil.StartOfDay(entry.StartDateTime)
+		if day.Before(minDay) {
+			minDay = day
+		}
+		if day.After(maxDay) {
+			maxDay = day
+		}
+	}
+	return minDay, maxDay
+}
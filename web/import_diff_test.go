@@ -0,0 +1,75 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestImportPreview_SplitsNewAndExistingEntries(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	// Stored from an earlier upload under a different source file.
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)),
+	})
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "import.csv")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	_, _ = part.Write([]byte("description,startdatetime,enddatetime,project,activity,skill\n" +
+		"task,2026-03-02 09:00,2026-03-02 10:00,P,A,S\n" +
+		"task,2026-03-02 10:00,2026-03-02 11:00,P,A,S\n" +
+		"task,2026-03-03 09:00,2026-03-03 10:00,P,A,S\n"))
+	_ = writer.WriteField("mapper", "generic")
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+
+	resp, err := http.Post(ts.URL+"/api/import?preview=1", writer.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatalf("preview request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(payload))
+	}
+	var diff importDiffResponse
+	if err := json.NewDecoder(resp.Body).Decode(&diff); err != nil {
+		t.Fatalf("decode preview response: %v", err)
+	}
+	if !diff.Preview || diff.New != 2 || diff.Existing != 1 {
+		t.Fatalf("expected 2 new and 1 existing entry, got %+v", diff)
+	}
+	wantStatus := []string{"existing", "new", "new"}
+	if len(diff.Entries) != len(wantStatus) {
+		t.Fatalf("expected %d entries, got %+v", len(wantStatus), diff.Entries)
+	}
+	for i, want := range wantStatus {
+		if diff.Entries[i].Status != want {
+			t.Fatalf("entry %d: expected status %q, got %+v", i, want, diff.Entries[i])
+		}
+	}
+
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected preview not to insert rows, got %d stored", len(entries))
+	}
+}
@@ -0,0 +1,165 @@
+package web
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// serverMetrics collects basic counters exposed at GET /metrics in the
+// Prometheus text exposition format. A nil *serverMetrics is a no-op.
+type serverMetrics struct {
+	mu sync.Mutex
+
+	requestsByStatus map[int]int64
+
+	onepointFetches       int64
+	onepointFetchSeconds  float64
+	onepointFetchFailures int64
+
+	cacheHits   map[string]int64
+	cacheMisses map[string]int64
+
+	submitRequests   map[string]int64
+	submittedEntries int64
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		requestsByStatus: make(map[int]int64),
+		cacheHits:        map[string]int64{"local": 0, "remote": 0, "lookup": 0},
+		cacheMisses:      map[string]int64{"local": 0, "remote": 0, "lookup": 0},
+		submitRequests:   map[string]int64{"dry_run": 0, "submit": 0},
+	}
+}
+
+func (m *serverMetrics) observeRequest(status int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.requestsByStatus[status]++
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) observeOnePointFetch(duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.onepointFetches++
+	m.onepointFetchSeconds += duration.Seconds()
+	if err != nil {
+		m.onepointFetchFailures++
+	}
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) observeCache(cache string, hit bool) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	if hit {
+		m.cacheHits[cache]++
+	} else {
+		m.cacheMisses[cache]++
+	}
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) observeSubmit(dryRun bool, submitted int) {
+	if m == nil {
+		return
+	}
+	mode := "submit"
+	if dryRun {
+		mode = "dry_run"
+	}
+	m.mu.Lock()
+	m.submitRequests[mode]++
+	m.submittedEntries += int64(submitted)
+	m.mu.Unlock()
+}
+
+func (m *serverMetrics) writeTo(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP gohour_http_requests_total HTTP requests handled, by response status.")
+	fmt.Fprintln(w, "# TYPE gohour_http_requests_total counter")
+	statuses := make([]int, 0, len(m.requestsByStatus))
+	for status := range m.requestsByStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(w, "gohour_http_requests_total{status=\"%d\"} %d\n", status, m.requestsByStatus[status])
+	}
+
+	fmt.Fprintln(w, "# HELP gohour_onepoint_fetch_duration_seconds Latency of OnePoint worklog and lookup fetches.")
+	fmt.Fprintln(w, "# TYPE gohour_onepoint_fetch_duration_seconds summary")
+	fmt.Fprintf(w, "gohour_onepoint_fetch_duration_seconds_sum %g\n", m.onepointFetchSeconds)
+	fmt.Fprintf(w, "gohour_onepoint_fetch_duration_seconds_count %d\n", m.onepointFetches)
+	fmt.Fprintln(w, "# HELP gohour_onepoint_fetch_failures_total OnePoint fetches that returned an error.")
+	fmt.Fprintln(w, "# TYPE gohour_onepoint_fetch_failures_total counter")
+	fmt.Fprintf(w, "gohour_onepoint_fetch_failures_total %d\n", m.onepointFetchFailures)
+
+	writeLabeledCounter(w, "gohour_cache_hits_total", "Cache hits, by cache.", "cache", m.cacheHits)
+	writeLabeledCounter(w, "gohour_cache_misses_total", "Cache misses, by cache.", "cache", m.cacheMisses)
+	writeLabeledCounter(w, "gohour_submit_requests_total", "Submit requests, by mode.", "mode", m.submitRequests)
+
+	fmt.Fprintln(w, "# HELP gohour_submitted_entries_total Worklog entries persisted to OnePoint.")
+	fmt.Fprintln(w, "# TYPE gohour_submitted_entries_total counter")
+	fmt.Fprintf(w, "gohour_submitted_entries_total %d\n", m.submittedEntries)
+}
+
+func writeLabeledCounter(w io.Writer, name, help, label string, values map[string]int64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s{%s=%q} %d\n", name, label, key, values[key])
+	}
+}
+
+// onePointCallCounter is implemented by OnePoint clients that count their
+// API calls, such as *onepoint.HTTPClient.
+type onePointCallCounter interface {
+	CallCount() int64
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	s.metrics.writeTo(w)
+	if counter, ok := s.client.(onePointCallCounter); ok {
+		fmt.Fprintln(w, "# HELP gohour_onepoint_calls_total OnePoint API calls made by the server's client.")
+		fmt.Fprintln(w, "# TYPE gohour_onepoint_calls_total counter")
+		fmt.Fprintf(w, "gohour_onepoint_calls_total %d\n", counter.CallCount())
+	}
+}
+
+// statusRecorder captures the response status for request metrics.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(body []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(body)
+}
@@ -0,0 +1,111 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+func TestMetrics_DisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("metrics request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 without metrics option, got %d", resp.StatusCode)
+	}
+}
+
+func TestMetrics_ExposesCountersAfterRequests(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := &fakeClient{
+		snapshot: onepoint.LookupSnapshot{
+			Projects: []onepoint.Project{{ID: 1, Name: "Project A", Archived: "0"}},
+		},
+	}
+	ts := httptest.NewServer(NewServerWithOptions(store, client, testConfig(nil), Options{EnableMetrics: true}))
+	defer ts.Close()
+
+	for _, path := range []string{"/api/month/2026-03", "/api/month/2026-03", "/api/lookup", "/api/day/not-a-date"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("request %s: %v", path, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("metrics request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read metrics: %v", err)
+	}
+	text := string(body)
+
+	for _, want := range []string{
+		`gohour_http_requests_total{status="200"} 3`,
+		`gohour_http_requests_total{status="400"} 1`,
+		"gohour_onepoint_fetch_duration_seconds_count 2",
+		`gohour_cache_hits_total{cache="remote"} 1`,
+		`gohour_cache_misses_total{cache="remote"} 1`,
+		`gohour_cache_misses_total{cache="lookup"} 1`,
+		`gohour_cache_hits_total{cache="local"}`,
+		`gohour_submit_requests_total{mode="submit"} 0`,
+		"gohour_submitted_entries_total 0",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected metrics to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+// countingClient is a fakeClient that reports a fixed OnePoint call count.
+type countingClient struct {
+	*fakeClient
+	calls int64
+}
+
+func (c countingClient) CallCount() int64 {
+	return c.calls
+}
+
+func TestMetrics_ExposesOnePointCallCount(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := countingClient{fakeClient: &fakeClient{}, calls: 7}
+	ts := httptest.NewServer(NewServerWithOptions(store, client, testConfig(nil), Options{EnableMetrics: true}))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("metrics request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read metrics: %v", err)
+	}
+	if !strings.Contains(string(body), "gohour_onepoint_calls_total 7") {
+		t.Fatalf("expected OnePoint call counter, got:\n%s", string(body))
+	}
+}
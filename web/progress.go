@@ -0,0 +1,75 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/internal/timeutil"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+// monthProgressResponse holds cumulative logged and target hours per day of
+// a month, index-aligned with Days, for drawing a burn-up chart.
+type monthProgressResponse struct {
+	Month            string    `json:"month"`
+	DailyTargetHours float64   `json:"dailyTargetHours"`
+	Days             []string  `json:"days"`
+	Logged           []float64 `json:"logged"`
+	Target           []float64 `json:"target"`
+	TotalTarget      float64   `json:"totalTarget"`
+}
+
+func (s *Server) handleAPIMonthProgress(w http.ResponseWriter, r *http.Request) {
+	monthRaw := strings.TrimSpace(r.PathValue("month"))
+	monthStart, err := parseMonth(monthRaw, s.location)
+	if err != nil {
+		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
+		return
+	}
+
+	localEntries, err := s.loadLocalRange(monthStart, endOfMonth(monthStart))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := buildMonthProgress(monthStart, localEntries, s.cfg.Web, time.Now().In(s.location))
+	response.Month = monthRaw
+	writeJSON(w, http.StatusOK, response)
+}
+
+// buildMonthProgress accumulates local worked hours and the daily target over
+// the workdays of the month starting at monthStart. Days after today still
+// add their target but never logged hours.
+func buildMonthProgress(monthStart time.Time, localEntries []worklog.Entry, webCfg config.WebConfig, today time.Time) monthProgressResponse {
+	workedByDay := make(map[string]float64)
+	for _, day := range BuildDailyView(localEntries, nil) {
+		workedByDay[timeutil.StartOfDay(day.Date).Format("2006-01-02")] = day.LocalWorkedHours
+	}
+
+	today = timeutil.StartOfDay(today)
+	days := rangeDays(monthStart, endOfMonth(monthStart))
+	response := monthProgressResponse{
+		DailyTargetHours: webCfg.DailyTargetHours,
+		Days:             make([]string, 0, len(days)),
+		Logged:           make([]float64, 0, len(days)),
+		Target:           make([]float64, 0, len(days)),
+	}
+	logged, target := 0.0, 0.0
+	for _, day := range days {
+		dayISO := day.Format("2006-01-02")
+		if webCfg.IsWorkday(day.Weekday()) {
+			target += webCfg.DailyTargetHours
+		}
+		if !day.After(today) {
+			logged += workedByDay[dayISO]
+		}
+		response.Days = append(response.Days, dayISO)
+		response.Logged = append(response.Logged, logged)
+		response.Target = append(response.Target, target)
+	}
+	response.TotalTarget = target
+	return response
+}
@@ -0,0 +1,92 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/config"
+	"github.com/riadshalaby/gohour/worklog"
+)
+
+func TestBuildMonthProgress_CumulatesWorkdaysAndSkipsFutureLogged(t *testing.T) {
+	t.Parallel()
+
+	// February 2026 starts on a Sunday; the first days cover a weekend on
+	// both ends of the first week.
+	monthStart := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	entries := []worklog.Entry{
+		newLocalEntry(time.Date(2026, 2, 2, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 2, 2, 10, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 2, 3, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 2, 7, 9, 0, 0, 0, time.Local)),
+		// After "today": counted for the target, not as logged.
+		newLocalEntry(time.Date(2026, 2, 9, 9, 0, 0, 0, time.Local)),
+	}
+	webCfg := config.WebConfig{DailyTargetHours: 8}
+	today := time.Date(2026, 2, 8, 15, 0, 0, 0, time.Local)
+
+	progress := buildMonthProgress(monthStart, entries, webCfg, today)
+
+	if len(progress.Days) != 28 || progress.Days[0] != "2026-02-01" || progress.Days[27] != "2026-02-28" {
+		t.Fatalf("unexpected days: %v", progress.Days)
+	}
+	wantTarget := []float64{0, 8, 16, 24, 32, 40, 40, 40, 48, 56}
+	if !reflect.DeepEqual(progress.Target[:10], wantTarget) {
+		t.Fatalf("unexpected cumulative target: want %v, got %v", wantTarget, progress.Target[:10])
+	}
+	wantLogged := []float64{0, 2, 3, 3, 3, 3, 4, 4, 4, 4}
+	if !reflect.DeepEqual(progress.Logged[:10], wantLogged) {
+		t.Fatalf("unexpected cumulative logged: want %v, got %v", wantLogged, progress.Logged[:10])
+	}
+	if progress.TotalTarget != 160 || progress.Target[27] != 160 {
+		t.Fatalf("expected 20 workdays of 8h, got total %v", progress.TotalTarget)
+	}
+}
+
+func TestBuildMonthProgress_UsesConfiguredWorkdays(t *testing.T) {
+	t.Parallel()
+
+	monthStart := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	webCfg := config.WebConfig{DailyTargetHours: 6, Workdays: []string{"Sunday", "mon"}}
+	today := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+
+	progress := buildMonthProgress(monthStart, nil, webCfg, today)
+
+	wantTarget := []float64{6, 12, 12, 12, 12, 12, 12, 18}
+	if !reflect.DeepEqual(progress.Target[:8], wantTarget) {
+		t.Fatalf("unexpected cumulative target: want %v, got %v", wantTarget, progress.Target[:8])
+	}
+}
+
+func TestMonthProgressAPI_RejectsInvalidMonthAndListsDays(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(NewServer(openTestStore(t), &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/month/2026-13/progress")
+	if err != nil {
+		t.Fatalf("progress request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(ts.URL + "/api/month/2026-02/progress")
+	if err != nil {
+		t.Fatalf("progress request: %v", err)
+	}
+	defer resp.Body.Close()
+	var payload monthProgressResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode progress response: %v", err)
+	}
+	if payload.Month != "2026-02" || len(payload.Days) != 28 {
+		t.Fatalf("unexpected progress payload: %+v", payload)
+	}
+}
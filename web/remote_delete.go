@@ -0,0 +1,54 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+// handleAPIDeleteRemoteWorklog removes one OnePoint entry of a day. Locked
+// entries are refused with 409 and unknown entries with 404; other OnePoint
+// failures map to 502.
+func (s *Server) handleAPIDeleteRemoteWorklog(w http.ResponseWriter, r *http.Request) {
+	dateRaw := strings.TrimSpace(r.PathValue("date"))
+	day, err := parseISODate(dateRaw, s.location)
+	if err != nil {
+		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	timeRecordID, err := parsePositiveInt64(r.PathValue("timeRecordId"))
+	if err != nil {
+		http.Error(w, "invalid time record id", http.StatusBadRequest)
+		return
+	}
+
+	record := auditRecord{
+		Operation: "delete_remote_worklog",
+		Scope:     "day",
+		Target:    fmt.Sprintf("%s/%d", dateRaw, timeRecordID),
+	}
+	if err := s.client.DeleteDayWorklog(r.Context(), day, timeRecordID); err != nil {
+		record.Outcome = "error"
+		record.Error = err.Error()
+		s.logAudit(record)
+		switch {
+		case errors.Is(err, onepoint.ErrWorklogLocked):
+			http.Error(w, "remote worklog is locked", http.StatusConflict)
+		case errors.Is(err, onepoint.ErrWorklogNotFound):
+			http.Error(w, "remote worklog not found", http.StatusNotFound)
+		default:
+			http.Error(w, fmt.Sprintf("delete remote worklog: %v", err), remoteErrorStatus(err))
+		}
+		return
+	}
+
+	s.invalidateRemoteDays([]time.Time{day})
+	record.Deleted = 1
+	record.Outcome = "success"
+	s.logAudit(record)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+func TestServer_DeleteRemoteWorklog(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := &fakeClient{
+		dayWorklogs: map[string][]onepoint.DayWorklog{
+			"2026-03-02": {
+				{TimeRecordID: 11, WorklogDate: "02-03-2026", StartTime: 9 * 60, FinishTime: 10 * 60, Duration: 60, Billable: 60},
+				{TimeRecordID: 12, WorklogDate: "02-03-2026", StartTime: 10 * 60, FinishTime: 11 * 60, Duration: 60, Billable: 60},
+				{TimeRecordID: 13, WorklogDate: "02-03-2026", StartTime: 11 * 60, FinishTime: 12 * 60, Duration: 60, Billable: 60, Locked: 1},
+			},
+		},
+	}
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	deleteRemote := func(path string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodDelete, ts.URL+path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("delete %s: %v", path, err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := deleteRemote("/api/remote/2026-03-02/12")
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 204, got %d body=%s", resp.StatusCode, string(body))
+	}
+	remaining := client.persistByDate["2026-03-02"]
+	if len(remaining) != 2 || remaining[0].TimeRecordID != 11 || remaining[1].TimeRecordID != 13 {
+		t.Fatalf("expected entries 11 and 13 to remain, got %+v", remaining)
+	}
+
+	cases := []struct {
+		path   string
+		status int
+	}{
+		{path: "/api/remote/2026-03-02/13", status: http.StatusConflict},
+		{path: "/api/remote/2026-03-02/99", status: http.StatusNotFound},
+		{path: "/api/remote/2026-02-30/12", status: http.StatusBadRequest},
+		{path: "/api/remote/2026-03-02/abc", status: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		if resp := deleteRemote(tc.path); resp.StatusCode != tc.status {
+			t.Fatalf("expected %d for %s, got %d", tc.status, tc.path, resp.StatusCode)
+		}
+	}
+	if client.persistCalls != 1 {
+		t.Fatalf("expected refused deletes not to persist, got %d persist calls", client.persistCalls)
+	}
+}
+
+func TestServer_DeleteRemoteWorklog_UpstreamFailureIs502(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := &fakeClient{getDayErr: io.ErrUnexpectedEOF}
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/api/remote/2026-03-02/12", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("delete request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,109 @@
+package web
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+const (
+	// remoteFetchChunkDays is the span of one GetFilteredWorklogs call when
+	// weekly remote fetching is enabled.
+	remoteFetchChunkDays = 7
+	// remoteFetchConcurrency bounds parallel chunk requests to OnePoint.
+	remoteFetchConcurrency = 4
+)
+
+type dayRange struct {
+	from time.Time
+	to   time.Time
+}
+
+// fetchRemoteWorklogs loads remote worklogs for [from, to]. With weekly
+// chunking enabled, ranges longer than a week are split into week-sized
+// requests that run concurrently; the merged result drops exact duplicates.
+func (s *Server) fetchRemoteWorklogs(ctx context.Context, from, to time.Time) ([]onepoint.DayWorklog, error) {
+	chunks := []dayRange{{from: from, to: to}}
+	if s.weeklyRemoteFetch {
+		chunks = splitDayRange(from, to, remoteFetchChunkDays)
+	}
+	if len(chunks) == 1 {
+		return s.fetchRemoteChunk(ctx, chunks[0])
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]onepoint.DayWorklog, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, remoteFetchConcurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				errs[i] = ctx.Err()
+				return
+			}
+			results[i], errs[i] = s.fetchRemoteChunk(ctx, chunk)
+			if errs[i] != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Report the first real failure rather than a cancellation it caused.
+	var firstErr error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if firstErr == nil || (firstErr == context.Canceled && err != context.Canceled) {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	seen := make(map[onepoint.DayWorklog]struct{})
+	merged := make([]onepoint.DayWorklog, 0, 64)
+	for _, chunk := range results {
+		for _, item := range chunk {
+			if _, ok := seen[item]; ok {
+				continue
+			}
+			seen[item] = struct{}{}
+			merged = append(merged, item)
+		}
+	}
+	return merged, nil
+}
+
+func (s *Server) fetchRemoteChunk(ctx context.Context, chunk dayRange) ([]onepoint.DayWorklog, error) {
+	fetchStart := time.Now()
+	loaded, err := s.client.GetFilteredWorklogs(ctx, chunk.from, chunk.to)
+	s.metrics.observeOnePointFetch(time.Since(fetchStart), err)
+	return loaded, err
+}
+
+// splitDayRange cuts the calendar days of [from, to] into consecutive ranges
+// of at most size days.
+func splitDayRange(from, to time.Time, size int) []dayRange {
+	days := rangeDays(from, to)
+	if len(days) == 0 || size <= 0 {
+		return []dayRange{{from: from, to: to}}
+	}
+	chunks := make([]dayRange, 0, (len(days)+size-1)/size)
+	for start := 0; start < len(days); start += size {
+		end := min(start+size, len(days)) - 1
+		chunks = append(chunks, dayRange{from: days[start], to: days[end]})
+	}
+	return chunks
+}
@@ -5,6 +5,7 @@ package web
 import (
 	"context"
 	"embed"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"io/fs"
 	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -25,6 +27,7 @@ import (
 	"github.com/riadshalaby/gohour/importer"
 	"github.com/riadshalaby/gohour/internal/timeutil"
 	"github.com/riadshalaby/gohour/onepoint"
+	"github.com/riadshalaby/gohour/output"
 	"github.com/riadshalaby/gohour/reconcile"
 	"github.com/riadshalaby/gohour/storage"
 	"github.com/riadshalaby/gohour/submitter"
@@ -38,20 +41,30 @@ var templateFS embed.FS
 var staticFS embed.FS
 
 type Server struct {
-	store  *storage.SQLiteStore
+	store  storage.Store
 	client onepoint.Client
 	cfg    config.Config
+	// location is the configured timezone used to parse dates and group
+	// worklogs into calendar days.
+	location *time.Location
 
 	submitOptions onepoint.ResolveOptions
 	audit         auditLogger
+	metrics       *serverMetrics
 	mux           *http.ServeMux
+	// allowUnknownJSONFields relaxes decodeJSON to ignore unknown fields.
+	allowUnknownJSONFields bool
+	// weeklyRemoteFetch splits remote range loads into weekly requests.
+	weeklyRemoteFetch bool
+	// submitDisabled makes the submit endpoints answer 403.
+	submitDisabled bool
 
 	mu          sync.RWMutex
 	dayCache    map[string][]onepoint.DayWorklog
 	dayFetched  map[string]bool
 	dayRefresh  map[string]time.Time
 	localByDay  map[string][]worklog.Entry
-	localLoaded bool
+	localLoaded map[string]bool
 
 	remoteFetchMu sync.Mutex
 	localLoadMu   sync.Mutex
@@ -60,6 +73,9 @@ type Server struct {
 	lookupMu      sync.Mutex
 	lookupSnap    *onepoint.LookupSnapshot
 	lookupFetched bool
+
+	health       onePointHealthCache
+	sessionWatch *SessionWatch
 }
 
 type monthRowView struct {
@@ -93,6 +109,32 @@ type monthPageView struct {
 	TotalWorkedDelta   float64
 	TotalBillableDelta float64
 	RemoteRefreshedAt  string
+	Source             string
+	SourceOptions      []string
+	// Contract is nil unless web.contract_monthly_hours is set.
+	Contract *monthContractView
+	// SubmitDisabled hides the submit and remote delete actions (serve --no-submit).
+	SubmitDisabled bool
+}
+
+type weekPageView struct {
+	Title        string
+	CurrentMonth string
+	// Day is the breadcrumb label in base.html.
+	Day                string
+	WeekStart          string
+	WeekEnd            string
+	PreviousWeek       string
+	NextWeek           string
+	AuthErrorMsg       string
+	Rows               []monthRowView
+	TotalLocal         float64
+	TotalRemote        float64
+	TotalLocalWorked   float64
+	TotalRemoteWorked  float64
+	TotalWorkedDelta   float64
+	TotalBillableDelta float64
+	RemoteRefreshedAt  string
 }
 
 type dayPageView struct {
@@ -102,6 +144,13 @@ type dayPageView struct {
 	AuthErrorMsg      string
 	DayRow            DayRow
 	RemoteRefreshedAt string
+	MergeRemote       bool
+	Source            string
+	SourceOptions     []string
+	// TagColors maps a tag to its configured chip color (web.tag_colors).
+	TagColors map[string]string
+	// SubmitDisabled hides the submit and remote delete actions (serve --no-submit).
+	SubmitDisabled bool
 }
 
 type dayAPIResponse struct {
@@ -125,6 +174,22 @@ type monthAPIResponse struct {
 	TotalBillableDelta float64        `json:"totalBillableDelta"`
 	AuthErrorMsg       string         `json:"authErrorMsg,omitempty"`
 	RemoteRefreshedAt  string         `json:"remoteRefreshedAt,omitempty"`
+	// Contract is omitted unless web.contract_monthly_hours is set.
+	Contract *monthContractView `json:"contract,omitempty"`
+}
+
+type weekAPIResponse struct {
+	WeekStart          string         `json:"weekStart"`
+	WeekEnd            string         `json:"weekEnd"`
+	Rows               []monthRowView `json:"rows"`
+	TotalLocal         float64        `json:"totalLocal"`
+	TotalRemote        float64        `json:"totalRemote"`
+	TotalLocalWorked   float64        `json:"totalLocalWorked"`
+	TotalRemoteWorked  float64        `json:"totalRemoteWorked"`
+	TotalWorkedDelta   float64        `json:"totalWorkedDelta"`
+	TotalBillableDelta float64        `json:"totalBillableDelta"`
+	AuthErrorMsg       string         `json:"authErrorMsg,omitempty"`
+	RemoteRefreshedAt  string         `json:"remoteRefreshedAt,omitempty"`
 }
 
 type worklogMutationRequest struct {
@@ -135,7 +200,25 @@ type worklogMutationRequest struct {
 	Skill       string `json:"skill"`
 	Billable    int    `json:"billable"`
 	Description string `json:"description"`
-	Date        string `json:"date"`
+	// LocalNote is stored locally only and never submitted to OnePoint.
+	LocalNote string `json:"localNote"`
+	// Tags are local-only labels; they never affect submit.
+	Tags []string `json:"tags"`
+	Date string   `json:"date"`
+}
+
+type bulkWorklogResult struct {
+	Index int `json:"index"`
+	// Status is "inserted" or "duplicate" (an identical local entry or
+	// earlier item exists).
+	Status string `json:"status"`
+	ID     int64  `json:"id,omitempty"`
+}
+
+type bulkWorklogResponse struct {
+	Created int                 `json:"created"`
+	IDs     []int64             `json:"ids"`
+	Results []bulkWorklogResult `json:"results"`
 }
 
 type importResponse struct {
@@ -146,6 +229,14 @@ type importResponse struct {
 	RowsPersisted    int    `json:"rowsPersisted"`
 	ReconcileWarning string `json:"reconcileWarning,omitempty"`
 	OverlapsSkipped  int    `json:"overlapsSkipped,omitempty"`
+	// BatchID identifies the inserted rows for "gohour import undo" and, with
+	// import.store_sources, for POST /api/import/{batch}/remap; empty when
+	// nothing was inserted.
+	BatchID string `json:"batchId,omitempty"`
+	// DayTotalMismatches lists EPM days whose entries miss their Tagessumme.
+	DayTotalMismatches []importer.DayTotalMismatch `json:"dayTotalMismatches,omitempty"`
+	// FileDuplicates lists rows that repeat an earlier row of the file.
+	FileDuplicates []importer.FileDuplicate `json:"fileDuplicates,omitempty"`
 }
 
 type importPreviewEntry struct {
@@ -170,8 +261,11 @@ type importPreviewResponse struct {
 }
 
 type importFormResult struct {
-	tmpPath string
-	result  *importer.Result
+	tmpPath  string
+	fileName string
+	mapper   string
+	options  importer.RunOptions
+	result   *importer.Result
 }
 
 type importOverlapItem struct {
@@ -197,15 +291,110 @@ type submitDayResult struct {
 	Duplicates int    `json:"duplicates"`
 	Overlaps   int    `json:"overlaps"`
 	Locked     bool   `json:"locked"`
+	Warning    string `json:"warning,omitempty"`
+	// Preview lists the entries a dry run would add for the day.
+	Preview []submitPreviewEntry `json:"preview,omitempty"`
+}
+
+// submitPreviewEntry is one entry a dry-run submit would write.
+type submitPreviewEntry struct {
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	ProjectID  int64  `json:"projectId"`
+	ActivityID int64  `json:"activityId"`
+	SkillID    int64  `json:"skillId"`
+	Billable   int    `json:"billable"`
+	Comment    string `json:"comment"`
 }
 
 type submitResponse struct {
-	DryRun     bool              `json:"dryRun,omitempty"`
-	Submitted  int               `json:"submitted"`
-	Duplicates int               `json:"duplicates"`
-	Overlaps   int               `json:"overlaps"`
-	LockedDays []string          `json:"lockedDays"`
-	Days       []submitDayResult `json:"days"`
+	DryRun          bool              `json:"dryRun,omitempty"`
+	Submitted       int               `json:"submitted"`
+	Duplicates      int               `json:"duplicates"`
+	Overlaps        int               `json:"overlaps"`
+	OverlapsWritten int               `json:"overlapsWritten"`
+	OverlapsSkipped int               `json:"overlapsSkipped"`
+	LockedDays      []string          `json:"lockedDays"`
+	Days            []submitDayResult `json:"days"`
+}
+
+// submitDryRunFromQuery reports whether the submit request asks for a dry
+// run via ?dry_run=1 or ?dryRun=1.
+func submitDryRunFromQuery(r *http.Request) bool {
+	query := r.URL.Query()
+	return strings.TrimSpace(query.Get("dry_run")) == "1" || strings.TrimSpace(query.Get("dryRun")) == "1"
+}
+
+// buildSubmitPreview describes the entries of toAdd for a dry-run response.
+func buildSubmitPreview(toAdd []onepoint.PersistWorklog) []submitPreviewEntry {
+	preview := make([]submitPreviewEntry, 0, len(toAdd))
+	for _, item := range toAdd {
+		entry := submitPreviewEntry{
+			ProjectID:  item.ProjectID.Value,
+			ActivityID: item.ActivityID.Value,
+			SkillID:    item.SkillID.Value,
+			Billable:   item.Billable,
+			Comment:    item.Comment,
+		}
+		if item.StartTime != nil {
+			entry.Start = minutesToClock(*item.StartTime)
+		}
+		if item.FinishTime != nil {
+			entry.End = minutesToClock(*item.FinishTime)
+		}
+		preview = append(preview, entry)
+	}
+	return preview
+}
+
+// retryPersistAfterConflict reloads day after OnePoint rejected its persist as
+// a concurrent modification, merges toAdd with the current remote entries
+// and persists once more. Nothing is persisted when no entry remains to add.
+func retryPersistAfterConflict(
+	ctx context.Context,
+	client onepoint.Client,
+	day time.Time,
+	previous []onepoint.PersistWorklog,
+	toAdd []onepoint.PersistWorklog,
+) (submitter.ConflictMerge, []onepoint.PersistResult, error) {
+	fresh, err := client.GetDayWorklogs(ctx, day)
+	if err != nil {
+		return submitter.ConflictMerge{}, nil, fmt.Errorf("reload after conflict: %w", err)
+	}
+	merge, err := submitter.MergeAfterConflict(fresh, previous, toAdd)
+	if err != nil {
+		return submitter.ConflictMerge{}, nil, fmt.Errorf("merge after conflict: %w", err)
+	}
+	if len(merge.ToWrite) == 0 {
+		return merge, nil, nil
+	}
+	results, err := client.PersistWorklogs(ctx, day, merge.Payload)
+	return merge, results, err
+}
+
+// formatSubmitPreviewRange formats the time range of item as "HH:MM-HH:MM".
+func formatSubmitPreviewRange(item onepoint.PersistWorklog) string {
+	entry := buildSubmitPreview([]onepoint.PersistWorklog{item})[0]
+	return entry.Start + "-" + entry.End
+}
+
+// Overlap policies for the non-interactive web submit.
+const (
+	submitOverlapSkip  = "skip"
+	submitOverlapWrite = "write"
+)
+
+// parseSubmitOverlapPolicy reads the policy query parameter of the submit
+// API. Empty defaults to skip.
+func parseSubmitOverlapPolicy(raw string) (string, error) {
+	switch policy := strings.ToLower(strings.TrimSpace(raw)); policy {
+	case "", submitOverlapSkip:
+		return submitOverlapSkip, nil
+	case submitOverlapWrite:
+		return submitOverlapWrite, nil
+	default:
+		return "", fmt.Errorf("invalid policy %q (expected skip or write)", raw)
+	}
 }
 
 type worklogConflictResponse struct {
@@ -226,6 +415,7 @@ type submitPartialView struct {
 type lookupProject struct {
 	ID       int64  `json:"id"`
 	Name     string `json:"name"`
+	Code     string `json:"code,omitempty"`
 	Archived bool   `json:"archived"`
 }
 
@@ -254,16 +444,50 @@ type upstreamErrorClient struct {
 	base onepoint.Client
 }
 
-func NewServer(store *storage.SQLiteStore, client onepoint.Client, cfg config.Config) http.Handler {
+// Options configures optional server features.
+type Options struct {
+	// EnableMetrics exposes GET /metrics in Prometheus text format.
+	EnableMetrics bool
+	// AllowUnknownJSONFields makes JSON API endpoints ignore unknown request
+	// fields instead of rejecting them. Bodies must still hold one object.
+	AllowUnknownJSONFields bool
+	// FetchRemoteInWeeklyChunks loads remote ranges longer than a week as
+	// concurrent week-sized requests instead of one large request.
+	FetchRemoteInWeeklyChunks bool
+	// SessionWatch, when set, supplies the session banner for pages whose
+	// own remote load succeeded, e.g. from cache.
+	SessionWatch *SessionWatch
+	// DisableSubmit makes the submit and remote delete endpoints answer 403
+	// and hides their buttons, while local create, edit and delete keep
+	// working.
+	DisableSubmit bool
+}
+
+func NewServer(store storage.Store, client onepoint.Client, cfg config.Config) http.Handler {
+	return NewServerWithOptions(store, client, cfg, Options{})
+}
+
+func NewServerWithOptions(store storage.Store, client onepoint.Client, cfg config.Config, options Options) http.Handler {
 	server := &Server{
-		store:      store,
-		client:     client,
-		cfg:        cfg,
-		audit:      newFileAuditLogger(defaultAuditLogPath()),
-		dayCache:   make(map[string][]onepoint.DayWorklog),
-		dayFetched: make(map[string]bool),
-		dayRefresh: make(map[string]time.Time),
-		localByDay: make(map[string][]worklog.Entry),
+		store:       store,
+		client:      client,
+		cfg:         cfg,
+		location:    cfg.Location(),
+		audit:       newFileAuditLogger(defaultAuditLogPath()),
+		dayCache:    make(map[string][]onepoint.DayWorklog),
+		dayFetched:  make(map[string]bool),
+		dayRefresh:  make(map[string]time.Time),
+		localByDay:  make(map[string][]worklog.Entry),
+		localLoaded: make(map[string]bool),
+		submitOptions: onepoint.ResolveOptions{
+			ProjectCodePattern:        cfg.OnePoint.ProjectCodeRegexp(),
+			SelectableProjectStatuses: cfg.OnePoint.SelectableProjectStatuses,
+			IgnoreDiacritics:          cfg.OnePoint.IgnoreDiacritics,
+		},
+		allowUnknownJSONFields: options.AllowUnknownJSONFields,
+		weeklyRemoteFetch:      options.FetchRemoteInWeeklyChunks,
+		sessionWatch:           options.SessionWatch,
+		submitDisabled:         options.DisableSubmit,
 	}
 
 	mux := http.NewServeMux()
@@ -273,8 +497,10 @@ func NewServer(store *storage.SQLiteStore, client onepoint.Client, cfg config.Co
 	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServer(http.FS(staticSub))))
 
 	// Page routes
+	mux.HandleFunc("GET /healthz", server.handleHealthz)
 	mux.HandleFunc("GET /month", server.handleMonthPicker)
 	mux.HandleFunc("GET /month/{month}", server.handleMonth)
+	mux.HandleFunc("GET /week/{date}", server.handleWeek)
 	mux.HandleFunc("GET /day/{date}", server.handleDay)
 
 	// HTMX partial routes (Phase 2)
@@ -283,31 +509,71 @@ func NewServer(store *storage.SQLiteStore, client onepoint.Client, cfg config.Co
 	mux.HandleFunc("POST /partials/day/{date}/worklog", server.handlePartialWorklogCreate)
 	mux.HandleFunc("POST /partials/day/{date}/worklog/{id}", server.handlePartialWorklogUpdate)
 	mux.HandleFunc("POST /partials/day/{date}/worklog/{id}/delete", server.handlePartialWorklogDelete)
-	mux.HandleFunc("POST /partials/submit/day/{date}", server.handlePartialSubmitDay)
-	mux.HandleFunc("POST /partials/submit/month/{month}", server.handlePartialSubmitMonth)
+	mux.HandleFunc("POST /partials/submit/day/{date}", server.requireSubmitEnabled(server.handlePartialSubmitDay))
+	mux.HandleFunc("POST /partials/submit/month/{month}", server.requireSubmitEnabled(server.handlePartialSubmitMonth))
 
 	// JSON API routes
 	mux.HandleFunc("GET /api/month/{month}", server.handleAPIMonth)
+	mux.HandleFunc("GET /api/month/{month}/remote.csv", server.handleAPIMonthRemoteCSV)
+	mux.HandleFunc("GET /api/month/{month}/progress", server.handleAPIMonthProgress)
+	mux.HandleFunc("GET /api/week/{date}", server.handleAPIWeek)
 	mux.HandleFunc("GET /api/day/{date}", server.handleAPIDay)
+	mux.HandleFunc("DELETE /api/day/{date}", server.handleAPIDeleteDayWorklogs)
+	mux.HandleFunc("DELETE /api/remote/{date}/{timeRecordId}", server.requireSubmitEnabled(server.handleAPIDeleteRemoteWorklog))
 	mux.HandleFunc("GET /api/lookup", server.handleAPILookup)
 	mux.HandleFunc("POST /api/worklog", server.handleAPIWorklogCreate)
+	mux.HandleFunc("POST /api/worklogs", server.handleAPIWorklogsBulkCreate)
+	mux.HandleFunc("GET /api/worklog/{id}", server.handleAPIWorklogGet)
 	mux.HandleFunc("PATCH /api/worklog/{id}", server.handleAPIWorklogPatch)
 	mux.HandleFunc("DELETE /api/worklog/{id}", server.handleAPIWorklogDelete)
+	mux.HandleFunc("GET /api/templates", server.handleAPITemplatesList)
+	mux.HandleFunc("POST /api/templates", server.handleAPITemplateCreate)
+	mux.HandleFunc("DELETE /api/templates/{id}", server.handleAPITemplateDelete)
+	mux.HandleFunc("POST /api/templates/{id}/instantiate", server.handleAPITemplateInstantiate)
 	mux.HandleFunc("POST /api/import", server.handleAPIImport)
+	mux.HandleFunc("GET /api/import/template.csv", server.handleAPIImportTemplate)
+	mux.HandleFunc("POST /api/import/{batch}/remap", server.handleAPIImportRemap)
 	mux.HandleFunc("POST /api/import-preview", server.handleAPIImportPreview)
-	mux.HandleFunc("POST /api/submit/day/{date}", server.handleAPISubmitDay)
-	mux.HandleFunc("POST /api/submit/month/{month}", server.handleAPISubmitMonth)
+	mux.HandleFunc("POST /api/submit/day/{date}", server.requireSubmitEnabled(server.handleAPISubmitDay))
+	mux.HandleFunc("POST /api/submit/month/{month}", server.requireSubmitEnabled(server.handleAPISubmitMonth))
 	mux.HandleFunc("DELETE /api/month/{month}/worklogs", server.handleAPIDeleteMonthWorklogs)
-	mux.HandleFunc("DELETE /api/month/{month}/remote-worklogs", server.handleAPIDeleteMonthRemoteWorklogs)
+	mux.HandleFunc("DELETE /api/month/{month}/remote-worklogs", server.requireSubmitEnabled(server.handleAPIDeleteMonthRemoteWorklogs))
 	mux.HandleFunc("POST /api/month/{month}/copy-from-remote", server.handleAPICopyMonthRemote)
 	mux.HandleFunc("POST /api/month/{month}/sync", server.handleAPISyncMonthRemote)
+
+	if options.EnableMetrics {
+		server.metrics = newServerMetrics()
+		mux.HandleFunc("GET /metrics", server.handleMetrics)
+	}
 	server.mux = mux
 
 	return server
 }
 
+// requireSubmitEnabled rejects requests to next with 403 when submit is
+// disabled, including dry runs. It guards every endpoint that writes to
+// OnePoint.
+func (s *Server) requireSubmitEnabled(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.submitDisabled {
+			http.Error(w, "submit is disabled on this server (serve --no-submit)", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	s.mux.ServeHTTP(w, r)
+	if s.metrics == nil {
+		s.mux.ServeHTTP(w, r)
+		return
+	}
+	recorder := &statusRecorder{ResponseWriter: w}
+	s.mux.ServeHTTP(recorder, r)
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+	s.metrics.observeRequest(recorder.status)
 }
 
 func (s *Server) handleMonthPicker(w http.ResponseWriter, r *http.Request) {
@@ -316,7 +582,7 @@ func (s *Server) handleMonthPicker(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/", http.StatusFound)
 		return
 	}
-	if _, err := parseMonth(month); err != nil {
+	if _, err := parseMonth(month, s.location); err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
 	}
@@ -325,19 +591,19 @@ func (s *Server) handleMonthPicker(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleMonth(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	monthStart, err := parseMonth(monthRaw)
+	monthStart, err := parseMonth(monthRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
 	}
 	monthEnd := endOfMonth(monthStart)
 
-	localEntries, err := s.loadLocalRange(monthStart, monthEnd)
+	localEntries, err := s.loadLocalRangeForSource(monthStart, monthEnd, sourceFilterFromRequest(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	authErrorMsg := ""
+	authErrorMsg := s.sessionWatchMessage()
 	remoteEntries, refreshedAt, err := s.loadRemoteRange(r.Context(), monthStart, monthEnd, false)
 	if err != nil {
 		authErrorMsg = fmt.Sprintf(
@@ -348,6 +614,9 @@ func (s *Server) handleMonth(w http.ResponseWriter, r *http.Request) {
 	}
 
 	rows, summary := buildMonthRows(monthStart, localEntries, remoteEntries)
+	if s.hideEmptyDaysFromRequest(r) {
+		rows = omitEmptyDayRows(rows)
+	}
 
 	view := monthPageView{
 		Title:              "gohour - month " + monthRaw,
@@ -363,26 +632,80 @@ func (s *Server) handleMonth(w http.ResponseWriter, r *http.Request) {
 		TotalWorkedDelta:   summary.TotalLocalWorkedHours - summary.TotalRemoteWorkedHours,
 		TotalBillableDelta: summary.TotalDeltaHours,
 		RemoteRefreshedAt:  formatRefreshTime(refreshedAt),
+		Source:             sourceFilterFromRequest(r),
+		SourceOptions:      sourceFilterOptions(),
+		Contract:           buildMonthContract(monthStart, summary.TotalLocalWorkedHours, s.cfg.Web),
+		SubmitDisabled:     s.submitDisabled,
 	}
 	if err := renderTemplate(w, "month.html", view); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
+func (s *Server) handleWeek(w http.ResponseWriter, r *http.Request) {
+	dayRaw := strings.TrimSpace(r.PathValue("date"))
+	day, err := parseISODate(dayRaw, s.location)
+	if err != nil {
+		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	weekStart := startOfWeek(day)
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	localEntries, err := s.loadLocalRangeForSource(weekStart, weekEnd, sourceFilterFromRequest(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	authErrorMsg := s.sessionWatchMessage()
+	remoteEntries, refreshedAt, err := s.loadRemoteRange(r.Context(), weekStart, weekEnd, false)
+	if err != nil {
+		authErrorMsg = fmt.Sprintf(
+			"OnePoint session may have expired (%v). In a new terminal run: gohour auth login",
+			err,
+		)
+		remoteEntries = nil
+	}
+
+	rows, summary := buildRangeRows(weekStart, weekEnd, localEntries, remoteEntries)
+	weekStartISO := weekStart.Format("2006-01-02")
+	view := weekPageView{
+		Title:              "gohour - week " + weekStartISO,
+		CurrentMonth:       day.Format("2006-01"),
+		Day:                "week " + weekStartISO,
+		WeekStart:          weekStartISO,
+		WeekEnd:            weekEnd.Format("2006-01-02"),
+		PreviousWeek:       weekStart.AddDate(0, 0, -7).Format("2006-01-02"),
+		NextWeek:           weekStart.AddDate(0, 0, 7).Format("2006-01-02"),
+		AuthErrorMsg:       authErrorMsg,
+		Rows:               rows,
+		TotalLocal:         summary.TotalLocalHours,
+		TotalRemote:        summary.TotalRemoteHours,
+		TotalLocalWorked:   summary.TotalLocalWorkedHours,
+		TotalRemoteWorked:  summary.TotalRemoteWorkedHours,
+		TotalWorkedDelta:   summary.TotalLocalWorkedHours - summary.TotalRemoteWorkedHours,
+		TotalBillableDelta: summary.TotalDeltaHours,
+		RemoteRefreshedAt:  formatRefreshTime(refreshedAt),
+	}
+	if err := renderTemplate(w, "week.html", view); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (s *Server) handleDay(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
 	}
 
-	localEntries, err := s.loadLocalRange(day, day)
+	localEntries, err := s.loadLocalRangeForSource(day, day, sourceFilterFromRequest(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	authErrorMsg := ""
+	authErrorMsg := s.sessionWatchMessage()
 	remoteEntries, refreshedAt, err := s.loadRemoteRange(r.Context(), day, day, false)
 	if err != nil {
 		authErrorMsg = fmt.Sprintf(
@@ -396,6 +719,10 @@ func (s *Server) handleDay(w http.ResponseWriter, r *http.Request) {
 	if len(dayRows) > 0 {
 		row = dayRows[0]
 	}
+	mergeRemote := mergeRemoteRequested(r)
+	if mergeRemote {
+		row.Entries = MergeConsecutiveRemoteRows(row.Entries)
+	}
 
 	view := dayPageView{
 		Title:             "gohour - day " + dayRaw,
@@ -404,6 +731,11 @@ func (s *Server) handleDay(w http.ResponseWriter, r *http.Request) {
 		AuthErrorMsg:      authErrorMsg,
 		DayRow:            row,
 		RemoteRefreshedAt: formatRefreshTime(refreshedAt),
+		MergeRemote:       mergeRemote,
+		Source:            sourceFilterFromRequest(r),
+		SourceOptions:     sourceFilterOptions(),
+		TagColors:         s.cfg.Web.TagColors,
+		SubmitDisabled:    s.submitDisabled,
 	}
 	if err := renderTemplate(w, "day.html", view); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -414,24 +746,24 @@ func (s *Server) handleDay(w http.ResponseWriter, r *http.Request) {
 // (HTMX partial, Phase 2.1). The response includes OOB swaps for stats.
 func (s *Server) handlePartialMonth(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	monthStart, err := parseMonth(monthRaw)
+	monthStart, err := parseMonth(monthRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
 	}
 	monthEnd := endOfMonth(monthStart)
-	refresh := strings.TrimSpace(r.URL.Query().Get("refresh")) == "1"
+	refresh := refreshFromRequest(r)
 
-	localEntries, err := s.loadLocalRange(monthStart, monthEnd)
+	localEntries, err := s.loadLocalRangeForSource(monthStart, monthEnd, sourceFilterFromRequest(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	authErrorMsg := ""
+	authErrorMsg := s.sessionWatchMessage()
 	remoteEntries, refreshedAt, err := s.loadRemoteRange(r.Context(), monthStart, monthEnd, refresh)
 	if err != nil {
 		if refresh {
-			writePartialTableError(w, http.StatusBadGateway, 6, fmt.Sprintf("load remote worklogs: %v", err))
+			writePartialTableError(w, remoteErrorStatus(err), 6, fmt.Sprintf("load remote worklogs: %v", err))
 			return
 		}
 		authErrorMsg = fmt.Sprintf(
@@ -442,6 +774,9 @@ func (s *Server) handlePartialMonth(w http.ResponseWriter, r *http.Request) {
 	}
 
 	rows, summary := buildMonthRows(monthStart, localEntries, remoteEntries)
+	if s.hideEmptyDaysFromRequest(r) {
+		rows = omitEmptyDayRows(rows)
+	}
 	view := monthPageView{
 		CurrentMonth:       monthRaw,
 		Rows:               rows,
@@ -453,6 +788,7 @@ func (s *Server) handlePartialMonth(w http.ResponseWriter, r *http.Request) {
 		TotalBillableDelta: summary.TotalDeltaHours,
 		AuthErrorMsg:       authErrorMsg,
 		RemoteRefreshedAt:  formatRefreshTime(refreshedAt),
+		Contract:           buildMonthContract(monthStart, summary.TotalLocalWorkedHours, s.cfg.Web),
 	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := renderPartialTemplate(w, "partials/month_tbody.html", view); err != nil {
@@ -464,21 +800,21 @@ func (s *Server) handlePartialMonth(w http.ResponseWriter, r *http.Request) {
 // (HTMX partial, Phase 2.2).
 func (s *Server) handlePartialDay(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
 	}
-	refresh := strings.TrimSpace(r.URL.Query().Get("refresh")) == "1"
+	refresh := refreshFromRequest(r)
 	if err := s.renderDayPartial(w, r, day, refresh, refresh); err != nil {
-		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), http.StatusBadGateway)
+		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), remoteErrorStatus(err))
 		return
 	}
 }
 
 func (s *Server) handlePartialWorklogCreate(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
@@ -492,7 +828,7 @@ func (s *Server) handlePartialWorklogCreate(w http.ResponseWriter, r *http.Reque
 	if parseBoolFormValue(r.FormValue("force_overlap")) {
 		r.Header.Set("X-Force-Overlap", "1")
 	}
-	entry, err := buildEntryFromMutation(body)
+	entry, err := buildEntryFromMutation(body, s.cfg)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -509,6 +845,9 @@ func (s *Server) handlePartialWorklogCreate(w http.ResponseWriter, r *http.Reque
 		http.Error(w, fmt.Sprintf("load local worklogs: %v", err), http.StatusInternalServerError)
 		return
 	}
+	if s.writeDayEntryCapExceededIfAny(w, day, existingEntries) {
+		return
+	}
 	if s.writeMutationConflictIfAny(w, r, entry, existingEntries, 0) {
 		return
 	}
@@ -529,13 +868,13 @@ func (s *Server) handlePartialWorklogCreate(w http.ResponseWriter, r *http.Reque
 		fmt.Sprintf(`{"day-worklog-changed":{"day":"%s","action":"created","id":%d}}`, dayRaw, id),
 	)
 	if err := s.renderDayPartial(w, r, day, false, false); err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		http.Error(w, err.Error(), remoteErrorStatus(err))
 	}
 }
 
 func (s *Server) handlePartialWorklogUpdate(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
@@ -564,7 +903,7 @@ func (s *Server) handlePartialWorklogUpdate(w http.ResponseWriter, r *http.Reque
 	if parseBoolFormValue(r.FormValue("force_overlap")) {
 		r.Header.Set("X-Force-Overlap", "1")
 	}
-	entry, err := buildEntryFromMutation(body)
+	entry, err := buildEntryFromMutation(body, s.cfg)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -601,13 +940,13 @@ func (s *Server) handlePartialWorklogUpdate(w http.ResponseWriter, r *http.Reque
 		fmt.Sprintf(`{"day-worklog-changed":{"day":"%s","action":"updated","id":%d}}`, dayRaw, id),
 	)
 	if err := s.renderDayPartial(w, r, day, false, false); err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		http.Error(w, err.Error(), remoteErrorStatus(err))
 	}
 }
 
 func (s *Server) handlePartialWorklogDelete(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
@@ -634,13 +973,13 @@ func (s *Server) handlePartialWorklogDelete(w http.ResponseWriter, r *http.Reque
 		fmt.Sprintf(`{"day-worklog-changed":{"day":"%s","action":"deleted","id":%d}}`, dayRaw, id),
 	)
 	if err := s.renderDayPartial(w, r, day, false, false); err != nil {
-		http.Error(w, err.Error(), http.StatusBadGateway)
+		http.Error(w, err.Error(), remoteErrorStatus(err))
 	}
 }
 
 func (s *Server) handlePartialSubmitDay(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
@@ -650,7 +989,7 @@ func (s *Server) handlePartialSubmitDay(w http.ResponseWriter, r *http.Request)
 
 func (s *Server) handlePartialSubmitMonth(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	monthStart, err := parseMonth(monthRaw)
+	monthStart, err := parseMonth(monthRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
@@ -665,7 +1004,7 @@ func (s *Server) handlePartialSubmit(w http.ResponseWriter, r *http.Request, sco
 	}
 	dryRun := parseBoolFormValue(r.FormValue("dry_run"))
 	if !dryRun {
-		dryRun = strings.TrimSpace(r.URL.Query().Get("dry_run")) == "1"
+		dryRun = submitDryRunFromQuery(r)
 	}
 
 	s.logAudit(auditRecord{
@@ -686,7 +1025,7 @@ func (s *Server) handlePartialSubmit(w http.ResponseWriter, r *http.Request, sco
 			Days:       []submitDayResult{},
 		},
 	}
-	result, err := s.submitRange(r.Context(), from, to, dryRun)
+	result, err := s.submitRange(r.Context(), from, to, dryRun, submitOverlapSkip)
 	if err != nil {
 		s.logAudit(auditRecord{
 			Operation: "submit",
@@ -727,18 +1066,112 @@ func (s *Server) handlePartialSubmit(w http.ResponseWriter, r *http.Request, sco
 }
 
 func (s *Server) renderDayPartial(w http.ResponseWriter, r *http.Request, day time.Time, refresh bool, failOnRemoteErr bool) error {
-	view, err := s.buildDayPartialView(r.Context(), day, refresh, failOnRemoteErr)
+	view, err := s.buildDayPartialView(r.Context(), day, sourceFilterFromRequest(r), refresh, failOnRemoteErr)
 	if err != nil {
 		if failOnRemoteErr {
-			writePartialTableError(w, http.StatusBadGateway, 11, fmt.Sprintf("load remote worklogs: %v", err))
+			writePartialTableError(w, remoteErrorStatus(err), 11, fmt.Sprintf("load remote worklogs: %v", err))
 			return nil
 		}
 		return err
 	}
+	if mergeRemoteRequested(r) {
+		view.DayRow.Entries = MergeConsecutiveRemoteRows(view.DayRow.Entries)
+		view.MergeRemote = true
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	return renderPartialTemplate(w, "partials/day_tbody.html", view)
 }
 
+// mergeRemoteRequested reports whether the day view should merge consecutive
+// remote rows (?merge=1). Raw rows remain the default.
+func mergeRemoteRequested(r *http.Request) bool {
+	return viewQueryValue(r, "merge") == "1"
+}
+
+// viewQueryValue returns a view option from the request query. HTMX partial
+// requests fall back to the page URL (HX-Current-URL) so view filters survive
+// partial refreshes.
+func viewQueryValue(r *http.Request, key string) string {
+	if value := strings.TrimSpace(r.URL.Query().Get(key)); value != "" {
+		return value
+	}
+	if current := strings.TrimSpace(r.Header.Get("HX-Current-URL")); current != "" {
+		if parsed, err := url.Parse(current); err == nil {
+			return strings.TrimSpace(parsed.Query().Get(key))
+		}
+	}
+	return ""
+}
+
+// sourceFilterOptions lists the values offered for the ?source= view filter.
+func sourceFilterOptions() []string {
+	options := []string{"all"}
+	options = append(options, importer.SupportedMapperNames()...)
+	return append(options, "manual")
+}
+
+// refreshHeader forces a refetch like ?refresh=1, so tooling such as curl can
+// bypass the caches without changing the URL.
+const refreshHeader = "X-Gohour-Refresh"
+
+// refreshFromRequest reports whether cached remote data and lookups must be
+// refetched: ?refresh=1 or an X-Gohour-Refresh: 1 header.
+func refreshFromRequest(r *http.Request) bool {
+	return strings.TrimSpace(r.URL.Query().Get("refresh")) == "1" ||
+		strings.TrimSpace(r.Header.Get(refreshHeader)) == "1"
+}
+
+// hideEmptyDaysFromRequest reports whether month rows without local and
+// remote hours are omitted. ?hide-empty=1|0 overrides web.hide_empty_days.
+func (s *Server) hideEmptyDaysFromRequest(r *http.Request) bool {
+	switch strings.ToLower(viewQueryValue(r, "hide-empty")) {
+	case "1", "true":
+		return true
+	case "0", "false":
+		return false
+	}
+	return s.cfg.Web.HideEmptyDays
+}
+
+// omitEmptyDayRows drops rows with neither local nor remote hours. Totals
+// are computed before filtering and stay unchanged.
+func omitEmptyDayRows(rows []monthRowView) []monthRowView {
+	out := make([]monthRowView, 0, len(rows))
+	for _, row := range rows {
+		if row.LocalHours == 0 && row.RemoteHours == 0 && row.LocalWorked == 0 && row.RemoteWorked == 0 {
+			continue
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// sourceFilterFromRequest returns the lower-cased ?source= value, "all" by default.
+func sourceFilterFromRequest(r *http.Request) string {
+	source := strings.ToLower(viewQueryValue(r, "source"))
+	if source == "" {
+		return "all"
+	}
+	return source
+}
+
+// loadLocalRangeForSource loads local entries and keeps only those whose
+// SourceMapper matches source (case-insensitive). "all" keeps every entry.
+func (s *Server) loadLocalRangeForSource(from, to time.Time, source string) ([]worklog.Entry, error) {
+	entries, err := s.loadLocalRange(from, to)
+	if err != nil || source == "" || source == "all" {
+		return entries, err
+	}
+
+	filtered := make([]worklog.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if strings.EqualFold(strings.TrimSpace(entry.SourceMapper), source) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
 func writePartialTableError(w http.ResponseWriter, statusCode int, colspan int, message string) {
 	if colspan < 1 {
 		colspan = 1
@@ -749,8 +1182,8 @@ func writePartialTableError(w http.ResponseWriter, statusCode int, colspan int,
 	_, _ = fmt.Fprintf(w, `<tr><td colspan="%d"><div class="dialog-error">%s</div></td></tr>`, colspan, escaped)
 }
 
-func (s *Server) buildDayPartialView(ctx context.Context, day time.Time, refresh bool, failOnRemoteErr bool) (dayPageView, error) {
-	localEntries, err := s.loadLocalRange(day, day)
+func (s *Server) buildDayPartialView(ctx context.Context, day time.Time, source string, refresh bool, failOnRemoteErr bool) (dayPageView, error) {
+	localEntries, err := s.loadLocalRangeForSource(day, day, source)
 	if err != nil {
 		return dayPageView{}, err
 	}
@@ -771,31 +1204,90 @@ func (s *Server) buildDayPartialView(ctx context.Context, day time.Time, refresh
 		Day:               day.Format("2006-01-02"),
 		DayRow:            row,
 		RemoteRefreshedAt: formatRefreshTime(refreshedAt),
+		TagColors:         s.cfg.Web.TagColors,
+		SubmitDisabled:    s.submitDisabled,
 	}, nil
 }
 
 func (s *Server) handleAPIMonth(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	monthStart, err := parseMonth(monthRaw)
+	monthStart, err := parseMonth(monthRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
 	}
 	monthEnd := endOfMonth(monthStart)
-	refresh := strings.TrimSpace(r.URL.Query().Get("refresh")) == "1"
+	refresh := refreshFromRequest(r)
+	var csvFormat bool
+	switch strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))) {
+	case "", "json":
+	case "csv":
+		csvFormat = true
+	default:
+		http.Error(w, "invalid format (expected json or csv)", http.StatusBadRequest)
+		return
+	}
 
-	localEntries, err := s.loadLocalRange(monthStart, monthEnd)
+	localEntries, err := s.loadLocalRangeForSource(monthStart, monthEnd, sourceFilterFromRequest(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	authErrorMsg := ""
+	authErrorMsg := s.sessionWatchMessage()
 	remoteEntries, refreshedAt, err := s.loadRemoteRange(r.Context(), monthStart, monthEnd, refresh)
 	if err != nil {
-		// Local-only month refreshes should still succeed when remote auth is
-		// unavailable, mirroring page rendering behavior.
+		// Scripts reading the summary cannot tell local-only totals from a
+		// month without remote hours, so a failed remote fetch is an error.
+		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), remoteErrorStatus(err))
+		return
+	}
+
+	rows, summary := buildMonthRows(monthStart, localEntries, remoteEntries)
+	if csvFormat {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "month-"+monthRaw+".csv"))
+		_ = writeMonthComparisonCSV(w, rows, summary)
+		return
+	}
+	if s.hideEmptyDaysFromRequest(r) {
+		rows = omitEmptyDayRows(rows)
+	}
+	writeJSON(w, http.StatusOK, monthAPIResponse{
+		Month:              monthRaw,
+		Rows:               rows,
+		TotalLocal:         summary.TotalLocalHours,
+		TotalRemote:        summary.TotalRemoteHours,
+		TotalLocalWorked:   summary.TotalLocalWorkedHours,
+		TotalRemoteWorked:  summary.TotalRemoteWorkedHours,
+		TotalWorkedDelta:   summary.TotalLocalWorkedHours - summary.TotalRemoteWorkedHours,
+		TotalBillableDelta: summary.TotalDeltaHours,
+		AuthErrorMsg:       authErrorMsg,
+		RemoteRefreshedAt:  formatRefreshTime(refreshedAt),
+		Contract:           buildMonthContract(monthStart, summary.TotalLocalWorkedHours, s.cfg.Web),
+	})
+}
+
+func (s *Server) handleAPIWeek(w http.ResponseWriter, r *http.Request) {
+	dayRaw := strings.TrimSpace(r.PathValue("date"))
+	day, err := parseISODate(dayRaw, s.location)
+	if err != nil {
+		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+	weekStart := startOfWeek(day)
+	weekEnd := weekStart.AddDate(0, 0, 6)
+	refresh := refreshFromRequest(r)
+
+	localEntries, err := s.loadLocalRangeForSource(weekStart, weekEnd, sourceFilterFromRequest(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	authErrorMsg := s.sessionWatchMessage()
+	remoteEntries, refreshedAt, err := s.loadRemoteRange(r.Context(), weekStart, weekEnd, refresh)
+	if err != nil {
 		if refresh {
-			http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), http.StatusBadGateway)
+			http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), remoteErrorStatus(err))
 			return
 		}
 		authErrorMsg = fmt.Sprintf(
@@ -805,9 +1297,10 @@ func (s *Server) handleAPIMonth(w http.ResponseWriter, r *http.Request) {
 		remoteEntries = nil
 	}
 
-	rows, summary := buildMonthRows(monthStart, localEntries, remoteEntries)
-	writeJSON(w, http.StatusOK, monthAPIResponse{
-		Month:              monthRaw,
+	rows, summary := buildRangeRows(weekStart, weekEnd, localEntries, remoteEntries)
+	writeJSON(w, http.StatusOK, weekAPIResponse{
+		WeekStart:          weekStart.Format("2006-01-02"),
+		WeekEnd:            weekEnd.Format("2006-01-02"),
 		Rows:               rows,
 		TotalLocal:         summary.TotalLocalHours,
 		TotalRemote:        summary.TotalRemoteHours,
@@ -822,21 +1315,21 @@ func (s *Server) handleAPIMonth(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAPIDay(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
 	}
 
-	localEntries, err := s.loadLocalRange(day, day)
+	localEntries, err := s.loadLocalRangeForSource(day, day, sourceFilterFromRequest(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	refresh := strings.TrimSpace(r.URL.Query().Get("refresh")) == "1"
+	refresh := refreshFromRequest(r)
 	remoteEntries, refreshedAt, err := s.loadRemoteRange(r.Context(), day, day, refresh)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), http.StatusBadGateway)
+		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), remoteErrorStatus(err))
 		return
 	}
 	dayRows := BuildDailyView(localEntries, remoteEntries)
@@ -844,6 +1337,9 @@ func (s *Server) handleAPIDay(w http.ResponseWriter, r *http.Request) {
 	if len(dayRows) > 0 {
 		row = dayRows[0]
 	}
+	if mergeRemoteRequested(r) {
+		row.Entries = MergeConsecutiveRemoteRows(row.Entries)
+	}
 
 	writeJSON(w, http.StatusOK, dayAPIResponse{
 		Date:              row.Date.Format("2006-01-02"),
@@ -857,11 +1353,11 @@ func (s *Server) handleAPIDay(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleAPILookup(w http.ResponseWriter, r *http.Request) {
-	refresh := strings.TrimSpace(r.URL.Query().Get("refresh")) == "1"
+	refresh := refreshFromRequest(r)
 
 	snapshot, err := s.loadLookupSnapshot(r.Context(), refresh)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("load lookup snapshot: %v", err), http.StatusBadGateway)
+		http.Error(w, fmt.Sprintf("load lookup snapshot: %v", err), remoteErrorStatus(err))
 		return
 	}
 
@@ -874,6 +1370,7 @@ func (s *Server) handleAPILookup(w http.ResponseWriter, r *http.Request) {
 		resp.Projects = append(resp.Projects, lookupProject{
 			ID:       p.ID,
 			Name:     p.Name,
+			Code:     onepoint.ExtractProjectCode(s.submitOptions.ProjectCodePattern, p.Name),
 			Archived: p.IsArchived(),
 		})
 	}
@@ -898,16 +1395,145 @@ func (s *Server) handleAPILookup(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAPIWorklogCreate(w http.ResponseWriter, r *http.Request) {
 	var body worklogMutationRequest
-	if err := decodeJSON(r, &body); err != nil {
+	if err := s.decodeJSON(r, &body); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	entry, err := buildEntryFromMutation(body)
+	entry, err := buildEntryFromMutation(body, s.cfg)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	s.insertManualWorklog(w, r, entry)
+}
+
+// handleAPIWorklogsBulkCreate stores several manual entries in one
+// transaction. Any invalid or conflicting item rejects the whole batch.
+func (s *Server) handleAPIWorklogsBulkCreate(w http.ResponseWriter, r *http.Request) {
+	var body []worklogMutationRequest
+	if err := s.decodeJSON(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) == 0 {
+		http.Error(w, "no worklogs given", http.StatusBadRequest)
+		return
+	}
+
+	entries := make([]worklog.Entry, 0, len(body))
+	for i, item := range body {
+		entry, err := buildEntryFromMutation(item, s.cfg)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("worklog %d: %v", i, err), http.StatusBadRequest)
+			return
+		}
+		entry.SourceFormat = "manual"
+		entry.SourceMapper = "manual"
+		entry.SourceFile = "web-ui"
+		entries = append(entries, entry)
+	}
+
+	s.createMu.Lock()
+	defer s.createMu.Unlock()
+
+	duplicates, ok := s.checkBulkWorklogs(w, r, entries)
+	if !ok {
+		return
+	}
+	toInsert := make([]worklog.Entry, 0, len(entries))
+	for i, entry := range entries {
+		if !duplicates[i] {
+			toInsert = append(toInsert, entry)
+		}
+	}
+
+	ids, err := s.store.InsertWorklogsReturningIDs(toInsert)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("insert worklogs: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := bulkWorklogResponse{
+		IDs:     []int64{},
+		Results: make([]bulkWorklogResult, 0, len(entries)),
+	}
+	next := 0
+	for i := range entries {
+		var id int64
+		if !duplicates[i] {
+			id = ids[next]
+			next++
+		}
+		if id == 0 {
+			response.Results = append(response.Results, bulkWorklogResult{Index: i, Status: "duplicate"})
+			continue
+		}
+		response.Created++
+		response.IDs = append(response.IDs, id)
+		response.Results = append(response.Results, bulkWorklogResult{Index: i, Status: "inserted", ID: id})
+	}
+
+	status := http.StatusOK
+	if response.Created > 0 {
+		s.invalidateLocalCache()
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, response)
+}
+
+// checkBulkWorklogs applies the single-create checks to a bulk batch day by
+// day: each item is compared with the day's stored entries and the batch items
+// before it, and each day's stored plus new entries must stay within
+// web.max_entries_per_day. It reports the items that duplicate an existing or
+// earlier entry; on a conflict it writes the 409 and returns false.
+func (s *Server) checkBulkWorklogs(w http.ResponseWriter, r *http.Request, entries []worklog.Entry) (map[int]bool, bool) {
+	byDay := map[string][]worklog.Entry{}
+	duplicates := map[int]bool{}
+	for i, entry := range entries {
+		day := timeutil.StartOfDay(entry.StartDateTime)
+		key := day.Format("2006-01-02")
+		known, loaded := byDay[key]
+		if !loaded {
+			existing, err := s.loadLocalRange(day, day)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("load local worklogs: %v", err), http.StatusInternalServerError)
+				return nil, false
+			}
+			known = append([]worklog.Entry(nil), existing...)
+		}
+
+		conflictType, conflictID, hasConflict := detectLocalConflict(entry, known)
+		if hasConflict && conflictType == "duplicate" {
+			duplicates[i] = true
+			byDay[key] = known
+			continue
+		}
+		if hasConflict && r.Header.Get("X-Force-Overlap") != "1" {
+			writeJSON(w, http.StatusConflict, worklogConflictResponse{
+				Error:      fmt.Sprintf("worklog %d overlaps an existing local entry or an earlier item", i),
+				Type:       "overlap",
+				ExistingID: conflictID,
+			})
+			return nil, false
+		}
+
+		if limit := s.cfg.Web.MaxEntriesPerDay; limit > 0 && len(known)+1 > limit {
+			http.Error(
+				w,
+				fmt.Sprintf("day %s would have %d entries; limit is %d (web.max_entries_per_day)", key, len(known)+1, limit),
+				http.StatusConflict,
+			)
+			return nil, false
+		}
+		byDay[key] = append(known, entry)
+	}
+	return duplicates, true
+}
+
+// insertManualWorklog stores a web-created entry after the local conflict
+// check and responds with 201 and the new ID.
+func (s *Server) insertManualWorklog(w http.ResponseWriter, r *http.Request, entry worklog.Entry) {
 	entry.SourceFormat = "manual"
 	entry.SourceMapper = "manual"
 	entry.SourceFile = "web-ui"
@@ -921,6 +1547,9 @@ func (s *Server) handleAPIWorklogCreate(w http.ResponseWriter, r *http.Request)
 		http.Error(w, fmt.Sprintf("load local worklogs: %v", err), http.StatusInternalServerError)
 		return
 	}
+	if s.writeDayEntryCapExceededIfAny(w, day, existingEntries) {
+		return
+	}
 	if s.writeMutationConflictIfAny(w, r, entry, existingEntries, 0) {
 		return
 	}
@@ -939,6 +1568,26 @@ func (s *Server) handleAPIWorklogCreate(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusCreated, map[string]int64{"id": id})
 }
 
+func (s *Server) handleAPIWorklogGet(w http.ResponseWriter, r *http.Request) {
+	id, err := parsePositiveInt64(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid worklog id", http.StatusBadRequest)
+		return
+	}
+
+	entry, found, err := s.store.GetWorklogByID(id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("get worklog by id: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "worklog not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mutationFromEntry(entry))
+}
+
 func (s *Server) handleAPIWorklogPatch(w http.ResponseWriter, r *http.Request) {
 	id, err := parsePositiveInt64(r.PathValue("id"))
 	if err != nil {
@@ -957,12 +1606,12 @@ func (s *Server) handleAPIWorklogPatch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var body worklogMutationRequest
-	if err := decodeJSON(r, &body); err != nil {
+	if err := s.decodeJSON(r, &body); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	entry, err := buildEntryFromMutation(body)
+	entry, err := buildEntryFromMutation(body, s.cfg)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -1040,51 +1689,118 @@ func (s *Server) handleAPIImport(w http.ResponseWriter, r *http.Request) {
 		result.Entries = filtered
 	}
 
+	if parseBoolFormValue(r.URL.Query().Get("preview")) {
+		s.writeImportDiff(w, result)
+		return
+	}
+
+	persisted, ok := s.persistImportEntries(w, r, result.Entries, importBatchTarget{
+		files:   []string{formResult.fileName},
+		mappers: []string{formResult.mapper},
+	})
+	if !ok {
+		return
+	}
+
+	if s.cfg.Import.StoreSources && persisted.batchID != "" {
+		if err := s.storeImportSource(persisted.batchID, formResult); err != nil {
+			http.Error(w, fmt.Sprintf("store import source: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, importResponse{
+		FilesProcessed:     result.FilesProcessed,
+		RowsRead:           result.RowsRead,
+		RowsMapped:         result.RowsMapped,
+		RowsSkipped:        result.RowsSkipped + persisted.duplicates + persisted.overlapsSkipped,
+		RowsPersisted:      persisted.inserted,
+		ReconcileWarning:   persisted.reconcileWarning,
+		OverlapsSkipped:    persisted.overlapsSkipped,
+		BatchID:            persisted.batchID,
+		DayTotalMismatches: result.DayTotalMismatches,
+		FileDuplicates:     result.FileDuplicates,
+	})
+}
+
+// importBatchTarget selects where persistImportEntries stores entries: a new
+// import batch of files and mappers, or the existing batch replaceID.
+type importBatchTarget struct {
+	files     []string
+	mappers   []string
+	replaceID string
+}
+
+// importPersistResult reports how persistImportEntries stored entries.
+type importPersistResult struct {
+	batchID          string
+	removed          int
+	inserted         int
+	duplicates       int
+	overlapsSkipped  int
+	reconcileWarning string
+}
+
+// persistImportEntries checks entries against the local worklogs of their
+// days, applies the skipOverlapping/forceOverlapping choice of r, stores the
+// accepted entries as an import batch and reconciles the imported range when
+// import.auto_reconcile_after_import is on. When target replaces a batch, the
+// batch's current rows are left out of the checks and replaced in one
+// transaction. On failure it writes the error response and returns false.
+func (s *Server) persistImportEntries(w http.ResponseWriter, r *http.Request, entries []worklog.Entry, target importBatchTarget) (importPersistResult, bool) {
 	skipOverlapping := parseBoolFormValue(r.FormValue("skipOverlapping"))
 	forceOverlapping := parseBoolFormValue(r.FormValue("forceOverlapping"))
 	if skipOverlapping && forceOverlapping {
 		http.Error(w, "skipOverlapping and forceOverlapping cannot both be true", http.StatusBadRequest)
-		return
+		return importPersistResult{}, false
 	}
 
 	s.createMu.Lock()
 	defer s.createMu.Unlock()
 
-	toInsert := result.Entries
-	overlapsSkipped := 0
-	duplicateCount := 0
+	var replaced []worklog.Entry
+	if target.replaceID != "" {
+		var err error
+		replaced, err = s.store.ListImportBatchWorklogs(target.replaceID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("load import batch worklogs: %v", err), http.StatusInternalServerError)
+			return importPersistResult{}, false
+		}
+	}
+
+	persisted := importPersistResult{}
+	toInsert := entries
 	var (
 		importRangeStart time.Time
 		importRangeEnd   time.Time
 		hasImportRange   bool
 	)
-	if len(result.Entries) > 0 {
-		minDay := timeutil.StartOfDay(result.Entries[0].StartDateTime)
-		maxDay := minDay
-		for _, entry := range result.Entries[1:] {
-			day := timeutil.StartOfDay(entry.StartDateTime)
-			if day.Before(minDay) {
-				minDay = day
-			}
-			if day.After(maxDay) {
-				maxDay = day
-			}
-		}
-		importRangeStart = minDay
-		importRangeEnd = maxDay
+	if rangeEntries := append(append([]worklog.Entry(nil), entries...), replaced...); len(rangeEntries) > 0 {
+		importRangeStart, importRangeEnd = entriesDayRange(rangeEntries)
 		hasImportRange = true
-
+	}
+	if len(entries) > 0 {
+		minDay, maxDay := entriesDayRange(entries)
 		existingEntries, err := s.loadLocalRange(minDay, maxDay)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("load local worklogs: %v", err), http.StatusInternalServerError)
-			return
+			return importPersistResult{}, false
+		}
+		replacedIDs := make(map[int64]bool, len(replaced))
+		for _, entry := range replaced {
+			replacedIDs[entry.ID] = true
 		}
-		accepted := append([]worklog.Entry(nil), existingEntries...)
-		clean := make([]worklog.Entry, 0, len(result.Entries))
+		accepted := make([]worklog.Entry, 0, len(existingEntries))
+		for _, entry := range existingEntries {
+			if !replacedIDs[entry.ID] {
+				accepted = append(accepted, entry)
+			}
+		}
+		clean := make([]worklog.Entry, 0, len(entries))
 		overlapEntries := make([]worklog.Entry, 0)
 		overlapItems := make([]importOverlapItem, 0)
 
-		for _, entry := range result.Entries {
+		for _, entry := range entries {
 			conflictType, existingID, hasConflict := detectLocalConflict(entry, accepted)
 			if !hasConflict {
 				clean = append(clean, entry)
@@ -1093,7 +1809,7 @@ func (s *Server) handleAPIImport(w http.ResponseWriter, r *http.Request) {
 			}
 
 			if conflictType == "duplicate" {
-				duplicateCount++
+				persisted.duplicates++
 				continue
 			}
 			if conflictType == "overlap" {
@@ -1119,42 +1835,54 @@ func (s *Server) handleAPIImport(w http.ResponseWriter, r *http.Request) {
 				Error:      "overlapping entries detected",
 				Overlaps:   overlapItems,
 				CleanCount: len(clean),
-				Duplicates: duplicateCount,
+				Duplicates: persisted.duplicates,
 			})
-			return
+			return importPersistResult{}, false
 		}
 
 		toInsert = clean
 		if forceOverlapping {
 			toInsert = append(toInsert, overlapEntries...)
 		} else {
-			overlapsSkipped = len(overlapEntries)
+			persisted.overlapsSkipped = len(overlapEntries)
 		}
 	}
 
-	inserted, err := s.store.InsertWorklogs(toInsert)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("insert imported worklogs: %v", err), http.StatusInternalServerError)
-		return
+	if target.replaceID != "" {
+		removed, inserted, err := s.store.ReplaceImportBatchWorklogs(target.replaceID, toInsert)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("replace import batch worklogs: %v", err), http.StatusInternalServerError)
+			return importPersistResult{}, false
+		}
+		persisted.batchID = target.replaceID
+		persisted.removed = removed
+		persisted.inserted = inserted
+	} else {
+		record, err := s.store.InsertImportWorklogs(target.files, target.mappers, toInsert)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("insert imported worklogs: %v", err), http.StatusInternalServerError)
+			return importPersistResult{}, false
+		}
+		persisted.batchID = record.ID
+		persisted.inserted = record.RowsInserted
 	}
 
-	reconcileWarning := ""
 	if s.cfg.Import.AutoReconcileAfterImport && hasImportRange {
 		if _, err := s.autoReconcileImportedRange(r.Context(), importRangeStart, importRangeEnd); err != nil {
-			reconcileWarning = fmt.Sprintf("reconcile imported worklogs: %v", err)
+			persisted.reconcileWarning = fmt.Sprintf("reconcile imported worklogs: %v", err)
 		}
 	}
 
 	s.invalidateLocalCache()
-	writeJSON(w, http.StatusOK, importResponse{
-		FilesProcessed:   result.FilesProcessed,
-		RowsRead:         result.RowsRead,
-		RowsMapped:       result.RowsMapped,
-		RowsSkipped:      result.RowsSkipped + duplicateCount + overlapsSkipped,
-		RowsPersisted:    inserted,
-		ReconcileWarning: reconcileWarning,
-		OverlapsSkipped:  overlapsSkipped,
-	})
+	return persisted, true
+}
+
+// handleAPIImportTemplate downloads a CSV template for the generic mapper
+// with one example row.
+func (s *Server) handleAPIImportTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="gohour-import-template.csv"`)
+	_ = importer.WriteGenericCSVTemplate(w, s.cfg)
 }
 
 func (s *Server) handleAPIImportPreview(w http.ResponseWriter, r *http.Request) {
@@ -1177,17 +1905,7 @@ func (s *Server) handleAPIImportPreview(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	minDay := timeutil.StartOfDay(result.Entries[0].StartDateTime)
-	maxDay := minDay
-	for _, entry := range result.Entries[1:] {
-		day := timeutil.StartOfDay(entry.StartDateTime)
-		if day.Before(minDay) {
-			minDay = day
-		}
-		if day.After(maxDay) {
-			maxDay = day
-		}
-	}
+	minDay, maxDay := entriesDayRange(result.Entries)
 
 	existingEntries, err := s.loadLocalRange(minDay, maxDay)
 	if err != nil {
@@ -1206,7 +1924,7 @@ func (s *Server) handleAPIImportPreview(w http.ResponseWriter, r *http.Request)
 			Activity:     entry.Activity,
 			Skill:        entry.Skill,
 			BillableMins: entry.Billable,
-			DurationMins: max(0, int(entry.EndDateTime.Sub(entry.StartDateTime).Minutes())),
+			DurationMins: entry.DurationMinutes(),
 			Description:  entry.Description,
 			Status:       "clean",
 		}
@@ -1232,7 +1950,7 @@ func (s *Server) handleAPIImportPreview(w http.ResponseWriter, r *http.Request)
 
 func (s *Server) handleAPIDeleteMonthWorklogs(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	if _, err := parseMonth(monthRaw); err != nil {
+	if _, err := parseMonth(monthRaw, s.location); err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
 	}
@@ -1247,9 +1965,26 @@ func (s *Server) handleAPIDeleteMonthWorklogs(w http.ResponseWriter, r *http.Req
 	writeJSON(w, http.StatusOK, map[string]int{"deleted": deleted})
 }
 
+func (s *Server) handleAPIDeleteDayWorklogs(w http.ResponseWriter, r *http.Request) {
+	day, err := parseISODate(r.PathValue("date"), s.location)
+	if err != nil {
+		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := s.store.DeleteWorklogsBetween(day, day.AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("delete day worklogs: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.invalidateLocalDays([]time.Time{day})
+	writeJSON(w, http.StatusOK, map[string]int64{"deleted": deleted})
+}
+
 func (s *Server) handleAPIDeleteMonthRemoteWorklogs(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	monthStart, err := parseMonth(monthRaw)
+	monthStart, err := parseMonth(monthRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
@@ -1266,10 +2001,19 @@ func (s *Server) handleAPIDeleteMonthRemoteWorklogs(w http.ResponseWriter, r *ht
 	// days that no longer expose timerecord entries.
 	days := rangeDays(monthStart, monthEnd)
 
+	deleteLocal := s.deleteLocalOnRemoteDeleteFromRequest(r)
+	localEntries, err := s.loadLocalRange(monthStart, monthEnd)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("load local worklogs: %v", err), http.StatusInternalServerError)
+		return
+	}
+
 	client := upstreamErrorClient{base: s.client}
 	deleted := 0
 	lockedDays := make([]string, 0)
 	clearedDays := make([]time.Time, 0)
+	localMatches := make([]worklog.Entry, 0)
+	localMatchDays := make([]string, 0)
 	for _, day := range days {
 		dayKey := day.Format("2006-01-02")
 		existing, err := client.GetDayWorklogs(r.Context(), day)
@@ -1284,7 +2028,7 @@ func (s *Server) handleAPIDeleteMonthRemoteWorklogs(w http.ResponseWriter, r *ht
 				Outcome:       "error",
 				Error:         fmt.Sprintf("load day %s: %v", dayKey, err),
 			})
-			http.Error(w, fmt.Sprintf("load existing day %s failed: %v", dayKey, err), http.StatusBadGateway)
+			http.Error(w, fmt.Sprintf("load existing day %s failed: %v", dayKey, err), remoteErrorStatus(err))
 			return
 		}
 		if submitter.CountLockedDayWorklogs(existing) > 0 {
@@ -1302,14 +2046,32 @@ func (s *Server) handleAPIDeleteMonthRemoteWorklogs(w http.ResponseWriter, r *ht
 				Outcome:       "error",
 				Error:         fmt.Sprintf("clear day %s: %v", dayKey, err),
 			})
-			http.Error(w, fmt.Sprintf("clear remote day %s failed: %v", dayKey, err), http.StatusBadGateway)
+			http.Error(w, fmt.Sprintf("clear remote day %s failed: %v", dayKey, err), remoteErrorStatus(err))
 			return
 		}
 		deleted += len(existing)
 		clearedDays = append(clearedDays, day)
+		if matches := localEntriesMatchingRemote(localEntries, day, existing); len(matches) > 0 {
+			localMatches = append(localMatches, matches...)
+			localMatchDays = append(localMatchDays, dayKey)
+		}
 	}
 
 	s.invalidateRemoteDays(clearedDays)
+	deletedLocal := 0
+	if deleteLocal && len(localMatches) > 0 {
+		for _, entry := range localMatches {
+			ok, err := s.store.DeleteWorklog(entry.ID)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("delete matching local worklog %d: %v", entry.ID, err), http.StatusInternalServerError)
+				return
+			}
+			if ok {
+				deletedLocal++
+			}
+		}
+		s.invalidateLocalCache()
+	}
 	s.logAudit(auditRecord{
 		Operation:     "delete_remote_month",
 		Scope:         "month",
@@ -1319,16 +2081,113 @@ func (s *Server) handleAPIDeleteMonthRemoteWorklogs(w http.ResponseWriter, r *ht
 		LockedDays:    append([]string(nil), lockedDays...),
 		Outcome:       "success",
 	})
-	writeJSON(w, http.StatusOK, map[string]any{
-		"deleted":       deleted,
-		"skippedLocked": len(lockedDays),
-		"lockedDays":    lockedDays,
+	response := map[string]any{
+		"deleted":        deleted,
+		"skippedLocked":  len(lockedDays),
+		"lockedDays":     lockedDays,
+		"localMatches":   len(localMatches),
+		"localMatchDays": localMatchDays,
+		"deletedLocal":   deletedLocal,
+	}
+	if len(localMatches) > 0 && !deleteLocal {
+		response["warning"] = fmt.Sprintf("%d deleted remote entries still exist locally and will be submitted again by the next submit", len(localMatches))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// deleteLocalOnRemoteDeleteFromRequest reports whether deleting remote
+// entries also deletes their matching local entries.
+// ?delete_local=1|0 overrides web.delete_local_on_remote_delete.
+func (s *Server) deleteLocalOnRemoteDeleteFromRequest(r *http.Request) bool {
+	switch strings.ToLower(strings.TrimSpace(r.URL.Query().Get("delete_local"))) {
+	case "1", "true":
+		return true
+	case "0", "false":
+		return false
+	}
+	return s.cfg.Web.DeleteLocalOnRemoteDelete
+}
+
+// localEntriesMatchingRemote returns the local entries of day whose time
+// range equals one of the remote worklogs, the same rule the day page uses
+// for its "synced" badge. Such entries would be submitted again right after
+// the remote entries are deleted.
+func localEntriesMatchingRemote(localEntries []worklog.Entry, day time.Time, remote []onepoint.DayWorklog) []worklog.Entry {
+	if len(remote) == 0 {
+		return nil
+	}
+	remotePayload := remotePayloadFor(remote)
+	dayStart := timeutil.StartOfDay(day)
+	matches := make([]worklog.Entry, 0)
+	for _, entry := range localEntries {
+		if !timeutil.StartOfDay(entry.StartDateTime).Equal(dayStart) {
+			continue
+		}
+		if hasEquivalentLocal(remotePayload, localEntryToPersistWorklog(entry)) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// writeMonthComparisonCSV writes one date,local_hours,remote_hours,delta_hours
+// row per day with billable hours, followed by a "total" row.
+func writeMonthComparisonCSV(w io.Writer, rows []monthRowView, summary MonthSummary) error {
+	hours := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"date", "local_hours", "remote_hours", "delta_hours"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write([]string{row.Date, hours(row.LocalHours), hours(row.RemoteHours), hours(row.BillableDeltaHours)}); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write([]string{"total", hours(summary.TotalLocalHours), hours(summary.TotalRemoteHours), hours(summary.TotalDeltaHours)}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// handleAPIMonthRemoteCSV streams the month's remote worklogs as CSV with
+// project/activity/skill names resolved from the lookup snapshot. It reads
+// the same cached remote data as the month view.
+func (s *Server) handleAPIMonthRemoteCSV(w http.ResponseWriter, r *http.Request) {
+	monthRaw := strings.TrimSpace(r.PathValue("month"))
+	monthStart, err := parseMonth(monthRaw, s.location)
+	if err != nil {
+		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
+		return
+	}
+	monthEnd := endOfMonth(monthStart)
+
+	snapshot, err := s.loadLookupSnapshot(r.Context(), false)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("load lookup snapshot: %v", err), remoteErrorStatus(err))
+		return
+	}
+	remoteEntries, _, err := s.loadRemoteRange(r.Context(), monthStart, monthEnd, false)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), remoteErrorStatus(err))
+		return
+	}
+
+	entries := remoteWorklogsToEntries(snapshot, remoteEntries, "onepoint-"+monthRaw, s.location)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartDateTime.Before(entries[j].StartDateTime)
 	})
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "remote-"+monthRaw+".csv"))
+	_ = output.WriteCSV(w, entries)
 }
 
 func (s *Server) handleAPICopyMonthRemote(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	monthStart, err := parseMonth(monthRaw)
+	monthStart, err := parseMonth(monthRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
@@ -1337,42 +2196,17 @@ func (s *Server) handleAPICopyMonthRemote(w http.ResponseWriter, r *http.Request
 
 	snapshot, err := s.loadLookupSnapshot(r.Context(), false)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("load lookup snapshot: %v", err), http.StatusBadGateway)
+		http.Error(w, fmt.Sprintf("load lookup snapshot: %v", err), remoteErrorStatus(err))
 		return
 	}
 
 	remoteEntries, _, err := s.loadRemoteRange(r.Context(), monthStart, monthEnd, false)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), http.StatusBadGateway)
+		http.Error(w, fmt.Sprintf("load remote worklogs: %v", err), remoteErrorStatus(err))
 		return
 	}
 
-	entries := make([]worklog.Entry, 0, len(remoteEntries))
-	for _, item := range remoteEntries {
-		day, err := onepoint.ParseDay(item.WorklogDate)
-		if err != nil {
-			continue
-		}
-		day = timeutil.StartOfDay(day)
-		start := day.Add(time.Duration(item.StartTime) * time.Minute)
-		end := day.Add(time.Duration(item.FinishTime) * time.Minute)
-		if !end.After(start) {
-			continue
-		}
-
-		entries = append(entries, worklog.Entry{
-			StartDateTime: start,
-			EndDateTime:   end,
-			Billable:      item.Billable,
-			Description:   strings.TrimSpace(item.Comment),
-			Project:       lookupProjectName(snapshot, item.ProjectID),
-			Activity:      lookupActivityName(snapshot, item.ActivityID),
-			Skill:         lookupSkillName(snapshot, item.SkillID),
-			SourceFormat:  "remote",
-			SourceMapper:  "onepoint",
-			SourceFile:    "onepoint-sync-" + monthRaw,
-		})
-	}
+	entries := remoteWorklogsToEntries(snapshot, remoteEntries, "onepoint-sync-"+monthRaw, s.location)
 
 	existingLocal, err := s.loadLocalRange(monthStart, monthEnd)
 	if err != nil {
@@ -1410,13 +2244,18 @@ func (s *Server) handleAPISyncMonthRemote(w http.ResponseWriter, r *http.Request
 
 func (s *Server) handleAPISubmitDay(w http.ResponseWriter, r *http.Request) {
 	dayRaw := strings.TrimSpace(r.PathValue("date"))
-	day, err := parseISODate(dayRaw)
+	day, err := parseISODate(dayRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid date format (expected YYYY-MM-DD)", http.StatusBadRequest)
 		return
 	}
 
-	dryRun := strings.TrimSpace(r.URL.Query().Get("dry_run")) == "1"
+	dryRun := submitDryRunFromQuery(r)
+	policy, err := parseSubmitOverlapPolicy(r.URL.Query().Get("policy"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	s.logAudit(auditRecord{
 		Operation: "submit",
 		Scope:     "day",
@@ -1424,7 +2263,7 @@ func (s *Server) handleAPISubmitDay(w http.ResponseWriter, r *http.Request) {
 		DryRun:    dryRun,
 		Outcome:   "attempt",
 	})
-	resp, err := s.submitRange(r.Context(), day, day, dryRun)
+	resp, err := s.submitRange(r.Context(), day, day, dryRun, policy)
 	if err != nil {
 		s.logAudit(auditRecord{
 			Operation: "submit",
@@ -1453,13 +2292,18 @@ func (s *Server) handleAPISubmitDay(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleAPISubmitMonth(w http.ResponseWriter, r *http.Request) {
 	monthRaw := strings.TrimSpace(r.PathValue("month"))
-	monthStart, err := parseMonth(monthRaw)
+	monthStart, err := parseMonth(monthRaw, s.location)
 	if err != nil {
 		http.Error(w, "invalid month format (expected YYYY-MM)", http.StatusBadRequest)
 		return
 	}
 
-	dryRun := strings.TrimSpace(r.URL.Query().Get("dry_run")) == "1"
+	dryRun := submitDryRunFromQuery(r)
+	policy, err := parseSubmitOverlapPolicy(r.URL.Query().Get("policy"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	s.logAudit(auditRecord{
 		Operation: "submit",
 		Scope:     "month",
@@ -1467,7 +2311,7 @@ func (s *Server) handleAPISubmitMonth(w http.ResponseWriter, r *http.Request) {
 		DryRun:    dryRun,
 		Outcome:   "attempt",
 	})
-	resp, err := s.submitRange(r.Context(), monthStart, endOfMonth(monthStart), dryRun)
+	resp, err := s.submitRange(r.Context(), monthStart, endOfMonth(monthStart), dryRun, policy)
 	if err != nil {
 		s.logAudit(auditRecord{
 			Operation: "submit",
@@ -1494,7 +2338,7 @@ func (s *Server) handleAPISubmitMonth(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
-func (s *Server) submitRange(ctx context.Context, from, to time.Time, dryRun bool) (submitResponse, error) {
+func (s *Server) submitRange(ctx context.Context, from, to time.Time, dryRun bool, overlapPolicy string) (submitResponse, error) {
 	response := submitResponse{
 		DryRun:     dryRun,
 		LockedDays: make([]string, 0),
@@ -1514,16 +2358,34 @@ func (s *Server) submitRange(ctx context.Context, from, to time.Time, dryRun boo
 	if err != nil {
 		return response, err
 	}
+	if changed := submitter.EntriesWithChangedIDs(entries, idMap); len(changed) > 0 {
+		changedDays := make([]time.Time, 0, len(changed))
+		for _, entry := range changed {
+			if err := s.store.SetResolvedIDs(entry.ID, entry.ProjectID, entry.ActivityID, entry.SkillID); err != nil {
+				return response, err
+			}
+			changedDays = append(changedDays, timeutil.StartOfDay(entry.StartDateTime))
+		}
+		s.invalidateLocalDays(changedDays)
+	}
 
 	dayBatches, err := submitter.BuildDayBatches(entries, idMap)
 	if err != nil {
 		return response, err
 	}
 
+	sanitizeMode := submitter.CommentSanitization(s.cfg.Submit.CommentSanitizationMode())
+	ticketFormat := submitter.TicketFormat{
+		Pattern:  s.cfg.Submit.TicketRegexp(),
+		Template: s.cfg.Submit.TicketTemplate,
+		Required: s.cfg.Submit.RequireTicket,
+	}
 	submittedDays := make([]time.Time, 0)
 	for _, batch := range dayBatches {
 		dayLabel := onepoint.FormatDay(batch.Day)
 		dayResult := submitDayResult{Date: batch.Day.Format("2006-01-02")}
+		warnings := submitter.SanitizeDayBatchComments(&batch, sanitizeMode)
+		warnings = append(warnings, submitter.FormatDayBatchTickets(&batch, ticketFormat)...)
 
 		existing, err := client.GetDayWorklogs(ctx, batch.Day)
 		if err != nil {
@@ -1544,34 +2406,69 @@ func (s *Server) submitRange(ctx context.Context, from, to time.Time, dryRun boo
 		dayResult.Overlaps = len(overlaps)
 		response.Duplicates += len(duplicates)
 		response.Overlaps += len(overlaps)
+		if overlapPolicy == submitOverlapWrite {
+			for _, overlap := range overlaps {
+				toAdd = append(toAdd, overlap.Local)
+			}
+			dayResult.Added = len(toAdd)
+			response.OverlapsWritten += len(overlaps)
+		} else {
+			response.OverlapsSkipped += len(overlaps)
+		}
 
+		if dryRun && len(toAdd) > 0 {
+			dayResult.Preview = buildSubmitPreview(toAdd)
+		}
 		if !dryRun && len(toAdd) > 0 {
 			payload := submitter.BuildPersistPayload(existingPayload, toAdd)
 
-			if _, err := client.PersistWorklogs(ctx, batch.Day, payload); err != nil {
+			results, err := client.PersistWorklogs(ctx, batch.Day, payload)
+			if err != nil && s.cfg.Submit.RetryOnConflict && errors.Is(err, onepoint.ErrConflict) {
+				var merge submitter.ConflictMerge
+				merge, results, err = retryPersistAfterConflict(ctx, client, batch.Day, existingPayload, toAdd)
+				if err == nil {
+					toAdd = merge.ToWrite
+					dayResult.Added = len(toAdd)
+					dayResult.Duplicates += len(merge.Duplicates)
+					response.Duplicates += len(merge.Duplicates)
+					warnings = append(warnings, "day was modified concurrently; merged with the current remote entries and retried")
+					for _, skipped := range merge.Skipped {
+						warnings = append(warnings, fmt.Sprintf("skipped %s: overlaps an entry added concurrently", formatSubmitPreviewRange(skipped.Local)))
+					}
+				}
+			}
+			if err != nil {
 				return response, fmt.Errorf("submit day %s failed: %w", dayLabel, err)
 			}
+			if s.cfg.Submit.VerifyPersistResults {
+				if verifyErr := submitter.VerifyPersistResults(len(toAdd), results); verifyErr != nil {
+					warnings = append(warnings, verifyErr.Error())
+				}
+			}
 			response.Submitted += len(toAdd)
 			submittedDays = append(submittedDays, batch.Day)
 		}
 
+		dayResult.Warning = strings.Join(warnings, "; ")
 		response.Days = append(response.Days, dayResult)
 	}
 
 	if !dryRun {
 		s.invalidateRemoteDays(submittedDays)
 	}
+	s.metrics.observeSubmit(dryRun, response.Submitted)
 	return response, nil
 }
 
 func (s *Server) loadLocalRange(from, to time.Time) ([]worklog.Entry, error) {
-	if err := s.ensureLocalCache(); err != nil {
+	days := rangeDays(from, to)
+	if err := s.ensureLocalDays(days); err != nil {
 		return nil, err
 	}
 
 	filtered := make([]worklog.Entry, 0, 64)
 	s.mu.RLock()
-	for _, day := range rangeDays(from, to) {
+	for _, day := range days {
 		key := day.Format("2006-01-02")
 		filtered = append(filtered, s.localByDay[key]...)
 	}
@@ -1584,11 +2481,14 @@ func (s *Server) loadRemoteRange(ctx context.Context, from, to time.Time, refres
 	if refresh {
 		s.invalidateRemoteDays(days)
 	}
-	if s.hasRemoteCacheMiss(days) {
+	if !s.hasRemoteCacheMiss(days) {
+		s.metrics.observeCache("remote", true)
+	} else {
 		// Serialize miss handling so concurrent requests don't trigger duplicate fetches.
 		s.remoteFetchMu.Lock()
 		if s.hasRemoteCacheMiss(days) {
-			loaded, err := s.client.GetFilteredWorklogs(ctx, from, to)
+			s.metrics.observeCache("remote", false)
+			loaded, err := s.fetchRemoteWorklogs(ctx, from, to)
 			if err != nil {
 				s.remoteFetchMu.Unlock()
 				return nil, time.Time{}, err
@@ -1636,42 +2536,64 @@ func (s *Server) loadRemoteRange(ctx context.Context, from, to time.Time, refres
 	return out, refreshedAt, nil
 }
 
-func (s *Server) ensureLocalCache() error {
-	s.mu.RLock()
-	loaded := s.localLoaded
-	s.mu.RUnlock()
-	if loaded {
+// ensureLocalDays loads local worklogs for days that are not cached yet.
+// Only the span between the first and last missing day is read from the store.
+func (s *Server) ensureLocalDays(days []time.Time) error {
+	if !s.hasLocalCacheMiss(days) {
+		s.metrics.observeCache("local", true)
 		return nil
 	}
 
 	s.localLoadMu.Lock()
 	defer s.localLoadMu.Unlock()
 
-	s.mu.RLock()
-	loaded = s.localLoaded
-	s.mu.RUnlock()
-	if loaded {
+	missing := s.missingLocalDays(days)
+	if len(missing) == 0 {
 		return nil
 	}
 
-	allEntries, err := s.store.ListWorklogs()
+	s.metrics.observeCache("local", false)
+	first := missing[0]
+	last := missing[len(missing)-1]
+	entries, err := s.store.ListWorklogsBetween(first, last.AddDate(0, 0, 1).Add(-time.Nanosecond))
 	if err != nil {
 		return fmt.Errorf("list local worklogs: %w", err)
 	}
 
-	index := make(map[string][]worklog.Entry, len(allEntries))
-	for _, entry := range allEntries {
+	span := rangeDays(first, last)
+	index := make(map[string][]worklog.Entry, len(span))
+	for _, entry := range entries {
 		key := timeutil.StartOfDay(entry.StartDateTime).Format("2006-01-02")
 		index[key] = append(index[key], entry)
 	}
 
 	s.mu.Lock()
-	s.localByDay = index
-	s.localLoaded = true
+	for _, day := range span {
+		key := day.Format("2006-01-02")
+		s.localByDay[key] = index[key]
+		s.localLoaded[key] = true
+	}
 	s.mu.Unlock()
 	return nil
 }
 
+func (s *Server) hasLocalCacheMiss(days []time.Time) bool {
+	return len(s.missingLocalDays(days)) > 0
+}
+
+func (s *Server) missingLocalDays(days []time.Time) []time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	missing := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		if !s.localLoaded[timeutil.StartOfDay(day).Format("2006-01-02")] {
+			missing = append(missing, timeutil.StartOfDay(day))
+		}
+	}
+	return missing
+}
+
 func (s *Server) hasRemoteCacheMiss(days []time.Time) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -1687,10 +2609,22 @@ func (s *Server) hasRemoteCacheMiss(days []time.Time) bool {
 func (s *Server) invalidateLocalCache() {
 	s.mu.Lock()
 	s.localByDay = make(map[string][]worklog.Entry)
-	s.localLoaded = false
+	s.localLoaded = make(map[string]bool)
 	s.mu.Unlock()
 }
 
+// invalidateLocalDays drops cached local entries for the given days only,
+// leaving other local days and the remote cache untouched.
+func (s *Server) invalidateLocalDays(days []time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, day := range days {
+		key := timeutil.StartOfDay(day).Format("2006-01-02")
+		delete(s.localByDay, key)
+		delete(s.localLoaded, key)
+	}
+}
+
 func (s *Server) invalidateRemoteDays(days []time.Time) {
 	if len(days) == 0 {
 		return
@@ -1733,12 +2667,16 @@ func (s *Server) loadLookupSnapshot(ctx context.Context, refresh bool) (onepoint
 		if s.lookupFetched && s.lookupSnap != nil {
 			snapshot := *s.lookupSnap
 			s.lookupMu.Unlock()
+			s.metrics.observeCache("lookup", true)
 			return snapshot, nil
 		}
 		s.lookupMu.Unlock()
 	}
 
+	s.metrics.observeCache("lookup", false)
+	fetchStart := time.Now()
 	snapshot, err := s.client.FetchLookupSnapshot(ctx)
+	s.metrics.observeOnePointFetch(time.Since(fetchStart), err)
 	if err != nil {
 		return onepoint.LookupSnapshot{}, err
 	}
@@ -1798,7 +2736,7 @@ func (s *Server) autoReconcileImportedRange(ctx context.Context, from, to time.T
 		return &reconcile.Result{}, nil
 	}
 
-	return reconcile.RunForEligibleIDs(s.store, eligibleIDs)
+	return reconcile.RunForEligibleIDs(s.store, eligibleIDs, reconcile.OptionsFromConfig(s.cfg))
 }
 
 func localEntryIsSynced(entry worklog.Entry, remote []onepoint.PersistWorklog) bool {
@@ -1812,8 +2750,14 @@ func localEntryIsSynced(entry worklog.Entry, remote []onepoint.PersistWorklog) b
 }
 
 func buildMonthRows(monthStart time.Time, localEntries []worklog.Entry, remoteEntries []onepoint.DayWorklog) ([]monthRowView, MonthSummary) {
+	return buildRangeRows(monthStart, endOfMonth(monthStart), localEntries, remoteEntries)
+}
+
+// buildRangeRows builds one summary row per day from from to to (inclusive),
+// filling days without entries.
+func buildRangeRows(from, to time.Time, localEntries []worklog.Entry, remoteEntries []onepoint.DayWorklog) ([]monthRowView, MonthSummary) {
 	dayRows := BuildDailyView(localEntries, remoteEntries)
-	dayRows = fillMonthDays(monthStart, dayRows)
+	dayRows = fillRangeDays(from, to, dayRows)
 	summary := BuildMonthlyView(dayRows)
 	lockedByDay := make(map[string]bool)
 	for _, item := range remoteEntries {
@@ -1827,7 +2771,7 @@ func buildMonthRows(monthStart time.Time, localEntries []worklog.Entry, remoteEn
 		lockedByDay[timeutil.StartOfDay(day).Format("2006-01-02")] = true
 	}
 
-	now := timeutil.StartOfDay(time.Now())
+	today := time.Now().In(from.Location()).Format("2006-01-02")
 	rows := make([]monthRowView, 0, len(summary.Days))
 	for _, day := range summary.Days {
 		dayDate := timeutil.StartOfDay(day.Date)
@@ -1836,7 +2780,7 @@ func buildMonthRows(monthStart time.Time, localEntries []worklog.Entry, remoteEn
 		rows = append(rows, monthRowView{
 			Date:               dayISO,
 			IsWeekend:          wd == time.Saturday || wd == time.Sunday,
-			IsToday:            dayDate.Equal(now),
+			IsToday:            dayISO == today,
 			HasLockedRemote:    lockedByDay[dayISO],
 			LocalHours:         day.LocalHours,
 			RemoteHours:        day.RemoteHours,
@@ -1873,18 +2817,34 @@ func (s *Server) parseAndRunImportForm(r *http.Request) (importFormResult, error
 	if mapperName == "" {
 		mapperName = "epm"
 	}
+	billable, err := parseImportBillableOverride(r.FormValue("billable"))
+	if err != nil {
+		return importFormResult{}, err
+	}
+
+	return s.runImportSource(header.Filename, file, mapperName, importer.RunOptions{
+		EPMProject:  strings.TrimSpace(r.FormValue("project")),
+		EPMActivity: strings.TrimSpace(r.FormValue("activity")),
+		EPMSkill:    strings.TrimSpace(r.FormValue("skill")),
+		Billable:    billable,
+	})
+}
+
+// runImportSource saves source to a temp file named after fileName and maps
+// it with mapperName. The caller removes the returned tmpPath.
+func (s *Server) runImportSource(fileName string, source io.Reader, mapperName string, options importer.RunOptions) (importFormResult, error) {
 	mapper, err := importer.MapperByName(mapperName)
 	if err != nil {
 		return importFormResult{}, err
 	}
 
-	tmp, err := os.CreateTemp("", tempUploadPattern(header.Filename))
+	tmp, err := os.CreateTemp("", tempUploadPattern(fileName))
 	if err != nil {
 		return importFormResult{}, fmt.Errorf("create temp upload: %w", err)
 	}
 	tmpPath := tmp.Name()
 
-	if _, err := io.Copy(tmp, file); err != nil {
+	if _, err := io.Copy(tmp, source); err != nil {
 		_ = tmp.Close()
 		_ = os.Remove(tmpPath)
 		return importFormResult{}, fmt.Errorf("save upload: %w", err)
@@ -1894,30 +2854,52 @@ func (s *Server) parseAndRunImportForm(r *http.Request) (importFormResult, error
 		return importFormResult{}, fmt.Errorf("close upload temp file: %w", err)
 	}
 
-	result, err := importer.Run(
-		[]string{tmpPath},
-		"",
-		mapper,
-		s.cfg,
-		importer.RunOptions{
-			EPMProject:  strings.TrimSpace(r.FormValue("project")),
-			EPMActivity: strings.TrimSpace(r.FormValue("activity")),
-			EPMSkill:    strings.TrimSpace(r.FormValue("skill")),
-		},
-	)
+	result, err := importer.Run([]string{tmpPath}, "", mapper, s.cfg, options)
 	if err != nil {
 		_ = os.Remove(tmpPath)
 		return importFormResult{}, err
 	}
 
-	billableMode := strings.TrimSpace(r.FormValue("billable"))
-	if billableMode == "non-billable" {
-		for i := range result.Entries {
-			result.Entries[i].Billable = 0
-		}
+	return importFormResult{
+		tmpPath:  tmpPath,
+		fileName: fileName,
+		mapper:   mapperName,
+		options:  options,
+		result:   result,
+	}, nil
+}
+
+// parseImportBillableOverride parses the optional import form field
+// "billable". Empty and "auto" keep the rule default (nil); "non-billable"
+// is the value sent by the month view import dialog.
+func parseImportBillableOverride(value string) (*bool, error) {
+	var billable bool
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return nil, nil
+	case "true", "1", "billable":
+		billable = true
+	case "false", "0", "non-billable":
+		billable = false
+	default:
+		return nil, fmt.Errorf("invalid billable value %q (expected true, false, 1, 0 or auto)", value)
 	}
+	return &billable, nil
+}
 
-	return importFormResult{tmpPath: tmpPath, result: result}, nil
+// writeDayEntryCapExceededIfAny rejects a create with 409 when the day
+// already holds web.max_entries_per_day local entries.
+func (s *Server) writeDayEntryCapExceededIfAny(w http.ResponseWriter, day time.Time, existingEntries []worklog.Entry) bool {
+	limit := s.cfg.Web.MaxEntriesPerDay
+	if limit <= 0 || len(existingEntries) < limit {
+		return false
+	}
+	http.Error(
+		w,
+		fmt.Sprintf("day %s already has %d entries; limit is %d (web.max_entries_per_day)", day.Format("2006-01-02"), len(existingEntries), limit),
+		http.StatusConflict,
+	)
+	return true
 }
 
 func (s *Server) writeMutationConflictIfAny(w http.ResponseWriter, r *http.Request, entry worklog.Entry, existingEntries []worklog.Entry, ignoreID int64) bool {
@@ -1963,6 +2945,10 @@ func templateFuncMap() template.FuncMap {
 		"isZeroDelta": func(value float64) bool {
 			return math.Abs(value) < 0.0001
 		},
+		// joinTags renders entry tags as the comma-separated edit value.
+		"joinTags": func(tags []string) string {
+			return strings.Join(tags, ",")
+		},
 		"toMins": func(hours float64) int {
 			return int(math.Round(hours * 60))
 		},
@@ -2006,20 +2992,20 @@ func renderPartialTemplate(w http.ResponseWriter, partialTemplate string, data a
 	return nil
 }
 
-func parseMonth(value string) (time.Time, error) {
-	parsed, err := time.ParseInLocation("2006-01", strings.TrimSpace(value), time.Local)
+func parseMonth(value string, loc *time.Location) (time.Time, error) {
+	parsed, err := time.ParseInLocation("2006-01", strings.TrimSpace(value), loc)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return timeutil.StartOfDay(parsed), nil
+	return timeutil.StartOfDayIn(parsed, loc), nil
 }
 
-func parseISODate(value string) (time.Time, error) {
-	parsed, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(value), time.Local)
+func parseISODate(value string, loc *time.Location) (time.Time, error) {
+	parsed, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(value), loc)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return timeutil.StartOfDay(parsed), nil
+	return timeutil.StartOfDayIn(parsed, loc), nil
 }
 
 func parsePositiveInt64(value string) (int64, error) {
@@ -2070,12 +3056,14 @@ func parseMutationFromForm(r *http.Request, fallbackDate string) (worklogMutatio
 		Skill:       strings.TrimSpace(r.FormValue("skill")),
 		Billable:    billable,
 		Description: strings.TrimSpace(r.FormValue("description")),
+		LocalNote:   strings.TrimSpace(r.FormValue("localNote")),
+		Tags:        worklog.ParseTags(r.FormValue("tags")),
 		Date:        date,
 	}, nil
 }
 
-func buildEntryFromMutation(body worklogMutationRequest) (worklog.Entry, error) {
-	day, err := parseISODate(body.Date)
+func buildEntryFromMutation(body worklogMutationRequest, cfg config.Config) (worklog.Entry, error) {
+	day, err := parseISODate(body.Date, cfg.Location())
 	if err != nil {
 		return worklog.Entry{}, fmt.Errorf("invalid date format (expected YYYY-MM-DD)")
 	}
@@ -2097,6 +3085,9 @@ func buildEntryFromMutation(body worklogMutationRequest) (worklog.Entry, error)
 	project := strings.TrimSpace(body.Project)
 	activity := strings.TrimSpace(body.Activity)
 	skill := strings.TrimSpace(body.Skill)
+	if skill == "" {
+		skill = cfg.DefaultSkillFor(activity)
+	}
 	if project == "" {
 		return worklog.Entry{}, fmt.Errorf("project must not be empty")
 	}
@@ -2118,9 +3109,28 @@ func buildEntryFromMutation(body worklogMutationRequest) (worklog.Entry, error)
 		Project:       project,
 		Activity:      activity,
 		Skill:         skill,
+		LocalNote:     strings.TrimSpace(body.LocalNote),
+		Tags:          worklog.NormalizeTags(body.Tags),
 	}, nil
 }
 
+// mutationFromEntry is the inverse of buildEntryFromMutation: it renders an
+// entry in the shape the client sends back on PATCH.
+func mutationFromEntry(entry worklog.Entry) worklogMutationRequest {
+	return worklogMutationRequest{
+		Date:        entry.StartDateTime.Format("2006-01-02"),
+		Start:       entry.StartDateTime.Format("15:04"),
+		End:         entry.EndDateTime.Format("15:04"),
+		Project:     entry.Project,
+		Activity:    entry.Activity,
+		Skill:       entry.Skill,
+		Billable:    entry.Billable,
+		Description: entry.Description,
+		LocalNote:   entry.LocalNote,
+		Tags:        entry.Tags,
+	}
+}
+
 func detectLocalConflict(candidate worklog.Entry, existing []worklog.Entry) (conflictType string, existingID int64, ok bool) {
 	for _, entry := range existing {
 		if sameLocalWorklogKey(candidate, entry) {
@@ -2198,6 +3208,38 @@ func parseClockMinutes(value string) (int, error) {
 	return parsed.Hour()*60 + parsed.Minute(), nil
 }
 
+// remoteWorklogsToEntries converts remote worklogs into local entries with
+// names resolved from snap. Worklogs with an unparsable date or an empty
+// time range are skipped.
+func remoteWorklogsToEntries(snap onepoint.LookupSnapshot, remote []onepoint.DayWorklog, sourceFile string, loc *time.Location) []worklog.Entry {
+	entries := make([]worklog.Entry, 0, len(remote))
+	for _, item := range remote {
+		day, err := onepoint.ParseDayIn(item.WorklogDate, loc)
+		if err != nil {
+			continue
+		}
+		start := day.Add(time.Duration(item.StartTime) * time.Minute)
+		end := day.Add(time.Duration(item.FinishTime) * time.Minute)
+		if !end.After(start) {
+			continue
+		}
+
+		entries = append(entries, worklog.Entry{
+			StartDateTime: start,
+			EndDateTime:   end,
+			Billable:      item.Billable,
+			Description:   strings.TrimSpace(item.Comment),
+			Project:       lookupProjectName(snap, item.ProjectID),
+			Activity:      lookupActivityName(snap, item.ActivityID),
+			Skill:         lookupSkillName(snap, item.SkillID),
+			SourceFormat:  "remote",
+			SourceMapper:  "onepoint",
+			SourceFile:    sourceFile,
+		})
+	}
+	return entries
+}
+
 func lookupProjectName(snap onepoint.LookupSnapshot, id int64) string {
 	for _, project := range snap.Projects {
 		if project.ID == id {
@@ -2235,9 +3277,11 @@ func parseBoolFormValue(value string) bool {
 	}
 }
 
-func decodeJSON(r *http.Request, out any) error {
+func (s *Server) decodeJSON(r *http.Request, out any) error {
 	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
+	if !s.allowUnknownJSONFields {
+		decoder.DisallowUnknownFields()
+	}
 	if err := decoder.Decode(out); err != nil {
 		return err
 	}
@@ -2254,12 +3298,25 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 }
 
 func submitErrorStatus(err error) int {
+	if errors.Is(err, onepoint.ErrSessionExpired) {
+		return http.StatusUnauthorized
+	}
 	if errors.Is(err, errOnePointUpstream) {
 		return http.StatusBadGateway
 	}
 	return http.StatusInternalServerError
 }
 
+// remoteErrorStatus is the status for a failed OnePoint call: 401 when the
+// session expired (the message tells the user to run "gohour auth login"),
+// otherwise 502.
+func remoteErrorStatus(err error) int {
+	if errors.Is(err, onepoint.ErrSessionExpired) {
+		return http.StatusUnauthorized
+	}
+	return http.StatusBadGateway
+}
+
 func wrapUpstreamError(err error) error {
 	if err == nil {
 		return nil
@@ -2282,6 +3339,16 @@ func (c upstreamErrorClient) ListSkills(ctx context.Context) ([]onepoint.Skill,
 	return values, wrapUpstreamError(err)
 }
 
+func (c upstreamErrorClient) ListProjectActivities(ctx context.Context, projectID int64) ([]onepoint.Activity, error) {
+	values, err := c.base.ListProjectActivities(ctx, projectID)
+	return values, wrapUpstreamError(err)
+}
+
+func (c upstreamErrorClient) ListActivitySkills(ctx context.Context, activityID int64) ([]onepoint.Skill, error) {
+	values, err := c.base.ListActivitySkills(ctx, activityID)
+	return values, wrapUpstreamError(err)
+}
+
 func (c upstreamErrorClient) GetFilteredWorklogs(ctx context.Context, from, to time.Time) ([]onepoint.DayWorklog, error) {
 	values, err := c.base.GetFilteredWorklogs(ctx, from, to)
 	return values, wrapUpstreamError(err)
@@ -2297,6 +3364,10 @@ func (c upstreamErrorClient) PersistWorklogs(ctx context.Context, day time.Time,
 	return values, wrapUpstreamError(err)
 }
 
+func (c upstreamErrorClient) DeleteDayWorklog(ctx context.Context, day time.Time, timeRecordID int64) error {
+	return wrapUpstreamError(c.base.DeleteDayWorklog(ctx, day, timeRecordID))
+}
+
 func (c upstreamErrorClient) FetchLookupSnapshot(ctx context.Context) (onepoint.LookupSnapshot, error) {
 	value, err := c.base.FetchLookupSnapshot(ctx)
 	return value, wrapUpstreamError(err)
@@ -2328,6 +3399,12 @@ func endOfMonth(monthStart time.Time) time.Time {
 	return monthStart.AddDate(0, 1, -1)
 }
 
+// startOfWeek returns the Monday of the ISO week containing day.
+func startOfWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return timeutil.StartOfDay(day).AddDate(0, 0, -offset)
+}
+
 func rangeDays(from, to time.Time) []time.Time {
 	out := make([]time.Time, 0, 32)
 	for day := timeutil.StartOfDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
@@ -2336,15 +3413,15 @@ func rangeDays(from, to time.Time) []time.Time {
 	return out
 }
 
-func fillMonthDays(monthStart time.Time, rows []DayRow) []DayRow {
+func fillRangeDays(from, to time.Time, rows []DayRow) []DayRow {
 	index := make(map[string]DayRow, len(rows))
 	for _, row := range rows {
 		index[timeutil.StartOfDay(row.Date).Format("2006-01-02")] = row
 	}
 
-	monthEnd := endOfMonth(monthStart)
-	out := make([]DayRow, 0, monthEnd.Day())
-	for day := monthStart; !day.After(monthEnd); day = day.AddDate(0, 0, 1) {
+	days := rangeDays(from, to)
+	out := make([]DayRow, 0, len(days))
+	for _, day := range days {
 		key := day.Format("2006-01-02")
 		if row, ok := index[key]; ok {
 			out = append(out, row)
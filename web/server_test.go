@@ -3,15 +3,19 @@ package web
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -197,15 +201,81 @@ func TestServer_APIMonth_ReturnsRowsAndRefreshTimestamp(t *testing.T) {
 	}
 }
 
-func TestServer_APIMonth_RemoteErrorWithoutRefresh_DegradesGracefully(t *testing.T) {
+func TestServer_APIMonth_HideEmptyOmitsDaysWithoutHours(t *testing.T) {
 	t.Parallel()
 
 	store := openTestStore(t)
 	insertWorklogs(t, store, []worklog.Entry{
-		newLocalEntry(time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 3, 3, 9, 0, 0, 0, time.Local)),
 	})
-	client := &fakeClient{filteredErr: errors.New("session expired")}
-	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	client := &fakeClient{
+		worklogs: []onepoint.DayWorklog{
+			{
+				WorklogDate: onepoint.FormatDay(time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local)),
+				StartTime:   9 * 60,
+				FinishTime:  10 * 60,
+				Billable:    60,
+			},
+		},
+	}
+	hideByDefault := testConfig(nil)
+	hideByDefault.Web.HideEmptyDays = true
+
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		query    string
+		wantRows int
+	}{
+		{name: "all days by default", cfg: testConfig(nil), query: "", wantRows: 31},
+		{name: "query hides empty days", cfg: testConfig(nil), query: "?hide-empty=1", wantRows: 2},
+		{name: "config hides empty days", cfg: hideByDefault, query: "", wantRows: 2},
+		{name: "query overrides config", cfg: hideByDefault, query: "?hide-empty=0", wantRows: 31},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(NewServer(store, client, tt.cfg))
+			defer ts.Close()
+
+			resp, err := http.Get(ts.URL + "/api/month/2026-03" + tt.query)
+			if err != nil {
+				t.Fatalf("request month api: %v", err)
+			}
+			defer resp.Body.Close()
+			var payload monthAPIResponse
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if len(payload.Rows) != tt.wantRows {
+				t.Fatalf("expected %d rows, got %d", tt.wantRows, len(payload.Rows))
+			}
+			if tt.wantRows == 2 && (payload.Rows[0].Date != "2026-03-03" || payload.Rows[1].Date != "2026-03-05") {
+				t.Fatalf("unexpected remaining rows: %+v", payload.Rows)
+			}
+			if payload.TotalLocal != 1 || payload.TotalRemote != 1 {
+				t.Fatalf("expected totals to be unchanged, got local=%v remote=%v", payload.TotalLocal, payload.TotalRemote)
+			}
+		})
+	}
+}
+
+func TestServer_APIMonth_WeeklyRemoteFetchChunksRange(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	worklogAt := func(day int) onepoint.DayWorklog {
+		return onepoint.DayWorklog{
+			WorklogDate:  onepoint.FormatDay(time.Date(2026, 3, day, 0, 0, 0, 0, time.Local)),
+			StartTime:    9 * 60,
+			FinishTime:   10 * 60,
+			Billable:     60,
+			TimeRecordID: int64(day),
+		}
+	}
+	client := &fakeClient{
+		worklogs: []onepoint.DayWorklog{worklogAt(2), worklogAt(9), worklogAt(9), worklogAt(30)},
+	}
+	ts := httptest.NewServer(NewServerWithOptions(store, client, testConfig(nil), Options{FetchRemoteInWeeklyChunks: true}))
 	defer ts.Close()
 
 	resp, err := http.Get(ts.URL + "/api/month/2026-03")
@@ -217,16 +287,73 @@ func TestServer_APIMonth_RemoteErrorWithoutRefresh_DegradesGracefully(t *testing
 		body, _ := io.ReadAll(resp.Body)
 		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
 	}
-
 	var payload monthAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		t.Fatalf("decode response: %v", err)
 	}
-	if payload.AuthErrorMsg == "" {
-		t.Fatalf("expected auth error message in payload")
+
+	ranges := append([][2]time.Time(nil), client.filteredRanges...)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0].Before(ranges[j][0]) })
+	// 31 days split into 7-day chunks: 1-7, 8-14, 15-21, 22-28, 29-31.
+	if len(ranges) != 5 {
+		t.Fatalf("expected 5 chunked requests, got %d: %v", len(ranges), ranges)
+	}
+	wantStarts := []int{1, 8, 15, 22, 29}
+	for i, r := range ranges {
+		if r[0].Day() != wantStarts[i] {
+			t.Fatalf("chunk %d starts on day %d, want %d", i, r[0].Day(), wantStarts[i])
+		}
+		if span := int(r[1].Sub(r[0]).Hours()/24) + 1; span > 7 {
+			t.Fatalf("chunk %d spans %d days", i, span)
+		}
+	}
+
+	// The duplicated day-9 worklog is merged once.
+	if payload.TotalRemote != 3 {
+		t.Fatalf("expected 3 remote hours after dedupe, got %v", payload.TotalRemote)
 	}
-	if payload.TotalLocal <= 0 {
-		t.Fatalf("expected local totals in degraded payload: %+v", payload)
+	if payload.Rows[8].RemoteHours != 1 {
+		t.Fatalf("expected 1 remote hour on 2026-03-09, got %+v", payload.Rows[8])
+	}
+}
+
+func TestSplitDayRange(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	chunks := splitDayRange(from, time.Date(2026, 2, 14, 0, 0, 0, 0, time.Local), 7)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if chunks[0].to.Day() != 7 || chunks[1].from.Day() != 8 || chunks[1].to.Day() != 14 {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+
+	single := splitDayRange(from, from, 7)
+	if len(single) != 1 || !single[0].from.Equal(from) || !single[0].to.Equal(from) {
+		t.Fatalf("unexpected single-day chunk: %+v", single)
+	}
+}
+
+func TestServer_APIMonth_RemoteErrorWithoutRefresh_ReturnsBadGateway(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)),
+	})
+	client := &fakeClient{filteredErr: errors.New("upstream down")}
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/month/2026-03")
+	if err != nil {
+		t.Fatalf("request month api: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusBadGateway || !strings.Contains(string(body), "load remote worklogs") {
+		t.Fatalf("expected 502, got %d body=%s", resp.StatusCode, string(body))
 	}
 }
 
@@ -252,6 +379,65 @@ func TestServer_APIMonth_RemoteErrorWithRefresh_ReturnsBadGateway(t *testing.T)
 	}
 }
 
+func TestServer_APIMonth_RowsCarryISODateAndTotals(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)),
+	})
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/month/2026-03")
+	if err != nil {
+		t.Fatalf("request month api: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Fatalf("expected JSON content type, got %q", ct)
+	}
+
+	var payload monthAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload.Rows) != 31 {
+		t.Fatalf("expected 31 rows, got %d", len(payload.Rows))
+	}
+	if payload.Rows[0].Date != "2026-03-01" || payload.Rows[30].Date != "2026-03-31" {
+		t.Fatalf("expected ISO dates on rows, got first=%q last=%q", payload.Rows[0].Date, payload.Rows[30].Date)
+	}
+	if payload.Rows[1].LocalHours != 1 || payload.Rows[1].WorkedDeltaHours != 1 {
+		t.Fatalf("expected 1h local delta on 2026-03-02, got %+v", payload.Rows[1])
+	}
+	if payload.TotalLocal != 1 || payload.TotalRemote != 0 || payload.TotalBillableDelta != 1 {
+		t.Fatalf("unexpected month totals: %+v", payload)
+	}
+}
+
+func TestServer_APIMonth_InvalidMonth_ReturnsBadRequest(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/month/2026-13")
+	if err != nil {
+		t.Fatalf("request month api: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 400, got %d body=%s", resp.StatusCode, string(body))
+	}
+}
+
 func TestServer_DayPageShowsClassificationBadges(t *testing.T) {
 	t.Parallel()
 
@@ -549,6 +735,109 @@ func TestServer_APIDay_RefreshForcesRemoteReload(t *testing.T) {
 	}
 }
 
+func TestServer_RefreshHeaderBypassesCaches(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := &fakeClient{
+		worklogs: []onepoint.DayWorklog{
+			{
+				WorklogDate: onepoint.FormatDay(time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)),
+				StartTime:   9 * 60,
+				FinishTime:  10 * 60,
+				Billable:    60,
+			},
+		},
+	}
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	getDay := func(header string) dayAPIResponse {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/day/2026-03-01", nil)
+		if err != nil {
+			t.Fatalf("build request: %v", err)
+		}
+		if header != "" {
+			req.Header.Set("X-Gohour-Refresh", header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("day request: %v", err)
+		}
+		defer resp.Body.Close()
+		var payload dayAPIResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return payload
+	}
+
+	getDay("")
+	client.worklogs[0].Billable = 30
+	if got := getDay("0"); got.RemoteHours != 1.0 || client.filteredCalls != 1 {
+		t.Fatalf("expected cached response without refresh header, got hours=%v calls=%d", got.RemoteHours, client.filteredCalls)
+	}
+	if got := getDay("1"); got.RemoteHours != 0.5 || client.filteredCalls != 2 {
+		t.Fatalf("expected refresh header to refetch, got hours=%v calls=%d", got.RemoteHours, client.filteredCalls)
+	}
+
+	for i := range 2 {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/lookup", nil)
+		if err != nil {
+			t.Fatalf("build lookup request: %v", err)
+		}
+		if i == 1 {
+			req.Header.Set("X-Gohour-Refresh", "1")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("lookup request: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if client.snapshotCalls != 2 {
+		t.Fatalf("expected refresh header to reload the lookup snapshot, got %d snapshot calls", client.snapshotCalls)
+	}
+}
+
+func TestServer_SessionExpiredRemoteErrorReturns401WithLoginHint(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := &fakeClient{
+		filteredErr: fmt.Errorf("%w (request POST /getFilteredWorklogs, status 200): <html>", onepoint.ErrSessionExpired),
+		snapshotErr: onepoint.ErrSessionExpired,
+	}
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	for _, path := range []string{"/api/day/2026-03-01?refresh=1", "/api/lookup", "/partials/month/2026-03?refresh=1"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("request %s: %v", path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("%s: expected 401, got %d body=%s", path, resp.StatusCode, string(body))
+		}
+		if !strings.Contains(string(body), "gohour auth login") {
+			t.Fatalf("%s: expected re-login hint, got %s", path, string(body))
+		}
+	}
+
+	client.filteredErr = errors.New("connection reset")
+	resp, err := http.Get(ts.URL + "/api/day/2026-03-01?refresh=1")
+	if err != nil {
+		t.Fatalf("request day: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected other remote errors to stay 502, got %d", resp.StatusCode)
+	}
+}
+
 // ── HTMX partial route tests ──────────────────────────────────────────────────
 
 func TestServer_PartialMonth_ReturnsRows(t *testing.T) {
@@ -727,6 +1016,26 @@ func TestServer_PartialDay_ReturnsEntries(t *testing.T) {
 	}
 }
 
+func TestRenderDayPartial_RowCarriesLocalNote(t *testing.T) {
+	t.Parallel()
+
+	view := dayPageView{
+		Day: "2026-03-01",
+		DayRow: DayRow{
+			Date:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local),
+			Entries: []EntryRow{{ID: 7, Source: "local", Start: "09:00", End: "10:00", LocalNote: "call back \"Bob\""}},
+		},
+	}
+	rec := httptest.NewRecorder()
+	if err := renderPartialTemplate(rec, "partials/day_tbody.html", view); err != nil {
+		t.Fatalf("render partial: %v", err)
+	}
+	// The edit dialog reads the note from the row, so swapped rows need it too.
+	if want := `data-local-note="call back &#34;Bob&#34;"`; !strings.Contains(rec.Body.String(), want) {
+		t.Fatalf("expected row attribute %s, got %s", want, rec.Body.String())
+	}
+}
+
 func TestServer_PartialDay_AuthError_GracefulWithoutRefreshAnd502WithRefresh(t *testing.T) {
 	t.Parallel()
 
@@ -1027,6 +1336,284 @@ func TestServer_PartialSubmitMonth_DryRunAndSubmit(t *testing.T) {
 	}
 }
 
+func TestGetWorklog_ReturnsPatchShape(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{newLocalEntry(time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local))})
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	id := entries[0].ID
+
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/worklog/" + strconvI64(id))
+	if err != nil {
+		t.Fatalf("get request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(payload))
+	}
+
+	var got worklogMutationRequest
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Date != "2026-03-01" || got.Start != "09:00" || got.End != "10:00" || got.Project != "P" || got.Activity != "A" || got.Skill != "S" || got.Billable != 60 || got.Description != "task" {
+		t.Fatalf("unexpected worklog payload: %+v", got)
+	}
+}
+
+func TestGetWorklog_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(NewServer(openTestStore(t), &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/worklog/999")
+	if err != nil {
+		t.Fatalf("get request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 404, got %d body=%s", resp.StatusCode, string(payload))
+	}
+}
+
+func TestBulkCreateWorklogs_ReportsInsertedAndDuplicates(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	body := strings.NewReader(`[
+		{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"planning"},
+		{"date":"2026-03-01","start":"10:00","end":"12:00","project":"P","activity":"A","skill":"S","billable":120,"description":"coding"},
+		{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"planning"}
+	]`)
+	resp, err := http.Post(ts.URL+"/api/worklogs", "application/json", body)
+	if err != nil {
+		t.Fatalf("bulk create request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 201, got %d body=%s", resp.StatusCode, string(payload))
+	}
+
+	var got bulkWorklogResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Created != 2 || len(got.IDs) != 2 || len(got.Results) != 3 {
+		t.Fatalf("unexpected bulk response: %+v", got)
+	}
+	if got.Results[0].Status != "inserted" || got.Results[1].Status != "inserted" || got.Results[2].Status != "duplicate" || got.Results[2].ID != 0 {
+		t.Fatalf("unexpected per-item results: %+v", got.Results)
+	}
+
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 2 || entries[0].SourceMapper != "manual" {
+		t.Fatalf("expected 2 manual entries, got %+v", entries)
+	}
+}
+
+func TestBulkCreateWorklogs_RejectsWholeBatchOnInvalidItem(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	body := strings.NewReader(`[
+		{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"ok"},
+		{"date":"2026-03-01","start":"11:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"backwards"}
+	]`)
+	resp, err := http.Post(ts.URL+"/api/worklogs", "application/json", body)
+	if err != nil {
+		t.Fatalf("bulk create request: %v", err)
+	}
+	defer resp.Body.Close()
+	payload, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", resp.StatusCode, string(payload))
+	}
+	if !strings.Contains(string(payload), "worklog 1: end time must be after start time") {
+		t.Fatalf("expected index-qualified error, got %s", string(payload))
+	}
+
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries after rejected batch, got %d", len(entries))
+	}
+}
+
+func TestBulkCreateWorklogs_RejectsOverlapWithinBatch(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	body := `[
+		{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"planning"},
+		{"date":"2026-03-01","start":"09:30","end":"11:00","project":"P","activity":"B","skill":"S","billable":90,"description":"coding"}
+	]`
+	resp, err := http.Post(ts.URL+"/api/worklogs", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("bulk create request: %v", err)
+	}
+	payload, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict || !strings.Contains(string(payload), "worklog 1 overlaps") {
+		t.Fatalf("expected 409 naming item 1, got %d body=%s", resp.StatusCode, string(payload))
+	}
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries after rejected batch, got %d", len(entries))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/worklogs", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Force-Overlap", "1")
+	forced, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("forced bulk create request: %v", err)
+	}
+	forced.Body.Close()
+	if forced.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201 with X-Force-Overlap, got %d", forced.StatusCode)
+	}
+}
+
+func TestBulkCreateWorklogs_AppliesDayEntryCapAcrossBatch(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 3, 1, 8, 0, 0, 0, time.Local)),
+	})
+	cfg := testConfig(nil)
+	cfg.Web.MaxEntriesPerDay = 2
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, cfg))
+	defer ts.Close()
+
+	body := strings.NewReader(`[
+		{"date":"2026-03-01","start":"10:00","end":"11:00","project":"P","activity":"A","skill":"S","billable":60,"description":"one"},
+		{"date":"2026-03-02","start":"10:00","end":"11:00","project":"P","activity":"A","skill":"S","billable":60,"description":"other day"},
+		{"date":"2026-03-01","start":"11:00","end":"12:00","project":"P","activity":"A","skill":"S","billable":60,"description":"two"}
+	]`)
+	resp, err := http.Post(ts.URL+"/api/worklogs", "application/json", body)
+	if err != nil {
+		t.Fatalf("bulk create request: %v", err)
+	}
+	payload, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict || !strings.Contains(string(payload), "day 2026-03-01 would have 3 entries; limit is 2") {
+		t.Fatalf("expected 409 for the capped day, got %d body=%s", resp.StatusCode, string(payload))
+	}
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the stored entry, got %d", len(entries))
+	}
+}
+
+func TestWeekPage_RendersSevenDaysAcrossMonthBoundary(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 3, 30, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 4, 2, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 4, 6, 9, 0, 0, 0, time.Local)),
+	})
+	client := &fakeClient{
+		worklogs: []onepoint.DayWorklog{
+			{WorklogDate: "31-03-2026", StartTime: 9 * 60, FinishTime: 10 * 60, Duration: 60, Billable: 60},
+		},
+	}
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/week/2026-04-01")
+	if err != nil {
+		t.Fatalf("request week page: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	text := string(body)
+	for _, date := range []string{"2026-03-30", "2026-03-31", "2026-04-01", "2026-04-02", "2026-04-03", "2026-04-04", "2026-04-05"} {
+		if !strings.Contains(text, `data-date="`+date+`"`) {
+			t.Fatalf("expected week page to contain row for %s", date)
+		}
+	}
+	if strings.Contains(text, `data-date="2026-04-06"`) || strings.Contains(text, `data-date="2026-03-29"`) {
+		t.Fatalf("expected week page to contain only Monday to Sunday")
+	}
+	if !strings.Contains(text, `href="/week/2026-03-23"`) || !strings.Contains(text, `href="/week/2026-04-06"`) {
+		t.Fatalf("expected previous/next week links")
+	}
+
+	apiResp, err := http.Get(ts.URL + "/api/week/2026-04-05")
+	if err != nil {
+		t.Fatalf("request week api: %v", err)
+	}
+	defer apiResp.Body.Close()
+	var week weekAPIResponse
+	if err := json.NewDecoder(apiResp.Body).Decode(&week); err != nil {
+		t.Fatalf("decode week response: %v", err)
+	}
+	if week.WeekStart != "2026-03-30" || week.WeekEnd != "2026-04-05" || len(week.Rows) != 7 {
+		t.Fatalf("unexpected week range: start=%s end=%s rows=%d", week.WeekStart, week.WeekEnd, len(week.Rows))
+	}
+	if week.TotalLocalWorked != 2 || week.TotalRemoteWorked != 1 {
+		t.Fatalf("expected entries from both months, got local=%v remote=%v", week.TotalLocalWorked, week.TotalRemoteWorked)
+	}
+}
+
+func TestWeekPage_InvalidDate(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(NewServer(openTestStore(t), &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	for _, path := range []string{"/week/2026-13-01", "/api/week/not-a-date"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("request %s: %v", path, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %s, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
 func TestPatchWorklog_ValidBody(t *testing.T) {
 	t.Parallel()
 
@@ -1166,25 +1753,267 @@ func TestCreateWorklog_ReturnsID(t *testing.T) {
 	if entry.Description != "created" || entry.Project != "P" || entry.Activity != "A" || entry.Skill != "S" {
 		t.Fatalf("unexpected entry: %+v", entry)
 	}
-}
-
-func TestCreateWorklog_EmptyProjectRejected(t *testing.T) {
-	t.Parallel()
-
-	store := openTestStore(t)
-	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
-	defer ts.Close()
+}
+
+func TestCreateWorklog_StoresLocalNote(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	body := strings.NewReader(`{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"created","localNote":" only for me "}`)
+	resp, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 201, got %d body=%s", resp.StatusCode, string(payload))
+	}
+
+	var payload map[string]int64
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	entry, _, err := store.GetWorklogByID(payload["id"])
+	if err != nil {
+		t.Fatalf("get worklog by id: %v", err)
+	}
+	if entry.LocalNote != "only for me" || entry.Description != "created" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestWorklogTags_RoundTripThroughCreatePatchAndDayJSON(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	body := strings.NewReader(`{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"created","tags":[" Travel","internal","travel"]}`)
+	resp, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 201, got %d body=%s", resp.StatusCode, string(payload))
+	}
+	var created map[string]int64
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	entry, _, err := store.GetWorklogByID(created["id"])
+	if err != nil {
+		t.Fatalf("get worklog by id: %v", err)
+	}
+	if strings.Join(entry.Tags, ",") != "travel,internal" {
+		t.Fatalf("expected normalized tags after create, got %v", entry.Tags)
+	}
+
+	patch := strings.NewReader(`{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"created","tags":["billable"]}`)
+	req, _ := http.NewRequest(http.MethodPatch, ts.URL+"/api/worklog/"+strconvI64(entry.ID), patch)
+	req.Header.Set("Content-Type", "application/json")
+	patchResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("patch request: %v", err)
+	}
+	defer patchResp.Body.Close()
+	if patchResp.StatusCode != http.StatusNoContent {
+		payload, _ := io.ReadAll(patchResp.Body)
+		t.Fatalf("expected 204, got %d body=%s", patchResp.StatusCode, string(payload))
+	}
+
+	dayResp, err := http.Get(ts.URL + "/api/day/2026-03-01")
+	if err != nil {
+		t.Fatalf("day request: %v", err)
+	}
+	defer dayResp.Body.Close()
+	var day dayAPIResponse
+	if err := json.NewDecoder(dayResp.Body).Decode(&day); err != nil {
+		t.Fatalf("decode day response: %v", err)
+	}
+	if len(day.Entries) != 1 || strings.Join(day.Entries[0].Tags, ",") != "billable" {
+		t.Fatalf("expected patched tags in day JSON, got %+v", day.Entries)
+	}
+}
+
+func TestDayPage_RendersTagChipsWithConfiguredColor(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	entry := newLocalEntry(time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local))
+	entry.Tags = []string{"travel", "internal"}
+	insertWorklogs(t, store, []worklog.Entry{entry})
+
+	cfg := testConfig(nil)
+	cfg.Web.TagColors = map[string]string{"travel": "#2f80ed"}
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, cfg))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/day/2026-03-01")
+	if err != nil {
+		t.Fatalf("day page request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	text := string(body)
+	if !strings.Contains(text, `data-tags="travel,internal"`) {
+		t.Fatalf("expected tags data attribute, got %s", text)
+	}
+	if !strings.Contains(text, `<span class="tag-chip" style="border-color: #2f80ed; color: #2f80ed">travel</span>`) {
+		t.Fatalf("expected colored travel chip, got %s", text)
+	}
+	if !strings.Contains(text, `<span class="tag-chip">internal</span>`) {
+		t.Fatalf("expected neutral internal chip, got %s", text)
+	}
+}
+
+func TestCreateWorklog_RejectsEntriesBeyondDailyCap(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	cfg := testConfig(nil)
+	cfg.Web.MaxEntriesPerDay = 2
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, cfg))
+	defer ts.Close()
+
+	post := func(start, end string) *http.Response {
+		t.Helper()
+		body := strings.NewReader(fmt.Sprintf(`{"date":"2026-03-01","start":%q,"end":%q,"project":"P","activity":"A","skill":"S","billable":60,"description":"capped"}`, start, end))
+		resp, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
+		if err != nil {
+			t.Fatalf("create request: %v", err)
+		}
+		return resp
+	}
+
+	for _, slot := range [][2]string{{"09:00", "10:00"}, {"10:00", "11:00"}} {
+		resp := post(slot[0], slot[1])
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected 201 for %s-%s, got %d", slot[0], slot[1], resp.StatusCode)
+		}
+	}
+
+	resp := post("11:00", "12:00")
+	defer resp.Body.Close()
+	payload, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409 beyond cap, got %d body=%s", resp.StatusCode, string(payload))
+	}
+	if !strings.Contains(string(payload), "web.max_entries_per_day") {
+		t.Fatalf("expected cap message, got %q", string(payload))
+	}
+
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 stored entries, got %d", len(entries))
+	}
+}
+
+func TestCreateWorklog_UnknownFieldStrictVsRelaxed(t *testing.T) {
+	t.Parallel()
+
+	const extraFieldBody = `{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"A","skill":"S","billable":60,"description":"extra","clientVersion":"2.1"}`
+
+	cases := []struct {
+		name       string
+		options    Options
+		body       string
+		wantStatus int
+	}{
+		{name: "strict rejects unknown field", options: Options{}, body: extraFieldBody, wantStatus: http.StatusBadRequest},
+		{name: "relaxed accepts unknown field", options: Options{AllowUnknownJSONFields: true}, body: extraFieldBody, wantStatus: http.StatusCreated},
+		{name: "relaxed still rejects trailing object", options: Options{AllowUnknownJSONFields: true}, body: extraFieldBody + `{}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := openTestStore(t)
+			ts := httptest.NewServer(NewServerWithOptions(store, &fakeClient{}, testConfig(nil), tc.options))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL+"/api/worklog", "application/json", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("create request: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				payload, _ := io.ReadAll(resp.Body)
+				t.Fatalf("expected %d, got %d body=%s", tc.wantStatus, resp.StatusCode, string(payload))
+			}
+		})
+	}
+}
+
+func TestCreateWorklog_EmptyProjectRejected(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	body := strings.NewReader(`{"date":"2026-03-01","start":"09:00","end":"10:00","project":"   ","activity":"A","skill":"S","billable":60,"description":"created"}`)
+	resp, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 400, got %d body=%s", resp.StatusCode, string(payload))
+	}
+}
+
+func TestCreateWorklog_EmptySkillUsesActivityDefault(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	cfg := testConfig(nil)
+	cfg.ActivityDefaultSkills = map[string]string{"development": "Go"}
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, cfg))
+	defer ts.Close()
+
+	body := strings.NewReader(`{"date":"2026-03-01","start":"09:00","end":"10:00","project":"P","activity":"Development","skill":" ","billable":60,"description":"created"}`)
+	resp, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		payload, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 201, got %d body=%s", resp.StatusCode, string(payload))
+	}
+
+	var payload map[string]int64
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	entry, _, err := store.GetWorklogByID(payload["id"])
+	if err != nil {
+		t.Fatalf("get worklog by id: %v", err)
+	}
+	if entry.Skill != "Go" {
+		t.Fatalf("expected default skill Go, got %+v", entry)
+	}
 
-	body := strings.NewReader(`{"date":"2026-03-01","start":"09:00","end":"10:00","project":"   ","activity":"A","skill":"S","billable":60,"description":"created"}`)
-	resp, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
+	// Activities without a default still require a skill.
+	body = strings.NewReader(`{"date":"2026-03-01","start":"10:00","end":"11:00","project":"P","activity":"Support","skill":"","billable":60,"description":"created"}`)
+	rejected, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
 	if err != nil {
 		t.Fatalf("create request: %v", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusBadRequest {
-		payload, _ := io.ReadAll(resp.Body)
-		t.Fatalf("expected 400, got %d body=%s", resp.StatusCode, string(payload))
+	defer rejected.Body.Close()
+	if rejected.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a default skill, got %d", rejected.StatusCode)
 	}
 }
 
@@ -1452,6 +2281,108 @@ func TestSubmitDay_LockedDay(t *testing.T) {
 	}
 }
 
+func TestDisableSubmit_BlocksSubmitButKeepsLocalCRUD(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{newLocalEntry(day)})
+
+	remote := onepoint.DayWorklog{TimeRecordID: 12, WorklogDate: "01-03-2026", StartTime: 14 * 60, FinishTime: 15 * 60, Duration: 60, Billable: 60}
+	client := &fakeClient{
+		worklogs:    []onepoint.DayWorklog{remote},
+		dayWorklogs: map[string][]onepoint.DayWorklog{"2026-03-01": {remote}},
+	}
+	ts := httptest.NewServer(NewServerWithOptions(store, client, testConfig([]config.Rule{ruleForLocal()}), Options{DisableSubmit: true}))
+	defer ts.Close()
+
+	for _, path := range []string{
+		"/api/submit/day/2026-03-01",
+		"/api/submit/day/2026-03-01?dry_run=1",
+		"/api/submit/month/2026-03",
+		"/partials/submit/day/2026-03-01",
+		"/partials/submit/month/2026-03",
+	} {
+		resp, err := http.Post(ts.URL+path, "application/json", nil)
+		if err != nil {
+			t.Fatalf("post %s: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403 for %s, got %d", path, resp.StatusCode)
+		}
+	}
+	for _, path := range []string{
+		"/api/remote/2026-03-01/12",
+		"/api/month/2026-03/remote-worklogs",
+	} {
+		req, _ := http.NewRequest(http.MethodDelete, ts.URL+path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("delete %s: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403 for DELETE %s, got %d", path, resp.StatusCode)
+		}
+	}
+	if client.persistCalls != 0 {
+		t.Fatalf("expected no persist calls, got %d", client.persistCalls)
+	}
+
+	for _, path := range []string{"/month/2026-03", "/day/2026-03-01", "/partials/day/2026-03-01"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("get %s: %v", path, err)
+		}
+		page, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		for _, hidden := range []string{"openSubmitAction(", "deleteMonthRemoteEntries(", "deleteRemoteRow("} {
+			if strings.Contains(string(page), hidden) {
+				t.Fatalf("expected %s to hide %q", path, hidden)
+			}
+		}
+	}
+
+	body := strings.NewReader(`{"date":"2026-03-01","start":"11:00","end":"12:00","project":"P","activity":"A","skill":"S","billable":60,"description":"created"}`)
+	created, err := http.Post(ts.URL+"/api/worklog", "application/json", body)
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	defer created.Body.Close()
+	if created.StatusCode != http.StatusCreated {
+		payload, _ := io.ReadAll(created.Body)
+		t.Fatalf("expected 201, got %d body=%s", created.StatusCode, string(payload))
+	}
+	var createdPayload map[string]int64
+	if err := json.NewDecoder(created.Body).Decode(&createdPayload); err != nil {
+		t.Fatalf("decode create response: %v", err)
+	}
+	id := createdPayload["id"]
+
+	body = strings.NewReader(`{"date":"2026-03-01","start":"11:00","end":"12:30","project":"P","activity":"A","skill":"S","billable":90,"description":"updated"}`)
+	req, _ := http.NewRequest(http.MethodPatch, ts.URL+"/api/worklog/"+strconvI64(id), body)
+	req.Header.Set("Content-Type", "application/json")
+	patched, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("patch request: %v", err)
+	}
+	patched.Body.Close()
+	if patched.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 for patch, got %d", patched.StatusCode)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, ts.URL+"/api/worklog/"+strconvI64(id), nil)
+	deleted, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("delete request: %v", err)
+	}
+	deleted.Body.Close()
+	if deleted.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 for delete, got %d", deleted.StatusCode)
+	}
+}
+
 func TestSubmitDay_NewEntry(t *testing.T) {
 	t.Parallel()
 
@@ -1485,6 +2416,114 @@ func TestSubmitDay_NewEntry(t *testing.T) {
 	}
 }
 
+func TestSubmitDay_OverlapPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy      string
+		wantPersist int
+		wantWritten int
+		wantSkipped int
+	}{
+		{policy: "", wantPersist: 0, wantWritten: 0, wantSkipped: 1},
+		{policy: "skip", wantPersist: 0, wantWritten: 0, wantSkipped: 1},
+		{policy: "write", wantPersist: 1, wantWritten: 1, wantSkipped: 0},
+	}
+	for _, tt := range tests {
+		t.Run("policy="+tt.policy, func(t *testing.T) {
+			t.Parallel()
+
+			day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+			store := openTestStore(t)
+			insertWorklogs(t, store, []worklog.Entry{newLocalEntry(day)})
+
+			client := &fakeClient{dayWorklogs: map[string][]onepoint.DayWorklog{
+				"2026-03-01": {
+					{TimeRecordID: 11, WorklogDate: "01-03-2026", StartTime: 570, FinishTime: 630, Duration: 60, ProjectID: 999, ActivityID: 200, SkillID: 300},
+				},
+			}}
+			ts := httptest.NewServer(NewServer(store, client, testConfig([]config.Rule{ruleForLocal()})))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL+"/api/submit/day/2026-03-01?policy="+tt.policy, "application/json", nil)
+			if err != nil {
+				t.Fatalf("submit day request: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+			}
+
+			var payload submitResponse
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if payload.Overlaps != 1 || payload.OverlapsWritten != tt.wantWritten || payload.OverlapsSkipped != tt.wantSkipped {
+				t.Fatalf("unexpected overlap counts: %+v", payload)
+			}
+			if client.persistCalls != tt.wantPersist {
+				t.Fatalf("expected %d persist calls, got %d", tt.wantPersist, client.persistCalls)
+			}
+			if tt.wantPersist > 0 && len(client.persistByDate["2026-03-01"]) != 2 {
+				t.Fatalf("expected existing and overlapping entry in payload, got %+v", client.persistByDate["2026-03-01"])
+			}
+		})
+	}
+}
+
+func TestSubmitDay_InvalidOverlapPolicyReturns400(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/submit/month/2026-03?policy=ask", "application/json", nil)
+	if err != nil {
+		t.Fatalf("submit month request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestSubmitDay_WarnsOnUnconfirmedPersist(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{newLocalEntry(day)})
+
+	client := &fakeClient{
+		dayWorklogs:    map[string][]onepoint.DayWorklog{},
+		persistResults: []onepoint.PersistResult{},
+	}
+	cfg := testConfig([]config.Rule{ruleForLocal()})
+	cfg.Submit.VerifyPersistResults = true
+	ts := httptest.NewServer(NewServer(store, client, cfg))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/submit/day/2026-03-01", "application/json", nil)
+	if err != nil {
+		t.Fatalf("submit day request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+	}
+
+	var payload submitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload.Days) != 1 || !strings.Contains(payload.Days[0].Warning, "confirmed 0 time record(s) for 1 added") {
+		t.Fatalf("expected persist warning, got %+v", payload.Days)
+	}
+}
+
 func TestSubmitDay_ChangedSyncedEntry_PropagatesUpdate(t *testing.T) {
 	t.Parallel()
 
@@ -1608,6 +2647,43 @@ func TestServer_SubmitDay_DryRun_DoesNotPersist(t *testing.T) {
 	}
 }
 
+func TestServer_SubmitDay_DryRunPreviewListsEntriesToAdd(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{newLocalEntry(day)})
+
+	client := &fakeClient{dayWorklogs: map[string][]onepoint.DayWorklog{}}
+	ts := httptest.NewServer(NewServer(store, client, testConfig([]config.Rule{ruleForLocal()})))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/api/submit/day/2026-03-01?dryRun=1", "application/json", nil)
+	if err != nil {
+		t.Fatalf("submit day dry-run request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+	}
+
+	var payload submitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !payload.DryRun || client.persistCalls != 0 {
+		t.Fatalf("expected a dry run without persist calls, got dryRun=%t persistCalls=%d", payload.DryRun, client.persistCalls)
+	}
+	if len(payload.Days) != 1 || len(payload.Days[0].Preview) != 1 {
+		t.Fatalf("expected one previewed entry, got %+v", payload.Days)
+	}
+	want := submitPreviewEntry{Start: "09:00", End: "10:00", ProjectID: 100, ActivityID: 200, SkillID: 300, Billable: 60, Comment: "task"}
+	if got := payload.Days[0].Preview[0]; got != want {
+		t.Fatalf("unexpected preview entry: got %+v want %+v", got, want)
+	}
+}
+
 func TestServer_SubmitMonth_DryRun_DoesNotPersist(t *testing.T) {
 	t.Parallel()
 
@@ -2148,6 +3224,70 @@ func TestServer_Import_BillableOverrideNonBillable(t *testing.T) {
 	}
 }
 
+func TestServer_Import_BillableFormFieldOverride(t *testing.T) {
+	t.Parallel()
+
+	postImport := func(t *testing.T, ts *httptest.Server, billable string) *http.Response {
+		t.Helper()
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "import.csv")
+		if err != nil {
+			t.Fatalf("create form file: %v", err)
+		}
+		_, _ = part.Write([]byte(
+			"description,startdatetime,enddatetime,project,activity,skill\n" +
+				"Task1,2026-03-01 09:00,2026-03-01 10:00,P,A,S\n",
+		))
+		_ = writer.WriteField("mapper", "generic")
+		_ = writer.WriteField("billable", billable)
+		if err := writer.Close(); err != nil {
+			t.Fatalf("close multipart writer: %v", err)
+		}
+		resp, err := http.Post(ts.URL+"/api/import", writer.FormDataContentType(), &body)
+		if err != nil {
+			t.Fatalf("import request: %v", err)
+		}
+		return resp
+	}
+
+	for _, tc := range []struct {
+		billable     string
+		wantBillable int
+	}{
+		{billable: "false", wantBillable: 0},
+		{billable: "0", wantBillable: 0},
+		{billable: "true", wantBillable: 60},
+	} {
+		store := openTestStore(t)
+		ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+
+		resp := postImport(t, ts, tc.billable)
+		if resp.StatusCode != http.StatusOK {
+			payload, _ := io.ReadAll(resp.Body)
+			t.Fatalf("billable=%s: expected 200, got %d body=%s", tc.billable, resp.StatusCode, string(payload))
+		}
+		resp.Body.Close()
+
+		entries, err := store.ListWorklogs()
+		if err != nil {
+			t.Fatalf("list worklogs: %v", err)
+		}
+		if len(entries) != 1 || entries[0].Billable != tc.wantBillable {
+			t.Fatalf("billable=%s: expected one entry with Billable=%d, got %+v", tc.billable, tc.wantBillable, entries)
+		}
+		ts.Close()
+	}
+
+	ts := httptest.NewServer(NewServer(openTestStore(t), &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+	resp := postImport(t, ts, "maybe")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid billable value, got %d", resp.StatusCode)
+	}
+}
+
 func TestServer_ImportPreview_ReturnsClassifiedEntries(t *testing.T) {
 	t.Parallel()
 
@@ -2440,27 +3580,150 @@ func TestGetLookup_ReturnsJSON(t *testing.T) {
 		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
 	}
 
-	var payload map[string][]map[string]any
+	var payload map[string][]map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload["projects"]) != 1 || payload["projects"][0]["name"] != "Project A" {
+		t.Fatalf("unexpected projects payload: %+v", payload["projects"])
+	}
+	if payload["projects"][0]["id"] != float64(1) {
+		t.Fatalf("unexpected project id payload: %+v", payload["projects"][0])
+	}
+	if len(payload["activities"]) != 1 || payload["activities"][0]["name"] != "Activity B" {
+		t.Fatalf("unexpected activities payload: %+v", payload["activities"])
+	}
+	if payload["activities"][0]["id"] != float64(2) || payload["activities"][0]["projectId"] != float64(1) {
+		t.Fatalf("unexpected activity ids payload: %+v", payload["activities"][0])
+	}
+	if len(payload["skills"]) != 1 || payload["skills"][0]["name"] != "Skill C" {
+		t.Fatalf("unexpected skills payload: %+v", payload["skills"])
+	}
+	if payload["skills"][0]["id"] != float64(3) || payload["skills"][0]["activityId"] != float64(2) {
+		t.Fatalf("unexpected skill ids payload: %+v", payload["skills"][0])
+	}
+}
+
+func TestAPIDay_FiltersBySource(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	epmEntry := newLocalEntry(day)
+	epmEntry.SourceMapper = "epm"
+	epmEntry.Description = "from epm"
+	atworkEntry := newLocalEntry(day.Add(2 * time.Hour))
+	atworkEntry.SourceMapper = "atwork"
+	atworkEntry.Description = "from atwork"
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{epmEntry, atworkEntry})
+
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	fetch := func(query string) dayAPIResponse {
+		t.Helper()
+		resp, err := http.Get(ts.URL + "/api/day/2026-03-01" + query)
+		if err != nil {
+			t.Fatalf("get day: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+		}
+		var payload dayAPIResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return payload
+	}
+
+	if all := fetch(""); len(all.Entries) != 2 {
+		t.Fatalf("expected both entries without filter, got %+v", all.Entries)
+	}
+
+	filtered := fetch("?source=EPM")
+	if len(filtered.Entries) != 1 || filtered.Entries[0].Description != "from epm" {
+		t.Fatalf("expected only the epm entry, got %+v", filtered.Entries)
+	}
+	if filtered.LocalHours != 1 {
+		t.Fatalf("expected totals to follow the filter, got %.2f", filtered.LocalHours)
+	}
+}
+
+func TestPartialDay_SourceFilterFromCurrentURL(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	epmEntry := newLocalEntry(day)
+	epmEntry.SourceMapper = "epm"
+	epmEntry.Description = "from epm"
+	atworkEntry := newLocalEntry(day.Add(2 * time.Hour))
+	atworkEntry.SourceMapper = "atwork"
+	atworkEntry.Description = "from atwork"
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{epmEntry, atworkEntry})
+
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/partials/day/2026-03-01", nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	req.Header.Set("HX-Current-URL", ts.URL+"/day/2026-03-01?source=atwork")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("get partial day: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if !strings.Contains(string(body), "from atwork") || strings.Contains(string(body), "from epm") {
+		t.Fatalf("expected only atwork rows in partial, got %s", string(body))
+	}
+}
+
+func TestGetLookup_IncludesProjectCode(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := &fakeClient{
+		snapshot: onepoint.LookupSnapshot{
+			Projects: []onepoint.Project{
+				{ID: 432904811, Name: "bfa211102 - ISO RVSE9 Los2", Archived: "0"},
+				{ID: 2, Name: "Internal", Archived: "0"},
+			},
+		},
+	}
+	cfg := testConfig(nil)
+	cfg.OnePoint.ProjectCodePattern = `^([a-z]+[0-9]+)`
+	ts := httptest.NewServer(NewServer(store, client, cfg))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/lookup")
+	if err != nil {
+		t.Fatalf("lookup request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var payload lookupResponse
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		t.Fatalf("decode response: %v", err)
 	}
-	if len(payload["projects"]) != 1 || payload["projects"][0]["name"] != "Project A" {
-		t.Fatalf("unexpected projects payload: %+v", payload["projects"])
-	}
-	if payload["projects"][0]["id"] != float64(1) {
-		t.Fatalf("unexpected project id payload: %+v", payload["projects"][0])
-	}
-	if len(payload["activities"]) != 1 || payload["activities"][0]["name"] != "Activity B" {
-		t.Fatalf("unexpected activities payload: %+v", payload["activities"])
-	}
-	if payload["activities"][0]["id"] != float64(2) || payload["activities"][0]["projectId"] != float64(1) {
-		t.Fatalf("unexpected activity ids payload: %+v", payload["activities"][0])
+	if len(payload.Projects) != 2 {
+		t.Fatalf("unexpected projects payload: %+v", payload.Projects)
 	}
-	if len(payload["skills"]) != 1 || payload["skills"][0]["name"] != "Skill C" {
-		t.Fatalf("unexpected skills payload: %+v", payload["skills"])
+	if payload.Projects[0].Code != "bfa211102" {
+		t.Fatalf("expected code bfa211102, got %q", payload.Projects[0].Code)
 	}
-	if payload["skills"][0]["id"] != float64(3) || payload["skills"][0]["activityId"] != float64(2) {
-		t.Fatalf("unexpected skill ids payload: %+v", payload["skills"][0])
+	if payload.Projects[1].Code != "" {
+		t.Fatalf("expected empty code for non-matching project, got %q", payload.Projects[1].Code)
 	}
 }
 
@@ -2569,6 +3832,100 @@ func TestServer_DeleteMonthWorklogs(t *testing.T) {
 	}
 }
 
+func TestServer_DeleteDayWorklogs(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 3, 2, 11, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 3, 3, 9, 0, 0, 0, time.Local)),
+	})
+	client := &fakeClient{
+		worklogs: []onepoint.DayWorklog{
+			{
+				WorklogDate: onepoint.FormatDay(time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)),
+				StartTime:   14 * 60,
+				FinishTime:  15 * 60,
+				Billable:    60,
+			},
+		},
+	}
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	getDay := func(date string) dayAPIResponse {
+		t.Helper()
+		resp, err := http.Get(ts.URL + "/api/day/" + date)
+		if err != nil {
+			t.Fatalf("get day %s: %v", date, err)
+		}
+		defer resp.Body.Close()
+		var payload dayAPIResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode day %s: %v", date, err)
+		}
+		return payload
+	}
+
+	// Warm the local and remote caches before deleting.
+	if before := getDay("2026-03-02"); before.LocalHours != 2 || before.RemoteHours != 1 {
+		t.Fatalf("unexpected day before delete: %+v", before)
+	}
+	filteredCalls := client.filteredCalls
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/api/day/2026-03-02", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("delete day request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+	}
+	var payload map[string]int64
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload["deleted"] != 2 {
+		t.Fatalf("expected deleted=2, got %+v", payload)
+	}
+
+	after := getDay("2026-03-02")
+	if after.LocalHours != 0 || after.RemoteHours != 1 {
+		t.Fatalf("expected only remote hours after delete, got %+v", after)
+	}
+	if client.filteredCalls != filteredCalls {
+		t.Fatalf("expected remote cache to stay warm, fetches went from %d to %d", filteredCalls, client.filteredCalls)
+	}
+	if client.persistCalls != 0 {
+		t.Fatalf("expected no remote writes, got %d", client.persistCalls)
+	}
+	if other := getDay("2026-03-03"); other.LocalHours != 1 {
+		t.Fatalf("expected neighbouring day untouched, got %+v", other)
+	}
+}
+
+func TestServer_DeleteDayWorklogs_InvalidDate(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServer(store, &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/api/day/2026-3-x", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("delete day request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 400, got %d body=%s", resp.StatusCode, string(body))
+	}
+}
+
 func TestServer_DeleteMonthRemoteWorklogs_SkipsLocked(t *testing.T) {
 	t.Parallel()
 
@@ -2672,6 +4029,101 @@ func TestServer_DeleteMonthRemoteWorklogs_SkipsLocked(t *testing.T) {
 	}
 }
 
+func TestServer_DeleteMonthRemoteWorklogs_WarnsAboutMatchingLocalEntries(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	newClient := func() *fakeClient {
+		return &fakeClient{
+			dayWorklogs: map[string][]onepoint.DayWorklog{
+				"2026-03-02": {
+					{WorklogDate: onepoint.FormatDay(day), StartTime: 9 * 60, FinishTime: 10 * 60, ProjectID: 11, ActivityID: 22, SkillID: 33},
+					{WorklogDate: onepoint.FormatDay(day), StartTime: 14 * 60, FinishTime: 15 * 60, ProjectID: 11, ActivityID: 22, SkillID: 33},
+				},
+			},
+		}
+	}
+	deleteRemote := func(t *testing.T, ts *httptest.Server, query string) map[string]any {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/api/month/2026-03/remote-worklogs"+query, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("delete remote month request: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return payload
+	}
+
+	t.Run("warns and keeps local entries", func(t *testing.T) {
+		store := openTestStore(t)
+		// Only the 09:00 entry has a remote twin; the 11:00 entry was never submitted.
+		insertWorklogs(t, store, []worklog.Entry{
+			newLocalEntry(day.Add(9 * time.Hour)),
+			newLocalEntry(day.Add(11 * time.Hour)),
+		})
+		ts := httptest.NewServer(NewServer(store, newClient(), testConfig(nil)))
+		defer ts.Close()
+
+		payload := deleteRemote(t, ts, "")
+		if int(payload["deleted"].(float64)) != 2 {
+			t.Fatalf("expected deleted=2, got %+v", payload)
+		}
+		if int(payload["localMatches"].(float64)) != 1 {
+			t.Fatalf("expected localMatches=1, got %+v", payload)
+		}
+		matchDays, ok := payload["localMatchDays"].([]any)
+		if !ok || len(matchDays) != 1 || matchDays[0].(string) != "2026-03-02" {
+			t.Fatalf("unexpected localMatchDays payload: %+v", payload["localMatchDays"])
+		}
+		if warning, _ := payload["warning"].(string); !strings.Contains(warning, "still exist locally") {
+			t.Fatalf("expected local match warning, got %+v", payload)
+		}
+		if int(payload["deletedLocal"].(float64)) != 0 {
+			t.Fatalf("expected local entries to be kept, got %+v", payload)
+		}
+		entries, err := store.ListWorklogs()
+		if err != nil {
+			t.Fatalf("list worklogs: %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("expected both local entries to remain, got %d", len(entries))
+		}
+	})
+
+	t.Run("delete_local removes matching local entries", func(t *testing.T) {
+		store := openTestStore(t)
+		insertWorklogs(t, store, []worklog.Entry{
+			newLocalEntry(day.Add(9 * time.Hour)),
+			newLocalEntry(day.Add(11 * time.Hour)),
+		})
+		ts := httptest.NewServer(NewServer(store, newClient(), testConfig(nil)))
+		defer ts.Close()
+
+		payload := deleteRemote(t, ts, "?delete_local=1")
+		if int(payload["localMatches"].(float64)) != 1 || int(payload["deletedLocal"].(float64)) != 1 {
+			t.Fatalf("expected one matching local entry to be deleted, got %+v", payload)
+		}
+		if _, ok := payload["warning"]; ok {
+			t.Fatalf("expected no warning after deleting local matches, got %+v", payload)
+		}
+		entries, err := store.ListWorklogs()
+		if err != nil {
+			t.Fatalf("list worklogs: %v", err)
+		}
+		if len(entries) != 1 || entries[0].StartDateTime.Hour() != 11 {
+			t.Fatalf("expected only the unmatched local entry to remain, got %+v", entries)
+		}
+	})
+}
+
 func TestServer_DeleteMonthRemoteWorklogs_AuditSuccessAndFailure(t *testing.T) {
 	t.Parallel()
 
@@ -2826,6 +4278,181 @@ func TestServer_CopyMonthRemote(t *testing.T) {
 	}
 }
 
+func TestServer_APIImportTemplateDownloadsGenericCSV(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(NewServer(openTestStore(t), &fakeClient{}, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/import/template.csv")
+	if err != nil {
+		t.Fatalf("template request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+	}
+	if got := resp.Header.Get("Content-Disposition"); !strings.Contains(got, "gohour-import-template.csv") {
+		t.Fatalf("unexpected content disposition: %q", got)
+	}
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	if len(lines) != 2 || lines[0] != "StartDateTime,EndDateTime,Billable,Description,Project,Activity,Skill" {
+		t.Fatalf("unexpected template:\n%s", string(body))
+	}
+}
+
+func TestServer_APIMonthCSV_WritesOneRowPerDayAndTotal(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	insertWorklogs(t, store, []worklog.Entry{
+		newLocalEntry(time.Date(2026, 2, 3, 9, 0, 0, 0, time.Local)),
+		newLocalEntry(time.Date(2026, 2, 3, 11, 0, 0, 0, time.Local)),
+	})
+	client := &fakeClient{
+		worklogs: []onepoint.DayWorklog{
+			{
+				WorklogDate: onepoint.FormatDay(time.Date(2026, 2, 3, 0, 0, 0, 0, time.Local)),
+				StartTime:   9 * 60,
+				FinishTime:  10 * 60,
+				Billable:    60,
+			},
+		},
+	}
+	cfg := testConfig(nil)
+	cfg.Web.HideEmptyDays = true
+	ts := httptest.NewServer(NewServer(store, client, cfg))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/month/2026-02?format=csv")
+	if err != nil {
+		t.Fatalf("month csv request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+	}
+	if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Fatalf("expected text/csv content type, got %q", got)
+	}
+	if got := resp.Header.Get("Content-Disposition"); !strings.Contains(got, "attachment") || !strings.Contains(got, "month-2026-02.csv") {
+		t.Fatalf("expected attachment disposition, got %q", got)
+	}
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	// Header, 28 days of February (empty days kept despite hide_empty_days), total.
+	if len(rows) != 1+28+1 {
+		t.Fatalf("expected header, 28 day rows and total, got %d: %v", len(rows), rows)
+	}
+	if strings.Join(rows[0], ",") != "date,local_hours,remote_hours,delta_hours" {
+		t.Fatalf("unexpected header: %v", rows[0])
+	}
+	if strings.Join(rows[1], ",") != "2026-02-01,0.00,0.00,0.00" {
+		t.Fatalf("expected empty first day with zeros, got %v", rows[1])
+	}
+	if strings.Join(rows[3], ",") != "2026-02-03,2.00,1.00,1.00" {
+		t.Fatalf("unexpected row for 2026-02-03: %v", rows[3])
+	}
+	if strings.Join(rows[29], ",") != "total,2.00,1.00,1.00" {
+		t.Fatalf("unexpected total row: %v", rows[29])
+	}
+}
+
+func TestServer_APIMonthRemoteCSV_StreamsRemoteEntriesWithNames(t *testing.T) {
+	t.Parallel()
+
+	store := openTestStore(t)
+	client := &fakeClient{
+		snapshot: onepoint.LookupSnapshot{
+			Projects:   []onepoint.Project{{ID: 11, Name: "Project A", Archived: "0"}},
+			Activities: []onepoint.Activity{{ID: 22, Name: "Activity B", ProjectNodeID: 11}},
+			Skills:     []onepoint.Skill{{SkillID: 33, Name: "Skill C", ActivityID: 22}},
+		},
+		worklogs: []onepoint.DayWorklog{
+			{
+				WorklogDate: onepoint.FormatDay(time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)),
+				StartTime:   10 * 60,
+				FinishTime:  11 * 60,
+				Billable:    0,
+				Comment:     "remote-b",
+				ProjectID:   11,
+				ActivityID:  22,
+				SkillID:     33,
+			},
+			{
+				WorklogDate: onepoint.FormatDay(time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)),
+				StartTime:   9 * 60,
+				FinishTime:  10 * 60,
+				Billable:    60,
+				Comment:     "remote-a",
+				ProjectID:   11,
+				ActivityID:  22,
+				SkillID:     33,
+			},
+			{
+				WorklogDate: onepoint.FormatDay(time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)),
+				StartTime:   9 * 60,
+				FinishTime:  10 * 60,
+				Billable:    60,
+				Comment:     "outside-range",
+				ProjectID:   11,
+				ActivityID:  22,
+				SkillID:     33,
+			},
+		},
+	}
+
+	ts := httptest.NewServer(NewServer(store, client, testConfig(nil)))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/month/2026-03/remote.csv")
+	if err != nil {
+		t.Fatalf("remote csv request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d body=%s", resp.StatusCode, string(body))
+	}
+	if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Fatalf("expected text/csv content type, got %q", got)
+	}
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d: %v", len(rows), rows)
+	}
+	if rows[0][0] != "StartDateTime" || rows[0][4] != "Project" {
+		t.Fatalf("unexpected header: %v", rows[0])
+	}
+	wantStart := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local).Format(time.RFC3339)
+	if rows[1][0] != wantStart || rows[1][2] != "60" || rows[1][3] != "remote-a" {
+		t.Fatalf("unexpected first row: %v", rows[1])
+	}
+	if rows[1][4] != "Project A" || rows[1][5] != "Activity B" || rows[1][6] != "Skill C" {
+		t.Fatalf("expected resolved names, got %v", rows[1])
+	}
+	if rows[2][3] != "remote-b" || rows[2][2] != "0" {
+		t.Fatalf("unexpected second row: %v", rows[2])
+	}
+
+	entries, err := store.ListWorklogs()
+	if err != nil {
+		t.Fatalf("list worklogs: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected export to leave local store untouched, got %d entries", len(entries))
+	}
+}
+
 func TestServer_CopyMonthRemote_SkipsEntriesAlreadyInLocal(t *testing.T) {
 	t.Parallel()
 
@@ -3377,6 +5004,13 @@ type fakeClient struct {
 	getDayErr     error
 	persistErr    error
 	snapshotErr   error
+	// persistResults overrides the default single confirmed result when set.
+	persistResults []onepoint.PersistResult
+
+	// filteredMu guards filteredRanges, since chunked remote loads call
+	// GetFilteredWorklogs concurrently.
+	filteredMu     sync.Mutex
+	filteredRanges [][2]time.Time
 }
 
 func (f *fakeClient) ListProjects(ctx context.Context) ([]onepoint.Project, error) {
@@ -3391,8 +5025,19 @@ func (f *fakeClient) ListSkills(ctx context.Context) ([]onepoint.Skill, error) {
 	return nil, errors.New("not implemented in test fake")
 }
 
+func (f *fakeClient) ListProjectActivities(ctx context.Context, projectID int64) ([]onepoint.Activity, error) {
+	return nil, errors.New("not implemented in test fake")
+}
+
+func (f *fakeClient) ListActivitySkills(ctx context.Context, activityID int64) ([]onepoint.Skill, error) {
+	return nil, errors.New("not implemented in test fake")
+}
+
 func (f *fakeClient) GetFilteredWorklogs(ctx context.Context, from, to time.Time) ([]onepoint.DayWorklog, error) {
+	f.filteredMu.Lock()
 	f.filteredCalls++
+	f.filteredRanges = append(f.filteredRanges, [2]time.Time{from, to})
+	f.filteredMu.Unlock()
 	if f.filteredErr != nil {
 		return nil, f.filteredErr
 	}
@@ -3434,9 +5079,25 @@ func (f *fakeClient) PersistWorklogs(ctx context.Context, day time.Time, worklog
 	}
 	key := timeutil.StartOfDay(day).Format("2006-01-02")
 	f.persistByDate[key] = append([]onepoint.PersistWorklog(nil), worklogs...)
+	if f.persistResults != nil {
+		return f.persistResults, nil
+	}
 	return []onepoint.PersistResult{{OldTimeRecordID: -1, NewTimeRecordID: 1}}, nil
 }
 
+func (f *fakeClient) DeleteDayWorklog(ctx context.Context, day time.Time, timeRecordID int64) error {
+	existing, err := f.GetDayWorklogs(ctx, day)
+	if err != nil {
+		return err
+	}
+	remaining, err := onepoint.WorklogsWithout(existing, timeRecordID)
+	if err != nil {
+		return err
+	}
+	_, err = f.PersistWorklogs(ctx, day, remaining)
+	return err
+}
+
 func (f *fakeClient) FetchLookupSnapshot(ctx context.Context) (onepoint.LookupSnapshot, error) {
 	f.snapshotCalls++
 	if f.snapshotErr != nil {
@@ -0,0 +1,97 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+// SessionWatch checks the OnePoint session in the background with a cheap
+// ListProjects call, so pages can show an expired session before the next
+// remote action fails.
+type SessionWatch struct {
+	client onepoint.Client
+	log    io.Writer
+
+	mu        sync.Mutex
+	err       error
+	checkedAt time.Time
+}
+
+// NewSessionWatch returns a watch for client. Changes of the session state
+// are written to log.
+func NewSessionWatch(client onepoint.Client, log io.Writer) *SessionWatch {
+	if log == nil {
+		log = io.Discard
+	}
+	return &SessionWatch{client: client, log: log}
+}
+
+// Run checks the session on every tick until ctx is done.
+func (w *SessionWatch) Run(ctx context.Context, tick <-chan time.Time) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-tick:
+			w.Check(ctx, now)
+		}
+	}
+}
+
+// Check verifies the session once and records the result as of now.
+func (w *SessionWatch) Check(ctx context.Context, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	projects, err := w.client.ListProjects(ctx)
+	if err == nil && len(projects) == 0 {
+		err = errors.New("ListProjects returned no projects (session may have expired)")
+	}
+	if errors.Is(err, context.Canceled) {
+		// Shutdown interrupted the check; keep the last known state.
+		return err
+	}
+
+	w.mu.Lock()
+	previous := w.err
+	w.err = err
+	w.checkedAt = now
+	w.mu.Unlock()
+
+	switch {
+	case err != nil && previous == nil:
+		fmt.Fprintf(w.log, "Warning: OnePoint session check failed at %s: %v\n", now.Format(time.RFC3339), err)
+	case err == nil && previous != nil:
+		fmt.Fprintf(w.log, "OnePoint session is valid again (%s)\n", now.Format(time.RFC3339))
+	}
+	return err
+}
+
+// Err returns the result of the last check; nil before the first check.
+func (w *SessionWatch) Err() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// sessionWatchMessage returns the banner text for a failed background
+// session check, or "" when no watch runs or the session was valid.
+func (s *Server) sessionWatchMessage() string {
+	err := s.sessionWatch.Err()
+	if err == nil {
+		return ""
+	}
+	return fmt.Sprintf(
+		"OnePoint session may have expired (%v). In a new terminal run: gohour auth login",
+		err,
+	)
+}
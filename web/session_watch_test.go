@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/riadshalaby/gohour/onepoint"
+)
+
+// sequenceProjectsClient answers ListProjects with errs in call order and
+// repeats the last one.
+type sequenceProjectsClient struct {
+	*fakeClient
+	errs  []error
+	calls int
+}
+
+func (c *sequenceProjectsClient) ListProjects(ctx context.Context) ([]onepoint.Project, error) {
+	err := c.errs[min(c.calls, len(c.errs)-1)]
+	c.calls++
+	if err != nil {
+		return nil, err
+	}
+	return []onepoint.Project{{ID: 1, Name: "P"}}, nil
+}
+
+func TestSessionWatch_DetectsExpiryWithinOneInterval(t *testing.T) {
+	t.Parallel()
+
+	client := &sequenceProjectsClient{fakeClient: &fakeClient{}, errs: []error{nil, onepoint.ErrAuthUnauthorized}}
+	var log strings.Builder
+	watch := NewSessionWatch(client, &log)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tick := make(chan time.Time)
+	done := make(chan struct{})
+	go func() {
+		watch.Run(ctx, tick)
+		close(done)
+	}()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	interval := 5 * time.Minute
+	tick <- start
+	// The session expires right after the first check; the next tick, one
+	// interval later, has to report it.
+	tick <- start.Add(interval)
+	cancel()
+	<-done
+
+	if client.calls != 2 {
+		t.Fatalf("expected one check per tick, got %d", client.calls)
+	}
+	if err := watch.Err(); !errors.Is(err, onepoint.ErrAuthUnauthorized) {
+		t.Fatalf("expected expired session after one interval, got %v", err)
+	}
+	if got := strings.Count(log.String(), "Warning: OnePoint session check failed"); got != 1 {
+		t.Fatalf("expected one expiry warning, got %d in %q", got, log.String())
+	}
+
+	store := openTestStore(t)
+	ts := httptest.NewServer(NewServerWithOptions(store, client, testConfig(nil), Options{SessionWatch: watch}))
+	defer ts.Close()
+	resp, err := http.Get(ts.URL + "/month/2026-03")
+	if err != nil {
+		t.Fatalf("month request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "OnePoint session may have expired") {
+		t.Fatalf("expected session banner on month page, got: %s", string(body))
+	}
+}
+
+func TestSessionWatch_LogsRecovery(t *testing.T) {
+	t.Parallel()
+
+	client := &sequenceProjectsClient{fakeClient: &fakeClient{}, errs: []error{onepoint.ErrAuthUnauthorized, nil}}
+	var log strings.Builder
+	watch := NewSessionWatch(client, &log)
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	if err := watch.Check(context.Background(), now); err == nil {
+		t.Fatalf("expected first check to fail")
+	}
+	if err := watch.Check(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("expected second check to pass, got %v", err)
+	}
+	if watch.Err() != nil {
+		t.Fatalf("expected recovered session, got %v", watch.Err())
+	}
+	if !strings.Contains(log.String(), "OnePoint session is valid again") {
+		t.Fatalf("expected recovery log line, got %q", log.String())
+	}
+}
@@ -72,6 +72,8 @@ document.addEventListener('alpine:init', () => {
     end: '',
     billableHours: '',
     description: '',
+    localNote: '',
+    tags: '',
     error: '',
     close() {
       this.open = false;
@@ -85,6 +87,8 @@ document.addEventListener('alpine:init', () => {
       this.end = '';
       this.billableHours = '';
       this.description = '';
+      this.localNote = '';
+      this.tags = '';
       this.error = '';
     },
   });
@@ -211,6 +215,18 @@ function showToast(msg, isError) {
   }, 2600);
 }
 
+// ── Source filter ──
+// Reloads the current page with ?source= set; "all" removes the filter.
+function applySourceFilter(source) {
+  const url = new URL(window.location.href);
+  if (!source || source === 'all') {
+    url.searchParams.delete('source');
+  } else {
+    url.searchParams.set('source', source);
+  }
+  window.location.assign(url.toString());
+}
+
 // ── Formatting helpers ──
 function fmtHours(mins) {
   return new Intl.NumberFormat(navigator.language, {
@@ -304,11 +320,12 @@ document.addEventListener('htmx:afterSettle', (evt) => {
   applyLocaleFormatting(document);
 });
 
-// Allow month/day partial refreshes to swap server-provided 502 HTML fragments.
+// Allow month/day partial refreshes to swap server-provided 502 HTML fragments,
+// and 401 fragments carrying the "gohour auth login" hint for expired sessions.
 document.body.addEventListener('htmx:beforeSwap', (event) => {
   const detail = event.detail || {};
   const xhr = detail.xhr;
-  if (!xhr || xhr.status !== 502) return;
+  if (!xhr || (xhr.status !== 502 && xhr.status !== 401)) return;
 
   const target = detail.target;
   const targetID = target && target.id ? target.id : '';
@@ -740,7 +757,7 @@ function normalizeName(value) {
 }
 
 // ── Lookup select helpers ──
-function fillSelectByName(select, items, currentName, getID, getName) {
+function fillSelectByName(select, items, currentName, getID, getName, getLabel) {
   select.innerHTML = '';
   const currentNorm = normalizeName(currentName);
   let found = false;
@@ -748,7 +765,8 @@ function fillSelectByName(select, items, currentName, getID, getName) {
     const name = getName(item);
     const option = document.createElement('option');
     option.value = name;
-    option.textContent = name;
+    option.textContent = getLabel ? getLabel(item) : name;
+    option.title = name;
     option.dataset.name = name;
     option.dataset.id = String(getID(item));
     if (currentNorm && normalizeName(name) === currentNorm && !found) {
@@ -792,7 +810,7 @@ async function buildLookupSelects(currentProject, currentActivity, currentSkill)
   const skillSelect = document.createElement('select');
   skillSelect.name = 'skill';
 
-  fillSelectByName(projectSelect, projects, currentProject, (item) => item.id, (item) => item.name);
+  fillSelectByName(projectSelect, projects, currentProject, (item) => item.id, (item) => item.name, (item) => item.code || item.name);
 
   const selectedOptionID = (select) => {
     if (!select || !select.options.length || select.selectedIndex < 0) return NaN;
@@ -832,7 +850,9 @@ function parseDayRow(row) {
     activity: row.dataset.activity,
     skill: row.dataset.skill,
     billableMins: Number(row.dataset.billableMins || '0'),
-    description: row.dataset.description || ''
+    description: row.dataset.description || '',
+    localNote: row.dataset.localNote || '',
+    tags: row.dataset.tags || ''
   };
 }
 
@@ -893,6 +913,8 @@ async function openEditDialog(options) {
     state.billableHours = (Number(values.billableMins) / 60).toFixed(2);
   }
   state.description = values.description || '';
+  state.localNote = values.localNote || '';
+  state.tags = values.tags || '';
 
   let selects;
   try {
@@ -910,12 +932,16 @@ async function openEditDialog(options) {
   const endInput = form.querySelector('[name=end]');
   const billableInput = form.querySelector('[name=billableHours]');
   const descInput = form.querySelector('[name=description]');
+  const noteInput = form.querySelector('[name=localNote]');
+  const tagsInput = form.querySelector('[name=tags]');
   const dateInput = form.querySelector('[name=date]');
   if (dateInput) dateInput.value = state.date;
   if (startInput) startInput.value = state.start;
   if (endInput) endInput.value = state.end;
   if (billableInput) billableInput.value = state.billableHours;
   if (descInput) descInput.value = state.description;
+  if (noteInput) noteInput.value = state.localNote;
+  if (tagsInput) tagsInput.value = state.tags;
 
   if (startInput && endInput) {
     startInput.onchange = () => { recalcBillable(form); updateDialogDuration(form); };
@@ -1032,6 +1058,26 @@ function deleteRow(button) {
   }, 'Delete');
 }
 
+function deleteRemoteRow(button) {
+  const row = button.closest('tr');
+  if (!row) return;
+  const day = row.dataset.date;
+  const id = row.dataset.timeRecordId;
+  if (!day || !id) return;
+  openConfirmDialog('Delete remote entry', 'Delete this entry in OnePoint? This cannot be undone.', async function() {
+    try {
+      await apiFetch('DELETE', '/api/remote/' + encodeURIComponent(day) + '/' + encodeURIComponent(id));
+      showToast('Remote entry deleted.', false);
+      htmx.ajax('GET', '/partials/day/' + encodeURIComponent(day), {
+        target: '#day-entries',
+        swap: 'innerHTML',
+      });
+    } catch (err) {
+      showToast(String(err.message || err), true);
+    }
+  }, 'Delete');
+}
+
 async function editRow(button) {
   const row = button.closest('tr');
   if (!row || row.dataset.source === 'remote') return;
@@ -1054,7 +1100,9 @@ async function addEntryRow(day) {
       activity: '',
       skill: '',
       billableMins: null,
-      description: ''
+      description: '',
+      localNote: '',
+      tags: ''
     }
   });
 }
@@ -1110,6 +1158,12 @@ async function deleteMonthRemoteEntries(month) {
       }
       html += '</div>';
     }
+    if (result.deletedLocal > 0) {
+      html += '<div class="result-box">Matching local entries deleted: ' + result.deletedLocal + '</div>';
+    }
+    if (result.warning) {
+      html += '<div class="result-box">Warning: ' + escapeHtml(String(result.warning)) + '</div>';
+    }
     try {
       await refreshMonthPartial(month, false);
     } catch (reloadErr) {
@@ -1120,6 +1174,9 @@ async function deleteMonthRemoteEntries(month) {
     if (result.skippedLocked > 0) {
       msg += ' ' + result.skippedLocked + ' locked days skipped.';
     }
+    if (result.localMatches > 0 && !result.deletedLocal) {
+      msg += ' ' + result.localMatches + ' still exist locally.';
+    }
     showToast(msg, false);
   } catch (err) {
     openStatusDialog(
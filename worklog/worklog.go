@@ -1,6 +1,13 @@
 package worklog
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/riadshalaby/gohour/internal/timeutil"
+)
 
 // Entry is the normalized worklog record used across importers and outputs.
 type Entry struct {
@@ -15,4 +22,77 @@ type Entry struct {
 	SourceFormat  string
 	SourceMapper  string
 	SourceFile    string
+	// LocalNote is a private note kept in the local database only; it is
+	// never sent to OnePoint.
+	LocalNote string
+	// Tags are local-only labels (e.g. "travel") used for grouping in the
+	// web UI; they never affect submit.
+	Tags []string
+	// ProjectID, ActivityID and SkillID cache the OnePoint ids the names
+	// last resolved to during submit; 0 when not resolved yet.
+	ProjectID  int64
+	ActivityID int64
+	SkillID    int64
+}
+
+var (
+	ErrCrossesDayBoundary = errors.New("crosses day boundaries")
+	ErrInvalidTimeRange   = errors.New("invalid time range")
+	ErrNegativeBillable   = errors.New("negative billable value")
+)
+
+// DurationMinutes returns the whole minutes between start and end, or 0 when
+// the entry does not end after it starts.
+func (e Entry) DurationMinutes() int {
+	return max(0, int(e.EndDateTime.Sub(e.StartDateTime).Minutes()))
+}
+
+// CrossesDayBoundary reports whether start and end fall on different calendar
+// days. An entry ending exactly at midnight of the next day crosses it.
+func (e Entry) CrossesDayBoundary() bool {
+	return !timeutil.SameDay(e.StartDateTime, e.EndDateTime)
+}
+
+// Validate checks that the entry can be submitted as one OnePoint worklog:
+// it stays within one day, ends after it starts and has non-negative
+// billable minutes.
+func (e Entry) Validate() error {
+	if e.CrossesDayBoundary() {
+		return ErrCrossesDayBoundary
+	}
+	if e.DurationMinutes() <= 0 || timeutil.MinutesFromMidnight(e.EndDateTime) <= timeutil.MinutesFromMidnight(e.StartDateTime) {
+		return ErrInvalidTimeRange
+	}
+	if e.Billable < 0 {
+		return fmt.Errorf("%w (%d)", ErrNegativeBillable, e.Billable)
+	}
+	return nil
+}
+
+// ParseTags splits a comma-separated tag list and normalizes it like
+// NormalizeTags.
+func ParseTags(raw string) []string {
+	return NormalizeTags(strings.Split(raw, ","))
+}
+
+// NormalizeTags trims and lower-cases tags, drops empty ones and duplicates
+// and keeps the first-seen order. Commas are not allowed inside a tag and
+// split it.
+func NormalizeTags(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			tag := strings.ToLower(strings.TrimSpace(part))
+			if tag == "" {
+				continue
+			}
+			if _, ok := seen[tag]; ok {
+				continue
+			}
+			seen[tag] = struct{}{}
+			out = append(out, tag)
+		}
+	}
+	return out
 }
@@ -0,0 +1,76 @@
+package worklog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntryValidate(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name     string
+		start    time.Duration
+		end      time.Duration
+		billable int
+		wantErr  error
+	}{
+		{name: "valid", start: 9 * time.Hour, end: 10 * time.Hour, billable: 60},
+		{name: "zero billable", start: 9 * time.Hour, end: 10 * time.Hour},
+		{name: "negative billable", start: 9 * time.Hour, end: 10 * time.Hour, billable: -1, wantErr: ErrNegativeBillable},
+		{name: "end equals start", start: 9 * time.Hour, end: 9 * time.Hour, wantErr: ErrInvalidTimeRange},
+		{name: "end before start", start: 10 * time.Hour, end: 9 * time.Hour, wantErr: ErrInvalidTimeRange},
+		{name: "sub-minute range", start: 9 * time.Hour, end: 9*time.Hour + 30*time.Second, wantErr: ErrInvalidTimeRange},
+		{name: "ends at next midnight", start: 23 * time.Hour, end: 24 * time.Hour, wantErr: ErrCrossesDayBoundary},
+		{name: "crosses midnight", start: 23 * time.Hour, end: 25 * time.Hour, billable: 120, wantErr: ErrCrossesDayBoundary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := Entry{
+				StartDateTime: day.Add(tt.start),
+				EndDateTime:   day.Add(tt.end),
+				Billable:      tt.billable,
+			}
+			err := entry.Validate()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("expected valid entry, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEntryDurationMinutes(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	if got := (Entry{StartDateTime: start, EndDateTime: start.Add(90 * time.Minute)}).DurationMinutes(); got != 90 {
+		t.Fatalf("expected 90, got %d", got)
+	}
+	if got := (Entry{StartDateTime: start, EndDateTime: start.Add(-time.Hour)}).DurationMinutes(); got != 0 {
+		t.Fatalf("expected 0 for inverted range, got %d", got)
+	}
+}
+
+func TestParseTags_NormalizesAndDeduplicates(t *testing.T) {
+	t.Parallel()
+
+	got := ParseTags(" Travel, internal,,travel ,Billable")
+	if strings.Join(got, "|") != "travel|internal|billable" {
+		t.Fatalf("unexpected tags: %v", got)
+	}
+	if got := ParseTags("  "); len(got) != 0 {
+		t.Fatalf("expected no tags, got %v", got)
+	}
+}